@@ -48,9 +48,17 @@ type Agent[T ConsensusData] struct {
 	lastUpdate     time.Time
 
 	// Training state
-	trainingData []TrainingExample[T]
+	trainingData *trainingBuffer[T]
 	gradients    map[string][]float64
 	consensus    *ConsensusState[T]
+
+	// seedErr is ErrModelNotDeterministic when deterministic mode was
+	// requested for a model that cannot be seeded
+	seedErr error
+
+	// det derives the IDs and timestamps of decisions the agent makes
+	// itself, such as retry defaults
+	det determinism
 }
 
 // ConsensusData is anything that needs AI consensus
@@ -130,7 +138,7 @@ type SharedMemory[T ConsensusData] struct {
 	// Distributed state
 	modelStates   map[string]map[string]interface{} // modelID -> state
 	nodeWeights   map[string]float64                // nodeID -> weight
-	trainingQueue []TrainingExample[T]
+	trainingQueue *trainingBuffer[T]
 	gradientSync  map[string][]float64 // modelID -> gradients
 
 	// Synchronization
@@ -177,8 +185,17 @@ func New[T ConsensusData](
 	model Model[T],
 	quasarEngine *quasar.Quasar,
 	photonEngine *photon.UniformEmitter,
+	opts ...AgentOption,
 ) *Agent[T] {
-	return &Agent[T]{
+	var o agentOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	seed := time.Now().UnixNano()
+	if o.deterministic {
+		seed = o.seed
+	}
+	a := &Agent[T]{
 		nodeID:         nodeID,
 		model:          model,
 		quasar:         quasarEngine,
@@ -187,10 +204,11 @@ func New[T ConsensusData](
 		hallucinations: make(map[string]*Hallucination[T]),
 		weights:        make(map[string]float64),
 		usage:          make(map[string]int64),
+		trainingData:   newTrainingBuffer[T](o.training, seed),
 		memory: &SharedMemory[T]{
 			modelStates:   make(map[string]map[string]interface{}),
 			nodeWeights:   make(map[string]float64),
-			trainingQueue: make([]TrainingExample[T], 0),
+			trainingQueue: newTrainingBuffer[T](o.training, seed),
 			gradientSync:  make(map[string][]float64),
 			syncInterval:  30 * time.Second,
 		},
@@ -200,6 +218,15 @@ func New[T ConsensusData](
 		},
 		lastUpdate: time.Now(),
 	}
+	if o.deterministic {
+		a.det.SetDeterministicSeed(o.seed)
+		if dm, ok := model.(DeterministicModel[T]); ok {
+			dm.SetDeterministicSeed(o.seed)
+		} else {
+			a.seedErr = fmt.Errorf("%w: %T", ErrModelNotDeterministic, model)
+		}
+	}
+	return a
 }
 
 // === SHARED HALLUCINATION CONSENSUS ===
@@ -209,6 +236,10 @@ func (a *Agent[T]) ProposeDecision(ctx context.Context, input T, context map[str
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	if a.seedErr != nil {
+		return nil, a.seedErr
+	}
+
 	// Phase 1: Photon - Emit proposal
 	proposal, err := a.model.ProposeDecision(ctx, input)
 	if err != nil {
@@ -263,11 +294,11 @@ func (a *Agent[T]) AddTrainingData(example TrainingExample[T]) {
 	}
 
 	example.Weight = nodeWeight
-	a.trainingData = append(a.trainingData, example)
+	a.trainingData.add(example)
 
 	// Add to shared memory
 	a.memory.mu.Lock()
-	a.memory.trainingQueue = append(a.memory.trainingQueue, example)
+	a.memory.trainingQueue.add(example)
 	a.memory.mu.Unlock()
 
 	// Update usage statistics
@@ -275,6 +306,14 @@ func (a *Agent[T]) AddTrainingData(example TrainingExample[T]) {
 	a.usage[modelAction]++
 }
 
+// TrainingBufferStats reports the size of the agent's training data and how
+// many examples were evicted to keep it within its limits
+func (a *Agent[T]) TrainingBufferStats() TrainingBufferStats {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.trainingData.stats()
+}
+
 // SyncSharedMemory synchronizes model state across network
 func (a *Agent[T]) SyncSharedMemory(ctx context.Context) error {
 	a.memory.mu.Lock()
@@ -297,11 +336,11 @@ func (a *Agent[T]) SyncSharedMemory(ctx context.Context) error {
 	}
 
 	// Train on shared examples
-	if len(a.memory.trainingQueue) > 0 {
-		if err := a.model.Learn(a.memory.trainingQueue); err != nil {
+	if len(a.memory.trainingQueue.examples) > 0 {
+		if err := a.model.Learn(a.memory.trainingQueue.examples); err != nil {
 			return fmt.Errorf("shared learning failed: %w", err)
 		}
-		a.memory.trainingQueue = a.memory.trainingQueue[:0] // clear
+		a.memory.trainingQueue.reset()
 	}
 
 	a.memory.lastSync = time.Now()
@@ -348,12 +387,10 @@ func (a *Agent[T]) broadcastProposal(proposal *Proposal[T]) error {
 	if err != nil {
 		return fmt.Errorf("photon broadcast failed: %w", err)
 	}
-	// Track emitted nodes for vote collection
-	a.mu.Lock()
+	// Track emitted nodes for vote collection; ProposeDecision holds a.mu
 	for _, nodeID := range nodes {
 		a.consensus.Participants = append(a.consensus.Participants, nodeID.String())
 	}
-	a.mu.Unlock()
 	return nil
 }
 
@@ -4,9 +4,16 @@
 package ai
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/photon"
 )
 
 // === MOCK IMPLEMENTATIONS ===
@@ -154,18 +161,18 @@ func TestAgentAddTrainingData(t *testing.T) {
 	agent.AddTrainingData(example)
 
 	// Check training data was added
-	if len(agent.trainingData) != 1 {
-		t.Errorf("Expected 1 training example, got %d", len(agent.trainingData))
+	if len(agent.trainingData.examples) != 1 {
+		t.Errorf("Expected 1 training example, got %d", len(agent.trainingData.examples))
 	}
 
 	// Check weight was applied
-	if agent.trainingData[0].Weight != 0.8 {
-		t.Errorf("Expected weight 0.8, got %f", agent.trainingData[0].Weight)
+	if agent.trainingData.examples[0].Weight != 0.8 {
+		t.Errorf("Expected weight 0.8, got %f", agent.trainingData.examples[0].Weight)
 	}
 
 	// Check shared memory
-	if len(agent.memory.trainingQueue) != 1 {
-		t.Errorf("Expected 1 example in memory queue, got %d", len(agent.memory.trainingQueue))
+	if len(agent.memory.trainingQueue.examples) != 1 {
+		t.Errorf("Expected 1 example in memory queue, got %d", len(agent.memory.trainingQueue.examples))
 	}
 
 	// Check usage tracking
@@ -189,8 +196,8 @@ func TestAgentAddTrainingData_NewNode(t *testing.T) {
 	agent.AddTrainingData(example)
 
 	// Check default weight was applied
-	if agent.trainingData[0].Weight != 0.1 {
-		t.Errorf("Expected default weight 0.1, got %f", agent.trainingData[0].Weight)
+	if agent.trainingData.examples[0].Weight != 0.1 {
+		t.Errorf("Expected default weight 0.1, got %f", agent.trainingData.examples[0].Weight)
 	}
 }
 
@@ -225,13 +232,11 @@ func TestAgentSyncSharedMemory_Success(t *testing.T) {
 	agent.memory.lastSync = time.Now().Add(-1 * time.Minute)
 
 	// Add training examples
-	agent.memory.trainingQueue = []TrainingExample[BlockData]{
-		{
-			Input:    BlockData{Height: 100, Timestamp: time.Now()},
-			Output:   Decision[BlockData]{Action: "approve"},
-			Feedback: 1.0,
-		},
-	}
+	agent.memory.trainingQueue.add(TrainingExample[BlockData]{
+		Input:    BlockData{Height: 100, Timestamp: time.Now()},
+		Output:   Decision[BlockData]{Action: "approve"},
+		Feedback: 1.0,
+	})
 
 	ctx := context.Background()
 	err := agent.SyncSharedMemory(ctx)
@@ -241,8 +246,8 @@ func TestAgentSyncSharedMemory_Success(t *testing.T) {
 	}
 
 	// Check training queue was cleared
-	if len(agent.memory.trainingQueue) != 0 {
-		t.Errorf("Expected empty training queue, got %d items", len(agent.memory.trainingQueue))
+	if len(agent.memory.trainingQueue.examples) != 0 {
+		t.Errorf("Expected empty training queue, got %d items", len(agent.memory.trainingQueue.examples))
 	}
 
 	// Check lastSync was updated
@@ -276,9 +281,7 @@ func TestAgentSyncSharedMemory_LearnError(t *testing.T) {
 	}
 	agent := New[BlockData]("test-node", model, nil, nil)
 	agent.memory.lastSync = time.Now().Add(-1 * time.Minute)
-	agent.memory.trainingQueue = []TrainingExample[BlockData]{
-		{Input: BlockData{Height: 100, Timestamp: time.Now()}},
-	}
+	agent.memory.trainingQueue.add(TrainingExample[BlockData]{Input: BlockData{Height: 100, Timestamp: time.Now()}})
 
 	ctx := context.Background()
 	err := agent.SyncSharedMemory(ctx)
@@ -513,8 +516,8 @@ func TestAgentConcurrentAddTraining(t *testing.T) {
 		<-done
 	}
 
-	if len(agent.trainingData) != numGoroutines {
-		t.Errorf("Expected %d training examples, got %d", numGoroutines, len(agent.trainingData))
+	if len(agent.trainingData.examples) != numGoroutines {
+		t.Errorf("Expected %d training examples, got %d", numGoroutines, len(agent.trainingData.examples))
 	}
 }
 
@@ -552,3 +555,83 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+// === DETERMINISTIC MODE TESTS ===
+
+func newDeterministicAgent(t *testing.T, seed int64, training []TrainingExample[TransactionData]) *Agent[TransactionData] {
+	t.Helper()
+	nodes := []types.NodeID{{1}, {2}, {3}}
+	emitter := photon.NewUniformEmitter(nodes, photon.DefaultEmitterOptions())
+	model := NewSimpleModel[TransactionData]("test-node", &TransactionFeatureExtractor{})
+	agent := New[TransactionData]("test-node", model, nil, emitter, WithDeterministicSeed(seed))
+	if err := model.Learn(training); err != nil {
+		t.Fatalf("Learn failed: %v", err)
+	}
+	return agent
+}
+
+func TestAgentDeterministicSeed(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var training []TrainingExample[TransactionData]
+	for i := 0; i < 50; i++ {
+		training = append(training, TrainingExample[TransactionData]{
+			Input: TransactionData{
+				From:      "0xaaaa",
+				To:        "0xbbbb",
+				Amount:    uint64(i%5 + 1),
+				Fee:       1,
+				Timestamp: base,
+			},
+			Feedback: 1,
+			Weight:   1,
+		})
+	}
+
+	a := newDeterministicAgent(t, 42, training)
+	b := newDeterministicAgent(t, 42, training)
+	other := newDeterministicAgent(t, 7, training)
+
+	for i := 0; i < 20; i++ {
+		input := TransactionData{
+			Hash:      fmt.Sprintf("0x%04x", i),
+			From:      "0xaaaa",
+			To:        "0xbbbb",
+			Amount:    uint64(i + 1),
+			Fee:       uint64(i%3 + 1),
+			Data:      map[string]interface{}{"memo": i, "kind": "transfer"},
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+		}
+
+		da, err := a.ProposeDecision(context.Background(), input, nil)
+		if err != nil {
+			t.Fatalf("input %d: ProposeDecision failed: %v", i, err)
+		}
+		db, err := b.ProposeDecision(context.Background(), input, nil)
+		if err != nil {
+			t.Fatalf("input %d: ProposeDecision failed: %v", i, err)
+		}
+		ja, _ := json.Marshal(da)
+		jb, _ := json.Marshal(db)
+		if !bytes.Equal(ja, jb) {
+			t.Fatalf("input %d: decisions differ:\n%s\n%s", i, ja, jb)
+		}
+
+		do, err := other.ProposeDecision(context.Background(), input, nil)
+		if err != nil {
+			t.Fatalf("input %d: ProposeDecision failed: %v", i, err)
+		}
+		if do.ID == da.ID {
+			t.Errorf("input %d: different seeds derived the same decision ID %s", i, da.ID)
+		}
+	}
+}
+
+func TestAgentDeterministicSeedNeedsSeedableModel(t *testing.T) {
+	model := &mockAgentModel[BlockData]{}
+	agent := New[BlockData]("test-node", model, nil, nil, WithDeterministicSeed(1))
+
+	_, err := agent.ProposeDecision(context.Background(), BlockData{}, nil)
+	if !errors.Is(err, ErrModelNotDeterministic) {
+		t.Fatalf("expected ErrModelNotDeterministic, got %v", err)
+	}
+}
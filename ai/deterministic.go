@@ -0,0 +1,115 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Deterministic Mode - Reproducible decisions for consensus on AI output
+
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ErrModelNotDeterministic is returned by ProposeDecision when the agent was
+// built with WithDeterministicSeed around a model that cannot be seeded
+var ErrModelNotDeterministic = errors.New("ai: model does not support deterministic mode")
+
+// AgentOption configures an Agent built by New
+type AgentOption func(*agentOptions)
+
+type agentOptions struct {
+	deterministic bool
+	seed          int64
+	training      TrainingBufferConfig
+}
+
+// WithDeterministicSeed makes ProposeDecision reproducible: for a given
+// model, training set and input, agents built with the same seed return
+// byte-identical Decisions, so every node computes the same decision. The
+// model must implement DeterministicModel.
+func WithDeterministicSeed(seed int64) AgentOption {
+	return func(o *agentOptions) {
+		o.deterministic = true
+		o.seed = seed
+	}
+}
+
+// DeterministicModel is a Model that can run reproducibly. Once seeded, its
+// decisions depend only on the seed, its training and the input: IDs are
+// derived rather than random, timestamps are zero and wall-clock features
+// such as data age are reported as 0.
+type DeterministicModel[T ConsensusData] interface {
+	Model[T]
+	SetDeterministicSeed(seed int64)
+}
+
+// ClockedExtractor is a FeatureExtractor whose time-relative features can
+// be measured against a given time instead of the wall clock. A zero now
+// reports them as 0.
+type ClockedExtractor[T ConsensusData] interface {
+	FeatureExtractor[T]
+	ExtractAt(data T, now time.Time) map[string]float64
+}
+
+// determinism holds the seed of a model in deterministic mode
+type determinism struct {
+	on   bool
+	seed int64
+}
+
+// SetDeterministicSeed switches the model to deterministic mode
+func (d *determinism) SetDeterministicSeed(seed int64) {
+	d.on = true
+	d.seed = seed
+}
+
+// now returns the wall clock, or the zero time in deterministic mode
+func (d *determinism) now() time.Time {
+	if d.on {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// newID returns a random ID, or in deterministic mode one derived from the
+// seed and parts
+func (d *determinism) newID(parts ...string) string {
+	if !d.on {
+		return generateID()
+	}
+	h := sha256.New()
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(d.seed)))
+	for _, p := range parts {
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(p))))
+		h.Write([]byte(p))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+// inputKey encodes input for ID derivation. JSON sorts map keys and drops
+// monotonic clock readings, so equal inputs encode equally on every node.
+func inputKey[T ConsensusData](input T) string {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Sprintf("%v", input)
+	}
+	return string(b)
+}
+
+// sortedFeatures returns the feature names in a fixed order, so sums over
+// features round identically on every run
+func sortedFeatures(features map[string]float64) []string {
+	return slices.Sorted(maps.Keys(features))
+}
+
+// elapsed returns the time from t to now, or 0 for a zero now
+func elapsed(now, t time.Time) time.Duration {
+	if now.IsZero() {
+		return 0
+	}
+	return now.Sub(t)
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Ensemble Models - Combine sub-model decisions into one
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AggregationStrategy selects how an EnsembleModel folds sub-model decisions
+type AggregationStrategy string
+
+const (
+	// AggregateMajority picks the action most sub-models chose; a tie goes
+	// to "reject". Confidence is the winners' share of the votes times
+	// their mean confidence.
+	AggregateMajority AggregationStrategy = "majority"
+
+	// AggregateWeightedConfidence sums confidence per action and picks the
+	// largest sum. Confidence is that sum's share of the total.
+	AggregateWeightedConfidence AggregationStrategy = "weighted_confidence"
+
+	// AggregateVeto rejects if any sub-model rejects, with the strongest
+	// rejecting confidence. Otherwise it takes the majority action with
+	// the weakest confidence of all sub-models.
+	AggregateVeto AggregationStrategy = "veto"
+)
+
+// EnsembleModel runs several models on every input and folds their
+// decisions with an AggregationStrategy. Training fans out to every
+// sub-model.
+type EnsembleModel[T ConsensusData] struct {
+	models   []Model[T]
+	strategy AggregationStrategy
+	determinism
+}
+
+// NewEnsembleModel combines models under strategy
+func NewEnsembleModel[T ConsensusData](models []Model[T], strategy AggregationStrategy) *EnsembleModel[T] {
+	return &EnsembleModel[T]{
+		models:   append([]Model[T](nil), models...),
+		strategy: strategy,
+	}
+}
+
+// Decide runs every sub-model and folds their decisions
+func (e *EnsembleModel[T]) Decide(ctx context.Context, input T, context map[string]interface{}) (*Decision[T], error) {
+	if len(e.models) == 0 {
+		return nil, fmt.Errorf("ensemble has no models")
+	}
+
+	decisions := make([]*Decision[T], len(e.models))
+	for i, m := range e.models {
+		d, err := m.Decide(ctx, input, context)
+		if err != nil {
+			return nil, fmt.Errorf("ensemble model %d: %w", i, err)
+		}
+		decisions[i] = d
+	}
+
+	var action string
+	var confidence float64
+	switch e.strategy {
+	case AggregateMajority:
+		action, confidence = majorityVote(decisions)
+	case AggregateWeightedConfidence:
+		action, confidence = weightedConfidenceVote(decisions)
+	case AggregateVeto:
+		action, confidence = vetoVote(decisions)
+	default:
+		return nil, fmt.Errorf("unknown aggregation strategy %q", e.strategy)
+	}
+
+	alternatives := make([]string, 0)
+	for _, d := range decisions {
+		if d.Action != action && !slices.Contains(alternatives, d.Action) {
+			alternatives = append(alternatives, d.Action)
+		}
+	}
+
+	reasoning := e.generateReasoning(decisions, action, confidence)
+	return &Decision[T]{
+		ID:           e.newID("ensemble_decision", inputKey(input), action, reasoning),
+		Action:       action,
+		Data:         input,
+		Confidence:   confidence,
+		Reasoning:    reasoning,
+		Alternatives: alternatives,
+		Context:      context,
+		Timestamp:    e.now(),
+		ProposerID:   decisions[0].ProposerID,
+		VoteCount:    len(decisions),
+	}, nil
+}
+
+// ProposeDecision creates a proposal for consensus from the folded decision
+func (e *EnsembleModel[T]) ProposeDecision(ctx context.Context, input T) (*Proposal[T], error) {
+	decision, err := e.Decide(ctx, input, make(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Proposal[T]{
+		ID:       e.newID("ensemble_proposal", decision.ID),
+		NodeID:   decision.ProposerID,
+		Decision: decision,
+		Evidence: []Evidence[T]{{
+			Data:      input,
+			NodeID:    decision.ProposerID,
+			Weight:    1.0,
+			Timestamp: e.now(),
+		}},
+		Weight:     1.0,
+		Confidence: decision.Confidence,
+		Timestamp:  e.now(),
+	}, nil
+}
+
+// ValidateProposal returns the mean validation of all sub-models
+func (e *EnsembleModel[T]) ValidateProposal(proposal *Proposal[T]) (float64, error) {
+	if len(e.models) == 0 {
+		return 0.0, fmt.Errorf("ensemble has no models")
+	}
+
+	total := 0.0
+	for i, m := range e.models {
+		v, err := m.ValidateProposal(proposal)
+		if err != nil {
+			return 0.0, fmt.Errorf("ensemble model %d: %w", i, err)
+		}
+		total += v
+	}
+	return total / float64(len(e.models)), nil
+}
+
+// Learn trains every sub-model on examples
+func (e *EnsembleModel[T]) Learn(examples []TrainingExample[T]) error {
+	for i, m := range e.models {
+		if err := m.Learn(examples); err != nil {
+			return fmt.Errorf("ensemble model %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// UpdateWeights applies gradients to every sub-model
+func (e *EnsembleModel[T]) UpdateWeights(gradients []float64) error {
+	for i, m := range e.models {
+		if err := m.UpdateWeights(gradients); err != nil {
+			return fmt.Errorf("ensemble model %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetState returns the strategy and every sub-model's state
+func (e *EnsembleModel[T]) GetState() map[string]interface{} {
+	states := make([]map[string]interface{}, len(e.models))
+	for i, m := range e.models {
+		states[i] = m.GetState()
+	}
+	return map[string]interface{}{
+		"strategy": string(e.strategy),
+		"models":   states,
+	}
+}
+
+// LoadState loads per-model states saved by GetState. A state without
+// them, such as an aggregate of shared numeric values, is passed to every
+// sub-model as is.
+func (e *EnsembleModel[T]) LoadState(state map[string]interface{}) error {
+	states, ok := state["models"].([]map[string]interface{})
+	if !ok {
+		for i, m := range e.models {
+			if err := m.LoadState(state); err != nil {
+				return fmt.Errorf("ensemble model %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	if len(states) != len(e.models) {
+		return fmt.Errorf("ensemble state size mismatch: got %d, expected %d", len(states), len(e.models))
+	}
+	for i, m := range e.models {
+		if err := m.LoadState(states[i]); err != nil {
+			return fmt.Errorf("ensemble model %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Strategy returns the aggregation strategy
+func (e *EnsembleModel[T]) Strategy() AggregationStrategy {
+	return e.strategy
+}
+
+// SetDeterministicSeed switches the ensemble and every sub-model that
+// supports it to deterministic mode
+func (e *EnsembleModel[T]) SetDeterministicSeed(seed int64) {
+	e.determinism.SetDeterministicSeed(seed)
+	for _, m := range e.models {
+		if dm, ok := m.(DeterministicModel[T]); ok {
+			dm.SetDeterministicSeed(seed)
+		}
+	}
+}
+
+// Private methods
+
+func (e *EnsembleModel[T]) generateReasoning(decisions []*Decision[T], action string, confidence float64) string {
+	contributions := make([]string, len(decisions))
+	for i, d := range decisions {
+		contributions[i] = fmt.Sprintf("model%d=%s(%.2f)", i, d.Action, d.Confidence)
+	}
+	return fmt.Sprintf("Ensemble (%s) decision to %s with confidence %.3f. Contributions: %s",
+		e.strategy, action, confidence, strings.Join(contributions, ", "))
+}
+
+// tally groups decisions by action in first-seen order
+func tally[T ConsensusData](decisions []*Decision[T]) (actions []string, votes map[string]int, confidence map[string]float64) {
+	votes = make(map[string]int)
+	confidence = make(map[string]float64)
+	for _, d := range decisions {
+		if _, ok := votes[d.Action]; !ok {
+			actions = append(actions, d.Action)
+		}
+		votes[d.Action]++
+		confidence[d.Action] += d.Confidence
+	}
+	return actions, votes, confidence
+}
+
+// preferReject breaks a tie between candidate and best in favour of reject
+func preferReject(candidate, best string) bool {
+	return candidate == "reject" && best != "reject"
+}
+
+func majorityVote[T ConsensusData](decisions []*Decision[T]) (string, float64) {
+	actions, votes, confidence := tally(decisions)
+	best := actions[0]
+	for _, a := range actions[1:] {
+		if votes[a] > votes[best] || (votes[a] == votes[best] && preferReject(a, best)) {
+			best = a
+		}
+	}
+	share := float64(votes[best]) / float64(len(decisions))
+	return best, share * confidence[best] / float64(votes[best])
+}
+
+func weightedConfidenceVote[T ConsensusData](decisions []*Decision[T]) (string, float64) {
+	actions, _, confidence := tally(decisions)
+	best := actions[0]
+	total := 0.0
+	for _, a := range actions {
+		total += confidence[a]
+		if confidence[a] > confidence[best] || (confidence[a] == confidence[best] && preferReject(a, best)) {
+			best = a
+		}
+	}
+	if total == 0 {
+		return best, 0.0
+	}
+	return best, confidence[best] / total
+}
+
+func vetoVote[T ConsensusData](decisions []*Decision[T]) (string, float64) {
+	vetoed := false
+	strongest, weakest := 0.0, 1.0
+	for _, d := range decisions {
+		if d.Action == "reject" {
+			vetoed = true
+			if d.Confidence > strongest {
+				strongest = d.Confidence
+			}
+		}
+		if d.Confidence < weakest {
+			weakest = d.Confidence
+		}
+	}
+	if vetoed {
+		return "reject", strongest
+	}
+	action, _ := majorityVote(decisions)
+	return action, weakest
+}
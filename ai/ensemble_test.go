@@ -0,0 +1,121 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Tests for Ensemble Models
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedModel always decides action with confidence and records training
+type fixedModel struct {
+	mockAgentModel[BlockData]
+	action     string
+	confidence float64
+	learned    int
+}
+
+func (m *fixedModel) Decide(ctx context.Context, input BlockData, context map[string]interface{}) (*Decision[BlockData], error) {
+	return &Decision[BlockData]{
+		ID:         generateID(),
+		Action:     m.action,
+		Data:       input,
+		Confidence: m.confidence,
+		Context:    context,
+		Timestamp:  time.Now(),
+		ProposerID: "node-1",
+	}, nil
+}
+
+func (m *fixedModel) Learn(examples []TrainingExample[BlockData]) error {
+	m.learned += len(examples)
+	return nil
+}
+
+// disagreeing is two weak approvals against one confident rejection
+func disagreeing() []Model[BlockData] {
+	return []Model[BlockData]{
+		&fixedModel{action: "approve", confidence: 0.4},
+		&fixedModel{action: "approve", confidence: 0.45},
+		&fixedModel{action: "reject", confidence: 0.95},
+	}
+}
+
+func TestEnsembleStrategies(t *testing.T) {
+	tests := []struct {
+		strategy   AggregationStrategy
+		models     []Model[BlockData]
+		action     string
+		confidence float64
+	}{
+		// Two of three approve
+		{AggregateMajority, disagreeing(), "approve", 2.0 / 3.0 * 0.425},
+		// Reject carries 0.95 of the 1.8 total confidence
+		{AggregateWeightedConfidence, disagreeing(), "reject", 0.95 / 1.8},
+		// A single reject vetoes
+		{AggregateVeto, disagreeing(), "reject", 0.95},
+		// No veto: the weakest approval bounds confidence
+		{AggregateVeto, disagreeing()[:2], "approve", 0.4},
+		// A tie goes to reject
+		{AggregateMajority, disagreeing()[1:], "reject", 0.5 * 0.95},
+	}
+
+	for _, tt := range tests {
+		ensemble := NewEnsembleModel(tt.models, tt.strategy)
+		proposal, err := ensemble.ProposeDecision(context.Background(), BlockData{Height: 1})
+		if err != nil {
+			t.Fatalf("%s: ProposeDecision failed: %v", tt.strategy, err)
+		}
+
+		d := proposal.Decision
+		if d.Action != tt.action {
+			t.Errorf("%s with %d models: expected %s, got %s", tt.strategy, len(tt.models), tt.action, d.Action)
+		}
+		if math.Abs(d.Confidence-tt.confidence) > 1e-9 {
+			t.Errorf("%s with %d models: expected confidence %.4f, got %.4f", tt.strategy, len(tt.models), tt.confidence, d.Confidence)
+		}
+		if proposal.Confidence != d.Confidence {
+			t.Errorf("%s: proposal confidence %.4f differs from decision %.4f", tt.strategy, proposal.Confidence, d.Confidence)
+		}
+		for i := range tt.models {
+			if want := fmt.Sprintf("model%d=", i); !strings.Contains(d.Reasoning, want) {
+				t.Errorf("%s: reasoning %q missing %s", tt.strategy, d.Reasoning, want)
+			}
+		}
+	}
+}
+
+func TestEnsembleUnknownStrategy(t *testing.T) {
+	ensemble := NewEnsembleModel(disagreeing(), "median")
+	if _, err := ensemble.Decide(context.Background(), BlockData{}, nil); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestEnsembleTrainingFansOut(t *testing.T) {
+	models := disagreeing()
+	agent := New[BlockData]("node-1", NewEnsembleModel(models, AggregateMajority), nil, nil)
+
+	for i := 0; i < 3; i++ {
+		agent.AddTrainingData(TrainingExample[BlockData]{
+			Input:    BlockData{Height: uint64(i)},
+			Output:   Decision[BlockData]{Action: "approve"},
+			Feedback: 1.0,
+			NodeID:   "node-2",
+		})
+	}
+	if err := agent.SyncSharedMemory(context.Background()); err != nil {
+		t.Fatalf("SyncSharedMemory failed: %v", err)
+	}
+
+	for i, m := range models {
+		if got := m.(*fixedModel).learned; got != 3 {
+			t.Errorf("model %d: expected 3 training examples, got %d", i, got)
+		}
+	}
+}
@@ -20,6 +20,7 @@ type SimpleModel[T ConsensusData] struct {
 	features     FeatureExtractor[T]
 	history      []TrainingExample[T]
 	nodeID       string
+	determinism
 }
 
 // FeatureExtractor converts consensus data to features for ML
@@ -42,14 +43,10 @@ func NewSimpleModel[T ConsensusData](nodeID string, extractor FeatureExtractor[T
 
 // Decide makes a decision based on current model state
 func (m *SimpleModel[T]) Decide(ctx context.Context, input T, context map[string]interface{}) (*Decision[T], error) {
-	features := m.features.Extract(input)
+	features := m.extract(input)
 
 	// Simple linear decision function
-	score := m.bias
-	for feature, value := range features {
-		weight := m.weights[feature]
-		score += weight * value
-	}
+	score := m.score(features)
 
 	// Convert to probability
 	confidence := sigmoid(score)
@@ -64,13 +61,13 @@ func (m *SimpleModel[T]) Decide(ctx context.Context, input T, context map[string
 	reasoning := m.generateReasoning(features, score, action)
 
 	decision := &Decision[T]{
-		ID:         generateID(),
+		ID:         m.newID("decision", inputKey(input), action, reasoning),
 		Action:     action,
 		Data:       input,
 		Confidence: confidence,
 		Reasoning:  reasoning,
 		Context:    context,
-		Timestamp:  time.Now(),
+		Timestamp:  m.now(),
 		ProposerID: m.nodeID,
 	}
 
@@ -85,18 +82,18 @@ func (m *SimpleModel[T]) ProposeDecision(ctx context.Context, input T) (*Proposa
 	}
 
 	proposal := &Proposal[T]{
-		ID:       generateID(),
+		ID:       m.newID("proposal", decision.ID),
 		NodeID:   m.nodeID,
 		Decision: decision,
 		Evidence: []Evidence[T]{{
 			Data:      input,
 			NodeID:    m.nodeID,
 			Weight:    1.0,
-			Timestamp: time.Now(),
+			Timestamp: m.now(),
 		}},
 		Weight:     1.0,
 		Confidence: decision.Confidence,
-		Timestamp:  time.Now(),
+		Timestamp:  m.now(),
 	}
 
 	return proposal, nil
@@ -204,13 +201,10 @@ func (m *SimpleModel[T]) LoadState(state map[string]interface{}) error {
 // Private methods
 
 func (m *SimpleModel[T]) learnExample(example TrainingExample[T]) error {
-	features := m.features.Extract(example.Input)
+	features := m.extract(example.Input)
 
 	// Current prediction
-	score := m.bias
-	for feature, value := range features {
-		score += m.weights[feature] * value
-	}
+	score := m.score(features)
 
 	prediction := sigmoid(score)
 
@@ -221,7 +215,8 @@ func (m *SimpleModel[T]) learnExample(example TrainingExample[T]) error {
 	error := target - prediction
 
 	// Gradient descent update
-	for feature, value := range features {
+	for _, feature := range sortedFeatures(features) {
+		value := features[feature]
 		gradient := error * prediction * (1 - prediction) * value * example.Weight
 		m.weights[feature] += m.learningRate * gradient
 	}
@@ -233,10 +228,29 @@ func (m *SimpleModel[T]) learnExample(example TrainingExample[T]) error {
 	return nil
 }
 
+// extract runs the feature extractor, off the wall clock in deterministic
+// mode when the extractor allows it
+func (m *SimpleModel[T]) extract(input T) map[string]float64 {
+	if ce, ok := m.features.(ClockedExtractor[T]); ok && m.on {
+		return ce.ExtractAt(input, time.Time{})
+	}
+	return m.features.Extract(input)
+}
+
+// score is the linear score of features, summed in feature-name order
+func (m *SimpleModel[T]) score(features map[string]float64) float64 {
+	score := m.bias
+	for _, feature := range sortedFeatures(features) {
+		score += m.weights[feature] * features[feature]
+	}
+	return score
+}
+
 func (m *SimpleModel[T]) generateReasoning(features map[string]float64, score float64, action string) string {
 	// Find most influential features
 	topFeatures := make([]string, 0)
-	for feature, value := range features {
+	for _, feature := range sortedFeatures(features) {
+		value := features[feature]
 		weight := m.weights[feature]
 		influence := math.Abs(weight * value)
 
@@ -257,8 +271,16 @@ func (m *SimpleModel[T]) generateReasoning(features map[string]float64, score fl
 type BlockFeatureExtractor struct{}
 
 func (e *BlockFeatureExtractor) Extract(data BlockData) map[string]float64 {
-	now := time.Now()
-	age := now.Sub(data.Timestamp).Seconds()
+	return e.ExtractAt(data, time.Now())
+}
+
+// ExtractAt extracts features with block age measured at now
+func (e *BlockFeatureExtractor) ExtractAt(data BlockData, now time.Time) map[string]float64 {
+	age := elapsed(now, data.Timestamp).Seconds()
+	sinceLast := 0.0
+	if !now.IsZero() {
+		sinceLast = float64(now.Unix() - data.Timestamp.Unix())
+	}
 
 	return map[string]float64{
 		"height":          float64(data.Height),
@@ -266,7 +288,7 @@ func (e *BlockFeatureExtractor) Extract(data BlockData) map[string]float64 {
 		"age_seconds":     age,
 		"age_normalized":  sigmoid(age / 3600), // normalize by hour
 		"hash_complexity": hashComplexity(data.Hash),
-		"time_since_last": float64(now.Unix() - data.Timestamp.Unix()),
+		"time_since_last": sinceLast,
 	}
 }
 
@@ -278,12 +300,17 @@ func (e *BlockFeatureExtractor) Names() []string {
 type TransactionFeatureExtractor struct{}
 
 func (e *TransactionFeatureExtractor) Extract(data TransactionData) map[string]float64 {
+	return e.ExtractAt(data, time.Now())
+}
+
+// ExtractAt extracts features with transaction age measured at now
+func (e *TransactionFeatureExtractor) ExtractAt(data TransactionData, now time.Time) map[string]float64 {
 	return map[string]float64{
 		"amount":       float64(data.Amount),
 		"fee":          float64(data.Fee),
 		"fee_ratio":    float64(data.Fee) / math.Max(float64(data.Amount), 1),
 		"data_size":    float64(len(fmt.Sprintf("%v", data.Data))),
-		"age_seconds":  time.Since(data.Timestamp).Seconds(),
+		"age_seconds":  elapsed(now, data.Timestamp).Seconds(),
 		"from_entropy": addressEntropy(data.From),
 		"to_entropy":   addressEntropy(data.To),
 	}
@@ -297,6 +324,11 @@ func (e *TransactionFeatureExtractor) Names() []string {
 type UpgradeFeatureExtractor struct{}
 
 func (e *UpgradeFeatureExtractor) Extract(data UpgradeData) map[string]float64 {
+	return e.ExtractAt(data, time.Now())
+}
+
+// ExtractAt extracts features with upgrade age measured at now
+func (e *UpgradeFeatureExtractor) ExtractAt(data UpgradeData, now time.Time) map[string]float64 {
 	riskScore := 0.0
 	switch data.Risk {
 	case "low":
@@ -313,7 +345,7 @@ func (e *UpgradeFeatureExtractor) Extract(data UpgradeData) map[string]float64 {
 		"change_count":    float64(len(data.Changes)),
 		"risk_score":      riskScore,
 		"test_count":      float64(len(data.TestResults)),
-		"age_hours":       time.Since(data.Timestamp).Hours(),
+		"age_hours":       elapsed(now, data.Timestamp).Hours(),
 		"version_entropy": versionEntropy(data.Version),
 	}
 }
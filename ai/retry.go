@@ -0,0 +1,137 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Retry Policy - Bounded time and retries for agent decisions
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrTransient marks a model error worth retrying. Models wrap it into
+	// errors caused by temporary conditions such as an overloaded backend.
+	ErrTransient = errors.New("ai: transient model error")
+
+	// ErrDecisionTimeout is returned when a decision attempt outlives
+	// RetryPolicy.Timeout
+	ErrDecisionTimeout = errors.New("ai: decision timed out")
+)
+
+// DefaultAction is the decision ProposeDecisionWithPolicy falls back to
+// once every attempt has failed
+type DefaultAction string
+
+const (
+	DefaultNone    DefaultAction = ""        // return the last error instead
+	DefaultApprove DefaultAction = "approve" // approve without a model decision
+	DefaultReject  DefaultAction = "reject"  // reject without a model decision
+	DefaultReview  DefaultAction = "review"  // defer to manual review
+)
+
+// RetryPolicy bounds how long ProposeDecisionWithPolicy waits for a decision
+type RetryPolicy struct {
+	Timeout    time.Duration // bound on each attempt; 0 waits indefinitely
+	MaxRetries int           // attempts after the first, made on transient errors
+	Backoff    time.Duration // wait before the first retry, doubled before each later one
+	Default    DefaultAction // decision returned when every attempt failed
+}
+
+// ProposeDecisionWithPolicy runs ProposeDecision under policy. Attempts that
+// time out or fail with ErrTransient are retried up to MaxRetries times;
+// other errors are final. When the last attempt fails, the error is
+// returned, wrapping ErrDecisionTimeout if it timed out, unless policy
+// names a Default, in which case a zero-confidence decision with that
+// action is returned instead. Once ctx is done, ctx's error is returned
+// and no default is applied. In deterministic mode the default decision's
+// ID and timestamp are derived like the model's.
+//
+// A model that ignores ctx keeps running after its attempt times out and
+// holds the agent until it returns, so the attempts after it time out too.
+func (a *Agent[T]) ProposeDecisionWithPolicy(ctx context.Context, input T, ctxMap map[string]interface{}, policy RetryPolicy) (*Decision[T], error) {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var decision *Decision[T]
+		decision, err = a.attemptDecision(ctx, input, ctxMap, policy.Timeout)
+		if err == nil {
+			return decision, nil
+		}
+		if ctx.Err() != nil || !isTransient(err) || attempt >= policy.MaxRetries {
+			break
+		}
+		if err = sleepContext(ctx, policy.Backoff<<attempt); err != nil {
+			break
+		}
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if policy.Default == DefaultNone {
+		return nil, err
+	}
+	action := string(policy.Default)
+	return &Decision[T]{
+		ID:         a.det.newID("default_decision", a.nodeID, inputKey(input), action),
+		Action:     action,
+		Data:       input,
+		Reasoning:  fmt.Sprintf("default decision: %v", err),
+		Context:    ctxMap,
+		Timestamp:  a.det.now(),
+		ProposerID: a.nodeID,
+	}, nil
+}
+
+// attemptDecision runs one ProposeDecision bounded by timeout
+func (a *Agent[T]) attemptDecision(ctx context.Context, input T, ctxMap map[string]interface{}, timeout time.Duration) (*Decision[T], error) {
+	if timeout <= 0 {
+		return a.ProposeDecision(ctx, input, ctxMap)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		decision *Decision[T]
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		decision, err := a.ProposeDecision(attemptCtx, input, ctxMap)
+		done <- result{decision, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+			return nil, fmt.Errorf("%w: after %v: %w", ErrDecisionTimeout, timeout, r.err)
+		}
+		return r.decision, r.err
+	case <-attemptCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: after %v", ErrDecisionTimeout, timeout)
+	}
+}
+
+func isTransient(err error) bool {
+	return errors.Is(err, ErrTransient) || errors.Is(err, ErrDecisionTimeout)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Retry Policy - Tests
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/photon"
+)
+
+// scriptedModel answers ProposeDecision through propose, counting calls
+type scriptedModel struct {
+	mockAgentModel[TransactionData]
+	calls   atomic.Int32
+	propose func(ctx context.Context, call int32) error
+}
+
+func (m *scriptedModel) ProposeDecision(ctx context.Context, input TransactionData) (*Proposal[TransactionData], error) {
+	if err := m.propose(ctx, m.calls.Add(1)); err != nil {
+		return nil, err
+	}
+	return m.mockAgentModel.ProposeDecision(ctx, input)
+}
+
+// SetDeterministicSeed lets scripted agents run in deterministic mode
+func (m *scriptedModel) SetDeterministicSeed(int64) {}
+
+func newScriptedAgent(propose func(ctx context.Context, call int32) error, opts ...AgentOption) (*Agent[TransactionData], *scriptedModel) {
+	model := &scriptedModel{propose: propose}
+	emitter := photon.NewUniformEmitter([]types.NodeID{{1}, {2}, {3}}, photon.DefaultEmitterOptions())
+	return New[TransactionData]("test-node", model, nil, emitter, opts...), model
+}
+
+func TestProposeDecisionWithPolicy_Timeout(t *testing.T) {
+	// The model hangs until its attempt is abandoned
+	agent, model := newScriptedAgent(func(ctx context.Context, _ int32) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	_, err := agent.ProposeDecisionWithPolicy(context.Background(), TransactionData{Hash: "0x01"}, nil, RetryPolicy{
+		Timeout:    20 * time.Millisecond,
+		MaxRetries: 2,
+	})
+	if !errors.Is(err, ErrDecisionTimeout) {
+		t.Fatalf("expected ErrDecisionTimeout, got %v", err)
+	}
+	if got := model.calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("hanging model held the call for %v", elapsed)
+	}
+}
+
+func TestProposeDecisionWithPolicy_RetriesTransient(t *testing.T) {
+	agent, model := newScriptedAgent(func(_ context.Context, call int32) error {
+		if call <= 2 {
+			return ErrTransient
+		}
+		return nil
+	})
+
+	decision, err := agent.ProposeDecisionWithPolicy(context.Background(), TransactionData{Hash: "0x02"}, nil, RetryPolicy{
+		Timeout:    time.Second,
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("expected success on the third attempt, got %v", err)
+	}
+	if decision.Action != "approve" || decision.Confidence == 0 {
+		t.Fatalf("expected the model's decision, got %+v", decision)
+	}
+	if got := model.calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+
+	// One retry is not enough for the same script
+	agent, _ = newScriptedAgent(func(_ context.Context, call int32) error {
+		if call <= 2 {
+			return ErrTransient
+		}
+		return nil
+	})
+	if _, err := agent.ProposeDecisionWithPolicy(context.Background(), TransactionData{Hash: "0x02"}, nil, RetryPolicy{MaxRetries: 1}); !errors.Is(err, ErrTransient) {
+		t.Fatalf("expected ErrTransient after retries ran out, got %v", err)
+	}
+}
+
+func TestProposeDecisionWithPolicy_PermanentErrorNotRetried(t *testing.T) {
+	errBadInput := errors.New("bad input")
+	agent, model := newScriptedAgent(func(context.Context, int32) error {
+		return errBadInput
+	})
+
+	_, err := agent.ProposeDecisionWithPolicy(context.Background(), TransactionData{Hash: "0x03"}, nil, RetryPolicy{MaxRetries: 3})
+	if !errors.Is(err, errBadInput) {
+		t.Fatalf("expected errBadInput, got %v", err)
+	}
+	if got := model.calls.Load(); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestProposeDecisionWithPolicy_DefaultDecision(t *testing.T) {
+	for _, action := range []DefaultAction{DefaultApprove, DefaultReject, DefaultReview} {
+		agent, _ := newScriptedAgent(func(ctx context.Context, _ int32) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		input := TransactionData{Hash: "0x04"}
+		ctxMap := map[string]interface{}{"type": "payment_validation"}
+		decision, err := agent.ProposeDecisionWithPolicy(context.Background(), input, ctxMap, RetryPolicy{
+			Timeout:    10 * time.Millisecond,
+			MaxRetries: 1,
+			Default:    action,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected the default decision, got %v", action, err)
+		}
+		if decision.Action != string(action) {
+			t.Fatalf("expected action %q, got %q", action, decision.Action)
+		}
+		if decision.Confidence != 0 {
+			t.Fatalf("expected zero confidence, got %f", decision.Confidence)
+		}
+		if decision.Data.Hash != input.Hash || decision.Context["type"] != "payment_validation" {
+			t.Fatalf("default decision lost its input: %+v", decision)
+		}
+		if decision.ProposerID != "test-node" {
+			t.Fatalf("expected proposer test-node, got %q", decision.ProposerID)
+		}
+	}
+}
+
+func TestProposeDecisionWithPolicy_CancelledSkipsDefault(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	agent, _ := newScriptedAgent(func(context.Context, int32) error {
+		cancel()
+		return ErrTransient
+	})
+
+	decision, err := agent.ProposeDecisionWithPolicy(ctx, TransactionData{Hash: "0x05"}, nil, RetryPolicy{
+		MaxRetries: 3,
+		Default:    DefaultApprove,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if decision != nil {
+		t.Fatalf("cancelled call returned decision %+v", decision)
+	}
+}
+
+func TestProposeDecisionWithPolicy_DeterministicDefault(t *testing.T) {
+	propose := func(context.Context, int32) error { return ErrTransient }
+	policy := RetryPolicy{Default: DefaultReview}
+	input := TransactionData{Hash: "0x06"}
+
+	var ids []string
+	for _, seed := range []int64{42, 42, 7} {
+		agent, _ := newScriptedAgent(propose, WithDeterministicSeed(seed))
+		decision, err := agent.ProposeDecisionWithPolicy(context.Background(), input, nil, policy)
+		if err != nil {
+			t.Fatalf("seed %d: expected the default decision, got %v", seed, err)
+		}
+		if !decision.Timestamp.IsZero() {
+			t.Fatalf("seed %d: expected a zero timestamp, got %v", seed, decision.Timestamp)
+		}
+		ids = append(ids, decision.ID)
+	}
+	if ids[0] != ids[1] {
+		t.Fatalf("same seed gave IDs %s and %s", ids[0], ids[1])
+	}
+	if ids[0] == ids[2] {
+		t.Fatalf("different seeds gave the same ID %s", ids[0])
+	}
+}
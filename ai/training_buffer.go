@@ -0,0 +1,157 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Training Buffer - Bounded memory for training examples
+
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+)
+
+// DefaultMaxTrainingExamples bounds an agent's training buffer when
+// TrainingBufferConfig.MaxExamples is unset. It matches the history kept by
+// SimpleModel.
+const DefaultMaxTrainingExamples = 10000
+
+// TrainingBufferConfig bounds the training examples an agent keeps
+type TrainingBufferConfig struct {
+	// MaxExamples caps the number of examples held
+	// (0 = DefaultMaxTrainingExamples, negative = no count limit)
+	MaxExamples int
+
+	// MaxBytes caps the JSON-encoded size of the examples held (0 = none)
+	MaxBytes int
+
+	// Reservoir keeps a uniform random sample of every example added once
+	// the buffer is full, instead of dropping the oldest
+	Reservoir bool
+}
+
+// TrainingBufferStats reports the state of an agent's training buffer
+type TrainingBufferStats struct {
+	Examples int    // examples held
+	Bytes    int    // JSON-encoded size of the examples held
+	Added    uint64 // examples ever added
+	Evicted  uint64 // examples dropped to stay within the limits
+}
+
+// WithTrainingBuffer bounds the agent's training data. In deterministic mode
+// reservoir sampling is seeded from the agent's seed.
+func WithTrainingBuffer(cfg TrainingBufferConfig) AgentOption {
+	return func(o *agentOptions) {
+		o.training = cfg
+	}
+}
+
+// trainingBuffer holds training examples within a count and byte budget.
+// It is not safe for concurrent use.
+type trainingBuffer[T ConsensusData] struct {
+	cfg TrainingBufferConfig
+	rng *rand.Rand
+
+	examples []TrainingExample[T]
+	sizes    []int // encoded size of each example
+	bytes    int
+	seen     uint64 // examples added since the last reset
+	added    uint64
+	evicted  uint64
+}
+
+func newTrainingBuffer[T ConsensusData](cfg TrainingBufferConfig, seed int64) *trainingBuffer[T] {
+	if cfg.MaxExamples == 0 {
+		cfg.MaxExamples = DefaultMaxTrainingExamples
+	}
+	return &trainingBuffer[T]{
+		cfg: cfg,
+		rng: rand.New(rand.NewSource(seed)), //nolint:gosec // sampling, not security
+	}
+}
+
+// add buffers example, evicting to stay within the limits. An example
+// larger than MaxBytes on its own is dropped.
+func (b *trainingBuffer[T]) add(example TrainingExample[T]) {
+	b.added++
+	b.seen++
+	size := exampleSize(example)
+	if b.cfg.MaxBytes > 0 && size > b.cfg.MaxBytes {
+		b.evicted++
+		return
+	}
+
+	if b.cfg.Reservoir && b.full(size) && len(b.examples) > 0 {
+		// Algorithm R: the n-th example replaces a random slot with
+		// probability len/n, so every example added so far is equally
+		// likely to be held
+		j := b.rng.Int63n(int64(b.seen))
+		b.evicted++
+		if j >= int64(len(b.examples)) {
+			return
+		}
+		b.bytes += size - b.sizes[j]
+		b.examples[j], b.sizes[j] = example, size
+		for b.cfg.MaxBytes > 0 && b.bytes > b.cfg.MaxBytes {
+			b.removeAt(b.rng.Intn(len(b.examples)))
+		}
+		return
+	}
+
+	for len(b.examples) > 0 && b.full(size) {
+		b.removeAt(0)
+	}
+	b.examples = append(b.examples, example)
+	b.sizes = append(b.sizes, size)
+	b.bytes += size
+}
+
+// full reports whether an example of size bytes does not fit without an
+// eviction
+func (b *trainingBuffer[T]) full(size int) bool {
+	if b.cfg.MaxExamples > 0 && len(b.examples) >= b.cfg.MaxExamples {
+		return true
+	}
+	return b.cfg.MaxBytes > 0 && b.bytes+size > b.cfg.MaxBytes
+}
+
+// removeAt evicts the example at i. Dropping the oldest reslices the front,
+// so append reallocates only the held examples once capacity runs out.
+func (b *trainingBuffer[T]) removeAt(i int) {
+	var zero TrainingExample[T]
+	b.bytes -= b.sizes[i]
+	b.evicted++
+	if i == 0 {
+		b.examples[0] = zero
+		b.examples, b.sizes = b.examples[1:], b.sizes[1:]
+		return
+	}
+	last := len(b.examples) - 1
+	b.examples[i], b.sizes[i] = b.examples[last], b.sizes[last]
+	b.examples[last] = zero
+	b.examples, b.sizes = b.examples[:last], b.sizes[:last]
+}
+
+// reset empties the buffer, starting a new sample. The held slice is
+// released rather than cleared, since a model may keep it.
+func (b *trainingBuffer[T]) reset() {
+	b.examples, b.sizes = nil, nil
+	b.bytes = 0
+	b.seen = 0
+}
+
+func (b *trainingBuffer[T]) stats() TrainingBufferStats {
+	return TrainingBufferStats{
+		Examples: len(b.examples),
+		Bytes:    b.bytes,
+		Added:    b.added,
+		Evicted:  b.evicted,
+	}
+}
+
+// exampleSize estimates the memory held by example from its JSON encoding
+func exampleSize[T ConsensusData](example TrainingExample[T]) int {
+	data, err := json.Marshal(example)
+	if err != nil {
+		return len(fmt.Sprintf("%v", example))
+	}
+	return len(data)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Training Buffer - Tests
+
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func heightExample(h uint64) TrainingExample[BlockData] {
+	return TrainingExample[BlockData]{
+		Input:  BlockData{Height: h},
+		Output: Decision[BlockData]{Action: "approve"},
+		NodeID: "node-1",
+	}
+}
+
+func TestTrainingBufferMaxExamples(t *testing.T) {
+	b := newTrainingBuffer[BlockData](TrainingBufferConfig{MaxExamples: 50}, 1)
+	for h := uint64(0); h < 1000; h++ {
+		b.add(heightExample(h))
+		if len(b.examples) > 50 {
+			t.Fatalf("buffer holds %d examples, limit 50", len(b.examples))
+		}
+	}
+
+	// Dropping the oldest keeps the newest 50
+	if b.examples[0].Input.Height != 950 || b.examples[49].Input.Height != 999 {
+		t.Fatalf("held heights %d..%d, want 950..999", b.examples[0].Input.Height, b.examples[49].Input.Height)
+	}
+	if s := b.stats(); s.Added != 1000 || s.Evicted != 950 || s.Examples != 50 {
+		t.Fatalf("stats = %+v", s)
+	}
+}
+
+func TestTrainingBufferMaxBytes(t *testing.T) {
+	size := exampleSize(heightExample(100))
+	limit := 10*size + size/2
+
+	for _, reservoir := range []bool{false, true} {
+		b := newTrainingBuffer[BlockData](TrainingBufferConfig{MaxExamples: -1, MaxBytes: limit, Reservoir: reservoir}, 1)
+		for h := uint64(100); h < 600; h++ {
+			b.add(heightExample(h))
+			if b.bytes > limit {
+				t.Fatalf("reservoir=%v: buffer holds %d bytes, limit %d", reservoir, b.bytes, limit)
+			}
+		}
+		if len(b.examples) != 10 {
+			t.Fatalf("reservoir=%v: buffer holds %d examples, want 10", reservoir, len(b.examples))
+		}
+
+		// An example over the whole budget is refused
+		big := heightExample(1)
+		big.Output.Reasoning = strings.Repeat("x", limit)
+		evicted := b.evicted
+		b.add(big)
+		if b.evicted != evicted+1 || b.bytes > limit {
+			t.Fatalf("reservoir=%v: oversized example kept", reservoir)
+		}
+	}
+}
+
+func TestTrainingBufferReservoirRepresentative(t *testing.T) {
+	const (
+		capacity = 1000
+		added    = 100000
+		buckets  = 10
+	)
+	b := newTrainingBuffer[BlockData](TrainingBufferConfig{MaxExamples: capacity, Reservoir: true}, 7)
+	for h := uint64(0); h < added; h++ {
+		b.add(heightExample(h))
+		if len(b.examples) > capacity {
+			t.Fatalf("buffer holds %d examples, limit %d", len(b.examples), capacity)
+		}
+	}
+
+	// Each tenth of the stream should hold about a tenth of the sample;
+	// the standard deviation of a bucket is about 9.5
+	var counts [buckets]int
+	seen := make(map[uint64]bool, capacity)
+	for _, e := range b.examples {
+		if seen[e.Input.Height] {
+			t.Fatalf("height %d sampled twice", e.Input.Height)
+		}
+		seen[e.Input.Height] = true
+		counts[e.Input.Height*buckets/added]++
+	}
+	for i, n := range counts {
+		if n < capacity/buckets-50 || n > capacity/buckets+50 {
+			t.Fatalf("bucket %d holds %d examples, want about %d: %v", i, n, capacity/buckets, counts)
+		}
+	}
+	if s := b.stats(); s.Evicted != added-capacity {
+		t.Fatalf("evicted %d, want %d", s.Evicted, added-capacity)
+	}
+}
+
+// learnRecorder records the batch sizes its agent learns from
+type learnRecorder struct {
+	mockAgentModel[BlockData]
+	batches []int
+}
+
+func (m *learnRecorder) Learn(examples []TrainingExample[BlockData]) error {
+	m.batches = append(m.batches, len(examples))
+	return nil
+}
+
+func TestAgentLearnsFromBoundedBuffer(t *testing.T) {
+	model := &learnRecorder{}
+	agent := New[BlockData]("node-1", model, nil, nil,
+		WithTrainingBuffer(TrainingBufferConfig{MaxExamples: 20, Reservoir: true}),
+		WithDeterministicSeed(3),
+	)
+
+	for h := uint64(0); h < 500; h++ {
+		agent.AddTrainingData(heightExample(h))
+	}
+	if s := agent.TrainingBufferStats(); s.Examples != 20 || s.Added != 500 || s.Evicted != 480 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	agent.memory.lastSync = time.Now().Add(-time.Minute)
+	if err := agent.SyncSharedMemory(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(model.batches) != 1 || model.batches[0] != 20 {
+		t.Fatalf("learned from batches %v, want [20]", model.batches)
+	}
+
+	// After a sync the queue samples afresh
+	agent.AddTrainingData(heightExample(1000))
+	if len(agent.memory.trainingQueue.examples) != 1 {
+		t.Fatalf("queue holds %d examples after sync, want 1", len(agent.memory.trainingQueue.examples))
+	}
+}
+
+func TestAgentDefaultTrainingBuffer(t *testing.T) {
+	agent := New[BlockData]("node-1", &mockAgentModel[BlockData]{}, nil, nil)
+	for h := uint64(0); h < DefaultMaxTrainingExamples+10; h++ {
+		agent.AddTrainingData(heightExample(h))
+	}
+	if s := agent.TrainingBufferStats(); s.Examples != DefaultMaxTrainingExamples || s.Evicted != 10 {
+		t.Fatalf("stats = %+v", s)
+	}
+}
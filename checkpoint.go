@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/bls"
+)
+
+// checkpointDomain separates checkpoint digests from every other message a
+// validator BLS key signs.
+const checkpointDomain = "LUX_CONSENSUS_CHECKPOINT_V1"
+
+// Checkpoint errors
+var (
+	ErrCheckpointNotFinalized = errors.New("checkpoint: height not finalized")
+	ErrCheckpointNoSigners    = errors.New("checkpoint: no local signers in validator set")
+	ErrCheckpointSigners      = errors.New("checkpoint: malformed signer bitmap")
+	ErrCheckpointQuorum       = errors.New("checkpoint: signers below 2/3 quorum")
+	ErrCheckpointSignature    = errors.New("checkpoint: invalid aggregate signature")
+	ErrCheckpointMismatch     = errors.New("checkpoint: merged checkpoints differ")
+)
+
+// Checkpoint is a compact, signed summary of a finalized height suitable for
+// anchoring on an external L1. A light verifier needs only the validator set
+// public keys to check it.
+type Checkpoint struct {
+	Height    uint64 `json:"height"`
+	BlockID   ID     `json:"block_id"`
+	StateRoot Hash   `json:"state_root"`
+	Signers   []byte `json:"signers"`   // bitmap over the validator set order
+	Signature []byte `json:"signature"` // aggregate BLS signature over Digest
+}
+
+// CheckpointValidator is one member of the validator set a checkpoint is
+// signed against. The slice order defines the signer bitmap layout.
+type CheckpointValidator struct {
+	NodeID    NodeID
+	PublicKey *bls.PublicKey
+}
+
+// CheckpointSigner is a validator key held by the exporting node.
+type CheckpointSigner struct {
+	NodeID    NodeID
+	SecretKey *bls.SecretKey
+}
+
+// StateRootFunc resolves the application state root committed by a block.
+type StateRootFunc func(blockID ID, height uint64) (Hash, error)
+
+// Checkpointer exports checkpoints from a chain's finalized history.
+type Checkpointer struct {
+	chain      *Chain
+	stateRoot  StateRootFunc
+	validators []CheckpointValidator
+	signers    map[NodeID]*bls.SecretKey
+}
+
+// NewCheckpointer creates a checkpoint exporter for the given chain. Only
+// signers that appear in validators contribute to the aggregate signature.
+func NewCheckpointer(chain *Chain, stateRoot StateRootFunc, validators []CheckpointValidator, signers []CheckpointSigner) *Checkpointer {
+	keys := make(map[NodeID]*bls.SecretKey, len(signers))
+	for _, s := range signers {
+		keys[s.NodeID] = s.SecretKey
+	}
+	return &Checkpointer{
+		chain:      chain,
+		stateRoot:  stateRoot,
+		validators: validators,
+		signers:    keys,
+	}
+}
+
+// ExportCheckpoint produces a signed checkpoint for a finalized height.
+func (c *Checkpointer) ExportCheckpoint(height uint64) (Checkpoint, error) {
+	if height > c.chain.FinalizedHeight() {
+		return Checkpoint{}, ErrCheckpointNotFinalized
+	}
+	block, ok := c.chain.AcceptedAt(height)
+	if !ok {
+		return Checkpoint{}, ErrCheckpointNotFinalized
+	}
+	root, err := c.stateRoot(block.ID, height)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("checkpoint: state root: %w", err)
+	}
+
+	cp := Checkpoint{
+		Height:    height,
+		BlockID:   block.ID,
+		StateRoot: root,
+		Signers:   make([]byte, (len(c.validators)+7)/8),
+	}
+	digest := cp.Digest(c.validators)
+
+	sigs := make([]*bls.Signature, 0, len(c.signers))
+	for i, v := range c.validators {
+		sk, ok := c.signers[v.NodeID]
+		if !ok {
+			continue
+		}
+		sig, err := sk.Sign(digest[:])
+		if err != nil {
+			return Checkpoint{}, fmt.Errorf("checkpoint: sign: %w", err)
+		}
+		sigs = append(sigs, sig)
+		cp.Signers[i/8] |= 1 << (i % 8)
+	}
+	if len(sigs) == 0 {
+		return Checkpoint{}, ErrCheckpointNoSigners
+	}
+
+	agg, err := bls.AggregateSignatures(sigs)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("checkpoint: aggregate: %w", err)
+	}
+	cp.Signature = bls.SignatureToBytes(agg)
+	return cp, nil
+}
+
+// Digest returns the message signed by validators. It binds the checkpoint
+// fields and the validator set so a checkpoint cannot be replayed against a
+// different set.
+func (cp Checkpoint) Digest(validators []CheckpointValidator) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(checkpointDomain))
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], cp.Height)
+	h.Write(buf[:])
+	h.Write(cp.BlockID[:])
+	h.Write(cp.StateRoot[:])
+
+	binary.BigEndian.PutUint64(buf[:], uint64(len(validators)))
+	h.Write(buf[:])
+	for _, v := range validators {
+		h.Write(v.NodeID[:])
+		h.Write(bls.PublicKeyToCompressedBytes(v.PublicKey))
+	}
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// MergeCheckpoints combines checkpoints exported by different nodes for the
+// same height into one carrying all of their signers, so peers holding
+// disjoint validator keys can together reach the quorum VerifyCheckpoint
+// requires. Every part must name the same height, block and state root,
+// carry a signature that verifies for its own signers, and share no signer
+// with another part. The result is not checked for quorum.
+func MergeCheckpoints(validators []CheckpointValidator, parts ...Checkpoint) (Checkpoint, error) {
+	if len(parts) == 0 {
+		return Checkpoint{}, ErrCheckpointNoSigners
+	}
+
+	merged := Checkpoint{
+		Height:    parts[0].Height,
+		BlockID:   parts[0].BlockID,
+		StateRoot: parts[0].StateRoot,
+		Signers:   make([]byte, (len(validators)+7)/8),
+	}
+	sigs := make([]*bls.Signature, 0, len(parts))
+	for i, part := range parts {
+		if part.Height != merged.Height || part.BlockID != merged.BlockID || part.StateRoot != merged.StateRoot {
+			return Checkpoint{}, fmt.Errorf("%w: part %d is for height %d block %s", ErrCheckpointMismatch, i, part.Height, part.BlockID)
+		}
+		pubKeys, err := signerKeys(part, validators)
+		if err != nil {
+			return Checkpoint{}, fmt.Errorf("part %d: %w", i, err)
+		}
+		sig, err := verifySignature(part, validators, pubKeys)
+		if err != nil {
+			return Checkpoint{}, fmt.Errorf("part %d: %w", i, err)
+		}
+		for j, b := range part.Signers {
+			if merged.Signers[j]&b != 0 {
+				return Checkpoint{}, fmt.Errorf("%w: part %d repeats a signer", ErrCheckpointSigners, i)
+			}
+			merged.Signers[j] |= b
+		}
+		sigs = append(sigs, sig)
+	}
+
+	agg, err := bls.AggregateSignatures(sigs)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("checkpoint: aggregate: %w", err)
+	}
+	merged.Signature = bls.SignatureToBytes(agg)
+	return merged, nil
+}
+
+// VerifyCheckpoint performs L1-side light verification of a checkpoint: the
+// signer bitmap must cover more than 2/3 of the validator set and the
+// aggregate signature must verify over the checkpoint digest.
+func VerifyCheckpoint(cp Checkpoint, validators []CheckpointValidator) error {
+	pubKeys, err := signerKeys(cp, validators)
+	if err != nil {
+		return err
+	}
+	if 3*len(pubKeys) <= 2*len(validators) {
+		return ErrCheckpointQuorum
+	}
+	_, err = verifySignature(cp, validators, pubKeys)
+	return err
+}
+
+// signerKeys returns the public keys of the validators in cp's signer
+// bitmap
+func signerKeys(cp Checkpoint, validators []CheckpointValidator) ([]*bls.PublicKey, error) {
+	if len(cp.Signers) != (len(validators)+7)/8 {
+		return nil, ErrCheckpointSigners
+	}
+
+	pubKeys := make([]*bls.PublicKey, 0, len(validators))
+	for i := range cp.Signers {
+		for bit := 0; bit < 8; bit++ {
+			if cp.Signers[i]&(1<<bit) == 0 {
+				continue
+			}
+			idx := i*8 + bit
+			if idx >= len(validators) {
+				return nil, ErrCheckpointSigners
+			}
+			pubKeys = append(pubKeys, validators[idx].PublicKey)
+		}
+	}
+	return pubKeys, nil
+}
+
+// verifySignature checks cp's aggregate signature over its digest against
+// the signers' public keys and returns the parsed signature
+func verifySignature(cp Checkpoint, validators []CheckpointValidator, pubKeys []*bls.PublicKey) (*bls.Signature, error) {
+	if len(pubKeys) == 0 {
+		return nil, ErrCheckpointNoSigners
+	}
+	sig, err := bls.SignatureFromBytes(cp.Signature)
+	if err != nil {
+		return nil, ErrCheckpointSignature
+	}
+	aggPubKey, err := bls.AggregatePublicKeys(pubKeys)
+	if err != nil {
+		return nil, ErrCheckpointSignature
+	}
+	digest := cp.Digest(validators)
+	if !bls.Verify(aggPubKey, sig, digest[:]) {
+		return nil, ErrCheckpointSignature
+	}
+	return sig, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func newCheckpointFixture(t *testing.T, n int) (*Chain, []CheckpointValidator, []CheckpointSigner, ID) {
+	t.Helper()
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Alpha = 1
+	chain := NewChain(cfg)
+	require.NoError(chain.Start(context.Background()))
+
+	blockID := ids.GenerateTestID()
+	require.NoError(chain.Add(context.Background(), NewBlock(blockID, GenesisID, 1, nil)))
+	require.NoError(chain.RecordVote(context.Background(), NewVote(blockID, VoteCommit, ids.GenerateTestNodeID())))
+
+	validators := make([]CheckpointValidator, n)
+	signers := make([]CheckpointSigner, n)
+	for i := range validators {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		nodeID := ids.GenerateTestNodeID()
+		validators[i] = CheckpointValidator{NodeID: nodeID, PublicKey: sk.PublicKey()}
+		signers[i] = CheckpointSigner{NodeID: nodeID, SecretKey: sk}
+	}
+	return chain, validators, signers, blockID
+}
+
+func TestCheckpointVerifies(t *testing.T) {
+	require := require.New(t)
+	chain, validators, signers, blockID := newCheckpointFixture(t, 4)
+
+	root := ids.GenerateTestID()
+	stateRoot := func(ID, uint64) (Hash, error) { return root, nil }
+	cp, err := NewCheckpointer(chain, stateRoot, validators, signers).ExportCheckpoint(1)
+	require.NoError(err)
+	require.Equal(uint64(1), cp.Height)
+	require.Equal(blockID, cp.BlockID)
+	require.Equal(root, cp.StateRoot)
+
+	require.NoError(VerifyCheckpoint(cp, validators))
+}
+
+func TestCheckpointWrongStateRootFails(t *testing.T) {
+	require := require.New(t)
+	chain, validators, signers, _ := newCheckpointFixture(t, 4)
+
+	stateRoot := func(ID, uint64) (Hash, error) { return ids.GenerateTestID(), nil }
+	cp, err := NewCheckpointer(chain, stateRoot, validators, signers).ExportCheckpoint(1)
+	require.NoError(err)
+
+	cp.StateRoot = ids.GenerateTestID()
+	require.ErrorIs(VerifyCheckpoint(cp, validators), ErrCheckpointSignature)
+}
+
+func TestCheckpointBelowQuorumFails(t *testing.T) {
+	require := require.New(t)
+	chain, validators, signers, _ := newCheckpointFixture(t, 4)
+
+	stateRoot := func(ID, uint64) (Hash, error) { return ids.GenerateTestID(), nil }
+	cp, err := NewCheckpointer(chain, stateRoot, validators, signers[:2]).ExportCheckpoint(1)
+	require.NoError(err)
+
+	require.ErrorIs(VerifyCheckpoint(cp, validators), ErrCheckpointQuorum)
+}
+
+func TestCheckpointUnfinalizedHeight(t *testing.T) {
+	require := require.New(t)
+	chain, validators, signers, _ := newCheckpointFixture(t, 4)
+
+	stateRoot := func(ID, uint64) (Hash, error) { return ids.GenerateTestID(), nil }
+	_, err := NewCheckpointer(chain, stateRoot, validators, signers).ExportCheckpoint(2)
+	require.ErrorIs(err, ErrCheckpointNotFinalized)
+}
+
+func TestCheckpointMergeReachesQuorum(t *testing.T) {
+	require := require.New(t)
+	chain, validators, signers, _ := newCheckpointFixture(t, 4)
+
+	root := ids.GenerateTestID()
+	stateRoot := func(ID, uint64) (Hash, error) { return root, nil }
+	export := func(signers []CheckpointSigner) Checkpoint {
+		cp, err := NewCheckpointer(chain, stateRoot, validators, signers).ExportCheckpoint(1)
+		require.NoError(err)
+		return cp
+	}
+
+	// Two peers hold disjoint keys; neither reaches quorum alone
+	left, right := export(signers[:2]), export(signers[2:3])
+	require.ErrorIs(VerifyCheckpoint(left, validators), ErrCheckpointQuorum)
+
+	merged, err := MergeCheckpoints(validators, left, right)
+	require.NoError(err)
+	require.NoError(VerifyCheckpoint(merged, validators))
+	require.Equal([]byte{0b0111}, merged.Signers)
+
+	// Parts must agree on what they sign
+	other := export(signers[3:])
+	other.StateRoot = ids.GenerateTestID()
+	_, err = MergeCheckpoints(validators, left, other)
+	require.ErrorIs(err, ErrCheckpointMismatch)
+
+	// A signer counted twice would inflate the bitmap
+	_, err = MergeCheckpoints(validators, left, export(signers[1:3]))
+	require.ErrorIs(err, ErrCheckpointSigners)
+
+	// A part whose signature does not cover its signers is refused
+	forged := export(signers[3:])
+	forged.Signature = right.Signature
+	_, err = MergeCheckpoints(validators, left, forged)
+	require.ErrorIs(err, ErrCheckpointSignature)
+
+	_, err = MergeCheckpoints(validators)
+	require.ErrorIs(err, ErrCheckpointNoSigners)
+}
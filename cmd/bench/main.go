@@ -6,6 +6,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/luxfi/consensus/config"
@@ -137,44 +139,86 @@ func benchmarkDAG(ctx context.Context, params config.Parameters, blocks int, par
 	fmt.Println("=== DAG Engine Benchmark ===")
 	engine := dag.New()
 
-	start := time.Now()
 	if err := engine.Start(ctx, 1); err != nil {
 		fmt.Printf("Failed to start DAG engine: %v\n", err)
 		return
 	}
 
-	processed := 0
-	errors := 0
-
-	for i := 0; i < blocks && ctx.Err() == nil; i++ {
-		vertexID := ids.GenerateTestID()
-		_, err := engine.GetVtx(ctx, vertexID)
-		if err != nil {
-			errors++
-			if verbose {
-				fmt.Printf("Error processing vertex %d: %v\n", i, err)
-			}
-		} else {
-			processed++
-		}
-
-		if verbose && i%100 == 0 {
-			fmt.Printf("Processed %d vertices...\n", i)
-		}
-	}
-
-	elapsed := time.Since(start)
-	tps := float64(processed) / elapsed.Seconds()
+	res := runDAG(ctx, engine, blocks, parallel, verbose)
+	tps := float64(res.processed) / res.elapsed.Seconds()
 
 	fmt.Printf("Results:\n")
-	fmt.Printf("  Processed: %d vertices\n", processed)
-	fmt.Printf("  Errors:    %d\n", errors)
-	fmt.Printf("  Time:      %s\n", elapsed)
+	fmt.Printf("  Workers:   %d\n", res.workers)
+	fmt.Printf("  Processed: %d vertices\n", res.processed)
+	fmt.Printf("  Errors:    %d\n", res.errors)
+	fmt.Printf("  Time:      %s\n", res.elapsed)
 	fmt.Printf("  TPS:       %.2f vertices/sec\n", tps)
 
 	_ = engine.Shutdown(ctx)
 }
 
+// dagResult aggregates the work of every benchmark worker
+type dagResult struct {
+	workers   int
+	processed int64
+	errors    int64
+	elapsed   time.Duration // wall-clock time across all workers
+}
+
+// runDAG submits blocks vertices through engine from parallel workers. The
+// workers claim vertex indices from a shared counter, so each index is
+// submitted exactly once however many workers run.
+func runDAG(ctx context.Context, engine dag.Engine, blocks int, parallel int, verbose bool) dagResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	var (
+		next      atomic.Int64
+		processed atomic.Int64
+		failed    atomic.Int64
+		wg        sync.WaitGroup
+	)
+	start := time.Now()
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := next.Add(1) - 1
+				if i >= int64(blocks) {
+					return
+				}
+
+				vertex := dag.NewVertex(ids.GenerateTestID(), nil, 1, time.Now().Unix(), []byte{byte(i)})
+				err := engine.AddVertex(ctx, vertex, nil)
+				if err == nil {
+					_, err = engine.GetVtx(ctx, vertex.ID())
+				}
+				if err != nil {
+					failed.Add(1)
+					if verbose {
+						fmt.Printf("Error processing vertex %d: %v\n", i, err)
+					}
+					continue
+				}
+
+				if n := processed.Add(1); verbose && n%100 == 0 {
+					fmt.Printf("Processed %d vertices...\n", n)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return dagResult{
+		workers:   parallel,
+		processed: processed.Load(),
+		errors:    failed.Load(),
+		elapsed:   time.Since(start),
+	}
+}
+
 func init() {
 	// As of Go 1.20, rand.Seed is deprecated - random seeding is automatic
 	// No manual seeding required for better randomness
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/consensus/engine/dag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDAGParallelProcessesEachVertexOnce(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	engine := dag.New()
+	require.NoError(engine.Start(ctx, 1))
+	defer func() { _ = engine.Shutdown(ctx) }()
+
+	const blocks = 1000
+	res := runDAG(ctx, engine, blocks, 4, false)
+	require.Equal(4, res.workers)
+	require.Equal(int64(blocks), res.processed)
+	require.Zero(res.errors)
+	require.Positive(res.elapsed)
+
+	// Every submission reached the engine exactly once
+	health, err := engine.(interface {
+		HealthCheck(context.Context) (interface{}, error)
+	}).HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(blocks, health.(map[string]interface{})["total_vertices"])
+}
+
+func TestRunDAGStopsOnCancel(t *testing.T) {
+	require := require.New(t)
+
+	engine := dag.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(engine.Start(ctx, 1))
+	cancel()
+
+	res := runDAG(ctx, engine, 1000, 4, false)
+	require.Zero(res.processed)
+	require.Zero(res.errors)
+}
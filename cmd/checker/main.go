@@ -3,13 +3,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/luxfi/consensus"
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/consensus/engine/dag"
+	"github.com/luxfi/consensus/protocol/quasar"
 )
 
 func main() {
@@ -38,17 +43,24 @@ func main() {
 			exitCode = 1
 		}
 	case "dag":
-		// DAG engine not yet implemented in new API
-		fmt.Println("DAG engine check not yet available in new API")
+		if !checkDAG(ctx, *verbose) {
+			exitCode = 1
+		}
 	case "pq":
-		// PQ engine not yet implemented in new API
-		fmt.Println("PQ engine check not yet available in new API")
+		if !checkPQ(ctx, *verbose) {
+			exitCode = 1
+		}
 	case "all":
 		chain := consensus.NewChain(consensus.DefaultConfig())
 		if !checkEngine(ctx, "chain", chain, *verbose) {
 			exitCode = 1
 		}
-		fmt.Println("DAG and PQ engine checks not yet available in new API")
+		if !checkDAG(ctx, *verbose) {
+			exitCode = 1
+		}
+		if !checkPQ(ctx, *verbose) {
+			exitCode = 1
+		}
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown engine: %s\n", *engine)
 		os.Exit(1)
@@ -88,7 +100,7 @@ func checkEngine(ctx context.Context, name string, engine consensus.Engine, verb
 
 	// Start engine
 	if err := engine.Start(ctx); err != nil {
-		fmt.Printf("✗ Failed to start: %v\n", err)
+		fmt.Printf("✗ Failed to start (%s): %v\n", failureClass(err), err)
 		return false
 	}
 	defer func() { _ = engine.Stop() }()
@@ -103,6 +115,91 @@ func checkEngine(ctx context.Context, name string, engine consensus.Engine, verb
 	return true
 }
 
+func checkDAG(ctx context.Context, verbose bool) bool {
+	fmt.Printf("Checking dag engine... ")
+
+	engine := dag.New()
+	if err := engine.Start(ctx, 1); err != nil {
+		fmt.Printf("✗ Failed to start (%s): %v\n", failureClass(err), err)
+		return false
+	}
+	defer func() { _ = engine.Shutdown(ctx) }()
+
+	return checkHealth(ctx, engine.HealthCheck, verbose)
+}
+
+func checkPQ(ctx context.Context, verbose bool) bool {
+	fmt.Printf("Checking pq engine... ")
+
+	engine, err := quasar.NewEngine(quasar.DefaultConfig)
+	if err != nil {
+		fmt.Printf("✗ Failed to create (%s): %v\n", failureClass(err), err)
+		return false
+	}
+	if err := engine.Start(ctx); err != nil {
+		fmt.Printf("✗ Failed to start (%s): %v\n", failureClass(err), err)
+		return false
+	}
+	defer func() { _ = engine.Stop() }()
+
+	return checkHealth(ctx, engine.HealthCheck, verbose)
+}
+
+// checkHealth runs a started engine's health check and requires its
+// "healthy" field to be true
+func checkHealth(ctx context.Context, healthCheck func(context.Context) (interface{}, error), verbose bool) bool {
+	health, err := healthCheck(ctx)
+	if err != nil {
+		fmt.Printf("✗ Health check failed (%s): %v\n", failureClass(err), err)
+		return false
+	}
+	stats, ok := health.(map[string]interface{})
+	if !ok {
+		fmt.Printf("✗ Unexpected health report %T\n", health)
+		return false
+	}
+	if healthy, _ := stats["healthy"].(bool); !healthy {
+		fmt.Printf("✗ Unhealthy: %v\n", stats)
+		return false
+	}
+
+	fmt.Println("✓")
+
+	if verbose {
+		keys := make([]string, 0, len(stats))
+		for k := range stats {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("  %s: %v\n", k, stats[k])
+		}
+	}
+
+	return true
+}
+
+// failureClass names the shared engine error err is an instance of, so a
+// failure reads the same whichever engine reported it
+func failureClass(err error) string {
+	switch {
+	case errors.Is(err, engine.ErrNotStarted):
+		return "not started"
+	case errors.Is(err, engine.ErrAlreadyStarted):
+		return "already started"
+	case errors.Is(err, engine.ErrUnknownVertex):
+		return "unknown vertex"
+	case errors.Is(err, engine.ErrBufferFull):
+		return "buffer full"
+	case errors.Is(err, engine.ErrConflict):
+		return "conflict"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
+
 func checkConfigurations(verbose bool) bool {
 	fmt.Println("\nChecking configurations...")
 
@@ -125,6 +222,9 @@ func checkConfigurations(verbose bool) bool {
 			if verbose {
 				fmt.Printf("    K=%d, Alpha=%.2f, Beta=%d, BlockTime=%s\n",
 					cfg.K, cfg.Alpha, cfg.Beta, cfg.BlockTime)
+				for _, w := range cfg.ValidateSafety() {
+					fmt.Printf("    ⚠ %s\n", w)
+				}
 			}
 		}
 	}
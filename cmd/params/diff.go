@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+)
+
+// fieldDiff is one parameter that differs between two networks
+type fieldDiff struct {
+	Field  string `json:"field"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Unsafe bool   `json:"unsafe,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// paramsDiff is the comparison of two networks' parameters
+type paramsDiff struct {
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	Differences []fieldDiff `json:"differences"`
+}
+
+// paramField describes one compared parameter. weakens is set for fields
+// whose change can lower a safety margin, and explains the loss. The
+// change weakens safety when weaker reports so, or by default when the
+// value decreases; for flags, turning the flag off is the decrease.
+type paramField struct {
+	name    string
+	value   func(config.Parameters) any
+	weakens string
+	weaker  func(from, to any) bool
+}
+
+// diffFields lists every field of config.Parameters in the order a diff
+// reports them
+var diffFields = []paramField{
+	{"K", func(p config.Parameters) any { return p.K }, "smaller sample tolerates fewer Byzantine validators", nil},
+	{"Alpha", func(p config.Parameters) any { return p.Alpha }, "lower quorum threshold", nil},
+	{"Beta", func(p config.Parameters) any { return p.Beta }, "fewer rounds before finality", nil},
+	{"BlockTime", func(p config.Parameters) any { return p.BlockTime }, "", nil},
+	{"RoundTO", func(p config.Parameters) any { return p.RoundTO }, "", nil},
+	{"AlphaPreference", func(p config.Parameters) any { return p.AlphaPreference }, "fewer votes needed to change preference", nil},
+	{"AlphaConfidence", func(p config.Parameters) any { return p.AlphaConfidence }, "fewer votes needed to build confidence", nil},
+	{"BetaVirtuous", func(p config.Parameters) any { return p.BetaVirtuous }, "fewer rounds before finalizing virtuous items", nil},
+	{"BetaRogue", func(p config.Parameters) any { return p.BetaRogue }, "fewer rounds before finalizing conflicting items", nil},
+	{"ConcurrentPolls", func(p config.Parameters) any { return p.ConcurrentPolls }, "", nil},
+	{"ConcurrentRepolls", func(p config.Parameters) any { return p.ConcurrentRepolls }, "", nil},
+	{"OptimalProcessing", func(p config.Parameters) any { return p.OptimalProcessing }, "", nil},
+	{"MaxOutstandingItems", func(p config.Parameters) any { return p.MaxOutstandingItems }, "", nil},
+	{"Parents", func(p config.Parameters) any { return p.Parents }, "", nil},
+	{"BatchSize", func(p config.Parameters) any { return p.BatchSize }, "", nil},
+	{"MaxItemProcessingTime", func(p config.Parameters) any { return p.MaxItemProcessingTime }, "", nil},
+	{"MinRoundInterval", func(p config.Parameters) any { return p.MinRoundInterval }, "", nil},
+	{"GasLimit", func(p config.Parameters) any { return p.GasLimit }, "", nil},
+	{"ConvergenceSettleWindow", func(p config.Parameters) any { return p.ConvergenceSettleWindow }, "shorter settle window risks an unrecoverable vote split", nil},
+	{"PQMode", func(p config.Parameters) any { return p.PQMode }, "drops the post-quantum certificate layer", dropsPostQuantum},
+	{"ViewChange", func(p config.Parameters) any { return p.ViewChange }, "restores the halt-prone single-phase convergence", nil},
+	{"RingOnly", func(p config.Parameters) any { return p.RingOnly }, "", nil},
+}
+
+// dropsPostQuantum reports whether a PQMode change leaves certificates
+// without a post-quantum layer
+func dropsPostQuantum(from, to any) bool {
+	return from.(config.PQMode).IsPostQuantum() && !to.(config.PQMode).IsPostQuantum()
+}
+
+// diffParams returns the fields that differ between a and b, flagging
+// those whose change from a to b weakens safety
+func diffParams(a, b config.Parameters) []fieldDiff {
+	var diffs []fieldDiff
+	for _, f := range diffFields {
+		from, to := f.value(a), f.value(b)
+		if from == to {
+			continue
+		}
+		d := fieldDiff{Field: f.name, From: formatValue(from), To: formatValue(to)}
+		weaker := f.weaker
+		if weaker == nil {
+			weaker = func(from, to any) bool { return less(to, from) }
+		}
+		if f.weakens != "" && weaker(from, to) {
+			d.Unsafe = true
+			d.Reason = f.weakens
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// less reports whether a < b for two values of the same parameter
+func less(a, b any) bool {
+	switch a := a.(type) {
+	case int:
+		return a < b.(int)
+	case uint32:
+		return a < b.(uint32)
+	case uint64:
+		return a < b.(uint64)
+	case bool:
+		return !a && b.(bool)
+	case float64:
+		return a < b.(float64)
+	case time.Duration:
+		return a < b.(time.Duration)
+	}
+	return false
+}
+
+func formatValue(v any) string {
+	if f, ok := v.(float64); ok {
+		return fmt.Sprintf("%.2f", f)
+	}
+	return fmt.Sprint(v)
+}
+
+// parseDiffNetworks splits a -diff argument of the form network1,network2
+func parseDiffNetworks(arg string) (string, string, error) {
+	names := strings.Split(arg, ",")
+	if len(names) != 2 {
+		return "", "", fmt.Errorf("-diff wants two networks separated by a comma, got %q", arg)
+	}
+	from, to := strings.TrimSpace(names[0]), strings.TrimSpace(names[1])
+	if from == "" || to == "" {
+		return "", "", fmt.Errorf("-diff wants two networks separated by a comma, got %q", arg)
+	}
+	return from, to, nil
+}
+
+func printDiffTable(w io.Writer, d paramsDiff) {
+	fmt.Fprintf(w, "%s -> %s\n", d.From, d.To)
+	if len(d.Differences) == 0 {
+		fmt.Fprintln(w, "No differences")
+		return
+	}
+	for _, f := range d.Differences {
+		line := fmt.Sprintf("%-25s %s -> %s", f.Field+":", f.From, f.To)
+		if f.Unsafe {
+			line += "  UNSAFE: " + f.Reason
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func printDiffJSON(w io.Writer, d paramsDiff) error {
+	if d.Differences == nil {
+		d.Differences = []fieldDiff{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffMainnetLocal(t *testing.T) {
+	require := require.New(t)
+
+	diffs := diffParams(config.MainnetParams(), config.LocalParams())
+	byField := make(map[string]fieldDiff, len(diffs))
+	for _, d := range diffs {
+		byField[d.Field] = d
+	}
+
+	// Local shrinks the committee and every threshold, all of which weaken safety
+	for _, field := range []string{"K", "Alpha", "Beta", "AlphaPreference", "AlphaConfidence", "BetaVirtuous"} {
+		d, ok := byField[field]
+		require.True(ok, "%s not reported", field)
+		require.True(d.Unsafe, "%s decrease not flagged", field)
+		require.NotEmpty(d.Reason)
+	}
+	require.Equal("21", byField["K"].From)
+	require.Equal("3", byField["K"].To)
+	require.Equal("0.69", byField["Alpha"].From)
+	require.Equal("0.67", byField["Alpha"].To)
+
+	// Faster timing is a difference but not a safety boundary
+	for _, field := range []string{"BlockTime", "RoundTO"} {
+		d, ok := byField[field]
+		require.True(ok, "%s not reported", field)
+		require.False(d.Unsafe)
+	}
+	require.Equal("200ms", byField["BlockTime"].From)
+	require.Equal("1ms", byField["BlockTime"].To)
+
+	// Fields both networks inherit from the defaults are omitted
+	for _, field := range []string{"BetaRogue", "ConcurrentPolls", "ConcurrentRepolls", "OptimalProcessing", "MaxOutstandingItems", "Parents", "BatchSize", "PQMode", "ViewChange", "RingOnly"} {
+		require.NotContains(byField, field)
+	}
+	require.Len(diffs, 8)
+}
+
+func TestDiffReverseIsSafe(t *testing.T) {
+	require := require.New(t)
+
+	for _, d := range diffParams(config.LocalParams(), config.MainnetParams()) {
+		require.False(d.Unsafe, "%s increase flagged", d.Field)
+	}
+	require.Empty(diffParams(config.LocalParams(), config.XChainParams()))
+}
+
+func TestDiffEveryField(t *testing.T) {
+	require := require.New(t)
+
+	a := config.MainnetParams()
+	a.PQMode = config.PQModeQuasar
+	a.ViewChange = true
+	a.ConvergenceSettleWindow = 2 * time.Second
+	b := a
+	b.PQMode = config.PQModeBLS
+	b.ViewChange = false
+	b.ConvergenceSettleWindow = 500 * time.Millisecond
+	b.MaxItemProcessingTime = a.MaxItemProcessingTime / 2
+	b.MinRoundInterval = 50 * time.Millisecond
+	b.GasLimit = a.GasLimit + 1
+	b.RingOnly = !a.RingOnly
+
+	byField := make(map[string]fieldDiff)
+	for _, d := range diffParams(a, b) {
+		byField[d.Field] = d
+	}
+	require.Len(byField, 7)
+
+	// Dropping PQ, view change or settle time weakens safety or liveness
+	for _, field := range []string{"PQMode", "ViewChange", "ConvergenceSettleWindow"} {
+		require.True(byField[field].Unsafe, "%s weakening not flagged", field)
+		require.NotEmpty(byField[field].Reason)
+	}
+	require.Equal("bls", byField["PQMode"].To)
+	for _, field := range []string{"MaxItemProcessingTime", "MinRoundInterval", "GasLimit", "RingOnly"} {
+		d, ok := byField[field]
+		require.True(ok, "%s not reported", field)
+		require.False(d.Unsafe)
+	}
+
+	// The reverse strengthens every flagged field
+	for _, d := range diffParams(b, a) {
+		require.False(d.Unsafe, "%s strengthening flagged", d.Field)
+	}
+
+	// Switching between PQ modes keeps a PQ layer
+	b = a
+	b.PQMode = config.PQModeMLDSA
+	require.Equal([]fieldDiff{{Field: "PQMode", From: a.PQMode.String(), To: "mldsa"}}, diffParams(a, b))
+}
+
+func TestDiffJSON(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	require.NoError(printDiffJSON(&buf, paramsDiff{
+		From:        "mainnet",
+		To:          "local",
+		Differences: diffParams(config.MainnetParams(), config.LocalParams()),
+	}))
+
+	var got paramsDiff
+	require.NoError(json.Unmarshal(buf.Bytes(), &got))
+	require.Equal("mainnet", got.From)
+	require.Equal("local", got.To)
+	require.Len(got.Differences, 8)
+	require.Equal(fieldDiff{Field: "K", From: "21", To: "3", Unsafe: true, Reason: got.Differences[0].Reason}, got.Differences[0])
+
+	// An empty diff is an empty list, not null
+	buf.Reset()
+	require.NoError(printDiffJSON(&buf, paramsDiff{From: "local", To: "xchain"}))
+	require.Contains(buf.String(), `"differences": []`)
+}
+
+func TestParseDiffNetworks(t *testing.T) {
+	require := require.New(t)
+
+	from, to, err := parseDiffNetworks("mainnet, local")
+	require.NoError(err)
+	require.Equal("mainnet", from)
+	require.Equal("local", to)
+
+	for _, arg := range []string{"mainnet", "mainnet,", "a,b,c"} {
+		_, _, err := parseDiffNetworks(arg)
+		require.Error(err, arg)
+	}
+}
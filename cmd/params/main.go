@@ -12,6 +12,7 @@ import (
 func main() {
 	var (
 		network = flag.String("network", "mainnet", "Network to show parameters for (mainnet, testnet, local, xchain)")
+		diff    = flag.String("diff", "", "Compare two networks, e.g. mainnet,local")
 		json    = flag.Bool("json", false, "Output in JSON format")
 		help    = flag.Bool("help", false, "Show help message")
 	)
@@ -22,27 +23,64 @@ func main() {
 		os.Exit(0)
 	}
 
-	var params config.Parameters
-	switch *network {
+	if *diff != "" {
+		runDiff(*diff, *json)
+		return
+	}
+
+	params := mustParams(*network)
+
+	if *json {
+		printJSON(params)
+	} else {
+		printTable(params)
+	}
+}
+
+// paramsFor returns the parameters of the named network
+func paramsFor(network string) (config.Parameters, bool) {
+	switch network {
 	case "mainnet":
-		params = config.MainnetParams()
+		return config.MainnetParams(), true
 	case "testnet":
-		params = config.TestnetParams()
+		return config.TestnetParams(), true
 	case "local":
-		params = config.LocalParams()
+		return config.LocalParams(), true
 	case "xchain":
-		params = config.XChainParams()
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown network: %s\n", *network)
+		return config.XChainParams(), true
+	}
+	return config.Parameters{}, false
+}
+
+func mustParams(network string) config.Parameters {
+	params, ok := paramsFor(network)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown network: %s\n", network)
 		fmt.Fprintln(os.Stderr, "Valid networks: mainnet, testnet, local, xchain")
 		os.Exit(1)
 	}
+	return params
+}
 
-	if *json {
-		printJSON(params)
-	} else {
-		printTable(params)
+func runDiff(arg string, asJSON bool) {
+	from, to, err := parseDiffNetworks(arg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	d := paramsDiff{
+		From:        from,
+		To:          to,
+		Differences: diffParams(mustParams(from), mustParams(to)),
+	}
+	if asJSON {
+		if err := printDiffJSON(os.Stdout, d); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
 	}
+	printDiffTable(os.Stdout, d)
 }
 
 func printHelp() {
@@ -51,12 +89,15 @@ func printHelp() {
 	fmt.Println("\nOptions:")
 	fmt.Println("  -network string   Network to show parameters for (default: mainnet)")
 	fmt.Println("                    Options: mainnet, testnet, local, xchain")
+	fmt.Println("  -diff string     Compare two networks field by field (e.g. mainnet,local);")
+	fmt.Println("                    changes that weaken safety are flagged UNSAFE")
 	fmt.Println("  -json            Output in JSON format")
 	fmt.Println("  -help            Show this help message")
 	fmt.Println("\nExamples:")
 	fmt.Println("  params                      # Show mainnet parameters")
 	fmt.Println("  params -network testnet     # Show testnet parameters")
 	fmt.Println("  params -network local -json # Show local parameters in JSON")
+	fmt.Println("  params -diff mainnet,local  # Compare mainnet with local")
 }
 
 func printTable(p config.Parameters) {
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/luxfi/ids"
+)
+
+const (
+	// maxBatchSize bounds the items of one POST /consensus/batch
+	maxBatchSize = 1000
+
+	// maxBatchBytes bounds the body of one POST /consensus/batch: a full
+	// batch of items averaging up to 4 KiB each
+	maxBatchBytes = maxBatchSize * 4 << 10
+
+	// batchConcurrency bounds how many rounds of a batch run at once
+	batchConcurrency = 8
+)
+
+var (
+	errNoVotes       = errors.New("no votes")
+	errBatchTooLarge = fmt.Errorf("batch exceeds the limit of %d items", maxBatchSize)
+)
+
+// decodeBatch decodes a JSON array of consensus requests one item at a
+// time, failing with errBatchTooLarge as soon as it passes maxBatchSize
+// items rather than after decoding the whole body
+func decodeBatch(body io.Reader) ([]ConsensusRequest, error) {
+	dec := json.NewDecoder(body)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('[') {
+		return nil, errors.New("batch must be a JSON array")
+	}
+
+	var reqs []ConsensusRequest
+	for dec.More() {
+		if len(reqs) == maxBatchSize {
+			return nil, errBatchTooLarge
+		}
+		var req ConsensusRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+// BatchResult is one item of the POST /consensus/batch response. Status is
+// the HTTP status the item would have had on its own; Error explains a
+// failed item and Result holds a processed one.
+type BatchResult struct {
+	Status int                `json:"status"`
+	Error  string             `json:"error,omitempty"`
+	Result *ConsensusResponse `json:"result,omitempty"`
+}
+
+// handleConsensusBatch processes an array of consensus requests with
+// bounded concurrency and answers with one BatchResult per item, in input
+// order. The response is 200 when every item succeeded and 207 otherwise.
+func (s *ConsensusServer) handleConsensusBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reqs, err := decodeBatch(http.MaxBytesReader(w, r.Body, maxBatchBytes))
+	if err != nil {
+		s.metrics.ObserveError()
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.Is(err, errBatchTooLarge) || errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	results := make([]BatchResult, len(reqs))
+	slots := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = s.processBatchItem(req)
+		}()
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	for _, res := range results {
+		if res.Status != http.StatusOK {
+			status = http.StatusMultiStatus
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+// processBatchItem validates and runs one batch item. Unlike POST
+// /consensus, a batch item with an unparsable block ID or no votes fails
+// instead of being processed. Items run on their own goroutines, where
+// net/http does not recover panics, so a panicking item fails with a 500
+// rather than taking the server down.
+func (s *ConsensusServer) processBatchItem(req ConsensusRequest) (res BatchResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Batch item %q panicked: %v", req.BlockID, r)
+			s.metrics.ObserveError()
+			res = BatchResult{Status: http.StatusInternalServerError, Error: "internal error"}
+		}
+	}()
+
+	if err := validateBatchItem(req); err != nil {
+		s.metrics.ObserveError()
+		return BatchResult{Status: http.StatusBadRequest, Error: err.Error()}
+	}
+	resp, err := s.processRound(req)
+	if err != nil {
+		s.metrics.ObserveError()
+		return BatchResult{Status: http.StatusBadRequest, Error: err.Error()}
+	}
+	return BatchResult{Status: http.StatusOK, Result: &resp}
+}
+
+func validateBatchItem(req ConsensusRequest) error {
+	if _, err := ids.FromString(req.BlockID); err != nil {
+		return fmt.Errorf("invalid block_id %q: %w", req.BlockID, err)
+	}
+	_, total, err := tallyVotes(req.Votes)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		// The round's confidence would be undefined
+		return errNoVotes
+	}
+	return nil
+}
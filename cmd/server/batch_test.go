@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/luxfi/consensus"
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusBatch(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	// Enough items to exceed batchConcurrency; every third is malformed
+	// and the rest alternate between finalizing and not
+	var (
+		reqs []ConsensusRequest
+		want []BatchResult
+	)
+	for i := 0; i < 3*batchConcurrency; i++ {
+		id := ids.GenerateTestID()
+		switch {
+		case i%6 == 2:
+			reqs = append(reqs, ConsensusRequest{BlockID: fmt.Sprintf("bad-%d", i), Votes: map[string]int{"node1": 1}})
+			want = append(want, BatchResult{Status: http.StatusBadRequest})
+		case i%6 == 5:
+			reqs = append(reqs, ConsensusRequest{BlockID: id.String(), Votes: map[string]int{}})
+			want = append(want, BatchResult{Status: http.StatusBadRequest})
+		case i%2 == 0:
+			reqs = append(reqs, ConsensusRequest{BlockID: id.String(), Votes: map[string]int{"node1": 4, "node2": 1}})
+			want = append(want, BatchResult{Status: http.StatusOK, Result: &ConsensusResponse{BlockID: id.String(), Finalized: true}})
+		default:
+			reqs = append(reqs, ConsensusRequest{BlockID: id.String(), Votes: map[string]int{"node1": 1, "node2": -4}})
+			want = append(want, BatchResult{Status: http.StatusOK, Result: &ConsensusResponse{BlockID: id.String(), Finalized: false}})
+		}
+	}
+
+	body, err := json.Marshal(reqs)
+	require.NoError(err)
+	resp, err := http.Post(ts.URL+"/consensus/batch", "application/json", bytes.NewReader(body))
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusMultiStatus, resp.StatusCode)
+
+	var got []BatchResult
+	require.NoError(json.NewDecoder(resp.Body).Decode(&got))
+	require.Len(got, len(reqs))
+	for i, res := range got {
+		require.Equal(want[i].Status, res.Status, "item %d", i)
+		if want[i].Result == nil {
+			require.NotEmpty(res.Error, "item %d", i)
+			require.Nil(res.Result, "item %d", i)
+			continue
+		}
+		require.Empty(res.Error, "item %d", i)
+		require.Equal(want[i].Result.BlockID, res.Result.BlockID, "item %d", i)
+		require.Equal(want[i].Result.Finalized, res.Result.Finalized, "item %d", i)
+		require.Equal(reqs[i].Votes, res.Result.Votes, "item %d", i)
+	}
+}
+
+func TestConsensusBatchStatus(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	post := func(body string) *http.Response {
+		resp, err := http.Post(ts.URL+"/consensus/batch", "application/json", strings.NewReader(body))
+		require.NoError(err)
+		require.NoError(resp.Body.Close())
+		return resp
+	}
+
+	// A batch whose items all succeed is a plain 200
+	id := ids.GenerateTestID()
+	require.Equal(http.StatusOK, post(fmt.Sprintf(`[{"block_id": %q, "votes": {"node1": 3}}]`, id)).StatusCode)
+	require.Equal(http.StatusOK, post(`[]`).StatusCode)
+
+	require.Equal(http.StatusBadRequest, post(`{"block_id": "x"}`).StatusCode)
+	require.Equal(http.StatusRequestEntityTooLarge, post("["+strings.Repeat(`{},`, maxBatchSize)+"{}]").StatusCode)
+
+	// The body is capped before it is decoded, however few items it has
+	huge := fmt.Sprintf(`[{"block_id": %q, "votes": {%q: 1}}]`, id, strings.Repeat("n", maxBatchBytes))
+	require.Equal(http.StatusRequestEntityTooLarge, post(huge).StatusCode)
+
+	resp, err := http.Get(ts.URL + "/consensus/batch")
+	require.NoError(err)
+	require.NoError(resp.Body.Close())
+	require.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestConsensusBatchOutOfRangeVotes(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	id := ids.GenerateTestID()
+	body := fmt.Sprintf(`[{"block_id": %q, "votes": {"a": -9223372036854775808}}, {"block_id": %q, "votes": {"a": 1}}]`, id, id)
+	resp, err := http.Post(ts.URL+"/consensus/batch", "application/json", strings.NewReader(body))
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusMultiStatus, resp.StatusCode)
+
+	var got []BatchResult
+	require.NoError(json.NewDecoder(resp.Body).Decode(&got))
+	require.Len(got, 2)
+	require.Equal(http.StatusBadRequest, got[0].Status)
+	require.Contains(got[0].Error, errVoteCount.Error())
+	require.Equal(http.StatusOK, got[1].Status)
+}
+
+func TestConsensusBatchItemPanic(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+	// Make every processed round panic
+	server.metrics.VotesReceived = nil
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	body := fmt.Sprintf(`[{"block_id": %q, "votes": {"a": 1}}, {"block_id": "bad", "votes": {"a": 1}}]`, ids.GenerateTestID())
+	resp, err := http.Post(ts.URL+"/consensus/batch", "application/json", strings.NewReader(body))
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusMultiStatus, resp.StatusCode)
+
+	var got []BatchResult
+	require.NoError(json.NewDecoder(resp.Body).Decode(&got))
+	require.Len(got, 2)
+	require.Equal(http.StatusInternalServerError, got[0].Status)
+	require.NotEmpty(got[0].Error)
+	require.Equal(http.StatusBadRequest, got[1].Status)
+}
@@ -3,19 +3,43 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"math"
+	"math/bits"
 	"net/http"
 	"time"
 
 	"github.com/luxfi/consensus"
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/consensus/metrics"
 	"github.com/luxfi/ids"
 )
 
 type ConsensusServer struct {
-	engine consensus.Engine
-	config config.Parameters
+	engine  consensus.Engine
+	config  config.Parameters
+	metrics *metrics.Collector
+}
+
+// NewConsensusServer creates a server for the engine, using the engine's own
+// metrics collector when it exports one
+func NewConsensusServer(eng consensus.Engine, params config.Parameters) (*ConsensusServer, error) {
+	collector := engine.MetricsOf(eng)
+	if collector == nil {
+		var err error
+		if collector, err = metrics.NewCollector(); err != nil {
+			return nil, err
+		}
+	}
+	return &ConsensusServer{
+		engine:  eng,
+		config:  params,
+		metrics: collector,
+	}, nil
 }
 
 type StatusResponse struct {
@@ -40,6 +64,21 @@ type TestResponse struct {
 	Confidence float64 `json:"confidence"`
 }
 
+// ConsensusRequest is the body of POST /consensus
+type ConsensusRequest struct {
+	BlockID string         `json:"block_id"`
+	Votes   map[string]int `json:"votes"`
+}
+
+// ConsensusResponse is the result of POST /consensus
+type ConsensusResponse struct {
+	BlockID    string         `json:"block_id"`
+	Finalized  bool           `json:"finalized"`
+	Votes      map[string]int `json:"votes"`
+	Confidence float64        `json:"confidence"`
+	Alpha      float64        `json:"alpha"`
+}
+
 func (s *ConsensusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
@@ -146,16 +185,57 @@ func (s *ConsensusServer) handleConsensus(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var req struct {
-		BlockID string         `json:"block_id"`
-		Votes   map[string]int `json:"votes"`
-	}
-
+	var req ConsensusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.metrics.ObserveError()
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp, err := s.processRound(req)
+	if err != nil {
+		s.metrics.ObserveError()
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+var errVoteCount = errors.New("vote count out of range")
+
+// tallyVotes sums a round's votes. A positive count is accept votes and a
+// negative one reject votes. The votes come from the client, so a count
+// whose magnitude cannot be negated or a total that overflows fails with
+// errVoteCount instead of wrapping.
+func tallyVotes(votes map[string]int) (accept, total uint64, err error) {
+	for voter, count := range votes {
+		if count == math.MinInt {
+			return 0, 0, fmt.Errorf("%w: voter %q", errVoteCount, voter)
+		}
+		n := uint64(count)
+		if count < 0 {
+			n = uint64(-count)
+		}
+		var carry uint64
+		if total, carry = bits.Add64(total, n, 0); carry != 0 {
+			return 0, 0, fmt.Errorf("%w: total overflows", errVoteCount)
+		}
+		if count > 0 {
+			// accept never exceeds total, so it cannot overflow
+			accept += n
+		}
+	}
+	return accept, total, nil
+}
+
+// processRound runs one consensus round over req's votes. An unparsable
+// block ID is replaced by a generated one.
+func (s *ConsensusServer) processRound(req ConsensusRequest) (ConsensusResponse, error) {
+	start := time.Now()
+
 	// Process consensus
 	blockID, err := ids.FromString(req.BlockID)
 	if err != nil {
@@ -163,13 +243,9 @@ func (s *ConsensusServer) handleConsensus(w http.ResponseWriter, r *http.Request
 	}
 
 	// Calculate if consensus reached
-	totalVotes := 0
-	acceptVotes := 0
-	for _, count := range req.Votes {
-		totalVotes += count
-		if count > 0 {
-			acceptVotes += count
-		}
+	acceptVotes, totalVotes, err := tallyVotes(req.Votes)
+	if err != nil {
+		return ConsensusResponse{}, err
 	}
 
 	finalized := false
@@ -177,19 +253,34 @@ func (s *ConsensusServer) handleConsensus(w http.ResponseWriter, r *http.Request
 		confidence := float64(acceptVotes) / float64(totalVotes)
 		finalized = confidence >= s.config.Alpha
 	}
+	s.metrics.ObserveRound(totalVotes, finalized, time.Since(start))
+
+	return ConsensusResponse{
+		BlockID:    blockID.String(),
+		Finalized:  finalized,
+		Votes:      req.Votes,
+		Confidence: float64(acceptVotes) / float64(totalVotes) * 100,
+		Alpha:      s.config.Alpha * 100,
+	}, nil
+}
 
-	resp := map[string]interface{}{
-		"block_id":   blockID.String(),
-		"finalized":  finalized,
-		"votes":      req.Votes,
-		"confidence": float64(acceptVotes) / float64(totalVotes) * 100,
-		"alpha":      s.config.Alpha * 100,
-	}
+// Handler returns the server's routes
+func (s *ConsensusServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/test", s.handleTest)
+	mux.HandleFunc("/consensus", s.handleConsensus)
+	mux.HandleFunc("/consensus/batch", s.handleConsensusBatch)
+	mux.HandleFunc("/schema", handleSchema)
+	mux.Handle("/metrics", s.metrics.Handler())
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("OK")); err != nil {
+			log.Printf("Error writing response: %v", err)
+		}
+	})
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Error encoding response: %v", err)
-	}
+	return mux
 }
 
 func main() {
@@ -212,35 +303,26 @@ func main() {
 		params = config.MainnetParams()
 	}
 
-	server := &ConsensusServer{
-		engine: engine,
-		config: params,
+	server, err := NewConsensusServer(engine, params)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Set up routes
-	mux := http.NewServeMux()
-	mux.HandleFunc("/status", server.handleStatus)
-	mux.HandleFunc("/test", server.handleTest)
-	mux.HandleFunc("/consensus", server.handleConsensus)
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte("OK")); err != nil {
-			log.Printf("Error writing response: %v", err)
-		}
-	})
-
 	log.Printf("Starting consensus server on port %s with %s config", *port, *network)
 	log.Printf("Endpoints:")
 	log.Printf("  GET  /status    - Get engine status")
 	log.Printf("  GET  /health    - Health check")
+	log.Printf("  GET  /metrics   - Prometheus metrics")
 	log.Printf("  GET  /test      - Run consensus test")
 	log.Printf("  POST /test      - Run consensus test with custom params")
 	log.Printf("  POST /consensus - Process consensus round")
+	log.Printf("  POST /consensus/batch - Process a batch of consensus rounds")
+	log.Printf("  GET  /schema    - JSON Schema for request and response bodies")
 
 	// Create server with timeouts to avoid G114 warning
 	srv := &http.Server{
 		Addr:         ":" + *port,
-		Handler:      mux,
+		Handler:      server.Handler(),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
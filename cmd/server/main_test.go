@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luxfi/consensus"
+	"github.com/luxfi/consensus/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		body, err := json.Marshal(map[string]interface{}{
+			"votes": map[string]int{"node1": 4, "node2": 1},
+		})
+		require.NoError(err)
+		resp, err := http.Post(ts.URL+"/consensus", "application/json", bytes.NewReader(body))
+		require.NoError(err)
+		require.Equal(http.StatusOK, resp.StatusCode)
+		require.NoError(resp.Body.Close())
+	}
+
+	resp, err := http.Post(ts.URL+"/consensus", "application/json", bytes.NewReader([]byte("{")))
+	require.NoError(err)
+	require.Equal(http.StatusBadRequest, resp.StatusCode)
+	require.NoError(resp.Body.Close())
+
+	resp, err = http.Get(ts.URL + "/metrics")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	scraped, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+	out := string(scraped)
+
+	require.Contains(out, "# TYPE rounds_total counter")
+	require.Contains(out, "# TYPE votes_received counter")
+	require.Contains(out, "# TYPE finality_latency_seconds histogram")
+	require.Contains(out, "# TYPE engine_errors_total counter")
+	require.Contains(out, "rounds_total 3")
+	require.Contains(out, "votes_received 15")
+	require.Contains(out, "finality_latency_seconds_count 3")
+	require.Contains(out, "engine_errors_total 1")
+}
+
+func TestConsensusRejectsOverflowingVotes(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	for _, body := range []string{
+		// The count's magnitude does not fit in an int
+		`{"votes":{"a":-9223372036854775808}}`,
+		// The counts fit but their total overflows
+		`{"votes":{"a":9223372036854775807,"b":-9223372036854775807,"c":9223372036854775807}}`,
+	} {
+		resp, err := http.Post(ts.URL+"/consensus", "application/json", bytes.NewReader([]byte(body)))
+		require.NoError(err)
+		require.Equal(http.StatusBadRequest, resp.StatusCode, body)
+		require.NoError(resp.Body.Close())
+	}
+
+	// The server is still up and nothing was counted
+	resp, err := http.Get(ts.URL + "/metrics")
+	require.NoError(err)
+	defer resp.Body.Close()
+	scraped, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+	require.Contains(string(scraped), "engine_errors_total 2")
+	require.NotContains(string(scraped), "rounds_total 1")
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// jsonSchemaDialect is the JSON Schema draft the /schema document uses
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaTypes are the request and response bodies /schema describes, keyed
+// by their $defs name. The schema is derived from the structs by
+// reflection, so it cannot drift from what the handlers decode and encode.
+var schemaTypes = map[string]reflect.Type{
+	"TestRequest":       reflect.TypeFor[TestRequest](),
+	"TestResponse":      reflect.TypeFor[TestResponse](),
+	"ConsensusRequest":  reflect.TypeFor[ConsensusRequest](),
+	"ConsensusResponse": reflect.TypeFor[ConsensusResponse](),
+	"BatchResult":       reflect.TypeFor[BatchResult](),
+
+	"ConsensusBatchRequest":  reflect.TypeFor[[]ConsensusRequest](),
+	"ConsensusBatchResponse": reflect.TypeFor[[]BatchResult](),
+}
+
+// Schema returns the JSON Schema document for the server's bodies. A
+// field is required unless its json tag has omitempty.
+func Schema() map[string]interface{} {
+	defs := make(map[string]interface{}, len(schemaTypes))
+	for name, t := range schemaTypes {
+		defs[name] = typeSchema(t)
+	}
+	return map[string]interface{}{
+		"$schema": jsonSchemaDialect,
+		"title":   "Lux consensus server",
+		"$defs":   defs,
+	}
+}
+
+// typeSchema describes how encoding/json encodes t
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is base64 text
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		// interface{} and anything else accepts any value
+		return map[string]interface{}{}
+	}
+}
+
+func structSchema(t reflect.Type) map[string]interface{} {
+	props := make(map[string]interface{})
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = typeSchema(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func handleSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	if err := json.NewEncoder(w).Encode(Schema()); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
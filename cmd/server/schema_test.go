@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/luxfi/consensus"
+	"github.com/luxfi/consensus/config"
+	"github.com/stretchr/testify/require"
+)
+
+// validate checks v against the subset of JSON Schema that Schema emits
+func validate(schema map[string]interface{}, v interface{}) error {
+	switch schema["type"] {
+	case nil:
+		return nil
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("expected boolean, got %T", v)
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("expected integer, got %v", v)
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("expected number, got %T", v)
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("expected string, got %T", v)
+		}
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected array, got %T", v)
+		}
+		for i, item := range arr {
+			if err := validate(schema["items"].(map[string]interface{}), item); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, got %T", v)
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				return fmt.Errorf("missing required %q", name)
+			}
+		}
+		for name, val := range obj {
+			sub, ok := props[name].(map[string]interface{})
+			if !ok {
+				extra, ok := schema["additionalProperties"].(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("unexpected property %q", name)
+				}
+				sub = extra
+			}
+			if err := validate(sub, val); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported schema type %v", schema["type"])
+	}
+	return nil
+}
+
+func TestSchemaEndpoint(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewConsensusServer(consensus.NewChainEngine(), config.LocalParams())
+	require.NoError(err)
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/schema")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal("application/schema+json", resp.Header.Get("Content-Type"))
+
+	var doc map[string]interface{}
+	require.NoError(json.NewDecoder(resp.Body).Decode(&doc))
+	require.Equal(jsonSchemaDialect, doc["$schema"])
+	defs := doc["$defs"].(map[string]interface{})
+	for _, name := range []string{"TestRequest", "TestResponse", "ConsensusRequest", "ConsensusResponse", "BatchResult", "ConsensusBatchRequest", "ConsensusBatchResponse"} {
+		require.Contains(defs, name)
+	}
+	def := func(name string) map[string]interface{} { return defs[name].(map[string]interface{}) }
+
+	decode := func(s string) interface{} {
+		var v interface{}
+		require.NoError(json.Unmarshal([]byte(s), &v))
+		return v
+	}
+	require.NoError(validate(def("ConsensusRequest"), decode(`{"block_id": "abc", "votes": {"node1": 4, "node2": 1}}`)))
+	require.Error(validate(def("ConsensusRequest"), decode(`{"block_id": "abc", "votes": {"node1": "yes"}}`)))
+	require.Error(validate(def("ConsensusRequest"), decode(`{"block_id": "abc", "votes": {}, "round": 1}`)))
+	require.Error(validate(def("TestRequest"), decode(`{"rounds": 1.5, "nodes": 3}`)))
+	require.NoError(validate(def("BatchResult"), decode(`{"status": 400, "error": "no votes"}`)))
+	require.Error(validate(def("BatchResult"), decode(`{"error": "no votes"}`)))
+
+	// What the handlers actually send validates against the schema
+	for path, name := range map[string]string{"/test": "TestResponse", "/consensus": "ConsensusResponse", "/consensus/batch": "ConsensusBatchResponse"} {
+		var resp *http.Response
+		switch path {
+		case "/consensus":
+			resp, err = http.Post(ts.URL+path, "application/json", strings.NewReader(`{"block_id": "", "votes": {"node1": 3}}`))
+		case "/consensus/batch":
+			resp, err = http.Post(ts.URL+path, "application/json", strings.NewReader(`[{"block_id": "", "votes": {"node1": 3}}, {"block_id": "bad", "votes": {}}]`))
+		default:
+			resp, err = http.Get(ts.URL + path)
+		}
+		require.NoError(err)
+		var body interface{}
+		require.NoError(json.NewDecoder(resp.Body).Decode(&body))
+		require.NoError(resp.Body.Close())
+		require.NoError(validate(def(name), body), path)
+	}
+}
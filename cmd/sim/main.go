@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/sim"
 )
 
 func main() {
@@ -17,7 +18,10 @@ func main() {
 		nodes   = flag.Int("nodes", 100, "Number of nodes in the network")
 		rounds  = flag.Int("rounds", 10, "Number of consensus rounds to simulate")
 		network = flag.String("network", "mainnet", "Network configuration (mainnet, testnet, local)")
-		failure = flag.Float64("failure", 0.1, "Node failure rate (0.0-1.0)")
+		failure = flag.Float64("failure", 0.1, "Fraction of nodes that are Byzantine (0.0-1.0)")
+		mode    = flag.String("failure-mode", string(sim.FailWorst), "Which nodes fail: worst (highest stake first) or random")
+		honesty = flag.Float64("honesty", 0.9, "Minimum per-node probability an honest node votes correctly (0.0-1.0)")
+		seed    = flag.Int64("seed", 0, "Random seed (0 = time-based)")
 		latency = flag.Duration("latency", 50*time.Millisecond, "Network latency")
 		verbose = flag.Bool("verbose", false, "Verbose output")
 		help    = flag.Bool("help", false, "Show help message")
@@ -33,6 +37,18 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failure rate must be between 0.0 and 1.0\n")
 		os.Exit(1)
 	}
+	if *honesty < 0 || *honesty > 1 {
+		fmt.Fprintf(os.Stderr, "Honesty must be between 0.0 and 1.0\n")
+		os.Exit(1)
+	}
+	if *mode != string(sim.FailWorst) && *mode != string(sim.FailRandom) {
+		fmt.Fprintf(os.Stderr, "Unknown failure mode: %s\n", *mode)
+		os.Exit(1)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed)) //nolint:gosec // simulation randomness
 
 	// Get network configuration
 	params := getNetworkParams(*network)
@@ -41,12 +57,17 @@ func main() {
 	fmt.Printf("Network:    %s\n", *network)
 	fmt.Printf("Nodes:      %d\n", *nodes)
 	fmt.Printf("Rounds:     %d\n", *rounds)
-	fmt.Printf("Failure:    %.1f%%\n", *failure*100)
+	fmt.Printf("Failure:    %.1f%% (%s)\n", *failure*100, *mode)
 	fmt.Printf("Latency:    %s\n", *latency)
-	fmt.Printf("Parameters: K=%d, Alpha=%.2f, Beta=%d\n\n", params.K, params.Alpha, params.Beta)
+	fmt.Printf("Seed:       %d\n", *seed)
+	fmt.Printf("Parameters: K=%d, Alpha=%.2f, Beta=%d\n", params.K, params.Alpha, params.Beta)
+
+	net := sim.NewNetwork(*nodes, *honesty, rng)
+	net.Fail(*failure, sim.FailureMode(*mode), rng)
+	fmt.Printf("Byzantine:  %d nodes, %.1f%% of stake\n\n", net.ByzantineNodes(), net.ByzantineStake()*100)
 
 	// Run simulation
-	results := runSimulation(*nodes, *rounds, params, *failure, *latency, *verbose)
+	results := runSimulation(net, *rounds, params, *latency, *verbose, rng)
 
 	// Print results
 	printResults(results, params)
@@ -60,7 +81,11 @@ func printHelp() {
 	fmt.Println("  -rounds int       Number of consensus rounds (default: 10)")
 	fmt.Println("  -network string   Network configuration (default: mainnet)")
 	fmt.Println("                    Options: mainnet, testnet, local")
-	fmt.Println("  -failure float    Node failure rate 0.0-1.0 (default: 0.1)")
+	fmt.Println("  -failure float    Fraction of nodes that are Byzantine 0.0-1.0 (default: 0.1)")
+	fmt.Println("  -failure-mode     Which nodes fail: worst (highest stake first) or random")
+	fmt.Println("                    (default: worst)")
+	fmt.Println("  -honesty float    Minimum probability an honest node votes correctly (default: 0.9)")
+	fmt.Println("  -seed int         Random seed, 0 for time-based (default: 0)")
 	fmt.Println("  -latency duration Network latency (default: 50ms)")
 	fmt.Println("  -verbose          Verbose output")
 	fmt.Println("  -help             Show this help message")
@@ -68,6 +93,7 @@ func printHelp() {
 	fmt.Println("  sim                                  # Run default simulation")
 	fmt.Println("  sim -nodes 1000 -rounds 100          # Large scale simulation")
 	fmt.Println("  sim -failure 0.3 -latency 200ms      # High failure, slow network")
+	fmt.Println("  sim -failure 0.3 -failure-mode random # Byzantine nodes picked at random")
 	fmt.Println("  sim -network testnet -verbose        # Testnet config with details")
 }
 
@@ -88,13 +114,14 @@ func getNetworkParams(network string) config.Parameters {
 type SimulationResult struct {
 	Round           int
 	VotesReceived   int
-	Confidence      float64
+	Confidence      float64 // stake fraction of the sample voting ACCEPT
+	ByzantineStake  float64 // stake fraction of the sample held by Byzantine nodes
 	Decision        string
 	TimeToConsensus time.Duration
 	FailedNodes     int
 }
 
-func runSimulation(nodes int, rounds int, params config.Parameters, failureRate float64, latency time.Duration, verbose bool) []SimulationResult {
+func runSimulation(net *sim.Network, rounds int, params config.Parameters, latency time.Duration, verbose bool, rng *rand.Rand) []SimulationResult {
 	results := make([]SimulationResult, 0, rounds)
 	ctx := context.Background()
 
@@ -104,13 +131,13 @@ func runSimulation(nodes int, rounds int, params config.Parameters, failureRate
 		}
 
 		start := time.Now()
-		result := simulateRound(ctx, nodes, params, failureRate, latency)
+		result := simulateRound(ctx, net, params, latency, rng)
 		result.Round = round
 		result.TimeToConsensus = time.Since(start)
 
 		if verbose {
-			fmt.Printf("%s (confidence: %.2f%%, time: %s)\n",
-				result.Decision, result.Confidence*100, result.TimeToConsensus)
+			fmt.Printf("%s (confidence: %.2f%%, byzantine stake: %.2f%%, time: %s)\n",
+				result.Decision, result.Confidence*100, result.ByzantineStake*100, result.TimeToConsensus)
 		}
 
 		results = append(results, result)
@@ -122,43 +149,27 @@ func runSimulation(nodes int, rounds int, params config.Parameters, failureRate
 	return results
 }
 
-func simulateRound(ctx context.Context, nodes int, params config.Parameters, failureRate float64, latency time.Duration) SimulationResult {
-	// Calculate failed nodes
-	failedNodes := int(float64(nodes) * failureRate)
-	activeNodes := nodes - failedNodes
+// simulateRound runs one sim.Poll over net. Honest nodes vote ACCEPT with
+// their honesty probability; Byzantine nodes never do. The round accepts
+// when the accepting stake reaches alpha of the sampled stake, so Byzantine
+// stake above 1-alpha blocks acceptance. The network model has no latency
+// of its own: latency paces the run in real time instead.
+func simulateRound(ctx context.Context, net *sim.Network, params config.Parameters, latency time.Duration, rng *rand.Rand) SimulationResult {
+	p := sim.Poll(params, net, rng)
 
-	// Sample K nodes randomly
-	k := params.K
-	if k > activeNodes {
-		k = activeNodes
-	}
+	// Simulate network latency
+	time.Sleep(latency)
 
-	// Simulate voting
-	votes := 0
-	for i := 0; i < k; i++ {
-		// Simulate network latency
-		time.Sleep(latency / time.Duration(k))
-
-		// Random vote with Byzantine behavior (simulation only, not security-critical)
-		if rand.Float64() > 0.2 { // 80% honest nodes //nolint:gosec // simulation randomness
-			votes++
-		}
-	}
-
-	// Calculate confidence
-	confidence := float64(votes) / float64(k)
-
-	// Determine decision based on alpha threshold
 	decision := "REJECT"
-	if confidence >= params.Alpha {
+	if p.Accepted {
 		decision = "ACCEPT"
 	}
-
 	return SimulationResult{
-		VotesReceived: votes,
-		Confidence:    confidence,
-		Decision:      decision,
-		FailedNodes:   failedNodes,
+		VotesReceived:  p.Votes,
+		Confidence:     p.Confidence,
+		ByzantineStake: p.ByzantineStake,
+		Decision:       decision,
+		FailedNodes:    net.ByzantineNodes(),
 	}
 }
 
@@ -169,6 +180,7 @@ func printResults(results []SimulationResult, params config.Parameters) {
 	rejects := 0
 	totalTime := time.Duration(0)
 	totalConfidence := 0.0
+	totalByzantine := 0.0
 
 	for _, r := range results {
 		if r.Decision == "ACCEPT" {
@@ -178,6 +190,7 @@ func printResults(results []SimulationResult, params config.Parameters) {
 		}
 		totalTime += r.TimeToConsensus
 		totalConfidence += r.Confidence
+		totalByzantine += r.ByzantineStake
 	}
 
 	fmt.Printf("\nConsensus Decisions:\n")
@@ -188,6 +201,7 @@ func printResults(results []SimulationResult, params config.Parameters) {
 	fmt.Printf("  Avg Time:       %s\n", totalTime/time.Duration(len(results)))
 	fmt.Printf("  Avg Confidence: %.2f%%\n", totalConfidence/float64(len(results))*100)
 	fmt.Printf("  Alpha Required: %.2f%%\n", params.Alpha*100)
+	fmt.Printf("  Avg Byzantine:  %.2f%% of sampled stake\n", totalByzantine/float64(len(results))*100)
 
 	// Calculate finality probability
 	finalityProb := calculateFinalityProbability(params.Alpha, params.Beta, totalConfidence/float64(len(results)))
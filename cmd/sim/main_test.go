@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/sim"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByzantineStakeAboveOneMinusAlphaBlocksAccept(t *testing.T) {
+	require := require.New(t)
+	params := config.MainnetParams() // K=21 samples every node of these networks
+	rng := rand.New(rand.NewSource(1))
+
+	// Ten perfectly honest nodes of stake 68 and one Byzantine node of
+	// stake 320: 32% Byzantine stake, just above 1-alpha
+	net := &sim.Network{}
+	for i := 0; i < 10; i++ {
+		net.Nodes = append(net.Nodes, sim.Node{Stake: 68, Honesty: 1})
+	}
+	net.Nodes = append(net.Nodes, sim.Node{Stake: 320, Byzantine: true})
+	require.Greater(net.ByzantineStake(), 1-params.Alpha)
+
+	for _, r := range runSimulation(net, 20, params, 0, false, rng) {
+		require.Equal("REJECT", r.Decision)
+		require.InDelta(0.32, r.ByzantineStake, 1e-9)
+		require.Less(r.Confidence, params.Alpha)
+	}
+
+	// Just below 1-alpha the same honest majority accepts every round
+	net.Nodes[10].Stake = 300
+	require.Less(net.ByzantineStake(), 1-params.Alpha)
+	for _, r := range runSimulation(net, 20, params, 0, false, rng) {
+		require.Equal("ACCEPT", r.Decision)
+	}
+}
+
+func TestWorstCaseFailureBlocksAccept(t *testing.T) {
+	require := require.New(t)
+	params := config.MainnetParams() // K=21 samples every node of these networks
+	rng := rand.New(rand.NewSource(3))
+
+	// Removing the top half of the nodes by stake hands the Byzantine side
+	// well over 1-alpha of the stake
+	net := sim.NewNetwork(params.K, 1, rng)
+	net.Fail(0.5, sim.FailWorst, rng)
+	require.Greater(net.ByzantineStake(), 1-params.Alpha)
+
+	for _, r := range runSimulation(net, 20, params, 0, false, rng) {
+		require.Equal("REJECT", r.Decision)
+	}
+
+	// With every node honest and reliable every round accepts
+	honest := sim.NewNetwork(params.K, 1, rng)
+	r := simulateRound(context.Background(), honest, params, 0, rng)
+	require.Equal("ACCEPT", r.Decision)
+	require.InDelta(1.0, r.Confidence, 1e-9)
+	require.Zero(r.ByzantineStake)
+}
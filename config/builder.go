@@ -0,0 +1,243 @@
+// Copyright (C) 2019-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Builder assembles Parameters setting by setting. Each setter checks its
+// own value, and Build checks the constraints between fields, so a mistake
+// is reported with the setting that caused it rather than as a bare
+// ErrParametersInvalid.
+//
+// Changing K or Alpha re-derives AlphaPreference and AlphaConfidence as
+// ⌈Alpha·K⌉ unless they were set explicitly.
+//
+//	p, err := config.FromMainnet().WithBeta(20).Build()
+type Builder struct {
+	p    Parameters
+	errs []error
+
+	// derive is set once K or Alpha changes; explicit quorums are kept
+	derive               bool
+	explicitAlphaPref    bool
+	explicitAlphaConfirm bool
+}
+
+// NewBuilder returns a builder starting from DefaultParams
+func NewBuilder() *Builder {
+	return FromParams(DefaultParams())
+}
+
+// FromMainnet returns a builder starting from MainnetParams
+func FromMainnet() *Builder {
+	return FromParams(MainnetParams())
+}
+
+// FromTestnet returns a builder starting from TestnetParams
+func FromTestnet() *Builder {
+	return FromParams(TestnetParams())
+}
+
+// FromLocal returns a builder starting from LocalParams
+func FromLocal() *Builder {
+	return FromParams(LocalParams())
+}
+
+// FromParams returns a builder starting from p
+func FromParams(p Parameters) *Builder {
+	return &Builder{p: p}
+}
+
+func (b *Builder) fail(err error) *Builder {
+	b.errs = append(b.errs, err)
+	return b
+}
+
+// WithK sets the sample size
+func (b *Builder) WithK(k int) *Builder {
+	if k < 1 {
+		return b.fail(fmt.Errorf("%w: WithK(%d)", ErrInvalidK, k))
+	}
+	b.p.K = k
+	b.derive = true
+	return b
+}
+
+// WithAlpha sets the quorum threshold as a fraction of K
+func (b *Builder) WithAlpha(alpha float64) *Builder {
+	if math.IsNaN(alpha) || alpha < 0.66 || alpha > 1.0 {
+		return b.fail(fmt.Errorf("%w: WithAlpha(%v)", ErrInvalidAlpha, alpha))
+	}
+	b.p.Alpha = alpha
+	b.derive = true
+	return b
+}
+
+// WithBeta sets the consecutive successful rounds needed to finalize
+func (b *Builder) WithBeta(beta uint32) *Builder {
+	if beta < 1 {
+		return b.fail(fmt.Errorf("%w: WithBeta(%d)", ErrInvalidBeta, beta))
+	}
+	b.p.Beta = beta
+	return b
+}
+
+// WithAlphaPreference sets the integer quorum that changes preference
+func (b *Builder) WithAlphaPreference(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithAlphaPreference(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.AlphaPreference = n
+	b.explicitAlphaPref = true
+	return b
+}
+
+// WithAlphaConfidence sets the integer quorum that builds confidence
+func (b *Builder) WithAlphaConfidence(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithAlphaConfidence(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.AlphaConfidence = n
+	b.explicitAlphaConfirm = true
+	return b
+}
+
+// WithBetaVirtuous sets the confidence needed to finalize a virtuous item
+func (b *Builder) WithBetaVirtuous(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithBetaVirtuous(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.BetaVirtuous = n
+	return b
+}
+
+// WithBetaRogue sets the confidence needed to finalize a conflicting item
+func (b *Builder) WithBetaRogue(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithBetaRogue(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.BetaRogue = n
+	return b
+}
+
+// WithConcurrentPolls sets how many polls may be in flight
+func (b *Builder) WithConcurrentPolls(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithConcurrentPolls(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.ConcurrentPolls = n
+	return b
+}
+
+// WithMaxOutstandingItems bounds the undecided items
+func (b *Builder) WithMaxOutstandingItems(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithMaxOutstandingItems(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.MaxOutstandingItems = n
+	return b
+}
+
+// WithMaxItemProcessingTime bounds how long an item may stay undecided
+func (b *Builder) WithMaxItemProcessingTime(d time.Duration) *Builder {
+	if d <= 0 {
+		return b.fail(fmt.Errorf("%w: WithMaxItemProcessingTime(%v) must be > 0", ErrParametersInvalid, d))
+	}
+	b.p.MaxItemProcessingTime = d
+	return b
+}
+
+// WithParents sets the parent limit per vertex
+func (b *Builder) WithParents(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithParents(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.Parents = n
+	return b
+}
+
+// WithBatchSize sets how many items are batched together
+func (b *Builder) WithBatchSize(n int) *Builder {
+	if n < 1 {
+		return b.fail(fmt.Errorf("%w: WithBatchSize(%d) must be >= 1", ErrParametersInvalid, n))
+	}
+	b.p.BatchSize = n
+	return b
+}
+
+// WithBlockTime sets the block time and scales the round timeout with it,
+// as Parameters.WithBlockTime does. Set an explicit timeout afterwards with
+// WithRoundTimeout.
+func (b *Builder) WithBlockTime(d time.Duration) *Builder {
+	if d < time.Millisecond {
+		return b.fail(fmt.Errorf("%w: WithBlockTime(%v)", ErrBlockTimeTooLow, d))
+	}
+	b.p = b.p.WithBlockTime(d)
+	return b
+}
+
+// WithRoundTimeout sets how long a round waits for votes
+func (b *Builder) WithRoundTimeout(d time.Duration) *Builder {
+	if d <= 0 {
+		return b.fail(fmt.Errorf("%w: WithRoundTimeout(%v) must be > 0", ErrParametersInvalid, d))
+	}
+	b.p.RoundTO = d
+	return b
+}
+
+// WithMinRoundInterval sets the minimum gap between polls of one item
+func (b *Builder) WithMinRoundInterval(d time.Duration) *Builder {
+	if d < 0 {
+		return b.fail(fmt.Errorf("%w: WithMinRoundInterval(%v) must be >= 0", ErrParametersInvalid, d))
+	}
+	b.p.MinRoundInterval = d
+	return b
+}
+
+// WithPQMode sets the post-quantum signature mode
+func (b *Builder) WithPQMode(m PQMode) *Builder {
+	b.p = b.p.WithPQMode(m)
+	return b
+}
+
+// Build returns the parameters, or every setter error and the first
+// violated constraint between fields
+func (b *Builder) Build() (Parameters, error) {
+	if len(b.errs) > 0 {
+		return Parameters{}, errors.Join(b.errs...)
+	}
+
+	p := b.p
+	if b.derive {
+		quorum := int(math.Ceil(p.Alpha*float64(p.K) - 1e-9))
+		if !b.explicitAlphaPref {
+			p.AlphaPreference = quorum
+		}
+		if !b.explicitAlphaConfirm {
+			p.AlphaConfidence = quorum
+		}
+	}
+
+	switch {
+	case p.AlphaPreference > p.K:
+		return Parameters{}, fmt.Errorf("%w: AlphaPreference %d exceeds K %d", ErrParametersInvalid, p.AlphaPreference, p.K)
+	case p.AlphaConfidence > p.K:
+		return Parameters{}, fmt.Errorf("%w: AlphaConfidence %d exceeds K %d", ErrParametersInvalid, p.AlphaConfidence, p.K)
+	case p.AlphaConfidence < p.AlphaPreference:
+		return Parameters{}, fmt.Errorf("%w: AlphaConfidence %d is below AlphaPreference %d", ErrParametersInvalid, p.AlphaConfidence, p.AlphaPreference)
+	case p.BetaRogue != 0 && p.BetaRogue < p.BetaVirtuous:
+		return Parameters{}, fmt.Errorf("%w: BetaRogue %d is below BetaVirtuous %d", ErrParametersInvalid, p.BetaRogue, p.BetaVirtuous)
+	case p.BlockTime > 0 && p.RoundTO > 0 && p.RoundTO < p.BlockTime:
+		return Parameters{}, fmt.Errorf("%w: round timeout %v is below block time %v", ErrRoundTimeoutTooLow, p.RoundTO, p.BlockTime)
+	}
+	if err := p.Valid(); err != nil {
+		return Parameters{}, err
+	}
+	return p, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuilderPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    Parameters
+	}{
+		{"NewBuilder", NewBuilder(), DefaultParams()},
+		{"FromMainnet", FromMainnet(), MainnetParams()},
+		{"FromTestnet", FromTestnet(), TestnetParams()},
+		{"FromLocal", FromLocal(), LocalParams()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Build() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilderValid(t *testing.T) {
+	p, err := NewBuilder().
+		WithK(30).
+		WithAlpha(0.8).
+		WithBeta(20).
+		WithBetaVirtuous(20).
+		WithBetaRogue(25).
+		WithConcurrentPolls(8).
+		WithBlockTime(100 * time.Millisecond).
+		WithRoundTimeout(300 * time.Millisecond).
+		WithPQMode(PQModeQuasar).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if p.K != 30 || p.Alpha != 0.8 || p.Beta != 20 {
+		t.Fatalf("K, Alpha, Beta = %d, %v, %d", p.K, p.Alpha, p.Beta)
+	}
+	// Quorums follow K and Alpha: ⌈0.8·30⌉ = 24
+	if p.AlphaPreference != 24 || p.AlphaConfidence != 24 {
+		t.Fatalf("AlphaPreference, AlphaConfidence = %d, %d, want 24, 24", p.AlphaPreference, p.AlphaConfidence)
+	}
+	if p.RoundTO != 300*time.Millisecond || p.PQMode != PQModeQuasar {
+		t.Fatalf("RoundTO, PQMode = %v, %v", p.RoundTO, p.PQMode)
+	}
+
+	// Explicit quorums are kept when K changes
+	p, err = FromMainnet().WithAlphaPreference(20).WithAlphaConfidence(21).WithK(25).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if p.AlphaPreference != 20 || p.AlphaConfidence != 21 {
+		t.Fatalf("AlphaPreference, AlphaConfidence = %d, %d, want 20, 21", p.AlphaPreference, p.AlphaConfidence)
+	}
+}
+
+func TestBuilderSetterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    error
+	}{
+		{"K zero", NewBuilder().WithK(0), ErrInvalidK},
+		{"Alpha below 2/3", NewBuilder().WithAlpha(0.5), ErrInvalidAlpha},
+		{"Alpha above 1", NewBuilder().WithAlpha(1.1), ErrInvalidAlpha},
+		{"Beta zero", NewBuilder().WithBeta(0), ErrInvalidBeta},
+		{"AlphaPreference zero", NewBuilder().WithAlphaPreference(0), ErrParametersInvalid},
+		{"AlphaConfidence zero", NewBuilder().WithAlphaConfidence(0), ErrParametersInvalid},
+		{"BetaVirtuous zero", NewBuilder().WithBetaVirtuous(0), ErrParametersInvalid},
+		{"BetaRogue zero", NewBuilder().WithBetaRogue(0), ErrParametersInvalid},
+		{"ConcurrentPolls zero", NewBuilder().WithConcurrentPolls(0), ErrParametersInvalid},
+		{"MaxOutstandingItems zero", NewBuilder().WithMaxOutstandingItems(0), ErrParametersInvalid},
+		{"MaxItemProcessingTime zero", NewBuilder().WithMaxItemProcessingTime(0), ErrParametersInvalid},
+		{"Parents zero", NewBuilder().WithParents(0), ErrParametersInvalid},
+		{"BatchSize zero", NewBuilder().WithBatchSize(0), ErrParametersInvalid},
+		{"BlockTime below 1ms", NewBuilder().WithBlockTime(time.Microsecond), ErrBlockTimeTooLow},
+		{"RoundTimeout zero", NewBuilder().WithRoundTimeout(0), ErrParametersInvalid},
+		{"MinRoundInterval negative", NewBuilder().WithMinRoundInterval(-time.Millisecond), ErrParametersInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Build() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilderCrossFieldErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    error
+		mention string
+	}{
+		{"AlphaPreference above K", NewBuilder().WithAlphaPreference(21), ErrParametersInvalid, "AlphaPreference 21 exceeds K 20"},
+		{"AlphaConfidence above K", NewBuilder().WithK(10).WithAlphaConfidence(11), ErrParametersInvalid, "AlphaConfidence 11 exceeds K 10"},
+		{"AlphaConfidence below AlphaPreference", NewBuilder().WithAlphaPreference(16).WithAlphaConfidence(15), ErrParametersInvalid, "below AlphaPreference"},
+		{"BetaRogue below BetaVirtuous", NewBuilder().WithBetaVirtuous(10).WithBetaRogue(5), ErrParametersInvalid, "BetaRogue 5 is below BetaVirtuous 10"},
+		{"RoundTimeout below BlockTime", NewBuilder().WithBlockTime(100 * time.Millisecond).WithRoundTimeout(50 * time.Millisecond), ErrRoundTimeoutTooLow, "block time"},
+		{"AlphaPreference below BFT quorum", NewBuilder().WithAlphaPreference(11).WithAlphaConfidence(11), ErrAlphaBelowBFTQuorum, "AlphaPreference=11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Build() error = %v, want %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.mention) {
+				t.Fatalf("Build() error = %q, want it to mention %q", err, tt.mention)
+			}
+		})
+	}
+}
+
+func TestBuilderReportsEverySetterError(t *testing.T) {
+	_, err := NewBuilder().WithK(0).WithBeta(0).WithAlpha(2).Build()
+	for _, want := range []error{ErrInvalidK, ErrInvalidBeta, ErrInvalidAlpha} {
+		if !errors.Is(err, want) {
+			t.Errorf("Build() error = %v, want it to include %v", err, want)
+		}
+	}
+}
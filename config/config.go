@@ -79,6 +79,7 @@ type Parameters struct {
 	BatchSize             int
 	BlockTime             time.Duration // For compatibility
 	RoundTO               time.Duration // For compatibility
+	MinRoundInterval      time.Duration // Minimum gap between polls of one item (0 = none)
 	GasLimit              uint64        // Per-block gas limit (0 = use chain default)
 
 	// ConvergenceSettleWindow is how long a contested fork slot is observed (since the
@@ -108,6 +109,13 @@ type Parameters struct {
 	// owner-gated per network. Safety is a REFINEMENT: one-sig-per-height becomes
 	// one-precommit-per-(height,round) + the lock rule.
 	ViewChange bool
+
+	// RingOnly selects quasar.RingOnlyMode: finality certificates are formed
+	// from the Corona ring/lattice threshold signature alone, no BLS key is
+	// used, and the cert's BLS field is zero-filled so the wire layout is
+	// unchanged. False (default) keeps the BLS + PQ dual path. Read by
+	// quasar.ConfigFor and wire.NewQuantumPolicyForParams.
+	RingOnly bool
 }
 
 // WithPQMode returns a copy of Parameters with the given PQ mode set.
@@ -391,6 +399,9 @@ func (p Parameters) Valid() error {
 	if p.BlockTime > 0 && p.RoundTO > 0 && p.RoundTO < p.BlockTime {
 		return ErrRoundTimeoutTooLow
 	}
+	if p.MinRoundInterval < 0 {
+		return ErrParametersInvalid
+	}
 
 	// Only validate other fields if they are set (non-zero)
 	if p.AlphaPreference != 0 && (p.AlphaPreference < 0 || p.AlphaPreference > p.K) {
@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SafetyWarningCode identifies a parameter combination flagged by ValidateSafety
+type SafetyWarningCode string
+
+const (
+	// WarnAlphaConfidenceBelowPreference: AlphaConfidence < AlphaPreference
+	WarnAlphaConfidenceBelowPreference SafetyWarningCode = "alpha-confidence-below-preference"
+	// WarnAlphaPreferenceBelowRatio: AlphaPreference < ⌈Alpha·K⌉
+	WarnAlphaPreferenceBelowRatio SafetyWarningCode = "alpha-preference-below-ratio"
+	// WarnBetaBelowSafetyMargin: β too small for K to reach SafetyFailureTarget
+	WarnBetaBelowSafetyMargin SafetyWarningCode = "beta-below-safety-margin"
+	// WarnLivenessMargin: fewer than f unresponsive samples stall a round
+	WarnLivenessMargin SafetyWarningCode = "liveness-margin"
+	// WarnProcessingTimeBelowFinality: MaxItemProcessingTime < β·RoundTO
+	WarnProcessingTimeBelowFinality SafetyWarningCode = "processing-time-below-finality"
+)
+
+// SafetyFailureTarget is the per-decision probability of conflicting
+// finality that ValidateSafety accepts before warning about β.
+const SafetyFailureTarget = 1e-6
+
+// SafetyWarning describes a combination of parameters that each pass Valid
+// but together weaken safety or liveness.
+type SafetyWarning struct {
+	Code    SafetyWarningCode
+	Fields  []string // parameters involved
+	Message string   // the tradeoff the combination implies
+}
+
+func (w SafetyWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Code, w.Message)
+}
+
+// ValidateSafety is a dry run over parameter combinations. Unlike Valid it
+// returns no errors: each warning explains the safety or liveness tradeoff
+// the flagged combination implies, and operators decide whether to accept it.
+// A nil result means no combination was flagged.
+func (p Parameters) ValidateSafety() []SafetyWarning {
+	var warnings []SafetyWarning
+
+	if p.AlphaConfidence > 0 && p.AlphaPreference > 0 && p.AlphaConfidence < p.AlphaPreference {
+		warnings = append(warnings, SafetyWarning{
+			Code:   WarnAlphaConfidenceBelowPreference,
+			Fields: []string{"AlphaConfidence", "AlphaPreference"},
+			Message: fmt.Sprintf("AlphaConfidence=%d < AlphaPreference=%d: confidence advances on polls too weak to move preference, so β is reached on weaker quorums than preference demands",
+				p.AlphaConfidence, p.AlphaPreference),
+		})
+	}
+
+	if p.AlphaPreference > 0 && p.Alpha > 0 {
+		if floor := int(math.Ceil(p.Alpha*float64(p.K) - 1e-9)); p.AlphaPreference < floor {
+			warnings = append(warnings, SafetyWarning{
+				Code:   WarnAlphaPreferenceBelowRatio,
+				Fields: []string{"AlphaPreference", "Alpha", "K"},
+				Message: fmt.Sprintf("AlphaPreference=%d < ⌈Alpha·K⌉=%d (Alpha=%.2f, K=%d): the integer quorum the engine counts is weaker than the configured ratio",
+					p.AlphaPreference, floor, p.Alpha, p.K),
+			})
+		}
+	}
+
+	beta := p.finalityBeta()
+	if alpha := p.quorum(); p.K > 1 && alpha > 0 && beta > 0 {
+		if fail := splitFailureProbability(p.K, alpha, beta); fail > SafetyFailureTarget {
+			warnings = append(warnings, SafetyWarning{
+				Code:   WarnBetaBelowSafetyMargin,
+				Fields: []string{"BetaVirtuous", "Beta", "K", "AlphaPreference"},
+				Message: fmt.Sprintf("β=%d with K=%d, α=%d leaves a %.1e chance per decision that an evenly split network finalizes either side (target %.0e); raise β or α for safety at the cost of latency",
+					beta, p.K, alpha, fail, SafetyFailureTarget),
+			})
+		}
+	}
+
+	if alpha := p.quorum(); alpha > 0 {
+		if f := p.ByzantineFaultTolerance(); p.K-alpha < f {
+			warnings = append(warnings, SafetyWarning{
+				Code:   WarnLivenessMargin,
+				Fields: []string{"AlphaPreference", "K"},
+				Message: fmt.Sprintf("α=%d of K=%d stalls once %d sampled validators are unresponsive, fewer than the f=%d faults K is sized for; lower α for liveness at the cost of safety",
+					alpha, p.K, p.K-alpha+1, f),
+			})
+		}
+	}
+
+	if p.MaxItemProcessingTime > 0 && p.RoundTO > 0 && beta > 0 {
+		if minFinality := time.Duration(beta) * p.RoundTO; p.MaxItemProcessingTime < minFinality {
+			warnings = append(warnings, SafetyWarning{
+				Code:   WarnProcessingTimeBelowFinality,
+				Fields: []string{"MaxItemProcessingTime", "RoundTO", "BetaVirtuous"},
+				Message: fmt.Sprintf("MaxItemProcessingTime=%s < β·RoundTO=%s: an item whose rounds run to timeout is reported unhealthy before it can finalize",
+					p.MaxItemProcessingTime, minFinality),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// quorum returns the integer accept quorum, deriving it from Alpha when
+// AlphaPreference is unset
+func (p Parameters) quorum() int {
+	if p.AlphaPreference > 0 {
+		return p.AlphaPreference
+	}
+	return int(math.Ceil(p.Alpha * float64(p.K)))
+}
+
+// finalityBeta returns the consecutive successes an uncontested item needs
+func (p Parameters) finalityBeta() int {
+	if p.BetaVirtuous > 0 {
+		return p.BetaVirtuous
+	}
+	return int(p.Beta)
+}
+
+// splitFailureProbability bounds the chance that β consecutive K-samples
+// of an evenly split network each reach α for the same side: the worst case
+// for two nodes finalizing conflicting decisions.
+func splitFailureProbability(k, alpha, beta int) float64 {
+	// P[Bin(K, 1/2) >= α], for either side
+	tail := 0.0
+	for i := alpha; i <= k; i++ {
+		tail += binomial(k, i)
+	}
+	round := math.Min(1, 2*tail/math.Pow(2, float64(k)))
+	return math.Pow(round, float64(beta))
+}
+
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func warningCodes(ws []SafetyWarning) map[SafetyWarningCode]bool {
+	codes := make(map[SafetyWarningCode]bool, len(ws))
+	for _, w := range ws {
+		codes[w.Code] = true
+	}
+	return codes
+}
+
+func TestValidateSafetyMainnetClean(t *testing.T) {
+	p := MainnetParams()
+	if err := p.Valid(); err != nil {
+		t.Fatalf("mainnet params invalid: %v", err)
+	}
+	if ws := p.ValidateSafety(); len(ws) != 0 {
+		t.Fatalf("expected no warnings for mainnet, got %v", ws)
+	}
+}
+
+func TestValidateSafetyFlagsCombinations(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Parameters)
+		want   SafetyWarningCode
+	}{
+		{
+			name: "alpha confidence below preference",
+			modify: func(p *Parameters) {
+				p.AlphaConfidence = p.AlphaPreference - 1
+			},
+			want: WarnAlphaConfidenceBelowPreference,
+		},
+		{
+			name: "alpha preference below ratio",
+			modify: func(p *Parameters) {
+				p.Alpha = 0.9 // ⌈0.9·21⌉ = 19 > 15
+			},
+			want: WarnAlphaPreferenceBelowRatio,
+		},
+		{
+			name: "beta too low for K",
+			modify: func(p *Parameters) {
+				p.Beta = 2
+				p.BetaVirtuous = 2
+			},
+			want: WarnBetaBelowSafetyMargin,
+		},
+		{
+			name: "alpha leaves no liveness margin",
+			modify: func(p *Parameters) {
+				p.AlphaPreference = 19
+				p.AlphaConfidence = 19
+				p.Alpha = 0.9
+			},
+			want: WarnLivenessMargin,
+		},
+		{
+			name: "processing time shorter than finality",
+			modify: func(p *Parameters) {
+				p.MaxItemProcessingTime = time.Second // < 15 × 400ms
+			},
+			want: WarnProcessingTimeBelowFinality,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MainnetParams()
+			tt.modify(&p)
+			if err := p.Valid(); err != nil {
+				t.Fatalf("combination must pass Valid to be a dry-run warning: %v", err)
+			}
+			ws := p.ValidateSafety()
+			codes := warningCodes(ws)
+			if !codes[tt.want] {
+				t.Fatalf("expected warning %s, got %v", tt.want, ws)
+			}
+			if len(codes) != 1 {
+				t.Errorf("expected only %s, got %v", tt.want, ws)
+			}
+			for _, w := range ws {
+				if w.Message == "" || len(w.Fields) == 0 {
+					t.Errorf("warning %s lacks explanation: %+v", w.Code, w)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSafetyLocalK3(t *testing.T) {
+	// K=3/α=2 is the documented f=0 local profile; β=2 cannot reach the target
+	if !warningCodes(LocalParams().ValidateSafety())[WarnBetaBelowSafetyMargin] {
+		t.Fatal("expected beta safety warning for LocalParams")
+	}
+	// A single validator has no sampling to fail
+	if ws := SingleValidatorParams().ValidateSafety(); len(ws) != 0 {
+		t.Fatalf("expected no warnings for single validator, got %v", ws)
+	}
+}
@@ -90,6 +90,11 @@ var (
 	ErrTimeout        = types.ErrTimeout
 	ErrNotInitialized = types.ErrNotInitialized
 	ErrUnknownState   = errors.New("unknown state")
+
+	// Committee errors
+	ErrCommitteeMismatch = types.ErrCommitteeMismatch
+	ErrNotInCommittee    = types.ErrNotInCommittee
+	ErrUnknownRound      = types.ErrUnknownRound
 )
 
 // DefaultConfig returns the default consensus configuration
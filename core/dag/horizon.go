@@ -1,5 +1,13 @@
 package dag
 
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
 // VertexID represents a vertex identifier in the DAG
 type VertexID [32]byte
 
@@ -71,33 +79,102 @@ func ComputeSafePrefix[V VID](store Store[V], frontier []V) []V {
 	return safeVertices
 }
 
-// ChooseFrontier selects appropriate parents for a new vertex proposal
-// This typically involves choosing a subset of frontier vertices to reference
+// ChooseFrontier selects appropriate parents for a new vertex proposal:
+// 2f+1 frontier vertices with f = (n-1)/3, or the whole frontier when it
+// has at most 3 vertices. Candidates are first sorted by their canonical
+// byte encoding (the ID bytes for array IDs such as ids.ID), a total order,
+// so every node given the same frontier in any order chooses the same
+// parents in the same order. The input slice is not modified.
 func ChooseFrontier[V VID](frontier []V) []V {
-	// For Byzantine tolerance with f faults, we need 2f+1 vertices
-	// Assuming f = (n-1)/3 for optimal Byzantine tolerance
-	// We'll choose min(2f+1, all) vertices
+	type keyed struct {
+		v   V
+		key []byte
+	}
+	sorted := make([]keyed, len(frontier))
+	for i, v := range frontier {
+		sorted[i] = keyed{v: v, key: canonicalBytes(v)}
+	}
+	slices.SortFunc(sorted, func(a, b keyed) int {
+		return bytes.Compare(a.key, b.key)
+	})
+
+	chosen := make([]V, frontierQuorum(len(sorted)))
+	for i := range chosen {
+		chosen[i] = sorted[i].v
+	}
+	return chosen
+}
 
+// ChooseFrontierWith is ChooseFrontier under a caller-supplied ordering,
+// e.g. ByRound. less must be a strict total order over the candidates for
+// the choice to be deterministic.
+func ChooseFrontierWith[V VID](frontier []V, less func(a, b V) bool) []V {
 	if len(frontier) == 0 {
 		return []V{}
 	}
+	sorted := slices.Clone(frontier)
+	slices.SortFunc(sorted, func(a, b V) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return sorted[:frontierQuorum(len(sorted))]
+}
 
-	// For small frontiers, reference all vertices
-	if len(frontier) <= 3 {
-		return frontier
+// ByRound orders vertices by their round in store, then by canonical ID
+// bytes. Vertices missing from store sort first.
+func ByRound[V VID](store Store[V]) func(a, b V) bool {
+	round := func(v V) uint64 {
+		if b, ok := store.Get(v); ok {
+			return b.Round()
+		}
+		return 0
 	}
+	return func(a, b V) bool {
+		if ra, rb := round(a), round(b); ra != rb {
+			return ra < rb
+		}
+		return bytes.Compare(canonicalBytes(a), canonicalBytes(b)) < 0
+	}
+}
 
-	// For larger frontiers, choose 2f+1 where f = (len-1)/3
-	// This ensures Byzantine fault tolerance
-	f := (len(frontier) - 1) / 3
-	required := 2*f + 1
-
-	if required >= len(frontier) {
-		return frontier
+// frontierQuorum returns how many of n frontier vertices to reference.
+// For Byzantine tolerance with f = (n-1)/3 faults this is 2f+1, or all n
+// for small frontiers.
+func frontierQuorum(n int) int {
+	if n <= 3 {
+		return n
 	}
+	f := (n - 1) / 3
+	return min(2*f+1, n)
+}
 
-	// Select the most recent vertices (assuming they're ordered)
-	return frontier[:required]
+// canonicalBytes encodes a vertex ID so that bytes.Compare is a total
+// order: byte arrays and slices as themselves, strings as their bytes,
+// integers big-endian (signed ones offset so negatives sort first) and
+// anything else by its Go-syntax representation.
+func canonicalBytes(v any) []byte {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return b
+		}
+	case reflect.String:
+		return []byte(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.BigEndian.AppendUint64(nil, uint64(rv.Int())^(1<<63))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.BigEndian.AppendUint64(nil, rv.Uint())
+	}
+	return []byte(fmt.Sprintf("%#v", v))
 }
 
 // IsReachable checks if vertex 'from' can reach vertex 'to' in the DAG
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testVertexIDs(n int) []VertexID {
+	vs := make([]VertexID, n)
+	for i := range vs {
+		// Descending first byte so input order differs from byte order
+		vs[i] = VertexID{byte(n - i), byte(i)}
+	}
+	return vs
+}
+
+func TestChooseFrontierDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	frontier := testVertexIDs(10)
+	want := ChooseFrontier(frontier)
+	require.Len(want, 7) // f = 3, 2f+1 = 7
+	require.True(slices.IsSortedFunc(want, func(a, b VertexID) int {
+		return slices.Compare(a[:], b[:])
+	}))
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		shuffled := slices.Clone(frontier)
+		rng.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		before := slices.Clone(shuffled)
+		require.Equal(want, ChooseFrontier(shuffled))
+		require.Equal(before, shuffled, "input must not be reordered")
+	}
+}
+
+func TestChooseFrontierSmall(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(ChooseFrontier[VertexID](nil))
+	require.Equal([]string{"a", "b", "c"}, ChooseFrontier([]string{"c", "a", "b"}))
+	require.Equal([]int{-2, 1, 3}, ChooseFrontier([]int{3, -2, 1}))
+}
+
+func TestChooseFrontierWith(t *testing.T) {
+	require := require.New(t)
+
+	frontier := []int{5, 1, 4, 2, 3, 6, 0}
+	descending := func(a, b int) bool { return a > b }
+	require.Equal([]int{6, 5, 4, 3, 2}, ChooseFrontierWith(frontier, descending))
+	require.Equal([]int{5, 1, 4, 2, 3, 6, 0}, frontier)
+	require.Empty(ChooseFrontierWith(nil, descending))
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/luxfi/consensus/config"
 	"github.com/luxfi/consensus/core"
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/consensus/engine/chain/block"
 	"github.com/luxfi/ids"
 )
@@ -149,7 +150,7 @@ func TestChainConsensusProcessVoteUnknownBlock(t *testing.T) {
 	unknownID := ids.GenerateTestID()
 	err := consensus.ProcessVote(ctx, unknownID, true)
 	require.Error(err)
-	require.Contains(err.Error(), "block not found")
+	require.ErrorIs(err, engine.ErrUnknownVertex)
 }
 
 // TestChainConsensusProcessVoteNilConsensus tests voting when consensus not initialized
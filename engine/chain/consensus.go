@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/ids"
 )
 
@@ -62,6 +64,9 @@ type ChainConsensus struct {
 	alpha int // Quorum size
 	beta  int // Decision threshold
 
+	// driverOpts configure each block's consensus driver
+	driverOpts []engine.Option
+
 	// State
 	blocks map[ids.ID]*Block
 	tips   map[ids.ID]bool // Current chain tips
@@ -96,6 +101,21 @@ func NewChainConsensus(k, alpha, beta int) *ChainConsensus {
 	}
 }
 
+// SetDriverOptions sets the options applied to the consensus driver of each
+// block added from now on. Takes c.mu.
+func (c *ChainConsensus) SetDriverOptions(opts ...engine.Option) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.driverOpts = opts
+}
+
+// newParamsConsensus returns a ChainConsensus sized and configured by p
+func newParamsConsensus(p config.Parameters) *ChainConsensus {
+	c := NewChainConsensus(p.K, p.AlphaPreference, int(p.Beta))
+	c.driverOpts = engine.ParamsOptions(p)
+	return c
+}
+
 // ApplyCert is THE canonical finalize: fold a Cert into the committed ledger and return
 // the plan the engine applies to the VM. It is the production finality path
 // (engine.acceptWithCertCore calls it) — finality advances by replacing the ledger with
@@ -132,13 +152,27 @@ func (c *ChainConsensus) FinalizeBranch(target ids.ID, height uint64, parentID i
 func (c *ChainConsensus) applyCertLocked(cert Cert) (Plan, error) {
 	led, plan, err := Finalize(c.ledger, cert, c.ancestry())
 	if err != nil {
-		return Plan{}, err
+		return Plan{}, foldError(err)
 	}
 	c.ledger = led    // THE ONLY way finality advances — one value assignment after a pure fold
 	c.applyPlan(plan) // DAG-side effects only (accepted/rejected/tips); never finality
 	return plan, nil
 }
 
+// foldError tags a fold error with the shared engine error it is an instance of:
+// equivocation and a losing branch are conflicts, an untracked ancestor is an
+// unknown vertex. The fold's own error stays in the chain for errors.Is.
+func foldError(err error) error {
+	switch {
+	case errors.Is(err, ErrHeightAlreadyFinalized), errors.Is(err, ErrConflictsWithFinalizedBranch):
+		return fmt.Errorf("%w: %w", engine.ErrConflict, err)
+	case errors.Is(err, ErrAncestorNotTracked):
+		return fmt.Errorf("%w: %w", engine.ErrUnknownVertex, err)
+	default:
+		return err
+	}
+}
+
 // applyPlan applies the fold's plan to the live DAG: mark the Accept path accepted and
 // drop it from the build tips; mark the Reject (losing-sibling) subtrees rejected and
 // remove them from the live DAG/tips — avalanchego acceptPreferredChild + rejectTransitively
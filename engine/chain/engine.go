@@ -20,8 +20,10 @@ import (
 // -----------------------------------------------------------------------------
 
 var (
-	ErrNotStarted     = errors.New("engine not started")
-	ErrAlreadyStarted = errors.New("engine already started")
+	// ErrNotStarted and ErrAlreadyStarted are the shared engine errors, so
+	// callers can match any engine's lifecycle failures the same way
+	ErrNotStarted     = engine.ErrNotStarted
+	ErrAlreadyStarted = engine.ErrAlreadyStarted
 
 	// ErrQuorumVerifierRequired is returned by Start when a multi-validator
 	// engine (K>1) is started without a VoteVerifier. Multi-validator finality
@@ -228,7 +230,7 @@ type Option func(*Transitive)
 func WithParams(p config.Parameters) Option {
 	return func(t *Transitive) {
 		t.params = p
-		t.consensus = NewChainConsensus(p.K, p.AlphaPreference, int(p.Beta))
+		t.consensus = newParamsConsensus(p)
 	}
 }
 
@@ -435,6 +437,14 @@ type Transitive struct {
 	pendingBlocks      map[ids.ID]*PendingBlock
 	pendingBuildBlocks int
 
+	// Block-time pacing (WithBlockPacing). pacer is nil when pacing is off;
+	// pacedTimer is the single deferred build armed while a build waits.
+	pacingEnabled bool
+	pacingJitter  time.Duration
+	pacingSeed    int64
+	pacer         *blockPacer
+	pacedTimer    *time.Timer
+
 	// finalizedByCert is the engine's authoritative finality record: the set of
 	// block IDs that were committed through the SOLE cert-gated finalizer
 	// (AcceptWithCert, which requires a VerifiedQuorumCert). It is deliberately
@@ -744,7 +754,7 @@ func NewWithConfig(cfg Config, opts ...Option) *Transitive {
 	}
 
 	t := &Transitive{
-		consensus:        NewChainConsensus(cfg.Params.K, cfg.Params.AlphaPreference, int(cfg.Params.Beta)),
+		consensus:        newParamsConsensus(cfg.Params),
 		params:           cfg.Params,
 		vm:               cfg.VM,
 		proposer:         cfg.Proposer,
@@ -771,6 +781,9 @@ func NewWithConfig(cfg Config, opts ...Option) *Transitive {
 	if t.log == nil {
 		t.log = log.Noop()
 	}
+	if t.pacingEnabled && t.params.BlockTime > 0 {
+		t.pacer = newBlockPacer(t.params.BlockTime, t.pacingJitter, t.pacingSeed)
+	}
 
 	return t
 }
@@ -867,6 +880,10 @@ func (t *Transitive) Stop(ctx context.Context) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.pacedTimer != nil {
+		t.pacedTimer.Stop()
+		t.pacedTimer = nil
+	}
 	t.bootstrapped = false
 	t.started = false
 	return nil
@@ -3104,6 +3121,11 @@ func (t *Transitive) buildBlocksLocked(ctx context.Context) error {
 	}
 
 	for t.pendingBuildBlocks > 0 {
+		// Paced engines defer the build (pendingBuildBlocks stays set) until
+		// BlockTime has elapsed since the previous one
+		if !t.pacedBuildLocked() {
+			return nil
+		}
 		vmBlock, err := t.vm.BuildBlock(ctx)
 		if err != nil {
 			t.log.Error("BuildBlock failed, will retry next tick",
@@ -3140,6 +3162,9 @@ func (t *Transitive) buildBlocksLocked(ctx context.Context) error {
 			}
 			continue
 		}
+		if t.pacer != nil {
+			t.pacer.built(time.Now())
+		}
 
 		consensusBlock := &Block{
 			id:           vmBlock.ID(),
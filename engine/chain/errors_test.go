@@ -13,6 +13,7 @@ import (
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/consensus/engine/chain/block"
 	"github.com/luxfi/consensus/engine/chain/chaintest"
 	"github.com/luxfi/consensus/types/bag"
@@ -312,6 +313,72 @@ func (b *ErrorBlock) Verify(ctx context.Context) error {
 	return b.TestBlock.Verify(ctx)
 }
 
+// TestEngineErrors checks each chain failure path matches its shared engine
+// error with errors.Is, alongside the chain's own error where it has one
+func TestEngineErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("already started", func(t *testing.T) {
+		require := require.New(t)
+		e := newTestEngine()
+		require.NoError(e.Start(ctx, true))
+		defer e.Stop(ctx)
+		require.ErrorIs(e.Start(ctx, true), engine.ErrAlreadyStarted)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		c := NewChainConsensus(1, 1, 1)
+		require.ErrorIs(t, c.ProcessVote(ctx, ids.GenerateTestID(), true), engine.ErrUnknownVertex)
+	})
+
+	t.Run("duplicate block", func(t *testing.T) {
+		require := require.New(t)
+		c := NewChainConsensus(1, 1, 1)
+		id := ids.GenerateTestID()
+		require.NoError(c.AddBlock(ctx, &Block{id: id, height: 1}))
+		require.ErrorIs(c.AddBlock(ctx, &Block{id: id, height: 1}), engine.ErrConflict)
+	})
+
+	t.Run("equivocation", func(t *testing.T) {
+		require := require.New(t)
+		c := NewChainConsensus(1, 1, 1)
+		_, err := c.FinalizeBranch(ids.GenerateTestID(), 1, ids.Empty)
+		require.NoError(err)
+		_, err = c.FinalizeBranch(ids.GenerateTestID(), 1, ids.Empty)
+		require.ErrorIs(err, engine.ErrConflict)
+		require.ErrorIs(err, ErrHeightAlreadyFinalized)
+	})
+
+	t.Run("losing branch", func(t *testing.T) {
+		require := require.New(t)
+		c := NewChainConsensus(1, 1, 1)
+		g, a, b := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+		_, err := c.FinalizeBranch(g, 0, ids.Empty)
+		require.NoError(err)
+		_, err = c.FinalizeBranch(a, 1, g)
+		require.NoError(err)
+		addTracked(c, b, g, 1)
+		child := ids.GenerateTestID()
+		addTracked(c, child, b, 2)
+		_, err = c.FinalizeBranch(child, 2, b)
+		require.ErrorIs(err, engine.ErrConflict)
+		require.ErrorIs(err, ErrConflictsWithFinalizedBranch)
+	})
+
+	t.Run("untracked ancestor", func(t *testing.T) {
+		require := require.New(t)
+		c := NewChainConsensus(1, 1, 1)
+		g := ids.GenerateTestID()
+		_, err := c.FinalizeBranch(g, 0, ids.Empty)
+		require.NoError(err)
+		orphan, parent := ids.GenerateTestID(), ids.GenerateTestID()
+		addTracked(c, orphan, parent, 2)
+		_, err = c.FinalizeBranch(orphan, 2, parent)
+		require.ErrorIs(err, engine.ErrUnknownVertex)
+		require.ErrorIs(err, ErrAncestorNotTracked)
+	})
+}
+
 // Consensus represents the chain consensus engine with error handling
 type Consensus struct {
 	ctx          context.Context
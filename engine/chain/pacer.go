@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"math/rand"
+	"time"
+)
+
+// blockPacer spaces this node's block builds at least one BlockTime apart.
+// Each gap is extended by a jitter drawn from [0, jitter) so validators that
+// started together do not propose in lockstep. Jitter is drawn from a seeded
+// source, so a given (interval, jitter, seed) yields the same schedule.
+type blockPacer struct {
+	interval time.Duration
+	jitter   time.Duration
+	rng      *rand.Rand
+
+	next time.Time // earliest time the next build may start
+}
+
+func newBlockPacer(interval, jitter time.Duration, seed int64) *blockPacer {
+	if jitter < 0 {
+		jitter = 0
+	}
+	return &blockPacer{
+		interval: interval,
+		jitter:   jitter,
+		rng:      rand.New(rand.NewSource(seed)), //nolint:gosec // jitter only, not security relevant
+	}
+}
+
+// wait returns how long a build at now must still wait; zero means it may
+// proceed.
+func (p *blockPacer) wait(now time.Time) time.Duration {
+	if p.next.IsZero() || !now.Before(p.next) {
+		return 0
+	}
+	return p.next.Sub(now)
+}
+
+// built records a build at now and schedules the earliest next one
+func (p *blockPacer) built(now time.Time) {
+	gap := p.interval
+	if p.jitter > 0 {
+		gap += time.Duration(p.rng.Int63n(int64(p.jitter)))
+	}
+	p.next = now.Add(gap)
+}
+
+// WithBlockPacing paces block production to Params.BlockTime: this node
+// builds at most one block per BlockTime, plus up to jitter of delay drawn
+// from seed. Give each validator its own seed, e.g. one derived from its
+// node ID, so they do not propose in lockstep while a run stays
+// reproducible. Build requests arriving early are deferred, not dropped.
+// Without this option the engine builds as soon as the VM signals pending
+// transactions.
+func WithBlockPacing(jitter time.Duration, seed int64) Option {
+	return func(t *Transitive) {
+		t.pacingJitter = jitter
+		t.pacingSeed = seed
+		t.pacingEnabled = true
+	}
+}
+
+// pacedBuildLocked reports whether a build may proceed now. When it may not,
+// it arms a single timer that retries buildBlocksLocked under the engine's
+// context once the pacer allows it. A timer that fires after Stop, or after
+// being replaced, does nothing. Caller holds t.mu.
+func (t *Transitive) pacedBuildLocked() bool {
+	if t.pacer == nil {
+		return true
+	}
+	wait := t.pacer.wait(time.Now())
+	if wait == 0 {
+		return true
+	}
+	if t.pacedTimer == nil {
+		var timer *time.Timer
+		timer = time.AfterFunc(wait, func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.pacedTimer != timer {
+				return
+			}
+			t.pacedTimer = nil
+			if !t.started || t.ctx.Err() != nil {
+				return
+			}
+			_ = t.buildBlocksLocked(t.ctx)
+		})
+		t.pacedTimer = timer
+	}
+	return false
+}
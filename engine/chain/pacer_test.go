@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestBlockPacerRate drives the pacer on a virtual clock with a builder that
+// always wants to build, and checks the rate matches 1/BlockTime.
+func TestBlockPacerRate(t *testing.T) {
+	const (
+		blockTime = 100 * time.Millisecond
+		jitter    = 10 * time.Millisecond
+		window    = 60 * time.Second
+	)
+	p := newBlockPacer(blockTime, jitter, 42)
+
+	start := time.Unix(0, 0)
+	now := start
+	var builds int
+	var prev time.Time
+	for now.Sub(start) < window {
+		if wait := p.wait(now); wait > 0 {
+			now = now.Add(wait)
+			continue
+		}
+		if builds > 0 && now.Sub(prev) < blockTime {
+			t.Fatalf("builds %v apart, want >= %v", now.Sub(prev), blockTime)
+		}
+		p.built(now)
+		prev = now
+		builds++
+		now = now.Add(time.Millisecond) // builder immediately asks again
+	}
+
+	// Expected mean gap is BlockTime + jitter/2
+	want := float64(window) / float64(blockTime+jitter/2)
+	if diff := math.Abs(float64(builds)-want) / want; diff > 0.05 {
+		t.Fatalf("built %d blocks in %v, want ~%.0f (off by %.1f%%)", builds, window, want, diff*100)
+	}
+}
+
+func TestBlockPacerDeterministicJitter(t *testing.T) {
+	a := newBlockPacer(50*time.Millisecond, 20*time.Millisecond, 7)
+	b := newBlockPacer(50*time.Millisecond, 20*time.Millisecond, 7)
+	now := time.Unix(0, 0)
+	for i := 0; i < 20; i++ {
+		a.built(now)
+		b.built(now)
+		if a.next != b.next {
+			t.Fatalf("round %d: schedules diverged: %v vs %v", i, a.next, b.next)
+		}
+		now = a.next
+	}
+}
+
+// TestEnginePacesBlockProduction checks a paced engine flooded with build
+// requests produces blocks at about 1/BlockTime.
+func TestEnginePacesBlockProduction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping timing test in short mode")
+	}
+
+	params := singleNodeBurstParams()
+	params.BlockTime = 20 * time.Millisecond
+	vm := newBurstVM(1)
+	engine := NewWithConfig(Config{Params: params, VM: vm}, WithBlockPacing(2*time.Millisecond, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := engine.Start(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+
+	const window = time.Second
+	start := time.Now()
+	for time.Since(start) < window {
+		_ = engine.Notify(ctx, Message{Type: PendingTxs})
+		time.Sleep(100 * time.Microsecond)
+	}
+	built := vm.height.Load()
+	cancel()
+	_ = engine.Stop(context.Background())
+
+	// Upper bound is strict: never more than one block per BlockTime
+	maxBlocks := uint64(window/params.BlockTime) + 1
+	if built > maxBlocks {
+		t.Fatalf("built %d blocks in %v, pacing allows at most %d", built, window, maxBlocks)
+	}
+	// Lower bound is loose to tolerate scheduler noise
+	if minBlocks := maxBlocks * 6 / 10; built < minBlocks {
+		t.Fatalf("built %d blocks in %v, want at least %d", built, window, minBlocks)
+	}
+}
+
+// TestPacedBuildDoesNotFireAfterStop checks a build deferred by the pacer
+// is abandoned when the engine stops.
+func TestPacedBuildDoesNotFireAfterStop(t *testing.T) {
+	params := singleNodeBurstParams()
+	params.BlockTime = 50 * time.Millisecond
+	vm := newBurstVM(1)
+	engine := NewWithConfig(Config{Params: params, VM: vm}, WithBlockPacing(0, 1))
+
+	ctx := context.Background()
+	if err := engine.Start(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	_ = engine.Notify(ctx, Message{Type: PendingTxs})
+	_ = engine.Notify(ctx, Message{Type: PendingTxs}) // deferred by the pacer
+	if err := engine.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	built := vm.height.Load()
+
+	time.Sleep(2 * params.BlockTime)
+	if got := vm.height.Load(); got != built {
+		t.Fatalf("built %d blocks after Stop", got-built)
+	}
+}
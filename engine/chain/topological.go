@@ -103,11 +103,11 @@ func (c *ChainConsensus) AddBlock(ctx context.Context, block *Block) error {
 
 	// Check if block already exists
 	if _, exists := c.blocks[block.id]; exists {
-		return fmt.Errorf("block already exists: %s", block.id)
+		return fmt.Errorf("%w: block already exists: %s", engine.ErrConflict, block.id)
 	}
 
 	// Initialize Lux consensus for this block using Photon → Wave → Focus
-	block.driver = engine.NewLuxConsensus(c.k, c.alpha, c.beta)
+	block.driver = engine.NewLuxConsensus(c.k, c.alpha, c.beta, c.driverOpts...)
 
 	// Add to blocks map
 	c.blocks[block.id] = block
@@ -132,7 +132,7 @@ func (c *ChainConsensus) ProcessVote(ctx context.Context, blockID ids.ID, accept
 
 	block, exists := c.blocks[blockID]
 	if !exists {
-		return fmt.Errorf("block not found: %s", blockID)
+		return fmt.Errorf("%w: block %s", engine.ErrUnknownVertex, blockID)
 	}
 
 	if block.driver == nil {
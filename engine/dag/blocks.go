@@ -0,0 +1,306 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/consensus/types"
+	"github.com/luxfi/ids"
+)
+
+// BlockEngine runs blocks through DAG consensus behind engine.Engine. Each
+// block is a vertex whose parent is the block's parent, and each vote for
+// a block is a successful poll of its vertex, so a block is accepted after
+// Alpha consecutive votes. It implements engine.Swappable, so it can take
+// over from an engine.Chain without finalizing any block twice.
+type BlockEngine struct {
+	mu sync.Mutex
+
+	consensus *DAGConsensus
+	blocks    map[types.ID]*types.Block
+	pending   map[types.ID]time.Time           // undecided blocks, by when they were added
+	rounds    map[types.ID]map[uint64]struct{} // vote rounds per undecided block
+
+	height       uint64
+	lastAccepted types.ID
+	onAccept     func(*types.Block)
+	draining     bool
+}
+
+var (
+	_ engine.Engine    = (*BlockEngine)(nil)
+	_ engine.Swappable = (*BlockEngine)(nil)
+)
+
+// NewBlockEngine creates a DAG-backed block engine accepting a block after
+// cfg.Alpha votes
+func NewBlockEngine(cfg types.Config) *BlockEngine {
+	return &BlockEngine{
+		consensus:    NewDAGConsensus(1, 1, max(cfg.Alpha, 1)),
+		blocks:       make(map[types.ID]*types.Block),
+		pending:      make(map[types.ID]time.Time),
+		rounds:       make(map[types.ID]map[uint64]struct{}),
+		lastAccepted: types.GenesisID,
+	}
+}
+
+// OnAccept registers fn to be called once for each block the engine
+// finalizes. fn runs without the engine lock held.
+func (e *BlockEngine) OnAccept(fn func(*types.Block)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onAccept = fn
+}
+
+// Add adds block to consensus as a vertex on its parent
+func (e *BlockEngine) Add(ctx context.Context, block *types.Block) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.draining {
+		return engine.ErrDraining
+	}
+	// Re-adding a known block must not reopen its decision
+	if _, ok := e.blocks[block.ID]; ok {
+		return nil
+	}
+
+	var parents []ids.ID
+	if block.ParentID != types.GenesisID {
+		parents = []ids.ID{block.ParentID}
+	}
+	v := NewVertex(block.ID, parents, block.Height, block.Time.Unix(), block.Payload)
+	if err := e.consensus.AddVertex(ctx, v); err != nil {
+		return err
+	}
+	e.blocks[block.ID] = block
+	e.pending[block.ID] = time.Now()
+	e.rounds[block.ID] = make(map[uint64]struct{})
+	return nil
+}
+
+// RecordVote polls the block's vertex with vote as a successful response
+func (e *BlockEngine) RecordVote(ctx context.Context, vote *types.Vote) error {
+	e.mu.Lock()
+	accepted, err := e.recordVoteLocked(ctx, vote)
+	onAccept := e.onAccept
+	e.mu.Unlock()
+
+	if onAccept != nil {
+		for _, block := range accepted {
+			onAccept(block)
+		}
+	}
+	return err
+}
+
+// recordVoteLocked records vote and returns the blocks it finalized
+// Must be called with e.mu held
+func (e *BlockEngine) recordVoteLocked(ctx context.Context, vote *types.Vote) ([]*types.Block, error) {
+	if e.draining {
+		return nil, engine.ErrDraining
+	}
+	if _, ok := e.blocks[vote.BlockID]; !ok {
+		return nil, types.ErrBlockNotFound
+	}
+	if _, ok := e.pending[vote.BlockID]; !ok {
+		return nil, nil
+	}
+
+	e.rounds[vote.BlockID][vote.Round] = struct{}{}
+	if err := e.consensus.Poll(ctx, map[ids.ID]int{vote.BlockID: 1}); err != nil {
+		return nil, err
+	}
+
+	var accepted []*types.Block
+	for _, id := range slices.SortedFunc(maps.Keys(e.pending), ids.ID.Compare) {
+		switch {
+		case e.consensus.IsAccepted(id):
+			block := e.blocks[id]
+			if block.Height > e.height {
+				e.height = block.Height
+				e.lastAccepted = id
+			}
+			accepted = append(accepted, block)
+		case e.consensus.IsRejected(id):
+		default:
+			continue
+		}
+		delete(e.pending, id)
+		delete(e.rounds, id)
+	}
+	return accepted, nil
+}
+
+// IsAccepted returns whether a block has been accepted
+func (e *BlockEngine) IsAccepted(id types.ID) bool {
+	return e.consensus.IsAccepted(id)
+}
+
+// GetStatus returns the status of a block
+func (e *BlockEngine) GetStatus(id types.ID) types.Status {
+	switch {
+	case e.consensus.IsAccepted(id):
+		return types.StatusAccepted
+	case e.consensus.IsRejected(id):
+		return types.StatusRejected
+	}
+	if _, ok := e.consensus.GetVertex(id); ok {
+		return types.StatusProcessing
+	}
+	return types.StatusUnknown
+}
+
+// FinalizedHeight returns the height of the highest accepted block
+func (e *BlockEngine) FinalizedHeight() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.height
+}
+
+// AcceptedAt returns the accepted block at the given height, if any
+func (e *BlockEngine) AcceptedAt(height uint64) (*types.Block, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for id, block := range e.blocks {
+		if block.Height == height && e.consensus.IsAccepted(id) {
+			return block, true
+		}
+	}
+	return nil, false
+}
+
+// Start implements engine.Engine
+func (e *BlockEngine) Start(ctx context.Context) error {
+	return nil
+}
+
+// Stop implements engine.Engine
+func (e *BlockEngine) Stop() error {
+	return nil
+}
+
+// ActiveItems implements engine.Engine. Items are ordered by height, then
+// ID, and a block's confidence is its count of consecutive votes.
+func (e *BlockEngine) ActiveItems() []engine.ItemStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	items := make([]engine.ItemStatus, 0, len(e.pending))
+	preferred := make(map[uint64]engine.ItemStatus)
+	for id, added := range e.pending {
+		item := engine.ItemStatus{
+			ID:     id,
+			Height: e.blocks[id].Height,
+			Rounds: len(e.rounds[id]),
+			Age:    now.Sub(added),
+		}
+		if v, ok := e.consensus.GetVertex(id); ok && v.Driver() != nil {
+			item.Confidence = v.Driver().Confidence(id)
+		}
+		items = append(items, item)
+
+		best, ok := preferred[item.Height]
+		if !ok || item.Confidence > best.Confidence || (item.Confidence == best.Confidence && id.Compare(best.ID) < 0) {
+			preferred[item.Height] = item
+		}
+	}
+
+	slices.SortFunc(items, func(a, b engine.ItemStatus) int {
+		return cmp.Or(cmp.Compare(a.Height, b.Height), a.ID.Compare(b.ID))
+	})
+	for i := range items {
+		items[i].Preference = preferred[items[i].Height].ID
+	}
+	return items
+}
+
+// Drain implements engine.Swappable
+func (e *BlockEngine) Drain() engine.Handoff {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.draining = true
+	h := engine.Handoff{
+		FinalizedHeight: e.height,
+		Preference:      e.lastAccepted,
+	}
+	for id, block := range e.blocks {
+		switch {
+		case e.consensus.IsAccepted(id):
+			h.Accepted = append(h.Accepted, block)
+		case !e.consensus.IsRejected(id):
+			h.Processing = append(h.Processing, block)
+		}
+	}
+	byHeight := func(a, b *types.Block) int {
+		return cmp.Or(cmp.Compare(a.Height, b.Height), a.ID.Compare(b.ID))
+	}
+	slices.SortFunc(h.Accepted, byHeight)
+	slices.SortFunc(h.Processing, byHeight)
+	return h
+}
+
+// Reopen implements engine.Swappable
+func (e *BlockEngine) Reopen() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.draining = false
+}
+
+// Resume implements engine.Swappable. The predecessor's accepted blocks are
+// imported as finalized history, as from a frontier snapshot, without
+// invoking the OnAccept callback; they were reported by the predecessor.
+// The engine must not hold any block yet.
+func (e *BlockEngine) Resume(h engine.Handoff) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.draining {
+		return engine.ErrDraining
+	}
+	if len(e.blocks) > 0 {
+		return fmt.Errorf("%w: resume needs an empty engine, have %d blocks", engine.ErrConflict, len(e.blocks))
+	}
+
+	accepted := slices.Clone(h.Accepted)
+	slices.SortFunc(accepted, func(a, b *types.Block) int {
+		return cmp.Or(cmp.Compare(a.Height, b.Height), a.ID.Compare(b.ID))
+	})
+	known := make(map[types.ID]*types.Block, len(accepted))
+	snap := FrontierSnapshot{Watermark: h.FinalizedHeight}
+	for _, block := range accepted {
+		if block.ID == types.GenesisID {
+			continue
+		}
+		tip := FrontierTip{
+			VertexRef: VertexRef{ID: block.ID, Height: block.Height},
+			Status:    VertexAccepted,
+			Finalized: true,
+		}
+		if parent, ok := known[block.ParentID]; ok {
+			tip.Parents = []VertexRef{{ID: parent.ID, Height: parent.Height}}
+		}
+		snap.Tips = append(snap.Tips, tip)
+		known[block.ID] = block
+	}
+	if err := e.consensus.ImportFrontier(snap); err != nil {
+		return err
+	}
+
+	e.blocks = known
+	e.height = h.FinalizedHeight
+	e.lastAccepted = h.Preference
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/consensus/types"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockEngineAcceptsAfterAlphaVotes(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := types.DefaultConfig()
+	cfg.Alpha = 2
+	e := NewBlockEngine(cfg)
+	var accepted []types.ID
+	e.OnAccept(func(b *types.Block) { accepted = append(accepted, b.ID) })
+
+	b1 := &types.Block{ID: ids.GenerateTestID(), ParentID: types.GenesisID, Height: 1}
+	b2 := &types.Block{ID: ids.GenerateTestID(), ParentID: b1.ID, Height: 2}
+	require.NoError(e.Add(ctx, b1))
+	require.NoError(e.Add(ctx, b2))
+	require.ErrorIs(e.RecordVote(ctx, &types.Vote{BlockID: ids.GenerateTestID()}), types.ErrBlockNotFound)
+
+	require.NoError(e.RecordVote(ctx, &types.Vote{BlockID: b1.ID, Round: 1}))
+	require.Equal(types.StatusProcessing, e.GetStatus(b1.ID))
+	items := e.ActiveItems()
+	require.Len(items, 2)
+	require.Equal(b1.ID, items[0].ID)
+	require.Equal(1, items[0].Rounds)
+
+	require.NoError(e.RecordVote(ctx, &types.Vote{BlockID: b1.ID, Round: 2}))
+	require.Equal(types.StatusAccepted, e.GetStatus(b1.ID))
+	require.Equal(uint64(1), e.FinalizedHeight())
+	require.Equal([]types.ID{b1.ID}, accepted)
+
+	// A drained engine hands b2 over as processing and refuses new work
+	h := e.Drain()
+	require.Equal([]*types.Block{b1}, h.Accepted)
+	require.Equal([]*types.Block{b2}, h.Processing)
+	require.ErrorIs(e.Add(ctx, &types.Block{ID: ids.GenerateTestID(), ParentID: b2.ID, Height: 3}), engine.ErrDraining)
+	e.Reopen()
+	require.NoError(e.RecordVote(ctx, &types.Vote{BlockID: b2.ID}))
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/luxfi/ids"
+)
+
+// ConflictID identifies a resource that mutually exclusive vertices touch,
+// such as a UTXO. At most one vertex per ConflictID may be accepted.
+type ConflictID ids.ID
+
+// UTXOConflictID derives the ConflictID for spending a UTXO
+func UTXOConflictID(u UTXO) ConflictID {
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], u.OutputIndex)
+
+	h := sha256.New()
+	h.Write(u.TxID[:])
+	h.Write(idx[:])
+	var id ConflictID
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// ConflictSet is the set of vertices touching one ConflictID and the vertex
+// that won it, if any.
+type ConflictSet struct {
+	ID      ConflictID
+	members map[ids.ID]bool
+	winner  ids.ID
+}
+
+func newConflictSet(id ConflictID) *ConflictSet {
+	return &ConflictSet{
+		ID:      id,
+		members: make(map[ids.ID]bool),
+	}
+}
+
+// clone returns a copy of the set safe to hand out past the engine lock
+func (s *ConflictSet) clone() *ConflictSet {
+	c := newConflictSet(s.ID)
+	for id := range s.members {
+		c.members[id] = true
+	}
+	c.winner = s.winner
+	return c
+}
+
+// Members returns the vertices in the set
+func (s *ConflictSet) Members() []ids.ID {
+	result := make([]ids.ID, 0, len(s.members))
+	for id := range s.members {
+		result = append(result, id)
+	}
+	return result
+}
+
+// Winner returns the accepted vertex of the set, if one has been accepted
+func (s *ConflictSet) Winner() (ids.ID, bool) {
+	return s.winner, s.winner != ids.Empty
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func newConflictTestEngine(beta uint32) *dagEngine {
+	return NewWithParams(config.Parameters{K: 1, AlphaPreference: 1, Beta: beta}).(*dagEngine)
+}
+
+// addDoubleSpend adds two vertices spending the same UTXO
+func addDoubleSpend(t *testing.T, e *dagEngine) (ids.ID, ids.ID, ConflictID) {
+	ctx := context.Background()
+	spent := UTXOConflictID(UTXO{TxID: ids.GenerateTestID(), OutputIndex: 0})
+
+	a := NewVertex(ids.GenerateTestID(), nil, 1, 0, []byte("pay alice"))
+	b := NewVertex(ids.GenerateTestID(), nil, 1, 0, []byte("pay bob"))
+	require.NoError(t, e.AddVertex(ctx, a, []ConflictID{spent}))
+	require.NoError(t, e.AddVertex(ctx, b, []ConflictID{spent}))
+	return a.ID(), b.ID(), spent
+}
+
+func TestConflictSetDoubleSpendOneFinalizes(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	e := newConflictTestEngine(2)
+	a, b, spent := addDoubleSpend(t, e)
+	require.ElementsMatch([]ids.ID{b}, e.consensus.GetConflictSet(a))
+	for _, id := range []ids.ID{a, b} {
+		v, ok := e.consensus.GetVertex(id)
+		require.True(ok)
+		require.True(v.Driver().Explain(id).Contested)
+	}
+
+	// Drive both spends to finality in the same polls
+	for i := 0; i < 3; i++ {
+		require.NoError(e.Poll(ctx, map[ids.ID]int{a: 1, b: 1}))
+	}
+
+	require.NotEqual(e.IsAccepted(a), e.IsAccepted(b), "exactly one spend must finalize")
+	winner, loser := a, b
+	if e.IsAccepted(b) {
+		winner, loser = b, a
+	}
+	require.True(e.consensus.IsRejected(loser))
+
+	set, ok := e.consensus.GetConflictSetByID(spent)
+	require.True(ok)
+	got, ok := set.Winner()
+	require.True(ok)
+	require.Equal(winner, got)
+}
+
+func TestConflictSetRejectsLateFinalization(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	e := newConflictTestEngine(2)
+	a, b, _ := addDoubleSpend(t, e)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(e.Poll(ctx, map[ids.ID]int{a: 1}))
+	}
+	require.True(e.IsAccepted(a))
+	require.True(e.consensus.IsRejected(b))
+
+	// Further votes for the losing spend never finalize it
+	for i := 0; i < 3; i++ {
+		require.NoError(e.Poll(ctx, map[ids.ID]int{b: 1}))
+	}
+	require.False(e.IsAccepted(b))
+}
+
+func TestConflictSetPreferenceFollowsConfidence(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	e := newConflictTestEngine(5)
+	a, b, spent := addDoubleSpend(t, e)
+
+	require.NoError(e.Poll(ctx, map[ids.ID]int{a: 1}))
+	pref, ok := e.ConflictPreference(spent)
+	require.True(ok)
+	require.Equal(a, pref)
+
+	require.NoError(e.Poll(ctx, map[ids.ID]int{b: 1}))
+	require.NoError(e.Poll(ctx, map[ids.ID]int{b: 1}))
+	pref, ok = e.ConflictPreference(spent)
+	require.True(ok)
+	require.Equal(b, pref)
+
+	_, ok = e.ConflictPreference(ConflictID{0xFF})
+	require.False(ok)
+}
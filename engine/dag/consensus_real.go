@@ -6,6 +6,7 @@ package dag
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"sync"
 
@@ -22,6 +23,15 @@ type DAGConsensus struct {
 	alpha int // Quorum size
 	beta  int // Decision threshold
 
+	maxParents int  // Parent limit per vertex (see parents.go)
+	verifyIDs  bool // Reject vertices with non-derived IDs (see vertex_id.go)
+
+	// driverOpts configure each vertex's consensus driver
+	driverOpts []engine.Option
+
+	// store persists every vertex added and its decision (see store.go)
+	store VertexStore
+
 	// State
 	vertices   map[ids.ID]*Vertex
 	frontier   map[ids.ID]bool // Current frontier (vertices with no unprocessed children)
@@ -34,42 +44,127 @@ type DAGConsensus struct {
 	// Conflict sets - maps vertex ID to set of conflicting vertex IDs
 	conflictSets map[ids.ID]map[ids.ID]bool
 
+	// Declared conflicts - maps conflict ID to the vertices touching it,
+	// and vertex ID to the conflict IDs it declared
+	conflicts       map[ConflictID]*ConflictSet
+	vertexConflicts map[ids.ID][]ConflictID
+
 	// Consensus tracking
 	bootstrapped bool
 	lastAccepted ids.ID
+
+	// invalidHints counts dependency hints dropped on submission (see hints.go)
+	invalidHints int
+
+	// tracer receives consensus transitions, and round counts polls
+	// (see trace.go)
+	tracer engine.EventTracer
+	round  uint64
+
+	// Finalized order (see order.go)
+	byHeight    map[uint64][]ids.ID
+	order       []ids.ID
+	orderHeight uint64              // highest height in the order
+	ordered     map[ids.ID]bool     // ordered and not yet evicted
+	unordered   map[ids.ID]struct{} // accepted but not yet ordered
+	orderStale  bool                // decided since advanceOrder last ran
+
+	// checkOrder enables verifying that the finalized order only grows, and
+	// checkedOrder is the order as of the last check (see invariant.go)
+	checkOrder   bool
+	checkedOrder []ids.ID
+
+	// watermark is the finalized height imported from a frontier snapshot,
+	// and settled the heights of the finalized vertices its tips reference
+	// but it does not hold (see snapshot.go)
+	watermark uint64
+	settled   map[ids.ID]uint64
 }
 
 // NewDAGConsensus creates a real consensus engine for DAG
 func NewDAGConsensus(k, alpha, beta int) *DAGConsensus {
+	return NewDAGConsensusWithStore(k, alpha, beta, NewMemoryVertexStore())
+}
+
+// NewDAGConsensusWithStore creates a consensus engine persisting its
+// vertices in store. Call Recover to resume from a store that already
+// holds a DAG.
+func NewDAGConsensusWithStore(k, alpha, beta int, store VertexStore) *DAGConsensus {
 	return &DAGConsensus{
 		k:            k,
 		alpha:        alpha,
 		beta:         beta,
+		maxParents:   DefaultMaxParents,
+		store:        store,
 		vertices:     make(map[ids.ID]*Vertex),
 		frontier:     make(map[ids.ID]bool),
 		processing:   make(map[ids.ID]bool),
 		inputIndex:   make(map[string][]ids.ID),
 		conflictSets: make(map[ids.ID]map[ids.ID]bool),
+
+		conflicts:       make(map[ConflictID]*ConflictSet),
+		vertexConflicts: make(map[ids.ID][]ConflictID),
+
+		byHeight:  make(map[uint64][]ids.ID),
+		ordered:   make(map[ids.ID]bool),
+		unordered: make(map[ids.ID]struct{}),
+		settled:   make(map[ids.ID]uint64),
+
+		tracer: engine.NopTracer{},
 	}
 }
 
+// SetDriverOptions sets the options applied to the consensus driver of
+// each vertex added from now on
+func (d *DAGConsensus) SetDriverOptions(opts ...engine.Option) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.driverOpts = opts
+}
+
 // AddVertex adds a vertex to the DAG
 func (d *DAGConsensus) AddVertex(ctx context.Context, vertex *Vertex) error {
+	return d.AddVertexWithConflicts(ctx, vertex, nil)
+}
+
+// AddVertexWithConflicts adds a vertex to the DAG that touches the given
+// conflict IDs. Of all vertices sharing a conflict ID, at most one is accepted.
+func (d *DAGConsensus) AddVertexWithConflicts(ctx context.Context, vertex *Vertex, conflicts []ConflictID) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	// Check if vertex already exists
-	if _, exists := d.vertices[vertex.ID()]; exists {
-		return fmt.Errorf("vertex already exists: %s", vertex.ID())
+	// Check if vertex already exists, including decided history that is
+	// only in the store
+	if _, exists := d.vertices[vertex.ID()]; exists || d.store.Has(vertex.ID()) {
+		return fmt.Errorf("%w: vertex already exists: %s", engine.ErrConflict, vertex.ID())
 	}
 
 	// Verify the vertex
 	if err := vertex.Verify(ctx); err != nil {
 		return fmt.Errorf("vertex verification failed: %w", err)
 	}
+	if err := d.checkVertexID(vertex); err != nil {
+		return err
+	}
+	if err := d.checkParents(vertex); err != nil {
+		return err
+	}
+	// A conflict set that already has a winner can never accept another
+	// member, so a late double spend is refused outright
+	for _, conflictID := range conflicts {
+		if set, ok := d.conflicts[conflictID]; ok {
+			if winner, ok := set.Winner(); ok {
+				return fmt.Errorf("%w: conflict set %x already won by %s", engine.ErrConflict, conflictID[:], winner)
+			}
+		}
+	}
+
+	if err := d.store.Put(record(vertex, conflicts)); err != nil {
+		return fmt.Errorf("failed to store vertex %s: %w", vertex.ID(), err)
+	}
 
 	// Initialize Lux consensus for this vertex using Photon → Wave → Prism (DAG refraction)
-	vertex.SetLuxConsensus(engine.NewLuxConsensus(d.k, d.alpha, d.beta))
+	vertex.SetLuxConsensus(engine.NewLuxConsensus(d.k, d.alpha, d.beta, d.driverOpts...))
 
 	// Register inputs in the conflict graph for double-spend detection
 	vertexID := vertex.ID()
@@ -95,8 +190,27 @@ func (d *DAGConsensus) AddVertex(ctx context.Context, vertex *Vertex) error {
 		d.inputIndex[inputKey] = append(d.inputIndex[inputKey], vertexID)
 	}
 
+	// Register declared conflicts
+	for _, conflictID := range conflicts {
+		set, ok := d.conflicts[conflictID]
+		if !ok {
+			set = newConflictSet(conflictID)
+			d.conflicts[conflictID] = set
+		}
+		for memberID := range set.members {
+			if member, ok := d.vertices[memberID]; ok && member.IsAccepted() {
+				continue
+			}
+			d.addConflict(vertexID, memberID)
+		}
+		set.members[vertexID] = true
+		d.vertexConflicts[vertexID] = append(d.vertexConflicts[vertexID], conflictID)
+	}
+
 	// Add to vertices map
 	d.vertices[vertex.ID()] = vertex
+	d.addToHeight(vertex)
+	d.markContested(vertexID)
 
 	// Link with parent vertices
 	for _, parentID := range vertex.ParentIDs() {
@@ -104,12 +218,12 @@ func (d *DAGConsensus) AddVertex(ctx context.Context, vertex *Vertex) error {
 			continue
 		}
 
-		parent, exists := d.vertices[parentID]
-		if !exists {
-			return fmt.Errorf("parent vertex not found: %s", parentID)
+		// Link parent-child relationship (checkParents found every parent
+		// that is not settled history imported from a snapshot)
+		parent, ok := d.vertices[parentID]
+		if !ok {
+			continue
 		}
-
-		// Link parent-child relationship
 		parent.AddChild(vertex)
 		vertex.AddParent(parent)
 
@@ -120,6 +234,18 @@ func (d *DAGConsensus) AddVertex(ctx context.Context, vertex *Vertex) error {
 	// Add vertex to frontier (it has no children yet)
 	d.frontier[vertex.ID()] = true
 
+	if !d.checkHint(vertex) {
+		vertex.clearDependencyHint()
+		d.invalidHints++
+	}
+	d.markReady(vertex)
+
+	d.tracer.Trace(engine.Event{
+		Kind:   engine.EventVertexAdded,
+		Round:  d.round,
+		Item:   vertexID,
+		Height: vertex.Height(),
+	})
 	return nil
 }
 
@@ -137,6 +263,20 @@ func (d *DAGConsensus) addConflict(v1, v2 ids.ID) {
 	d.conflictSets[v2][v1] = true
 }
 
+// markContested marks vertex id and the vertices it conflicts with as
+// contested in their drivers, so they are decided under the rogue beta
+// Must be called with d.mu held, after id is in d.vertices
+func (d *DAGConsensus) markContested(id ids.ID) {
+	if len(d.conflictSets[id]) == 0 {
+		return
+	}
+	for _, memberID := range append([]ids.ID{id}, slices.Collect(maps.Keys(d.conflictSets[id]))...) {
+		if v, ok := d.vertices[memberID]; ok && v.Driver() != nil {
+			v.Driver().MarkContested(memberID)
+		}
+	}
+}
+
 // ProcessVote processes a vote for a vertex
 func (d *DAGConsensus) ProcessVote(ctx context.Context, vertexID ids.ID, accept bool) error {
 	d.mu.Lock()
@@ -144,7 +284,7 @@ func (d *DAGConsensus) ProcessVote(ctx context.Context, vertexID ids.ID, accept
 
 	vertex, exists := d.vertices[vertexID]
 	if !exists {
-		return fmt.Errorf("vertex not found: %s", vertexID)
+		return fmt.Errorf("%w: %s", engine.ErrUnknownVertex, vertexID)
 	}
 
 	driver := vertex.Driver()
@@ -152,9 +292,18 @@ func (d *DAGConsensus) ProcessVote(ctx context.Context, vertexID ids.ID, accept
 		return fmt.Errorf("vertex not initialized for consensus")
 	}
 
+	yes := 0
 	if accept {
 		driver.RecordVote(vertexID)
+		yes = 1
 	}
+	d.tracer.Trace(engine.Event{
+		Kind:  engine.EventVoteRecorded,
+		Round: d.round,
+		Item:  vertexID,
+		Yes:   yes,
+		Total: 1,
+	})
 
 	return nil
 }
@@ -164,8 +313,17 @@ func (d *DAGConsensus) Poll(ctx context.Context, responses map[ids.ID]int) error
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	// Poll each vertex's Lux consensus instance using Wave → Prism (DAG) protocols
-	for vertexID, votes := range responses {
+	d.round++
+	total := 0
+	for _, votes := range responses {
+		total += votes
+	}
+
+	// Poll each vertex's Lux consensus instance using Wave → Prism (DAG)
+	// protocols, in ID order so every node makes the same transitions
+	var finalizable []*Vertex
+	for _, vertexID := range slices.SortedFunc(maps.Keys(responses), ids.ID.Compare) {
+		votes := responses[vertexID]
 		vertex, exists := d.vertices[vertexID]
 		if !exists {
 			continue
@@ -176,47 +334,160 @@ func (d *DAGConsensus) Poll(ctx context.Context, responses map[ids.ID]int) error
 			continue
 		}
 
+		d.tracer.Trace(engine.Event{
+			Kind:  engine.EventPollIssued,
+			Round: d.round,
+			Item:  vertexID,
+			Yes:   votes,
+			Total: total,
+		})
+		preference := driver.Preference()
 		vertexResponses := map[ids.ID]int{vertexID: votes}
 		shouldContinue := driver.Poll(vertexResponses)
+		if p := driver.Preference(); p != preference {
+			d.tracer.Trace(engine.Event{
+				Kind:       engine.EventPreferenceChanged,
+				Round:      d.round,
+				Item:       p,
+				Confidence: driver.Confidence(p),
+			})
+		}
 
 		// Check if vertex reached finality through Prism DAG refraction
-		if !shouldContinue && driver.Decided() {
-			if err := vertex.Accept(ctx); err != nil {
-				return fmt.Errorf("failed to accept vertex: %w", err)
-			}
-			d.lastAccepted = vertexID
+		if !shouldContinue && driver.Decided() && !vertex.IsAccepted() && !vertex.IsRejected() {
+			finalizable = append(finalizable, vertex)
+		}
+	}
+
+	// Conflicting vertices finalizable in the same poll are forks; the
+	// fork rule picks which survive (see fork.go)
+	losers := d.forkLosers(finalizable)
+	for _, vertex := range finalizable {
+		vertexID := vertex.ID()
+		if vertex.IsAccepted() || vertex.IsRejected() {
+			continue
+		}
 
-			// Process children in topological order
-			if err := d.processChildrenInOrder(ctx, vertex); err != nil {
-				return fmt.Errorf("failed to process children: %w", err)
+		// A conflicting vertex already won one of this vertex's sets, or
+		// outranks it in this poll
+		if losers[vertexID] || d.lostConflict(vertexID) {
+			if err := d.decide(ctx, vertex, false); err != nil {
+				return fmt.Errorf("failed to reject vertex: %w", err)
 			}
+			continue
+		}
+
+		if err := d.decide(ctx, vertex, true); err != nil {
+			return fmt.Errorf("failed to accept vertex: %w", err)
+		}
+		d.lastAccepted = vertexID
+		d.unordered[vertexID] = struct{}{}
+
+		if err := d.settleConflicts(ctx, vertexID); err != nil {
+			return err
+		}
+
+		// Process children in topological order
+		if err := d.processChildrenInOrder(ctx, vertex); err != nil {
+			return fmt.Errorf("failed to process children: %w", err)
 		}
 	}
 
-	return nil
+	if err := d.advanceOrder(); err != nil {
+		return err
+	}
+	d.evictOrdered()
+	return d.verifyOrder()
 }
 
-// processChildrenInOrder processes children in topological order
-func (d *DAGConsensus) processChildrenInOrder(ctx context.Context, parent *Vertex) error {
-	// Get all children that are ready to be processed
-	children := parent.Children()
-
-	for _, child := range children {
-		// Check if all parents are accepted
-		allParentsAccepted := true
-		for _, p := range child.Parents() {
-			if !p.IsAccepted() {
-				allParentsAccepted = false
-				break
+// lostConflict reports whether another vertex already won a conflict set
+// that vertexID belongs to
+// Must be called with d.mu held
+func (d *DAGConsensus) lostConflict(vertexID ids.ID) bool {
+	for _, conflictID := range d.vertexConflicts[vertexID] {
+		if winner, ok := d.conflicts[conflictID].Winner(); ok && winner != vertexID {
+			return true
+		}
+	}
+	return false
+}
+
+// settleConflicts records vertexID as the winner of its conflict sets and
+// rejects the other pending members
+// Must be called with d.mu held
+func (d *DAGConsensus) settleConflicts(ctx context.Context, vertexID ids.ID) error {
+	for _, conflictID := range d.vertexConflicts[vertexID] {
+		set := d.conflicts[conflictID]
+		set.winner = vertexID
+		for memberID := range set.members {
+			if memberID == vertexID {
+				continue
+			}
+			member, ok := d.vertices[memberID]
+			if !ok || member.IsAccepted() || member.IsRejected() {
+				continue
+			}
+			if err := d.decide(ctx, member, false); err != nil {
+				return fmt.Errorf("failed to reject conflicting vertex: %w", err)
 			}
 		}
+	}
+	return nil
+}
 
-		// If all parents accepted, mark child as ready for processing
-		if allParentsAccepted && !child.IsProcessing() {
-			child.SetProcessing(true)
-			d.processing[child.ID()] = true
+// ConflictPreference returns the preferred vertex of a conflict set: its
+// winner once one is accepted, otherwise the pending member with the highest
+// focus confidence, ties broken by the lowest vertex ID.
+func (d *DAGConsensus) ConflictPreference(conflictID ConflictID) (ids.ID, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	set, ok := d.conflicts[conflictID]
+	if !ok {
+		return ids.Empty, false
+	}
+	if winner, ok := set.Winner(); ok {
+		return winner, true
+	}
+
+	var (
+		preferred  ids.ID
+		confidence = -1
+	)
+	for memberID := range set.members {
+		member, ok := d.vertices[memberID]
+		if !ok || member.IsRejected() {
+			continue
+		}
+		c := 0
+		if driver := member.Driver(); driver != nil {
+			c = driver.Confidence(memberID)
+		}
+		if c > confidence || (c == confidence && memberID.Compare(preferred) < 0) {
+			preferred, confidence = memberID, c
 		}
 	}
+	return preferred, confidence >= 0
+}
+
+// GetConflictSetByID returns the conflict set for a conflict ID
+func (d *DAGConsensus) GetConflictSetByID(conflictID ConflictID) (*ConflictSet, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	set, ok := d.conflicts[conflictID]
+	if !ok {
+		return nil, false
+	}
+	return set.clone(), true
+}
+
+// processChildrenInOrder processes children in topological order
+func (d *DAGConsensus) processChildrenInOrder(ctx context.Context, parent *Vertex) error {
+	// Mark every child whose dependencies are now accepted as ready
+	for _, child := range parent.Children() {
+		d.markReady(child)
+	}
 
 	return nil
 }
@@ -228,7 +499,8 @@ func (d *DAGConsensus) IsAccepted(vertexID ids.ID) bool {
 
 	vertex, exists := d.vertices[vertexID]
 	if !exists {
-		return false
+		rec, ok := d.store.Get(vertexID)
+		return ok && rec.Status == VertexAccepted
 	}
 
 	return vertex.IsAccepted()
@@ -241,7 +513,8 @@ func (d *DAGConsensus) IsRejected(vertexID ids.ID) bool {
 
 	vertex, exists := d.vertices[vertexID]
 	if !exists {
-		return false
+		rec, ok := d.store.Get(vertexID)
+		return ok && rec.Status == VertexRejected
 	}
 
 	return vertex.IsRejected()
@@ -265,13 +538,20 @@ func (d *DAGConsensus) Preference() ids.ID {
 	return ids.Empty
 }
 
-// GetVertex returns a vertex by ID
+// GetVertex returns a vertex by ID. A vertex held only in the store, such
+// as history beneath a recovered frontier, is returned detached from the
+// DAG with its recorded decision.
 func (d *DAGConsensus) GetVertex(vertexID ids.ID) (*Vertex, bool) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	vertex, exists := d.vertices[vertexID]
-	return vertex, exists
+	if vertex, exists := d.vertices[vertexID]; exists {
+		return vertex, true
+	}
+	if rec, ok := d.store.Get(vertexID); ok {
+		return rec.vertex(), true
+	}
+	return nil, false
 }
 
 // Frontier returns the current frontier vertices
@@ -322,6 +602,7 @@ func (d *DAGConsensus) Stats() map[string]interface{} {
 		"pending":        pending,
 		"frontier":       len(d.frontier),
 		"processing":     len(d.processing),
+		"invalid_hints":  d.invalidHints,
 		"last_accepted":  d.lastAccepted.String(),
 	}
 }
@@ -436,7 +717,7 @@ func (d *DAGConsensus) ResolveConflict(ctx context.Context, vertices []*Vertex)
 	}
 
 	// Create a temporary Lux consensus instance for conflict resolution using Prism protocol
-	conflictResolver := engine.NewLuxConsensus(d.k, d.alpha, d.beta)
+	conflictResolver := engine.NewLuxConsensus(d.k, d.alpha, d.beta, d.driverOpts...)
 
 	// Build responses map for conflict resolution
 	responses := make(map[ids.ID]int)
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/ids"
 	"github.com/stretchr/testify/require"
 )
@@ -324,7 +325,7 @@ func TestDAGConsensusProcessVoteNonExistent(t *testing.T) {
 	nonExistentID := ids.GenerateTestID()
 	err := dc.ProcessVote(ctx, nonExistentID, true)
 	require.Error(err)
-	require.Contains(err.Error(), "vertex not found")
+	require.ErrorIs(err, engine.ErrUnknownVertex)
 }
 
 func TestDAGConsensusPoll(t *testing.T) {
@@ -974,7 +975,7 @@ func TestDagEngineAddVertex(t *testing.T) {
 	id := ids.GenerateTestID()
 	vertex := NewVertex(id, nil, 0, 0, nil)
 
-	err := engine.AddVertex(ctx, vertex)
+	err := engine.AddVertex(ctx, vertex, nil)
 	require.NoError(err)
 	require.True(engine.consensus.IsAccepted(id) || !engine.consensus.IsRejected(id))
 }
@@ -987,7 +988,7 @@ func TestDagEngineProcessVote(t *testing.T) {
 
 	id := ids.GenerateTestID()
 	vertex := NewVertex(id, nil, 0, 0, nil)
-	err := engine.AddVertex(ctx, vertex)
+	err := engine.AddVertex(ctx, vertex, nil)
 	require.NoError(err)
 
 	err = engine.ProcessVote(ctx, id, true)
@@ -1002,7 +1003,7 @@ func TestDagEnginePoll(t *testing.T) {
 
 	id := ids.GenerateTestID()
 	vertex := NewVertex(id, nil, 0, 0, nil)
-	err := engine.AddVertex(ctx, vertex)
+	err := engine.AddVertex(ctx, vertex, nil)
 	require.NoError(err)
 
 	responses := map[ids.ID]int{id: 5}
@@ -1018,7 +1019,7 @@ func TestDagEngineIsAccepted(t *testing.T) {
 
 	id := ids.GenerateTestID()
 	vertex := NewVertex(id, nil, 0, 0, nil)
-	err := engine.AddVertex(ctx, vertex)
+	err := engine.AddVertex(ctx, vertex, nil)
 	require.NoError(err)
 
 	require.False(engine.IsAccepted(id))
@@ -1038,7 +1039,7 @@ func TestDagEnginePreference(t *testing.T) {
 
 	id := ids.GenerateTestID()
 	vertex := NewVertex(id, nil, 0, 0, nil)
-	err := engine.AddVertex(ctx, vertex)
+	err := engine.AddVertex(ctx, vertex, nil)
 	require.NoError(err)
 
 	pref := engine.Preference()
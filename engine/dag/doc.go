@@ -4,4 +4,13 @@
 // can reference multiple parents. The consensus pipeline flows:
 // photon → wave → focus → (prism + horizon) → flare → nebula.
 // This enables concurrent transaction processing while respecting causality.
+//
+// Conflicting vertices that become finalizable in the same poll are forks.
+// Every node settles them by the same rule: the branch with the higher
+// accumulated confidence wins, then the one with more descendants, then
+// the one whose lowest tip ID is smaller (see fork.go).
+//
+// A vertex whose parents have not arrived can be held by an AntiEntropy,
+// which fetches the missing parents through a VertexFetcher and adds the
+// held vertices once their history is complete (see fetch.go).
 package dag
@@ -7,9 +7,11 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/ids"
 )
 
@@ -35,6 +37,24 @@ type Engine interface {
 	// ParseVtx parses a vertex from bytes
 	ParseVtx(context.Context, []byte) (Transaction, error)
 
+	// AddVertex adds a vertex touching the given conflict IDs to consensus;
+	// at most one vertex per conflict ID is accepted, so a vertex touching a
+	// conflict ID that already has an accepted vertex fails with
+	// engine.ErrConflict
+	AddVertex(ctx context.Context, v *Vertex, conflicts []ConflictID) error
+
+	// HealthCheck reports whether the engine is healthy, with its frontier
+	// size and last finalized height
+	HealthCheck(context.Context) (interface{}, error)
+
+	// ExportFrontier snapshots the frontier, the undecided vertices beneath
+	// it and the finalized watermark, for a restarted node to resume from
+	ExportFrontier() FrontierSnapshot
+
+	// ImportFrontier restores a frontier snapshot into an empty engine,
+	// rejecting one whose parents are neither in it nor finalized history
+	ImportFrontier(snap FrontierSnapshot) error
+
 	// Start starts the engine
 	Start(context.Context, uint32) error
 
@@ -42,6 +62,21 @@ type Engine interface {
 	Shutdown(context.Context) error
 }
 
+// FinalizedOrderer is implemented by engines that export the canonical
+// order of finalized vertices. It is separate from Engine so that existing
+// Engine implementations keep satisfying it; callers type-assert for it.
+type FinalizedOrderer interface {
+	// FinalizedOrder returns the canonical order of finalized vertices
+	// from position from onwards; the order is append-only
+	FinalizedOrder(from uint64) ([]VertexID, error)
+
+	// EachFinalized streams the canonical order from position from to fn
+	// until fn returns false
+	EachFinalized(from uint64, fn func(pos uint64, id VertexID) bool) error
+}
+
+var _ FinalizedOrderer = (*dagEngine)(nil)
+
 // dagEngine implements real DAG consensus using Lux protocols (Photon → Wave → Prism)
 type dagEngine struct {
 	mu sync.RWMutex
@@ -63,8 +98,11 @@ func New() Engine {
 
 // NewWithParams creates an engine with specific parameters
 func NewWithParams(params config.Parameters) Engine {
+	consensus := NewDAGConsensus(params.K, params.AlphaPreference, int(params.Beta))
+	consensus.SetMaxParents(params.Parents)
+	consensus.SetDriverOptions(engine.ParamsOptions(params)...)
 	return &dagEngine{
-		consensus:    NewDAGConsensus(params.K, params.AlphaPreference, int(params.Beta)),
+		consensus:    consensus,
 		params:       params,
 		bootstrapped: false,
 		pendingData:  make([][]byte, 0),
@@ -98,16 +136,20 @@ func (e *dagEngine) BuildVtx(ctx context.Context) (Transaction, error) {
 	if len(frontier) == 0 {
 		frontier = []ids.ID{ids.Empty}
 	}
+	// Cap the parents at the lowest IDs so every node building from the
+	// same frontier references the same subset
+	if limit := e.consensus.MaxParents(); len(frontier) > limit {
+		slices.SortFunc(frontier, func(a, b ids.ID) int { return a.Compare(b) })
+		frontier = frontier[:limit]
+	}
 
-	// Create new vertex ID
-	vertexID := ids.GenerateTestID()
-
-	// Build vertex with first pending data
+	// Build vertex with first pending data, content-addressed so every
+	// node derives the same ID for it
 	data := e.pendingData[0]
 	e.pendingData = e.pendingData[1:]
 
 	vertex := NewVertex(
-		vertexID,
+		DeriveVertexID(frontier, data),
 		frontier,
 		0, // Height calculation would be based on parents
 		0, // Timestamp
@@ -177,11 +219,15 @@ func (e *dagEngine) ParseVtx(ctx context.Context, b []byte) (Transaction, error)
 	return NewVertex(id, parents, height, int64(timestamp), data), nil
 }
 
-// Start starts the engine
+// Start starts the engine. Starting a running engine fails with
+// engine.ErrAlreadyStarted; a shut down engine can be started again.
 func (e *dagEngine) Start(ctx context.Context, requestID uint32) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	if e.ctx != nil && e.ctx.Err() == nil {
+		return engine.ErrAlreadyStarted
+	}
 	e.ctx, e.cancel = context.WithCancel(ctx)
 	e.bootstrapped = true
 
@@ -206,12 +252,18 @@ func (e *dagEngine) Stop(ctx context.Context) error {
 	return e.Shutdown(ctx)
 }
 
-// HealthCheck performs a health check
+// HealthCheck reports the consensus stats along with whether the engine is
+// running, the frontier size and the height of the last finalized vertex.
+// The engine is healthy while it is started and bootstrapped.
 func (e *dagEngine) HealthCheck(ctx context.Context) (interface{}, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
+	running := e.ctx != nil && e.ctx.Err() == nil
 	stats := e.consensus.Stats()
+	stats["healthy"] = running && e.bootstrapped
+	stats["running"] = running
+	stats["last_finalized_height"] = e.consensus.lastFinalizedHeight()
 	stats["bootstrapped"] = e.bootstrapped
 	stats["k"] = e.params.K
 	stats["alpha"] = e.params.AlphaPreference
@@ -227,9 +279,9 @@ func (e *dagEngine) IsBootstrapped() bool {
 	return e.bootstrapped
 }
 
-// AddVertex adds a vertex to consensus
-func (e *dagEngine) AddVertex(ctx context.Context, vertex *Vertex) error {
-	return e.consensus.AddVertex(ctx, vertex)
+// AddVertex adds a vertex touching the given conflict IDs to consensus
+func (e *dagEngine) AddVertex(ctx context.Context, vertex *Vertex, conflicts []ConflictID) error {
+	return e.consensus.AddVertexWithConflicts(ctx, vertex, conflicts)
 }
 
 // ProcessVote processes a vote for a vertex
@@ -247,11 +299,43 @@ func (e *dagEngine) IsAccepted(vertexID ids.ID) bool {
 	return e.consensus.IsAccepted(vertexID)
 }
 
+// FinalizedOrder returns the canonical order of finalized vertices from
+// position from onwards
+func (e *dagEngine) FinalizedOrder(from uint64) ([]VertexID, error) {
+	return e.consensus.FinalizedOrder(from)
+}
+
+// ExportFrontier snapshots the frontier for a restarted node
+func (e *dagEngine) ExportFrontier() FrontierSnapshot {
+	return e.consensus.ExportFrontier()
+}
+
+// ImportFrontier restores a frontier snapshot into an empty engine
+func (e *dagEngine) ImportFrontier(snap FrontierSnapshot) error {
+	return e.consensus.ImportFrontier(snap)
+}
+
+// EachFinalized streams the canonical order from position from to fn
+// until fn returns false
+func (e *dagEngine) EachFinalized(from uint64, fn func(pos uint64, id VertexID) bool) error {
+	return e.consensus.EachFinalized(from, fn)
+}
+
+// Ready returns the undecided vertices whose dependencies are accepted
+func (e *dagEngine) Ready() []ids.ID {
+	return e.consensus.Ready()
+}
+
 // Preference returns the current preferred vertex
 func (e *dagEngine) Preference() ids.ID {
 	return e.consensus.Preference()
 }
 
+// ConflictPreference returns the preferred vertex of a conflict set
+func (e *dagEngine) ConflictPreference(conflictID ConflictID) (ids.ID, bool) {
+	return e.consensus.ConflictPreference(conflictID)
+}
+
 // QueueData queues data for the next vertex
 func (e *dagEngine) QueueData(data []byte) {
 	e.mu.Lock()
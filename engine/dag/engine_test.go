@@ -2,8 +2,10 @@ package dag
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/ids"
 )
 
@@ -79,3 +81,106 @@ func TestParseVtx(t *testing.T) {
 		t.Error("ParseVtx should return nil transaction")
 	}
 }
+
+func TestHealthCheck(t *testing.T) {
+	engine := New()
+	ctx := context.Background()
+
+	healthy := func() bool {
+		t.Helper()
+		health, err := engine.HealthCheck(ctx)
+		if err != nil {
+			t.Fatalf("HealthCheck failed: %v", err)
+		}
+		stats, ok := health.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected map health, got %T", health)
+		}
+		for _, key := range []string{"running", "frontier", "last_finalized_height"} {
+			if _, ok := stats[key]; !ok {
+				t.Fatalf("health missing %q", key)
+			}
+		}
+		return stats["healthy"].(bool)
+	}
+
+	if healthy() {
+		t.Fatal("engine healthy before Start")
+	}
+	if err := engine.Start(ctx, 1); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if !healthy() {
+		t.Fatal("engine unhealthy after Start")
+	}
+	if err := engine.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if healthy() {
+		t.Fatal("engine healthy after Shutdown")
+	}
+}
+
+func TestEngineErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("already started", func(t *testing.T) {
+		e := New()
+		if err := e.Start(ctx, 1); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		if err := e.Start(ctx, 1); !errors.Is(err, engine.ErrAlreadyStarted) {
+			t.Fatalf("second Start: got %v, want ErrAlreadyStarted", err)
+		}
+		if err := e.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+		if err := e.Start(ctx, 1); err != nil {
+			t.Fatalf("Start after Shutdown failed: %v", err)
+		}
+	})
+
+	t.Run("unknown vertex", func(t *testing.T) {
+		e := New().(*dagEngine)
+		if err := e.ProcessVote(ctx, ids.GenerateTestID(), true); !errors.Is(err, engine.ErrUnknownVertex) {
+			t.Fatalf("ProcessVote: got %v, want ErrUnknownVertex", err)
+		}
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		e := New()
+		v := NewVertex(ids.GenerateTestID(), []ids.ID{ids.GenerateTestID()}, 1, 0, nil)
+		err := e.AddVertex(ctx, v, nil)
+		if !errors.Is(err, engine.ErrUnknownVertex) || !errors.Is(err, ErrUnknownParent) {
+			t.Fatalf("AddVertex: got %v, want ErrUnknownParent and ErrUnknownVertex", err)
+		}
+	})
+
+	t.Run("duplicate vertex", func(t *testing.T) {
+		e := New()
+		v := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+		if err := e.AddVertex(ctx, v, nil); err != nil {
+			t.Fatalf("AddVertex failed: %v", err)
+		}
+		if err := e.AddVertex(ctx, v, nil); !errors.Is(err, engine.ErrConflict) {
+			t.Fatalf("duplicate AddVertex: got %v, want ErrConflict", err)
+		}
+	})
+
+	t.Run("won conflict set", func(t *testing.T) {
+		e := newConflictTestEngine(2)
+		a, _, spent := addDoubleSpend(t, e)
+		for i := 0; i < 2; i++ {
+			if err := e.Poll(ctx, map[ids.ID]int{a: 1}); err != nil {
+				t.Fatalf("Poll failed: %v", err)
+			}
+		}
+		if !e.IsAccepted(a) {
+			t.Fatal("first spend not accepted")
+		}
+		late := NewVertex(ids.GenerateTestID(), nil, 1, 0, []byte("pay carol"))
+		if err := e.AddVertex(ctx, late, []ConflictID{spent}); !errors.Is(err, engine.ErrConflict) {
+			t.Fatalf("late double spend: got %v, want ErrConflict", err)
+		}
+	})
+}
@@ -0,0 +1,386 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+)
+
+const (
+	// DefaultFetchInterval is the time between anti-entropy rounds in Run
+	DefaultFetchInterval = time.Second
+
+	// DefaultMaxOutstandingFetches bounds the fetches in flight
+	DefaultMaxOutstandingFetches = 16
+
+	// DefaultFetchTimeout bounds a single fetch
+	DefaultFetchTimeout = 5 * time.Second
+
+	// DefaultStallAttempts is how many failed fetches mark a parent stalled
+	DefaultStallAttempts = 3
+
+	// DefaultMaxOrphans bounds the vertices held waiting for a parent
+	DefaultMaxOrphans = 4096
+
+	// DefaultOrphanTTL is how long an orphan is held before it is dropped
+	DefaultOrphanTTL = 5 * time.Minute
+)
+
+// ErrFetchStalled is returned by AntiEntropy.Round while a missing parent
+// has failed StallAttempts fetches. Vertices that depend on it cannot be
+// added, so their part of the DAG cannot finalize.
+var ErrFetchStalled = errors.New("dag: missing parent vertex unavailable")
+
+// ErrTooManyOrphans is returned by AntiEntropy.AddVertex when MaxOrphans
+// vertices are already held waiting for a parent
+var ErrTooManyOrphans = errors.New("dag: too many orphan vertices")
+
+// VertexFetcher retrieves a vertex this node is missing, typically by
+// asking peers for it, together with the conflict IDs it declared so the
+// fetched vertex joins its conflict sets
+type VertexFetcher interface {
+	FetchVertex(ctx context.Context, id ids.ID) (*Vertex, []ConflictID, error)
+}
+
+// AntiEntropyConfig configures AntiEntropy. Zero fields take the defaults.
+type AntiEntropyConfig struct {
+	Interval       time.Duration // time between rounds in Run
+	MaxOutstanding int           // fetches in flight at once
+	FetchTimeout   time.Duration // bound on each fetch
+	StallAttempts  int           // failed fetches before a parent is reported stalled
+	MaxOrphans     int           // vertices held waiting for a parent
+	OrphanTTL      time.Duration // how long an orphan is held
+}
+
+// AntiEntropy heals gaps in a DAG. A vertex added through it whose parents
+// are not all known is held as an orphan instead of being refused. Each
+// round, the parents the orphans reference but the DAG lacks are requested
+// from a VertexFetcher; a fetched vertex is added like any other, and
+// orphans whose parents have all arrived are added after it.
+//
+// Fetches of the same vertex are never in flight twice, and at most
+// MaxOutstanding are in flight at once. A fetcher that ignores its context
+// keeps its slot until it returns. At most MaxOrphans vertices are held,
+// and each round drops those held longer than OrphanTTL.
+type AntiEntropy struct {
+	dag     *DAGConsensus
+	fetcher VertexFetcher
+	cfg     AntiEntropyConfig
+
+	mu      sync.Mutex
+	orphans map[ids.ID]orphan
+
+	// inflight holds the fetches not yet returned, each with the channel
+	// of the round waiting for it, or nil once that round has moved on
+	inflight map[ids.ID]chan<- fetchResult
+	attempts map[ids.ID]int // failed fetches per missing parent
+
+	now func() time.Time
+}
+
+type orphan struct {
+	vertex    *Vertex
+	conflicts []ConflictID
+	held      time.Time
+}
+
+// NewAntiEntropy creates an anti-entropy process for dag fetching missing
+// vertices from fetcher
+func NewAntiEntropy(dag *DAGConsensus, fetcher VertexFetcher, cfg AntiEntropyConfig) *AntiEntropy {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultFetchInterval
+	}
+	if cfg.MaxOutstanding <= 0 {
+		cfg.MaxOutstanding = DefaultMaxOutstandingFetches
+	}
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = DefaultFetchTimeout
+	}
+	if cfg.StallAttempts <= 0 {
+		cfg.StallAttempts = DefaultStallAttempts
+	}
+	if cfg.MaxOrphans <= 0 {
+		cfg.MaxOrphans = DefaultMaxOrphans
+	}
+	if cfg.OrphanTTL <= 0 {
+		cfg.OrphanTTL = DefaultOrphanTTL
+	}
+	return &AntiEntropy{
+		dag:      dag,
+		fetcher:  fetcher,
+		cfg:      cfg,
+		orphans:  make(map[ids.ID]orphan),
+		inflight: make(map[ids.ID]chan<- fetchResult),
+		attempts: make(map[ids.ID]int),
+		now:      time.Now,
+	}
+}
+
+// AddVertex adds vertex to the DAG, holding it as an orphan if a parent is
+// missing, or returning ErrTooManyOrphans if MaxOrphans are already held.
+// Any other error is returned as from DAGConsensus.AddVertex.
+func (a *AntiEntropy) AddVertex(ctx context.Context, vertex *Vertex, conflicts []ConflictID) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.addLocked(ctx, orphan{vertex: vertex, conflicts: conflicts})
+}
+
+// addLocked adds o, or parks it if a parent is missing, then adds every
+// orphan the addition unblocks
+// Must be called with a.mu held
+func (a *AntiEntropy) addLocked(ctx context.Context, o orphan) error {
+	err := a.dag.AddVertexWithConflicts(ctx, o.vertex, o.conflicts)
+	if errors.Is(err, ErrUnknownParent) {
+		// A vertex held already keeps its time, so gossiping it again does
+		// not keep it from expiring
+		if prev, held := a.orphans[o.vertex.ID()]; held {
+			o.held = prev.held
+		} else if len(a.orphans) >= a.cfg.MaxOrphans {
+			return fmt.Errorf("%w: holding %d", ErrTooManyOrphans, len(a.orphans))
+		} else {
+			o.held = a.now()
+		}
+		a.orphans[o.vertex.ID()] = o
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	delete(a.attempts, o.vertex.ID())
+
+	// Adding a vertex can complete the parents of held orphans
+	for progress := true; progress; {
+		progress = false
+		for _, id := range slices.SortedFunc(maps.Keys(a.orphans), ids.ID.Compare) {
+			held := a.orphans[id]
+			if a.missingParents(held.vertex) > 0 {
+				continue
+			}
+			delete(a.orphans, id)
+			if err := a.dag.AddVertexWithConflicts(ctx, held.vertex, held.conflicts); err == nil {
+				delete(a.attempts, id)
+				progress = true
+			}
+		}
+	}
+	return nil
+}
+
+// missingParents counts v's parents the DAG does not hold
+func (a *AntiEntropy) missingParents(v *Vertex) int {
+	n := 0
+	for _, parentID := range v.ParentIDs() {
+		if parentID == ids.Empty {
+			continue
+		}
+		if _, ok := a.dag.GetVertex(parentID); !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Missing returns the parents referenced by orphans that neither the DAG
+// nor the orphans hold, in ID order
+func (a *AntiEntropy) Missing() []ids.ID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.missingLocked()
+}
+
+// Must be called with a.mu held
+func (a *AntiEntropy) missingLocked() []ids.ID {
+	missing := make(map[ids.ID]bool)
+	for _, o := range a.orphans {
+		for _, parentID := range o.vertex.ParentIDs() {
+			if parentID == ids.Empty {
+				continue
+			}
+			if _, held := a.orphans[parentID]; held {
+				continue
+			}
+			if _, ok := a.dag.GetVertex(parentID); !ok {
+				missing[parentID] = true
+			}
+		}
+	}
+	return slices.SortedFunc(maps.Keys(missing), ids.ID.Compare)
+}
+
+// Orphans returns how many vertices are held waiting for a parent
+func (a *AntiEntropy) Orphans() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.orphans)
+}
+
+// Stalled returns the missing parents that have failed at least
+// StallAttempts fetches, in ID order
+func (a *AntiEntropy) Stalled() []ids.ID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stalledLocked()
+}
+
+// Must be called with a.mu held
+func (a *AntiEntropy) stalledLocked() []ids.ID {
+	var stalled []ids.ID
+	for _, id := range a.missingLocked() {
+		if a.attempts[id] >= a.cfg.StallAttempts {
+			stalled = append(stalled, id)
+		}
+	}
+	return stalled
+}
+
+type fetchResult struct {
+	id        ids.ID
+	vertex    *Vertex
+	conflicts []ConflictID
+	err       error
+}
+
+// expireLocked drops the orphans held longer than OrphanTTL
+// Must be called with a.mu held
+func (a *AntiEntropy) expireLocked() {
+	cutoff := a.now().Add(-a.cfg.OrphanTTL)
+	for id, o := range a.orphans {
+		if o.held.Before(cutoff) {
+			delete(a.orphans, id)
+		}
+	}
+
+	// Forget the failed fetches of parents no orphan waits on any more
+	missing := a.missingLocked()
+	for id := range a.attempts {
+		if _, found := slices.BinarySearchFunc(missing, id, ids.ID.Compare); !found {
+			delete(a.attempts, id)
+		}
+	}
+}
+
+// Round requests every missing parent not already in flight, up to the
+// outstanding limit, waits at most FetchTimeout for them and integrates
+// what arrives. Orphans held longer than OrphanTTL are dropped first. It
+// returns how many vertices were fetched, and ErrFetchStalled naming the
+// stalled parents if any.
+func (a *AntiEntropy) Round(ctx context.Context) (int, error) {
+	a.mu.Lock()
+	a.expireLocked()
+	var requested, pending []ids.ID
+	for _, id := range a.missingLocked() {
+		if _, ok := a.inflight[id]; ok {
+			// A fetch from an earlier round has not returned
+			pending = append(pending, id)
+			continue
+		}
+		if len(a.inflight)+len(requested) < a.cfg.MaxOutstanding {
+			requested = append(requested, id)
+		}
+	}
+	results := make(chan fetchResult, len(requested))
+	for _, id := range requested {
+		a.inflight[id] = results
+	}
+	a.mu.Unlock()
+
+	fetchCtx, cancel := context.WithTimeout(ctx, a.cfg.FetchTimeout)
+	defer cancel()
+	for _, id := range requested {
+		go func() {
+			v, conflicts, err := a.fetcher.FetchVertex(fetchCtx, id)
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if ch := a.inflight[id]; ch != nil {
+				ch <- fetchResult{id: id, vertex: v, conflicts: conflicts, err: err}
+				return
+			}
+			delete(a.inflight, id)
+		}()
+	}
+
+	var received []fetchResult
+wait:
+	for len(received) < len(requested) {
+		select {
+		case r := <-results:
+			received = append(received, r)
+		case <-fetchCtx.Done():
+			break wait
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Take results that arrived with the deadline, and leave fetches still
+	// running to clear their own slots
+drain:
+	for len(received) < len(requested) {
+		select {
+		case r := <-results:
+			received = append(received, r)
+		default:
+			break drain
+		}
+	}
+	for _, r := range received {
+		delete(a.inflight, r.id)
+	}
+	for _, id := range requested {
+		if _, ok := a.inflight[id]; ok {
+			a.inflight[id] = nil
+		}
+	}
+
+	failed := make(map[ids.ID]bool, len(requested)+len(pending))
+	for _, id := range append(requested, pending...) {
+		failed[id] = true
+	}
+	fetched := 0
+	for _, r := range received {
+		if r.err != nil || r.vertex == nil || r.vertex.ID() != r.id {
+			continue
+		}
+		if err := a.addLocked(ctx, orphan{vertex: r.vertex, conflicts: r.conflicts}); err != nil && !errors.Is(err, engine.ErrConflict) {
+			continue
+		}
+		delete(failed, r.id)
+		fetched++
+	}
+	for id := range failed {
+		a.attempts[id]++
+	}
+
+	if stalled := a.stalledLocked(); len(stalled) > 0 {
+		return fetched, fmt.Errorf("%w: %d parents, first %s", ErrFetchStalled, len(stalled), stalled[0])
+	}
+	return fetched, nil
+}
+
+// Run calls Round every Interval until ctx is done. Round errors, such as
+// ErrFetchStalled, are passed to onErr if it is not nil.
+func (a *AntiEntropy) Run(ctx context.Context, onErr func(error)) {
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.Round(ctx); err != nil && onErr != nil {
+				onErr(err)
+			}
+		}
+	}
+}
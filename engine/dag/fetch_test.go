@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("no peer has the vertex")
+
+// servingFetcher serves copies of the vertices it holds, with their
+// declared conflicts, and counts requests
+type servingFetcher struct {
+	mu        sync.Mutex
+	vertices  map[ids.ID]*Vertex
+	conflicts map[ids.ID][]ConflictID
+	calls     map[ids.ID]int
+
+	// release, if set, holds every fetch until closed, ignoring ctx
+	release chan struct{}
+}
+
+func newServingFetcher(vs ...*Vertex) *servingFetcher {
+	f := &servingFetcher{
+		vertices:  make(map[ids.ID]*Vertex),
+		conflicts: make(map[ids.ID][]ConflictID),
+		calls:     make(map[ids.ID]int),
+	}
+	for _, v := range vs {
+		f.vertices[v.ID()] = v
+	}
+	return f
+}
+
+func (f *servingFetcher) FetchVertex(ctx context.Context, id ids.ID) (*Vertex, []ConflictID, error) {
+	f.mu.Lock()
+	f.calls[id]++
+	v, ok := f.vertices[id]
+	conflicts := f.conflicts[id]
+	release := f.release
+	f.mu.Unlock()
+
+	if release != nil {
+		<-release
+	}
+	if !ok {
+		return nil, nil, errUnavailable
+	}
+	return NewVertex(v.ID(), v.ParentIDs(), v.Height(), 0, nil), conflicts, nil
+}
+
+func (f *servingFetcher) requests(id ids.ID) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[id]
+}
+
+// chainOf returns n vertices, each the parent of the next
+func chainOf(n int) []*Vertex {
+	chain := make([]*Vertex, n)
+	parents := []ids.ID(nil)
+	for i := range chain {
+		chain[i] = NewVertex(ids.GenerateTestID(), parents, uint64(i+1), 0, nil)
+		parents = []ids.ID{chain[i].ID()}
+	}
+	return chain
+}
+
+func TestAntiEntropyHealsDAG(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// The node received the root and the tip but missed the two between
+	chain := chainOf(4)
+	d := newConflictTestEngine(2).consensus
+	ae := NewAntiEntropy(d, newServingFetcher(chain...), AntiEntropyConfig{FetchTimeout: time.Second})
+	require.NoError(ae.AddVertex(ctx, chain[0], nil))
+	require.NoError(ae.AddVertex(ctx, chain[3], nil))
+
+	_, ok := d.GetVertex(chain[3].ID())
+	require.False(ok, "the tip cannot join the DAG without its parent")
+	require.Equal(1, ae.Orphans())
+	require.Equal([]ids.ID{chain[2].ID()}, ae.Missing())
+
+	// Each round fetches one more ancestor until the gap closes
+	fetched, err := ae.Round(ctx)
+	require.NoError(err)
+	require.Equal(1, fetched)
+	require.Equal([]ids.ID{chain[1].ID()}, ae.Missing())
+
+	fetched, err = ae.Round(ctx)
+	require.NoError(err)
+	require.Equal(1, fetched)
+	require.Empty(ae.Missing())
+	require.Zero(ae.Orphans())
+	for _, v := range chain {
+		_, ok := d.GetVertex(v.ID())
+		require.True(ok)
+	}
+
+	// Finalization resumes over the healed DAG
+	for _, v := range chain {
+		for i := 0; i < 2; i++ {
+			require.NoError(d.Poll(ctx, map[ids.ID]int{v.ID(): 1}))
+		}
+		require.True(d.IsAccepted(v.ID()))
+	}
+	order, err := d.FinalizedOrder(0)
+	require.NoError(err)
+	require.Len(order, 4)
+
+	fetched, err = ae.Round(ctx)
+	require.NoError(err)
+	require.Zero(fetched)
+}
+
+func TestAntiEntropyBoundsAndDedupesFetches(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Three tips whose parents are all missing
+	root := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+	var gaps, tips []*Vertex
+	for i := 0; i < 3; i++ {
+		gap := NewVertex(ids.GenerateTestID(), []ids.ID{root.ID()}, 2, 0, nil)
+		gaps = append(gaps, gap)
+		tips = append(tips, NewVertex(ids.GenerateTestID(), []ids.ID{gap.ID()}, 3, 0, nil))
+	}
+
+	f := newServingFetcher(gaps...)
+	f.release = make(chan struct{})
+	d := newConflictTestEngine(2).consensus
+	ae := NewAntiEntropy(d, f, AntiEntropyConfig{MaxOutstanding: 2, FetchTimeout: 10 * time.Millisecond, StallAttempts: 100})
+	require.NoError(ae.AddVertex(ctx, root, nil))
+	for _, tip := range tips {
+		require.NoError(ae.AddVertex(ctx, tip, nil))
+	}
+	require.Len(ae.Missing(), 3)
+
+	// The fetcher ignores its deadline, so the first two fetches stay in
+	// flight and hold both slots
+	fetched, err := ae.Round(ctx)
+	require.NoError(err)
+	require.Zero(fetched)
+	fetched, err = ae.Round(ctx)
+	require.NoError(err)
+	require.Zero(fetched)
+
+	total := 0
+	for _, gap := range gaps {
+		require.LessOrEqual(f.requests(gap.ID()), 1, "fetch repeated while in flight")
+		total += f.requests(gap.ID())
+	}
+	require.Equal(2, total)
+
+	// Once released, the slots clear and every gap is fetched
+	close(f.release)
+	require.Eventually(func() bool {
+		_, err := ae.Round(ctx)
+		return err == nil && len(ae.Missing()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(ae.Orphans())
+	for _, tip := range tips {
+		_, ok := d.GetVertex(tip.ID())
+		require.True(ok)
+	}
+}
+
+func TestAntiEntropyReportsStall(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// The middle vertex is lost for good
+	chain := chainOf(3)
+	d := newConflictTestEngine(2).consensus
+	ae := NewAntiEntropy(d, newServingFetcher(chain[0], chain[2]), AntiEntropyConfig{StallAttempts: 2})
+	require.NoError(ae.AddVertex(ctx, chain[0], nil))
+	require.NoError(ae.AddVertex(ctx, chain[2], nil))
+
+	_, err := ae.Round(ctx)
+	require.NoError(err)
+	require.Empty(ae.Stalled())
+
+	_, err = ae.Round(ctx)
+	require.ErrorIs(err, ErrFetchStalled)
+	require.Equal([]ids.ID{chain[1].ID()}, ae.Stalled())
+	require.Equal(1, ae.Orphans())
+
+	// A fetch that never answers times out instead of hanging the round
+	hung := NewVertex(ids.GenerateTestID(), []ids.ID{ids.GenerateTestID()}, 2, 0, nil)
+	ae = NewAntiEntropy(newConflictTestEngine(2).consensus, hangingFetcher{}, AntiEntropyConfig{FetchTimeout: 10 * time.Millisecond, StallAttempts: 1})
+	require.NoError(ae.AddVertex(ctx, hung, nil))
+
+	start := time.Now()
+	_, err = ae.Round(ctx)
+	require.ErrorIs(err, ErrFetchStalled)
+	require.Less(time.Since(start), 2*time.Second)
+}
+
+// hangingFetcher never answers before its deadline
+type hangingFetcher struct{}
+
+func (hangingFetcher) FetchVertex(ctx context.Context, _ ids.ID) (*Vertex, []ConflictID, error) {
+	<-ctx.Done()
+	return nil, nil, ctx.Err()
+}
+
+func TestAntiEntropyFetchedVertexJoinsConflicts(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// The missing parent double spends an output the DAG already knows
+	spent := UTXOConflictID(UTXO{TxID: ids.GenerateTestID()})
+	chain := chainOf(3)
+	rival := NewVertex(ids.GenerateTestID(), []ids.ID{chain[0].ID()}, 2, 0, nil)
+	f := newServingFetcher(chain...)
+	f.conflicts[chain[1].ID()] = []ConflictID{spent}
+
+	d := newConflictTestEngine(2).consensus
+	ae := NewAntiEntropy(d, f, AntiEntropyConfig{})
+	require.NoError(ae.AddVertex(ctx, chain[0], nil))
+	require.NoError(ae.AddVertex(ctx, rival, []ConflictID{spent}))
+	require.NoError(ae.AddVertex(ctx, chain[2], nil))
+
+	fetched, err := ae.Round(ctx)
+	require.NoError(err)
+	require.Equal(1, fetched)
+	set, ok := d.GetConflictSetByID(spent)
+	require.True(ok)
+	require.ElementsMatch([]ids.ID{rival.ID(), chain[1].ID()}, set.Members())
+	require.True(d.HasConflicts(chain[1].ID()))
+}
+
+func TestAntiEntropyBoundsOrphans(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	d := newConflictTestEngine(2).consensus
+	ae := NewAntiEntropy(d, hangingFetcher{}, AntiEntropyConfig{MaxOrphans: 2, OrphanTTL: time.Minute, FetchTimeout: time.Millisecond})
+	now := time.Unix(0, 0)
+	ae.now = func() time.Time { return now }
+
+	orphanOf := func() *Vertex {
+		return NewVertex(ids.GenerateTestID(), []ids.ID{ids.GenerateTestID()}, 2, 0, nil)
+	}
+	first := orphanOf()
+	require.NoError(ae.AddVertex(ctx, first, nil))
+	now = now.Add(30 * time.Second)
+	require.NoError(ae.AddVertex(ctx, orphanOf(), nil))
+	require.ErrorIs(ae.AddVertex(ctx, orphanOf(), nil), ErrTooManyOrphans)
+
+	// Re-adding a held orphan does not count against the limit
+	require.NoError(ae.AddVertex(ctx, first, nil))
+	require.Equal(2, ae.Orphans())
+
+	// Orphans held past their TTL are dropped, making room for more
+	now = now.Add(45 * time.Second)
+	_, err := ae.Round(ctx)
+	require.NoError(err)
+	require.Equal(1, ae.Orphans())
+	require.NoError(ae.AddVertex(ctx, orphanOf(), nil))
+}
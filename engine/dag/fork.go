@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"slices"
+
+	"github.com/luxfi/ids"
+)
+
+// Fork resolution
+//
+// Two conflicting vertices that become finalizable in the same poll are a
+// fork: only one branch may be accepted. A branch is the forking vertex
+// and every descendant that has not been rejected. Branches are ranked by
+//
+//  1. accumulated confidence: the sum of the focus confidence of the
+//     branch's vertices, higher first;
+//  2. descendant weight: the number of vertices below the forking vertex,
+//     higher first;
+//  3. tip ID: the lowest ID among the branch's leaves, lower first;
+//  4. the forking vertex's own ID, lower first.
+//
+// Each input is the same on every node that has seen the same vertices and
+// votes, whatever order the vertices arrived in. The forking vertices are
+// settled best-ranked first: each is accepted unless it conflicts with a
+// vertex already accepted, and is otherwise rejected.
+
+// branch is the summary of a forking vertex's branch used to rank it
+type branch struct {
+	id         ids.ID
+	confidence int
+	weight     int
+	tip        ids.ID
+}
+
+// branchOf summarises vertex's branch
+// Must be called with d.mu held
+func (d *DAGConsensus) branchOf(vertex *Vertex) branch {
+	b := branch{id: vertex.ID(), tip: vertex.ID()}
+	seen := map[ids.ID]bool{vertex.ID(): true}
+	hasTip := false
+	queue := []*Vertex{vertex}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if driver := v.Driver(); driver != nil {
+			b.confidence += driver.Confidence(v.ID())
+		}
+
+		leaf := true
+		for _, child := range v.Children() {
+			if child.IsRejected() {
+				continue
+			}
+			leaf = false
+			if !seen[child.ID()] {
+				seen[child.ID()] = true
+				b.weight++
+				queue = append(queue, child)
+			}
+		}
+		if leaf && (!hasTip || v.ID().Compare(b.tip) < 0) {
+			b.tip, hasTip = v.ID(), true
+		}
+	}
+	return b
+}
+
+// compareBranches orders a before b when a wins the fork rule
+func compareBranches(a, b branch) int {
+	switch {
+	case a.confidence != b.confidence:
+		return b.confidence - a.confidence
+	case a.weight != b.weight:
+		return b.weight - a.weight
+	case a.tip != b.tip:
+		return a.tip.Compare(b.tip)
+	default:
+		return a.id.Compare(b.id)
+	}
+}
+
+// forkLosers returns the vertices among finalizable that lose a fork to a
+// conflicting finalizable vertex
+// Must be called with d.mu held
+func (d *DAGConsensus) forkLosers(finalizable []*Vertex) map[ids.ID]bool {
+	pending := make(map[ids.ID]bool, len(finalizable))
+	for _, v := range finalizable {
+		pending[v.ID()] = true
+	}
+
+	var forks []branch
+	for _, v := range finalizable {
+		for rival := range d.conflictSets[v.ID()] {
+			if pending[rival] {
+				forks = append(forks, d.branchOf(v))
+				break
+			}
+		}
+	}
+	slices.SortFunc(forks, compareBranches)
+
+	losers := make(map[ids.ID]bool)
+	won := make(map[ids.ID]bool)
+	for _, b := range forks {
+		if d.lostConflict(b.id) {
+			continue
+		}
+		for rival := range d.conflictSets[b.id] {
+			if won[rival] {
+				losers[b.id] = true
+				break
+			}
+		}
+		if !losers[b.id] {
+			won[b.id] = true
+		}
+	}
+	return losers
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// forkDAG is two conflicting roots a and b, each with its own children
+type forkDAG struct {
+	a, b      *Vertex
+	aKids     []*Vertex
+	bKids     []*Vertex
+	conflicts []ConflictID
+}
+
+func newForkDAG(aID, bID byte, aKids, bKids []byte) forkDAG {
+	f := forkDAG{
+		a:         NewVertex(ids.ID{aID}, nil, 1, 0, nil),
+		b:         NewVertex(ids.ID{bID}, nil, 1, 0, nil),
+		conflicts: []ConflictID{{0xCC}},
+	}
+	for _, id := range aKids {
+		f.aKids = append(f.aKids, NewVertex(ids.ID{id}, []ids.ID{f.a.ID()}, 2, 0, nil))
+	}
+	for _, id := range bKids {
+		f.bKids = append(f.bKids, NewVertex(ids.ID{id}, []ids.ID{f.b.ID()}, 2, 0, nil))
+	}
+	return f
+}
+
+// node adds fresh copies of the fork's vertices to a new engine, in the
+// given arrival order
+func (f forkDAG) node(t *testing.T, order []*Vertex) *dagEngine {
+	e := newConflictTestEngine(3)
+	for _, v := range order {
+		var conflicts []ConflictID
+		if v == f.a || v == f.b {
+			conflicts = f.conflicts
+		}
+		require.NoError(t, e.AddVertex(context.Background(), NewVertex(v.ID(), v.ParentIDs(), v.Height(), 0, nil), conflicts))
+	}
+	return e
+}
+
+// arrivals returns several valid arrival orders of the fork's vertices
+func (f forkDAG) arrivals() [][]*Vertex {
+	aSide := append([]*Vertex{f.a}, f.aKids...)
+	bSide := append([]*Vertex{f.b}, f.bKids...)
+	interleaved := []*Vertex{f.b, f.a}
+	for i := len(f.bKids) - 1; i >= 0; i-- {
+		interleaved = append(interleaved, f.bKids[i])
+	}
+	for i := len(f.aKids) - 1; i >= 0; i-- {
+		interleaved = append(interleaved, f.aKids[i])
+	}
+	return [][]*Vertex{
+		append(append([]*Vertex{}, aSide...), bSide...),
+		append(append([]*Vertex{}, bSide...), aSide...),
+		interleaved,
+	}
+}
+
+func TestForkResolution(t *testing.T) {
+	tests := []struct {
+		name string
+		fork forkDAG
+		// kidVotes polls each child of b this many times, which raises the
+		// confidence of b's branch without finalizing the child
+		kidVotes int
+		winner   func(forkDAG) *Vertex
+	}{
+		{
+			// Mirror-image branches tie on confidence and weight; b's tip
+			// is lower although a's own ID is
+			name:   "symmetric fork resolved by tip ID",
+			fork:   newForkDAG(0x10, 0x20, []byte{0x90}, []byte{0x05}),
+			winner: func(f forkDAG) *Vertex { return f.b },
+		},
+		{
+			name:   "more descendants win",
+			fork:   newForkDAG(0x10, 0x20, []byte{0x30}, []byte{0x05, 0x06}),
+			winner: func(f forkDAG) *Vertex { return f.b },
+		},
+		{
+			name:     "higher confidence wins",
+			fork:     newForkDAG(0x20, 0x10, []byte{0x05, 0x06}, []byte{0x30}),
+			kidVotes: 2,
+			winner:   func(f forkDAG) *Vertex { return f.b },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			winner := tt.winner(tt.fork)
+			loser := tt.fork.a
+			if winner == tt.fork.a {
+				loser = tt.fork.b
+			}
+
+			for _, order := range tt.fork.arrivals() {
+				e := tt.fork.node(t, order)
+				for i := 0; i < tt.kidVotes; i++ {
+					responses := make(map[ids.ID]int)
+					for _, kid := range tt.fork.bKids {
+						responses[kid.ID()] = 1
+					}
+					require.NoError(e.Poll(ctx, responses))
+				}
+
+				// Both roots become finalizable in the same poll
+				for i := 0; i < 3; i++ {
+					require.NoError(e.Poll(ctx, map[ids.ID]int{tt.fork.a.ID(): 1, tt.fork.b.ID(): 1}))
+				}
+				require.True(e.IsAccepted(winner.ID()), "arrival order %v", order)
+				require.True(e.consensus.IsRejected(loser.ID()), "arrival order %v", order)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"slices"
+
+	"github.com/luxfi/ids"
+)
+
+// A dependency hint names the subset of a vertex's structural parents its
+// transactions actually depend on. A vertex becomes ready for processing
+// once its dependencies are accepted, so a hinted vertex no longer waits on
+// parents it is independent of and more vertices process concurrently.
+// Hints only affect readiness: acceptance and the finalized order (see
+// order.go) still follow the structural parents.
+//
+// A hint is checked when the vertex is added. It is dropped, and the vertex
+// depends on all its parents again, if it names a vertex that is not a
+// parent or leaves out a parent whose pending history created one of the
+// vertex's inputs.
+
+// SetDependencyHint declares the parents v depends on. An empty hint claims
+// independence from every parent; call it before adding v to consensus.
+func (v *Vertex) SetDependencyHint(dependsOn []ids.ID) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hint = append(make([]ids.ID, 0, len(dependsOn)), dependsOn...)
+}
+
+// DependencyHint returns v's dependency hint and whether it has one
+func (v *Vertex) DependencyHint() ([]ids.ID, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.hint == nil {
+		return nil, false
+	}
+	return slices.Clone(v.hint), true
+}
+
+func (v *Vertex) clearDependencyHint() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hint = nil
+}
+
+// Dependencies returns the parents v waits on before it is ready: the
+// hinted ones, or all parents without a hint
+func (v *Vertex) Dependencies() []*Vertex {
+	parents := v.Parents()
+	hint, ok := v.DependencyHint()
+	if !ok {
+		return parents
+	}
+	deps := make([]*Vertex, 0, len(hint))
+	for _, p := range parents {
+		if slices.Contains(hint, p.ID()) {
+			deps = append(deps, p)
+		}
+	}
+	return deps
+}
+
+// checkHint reports whether v's hint, if any, is consistent with its
+// parents and inputs
+// Must be called with d.mu held
+func (d *DAGConsensus) checkHint(v *Vertex) bool {
+	hint, ok := v.DependencyHint()
+	if !ok {
+		return true
+	}
+	parentIDs := v.ParentIDs()
+	for _, id := range hint {
+		if !slices.Contains(parentIDs, id) {
+			return false
+		}
+	}
+
+	inputs := make(map[UTXO]bool)
+	for _, in := range v.Inputs() {
+		inputs[in] = true
+	}
+	if len(inputs) == 0 {
+		return true
+	}
+	for _, p := range v.Parents() {
+		if !slices.Contains(hint, p.ID()) && createsInput(p, inputs) {
+			return false
+		}
+	}
+	return true
+}
+
+// createsInput reports whether v or its not yet accepted ancestors created
+// one of inputs. Accepted vertices are skipped: their outputs are settled.
+func createsInput(v *Vertex, inputs map[UTXO]bool) bool {
+	stack := []*Vertex{v}
+	seen := map[ids.ID]bool{v.ID(): true}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if cur.IsAccepted() {
+			continue
+		}
+		for _, out := range cur.Outputs() {
+			if inputs[out] {
+				return true
+			}
+		}
+		for _, p := range cur.Parents() {
+			if !seen[p.ID()] {
+				seen[p.ID()] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+	return false
+}
+
+// markReady marks v ready for processing once all its dependencies are
+// accepted. Parents no longer linked are accepted history evicted from
+// memory or settled by a snapshot, so only a genesis vertex is skipped.
+// Must be called with d.mu held
+func (d *DAGConsensus) markReady(v *Vertex) {
+	if isGenesis(v) || v.IsAccepted() || v.IsRejected() || v.IsProcessing() {
+		return
+	}
+	for _, dep := range v.Dependencies() {
+		if !dep.IsAccepted() {
+			return
+		}
+	}
+	v.SetProcessing(true)
+	d.processing[v.ID()] = true
+}
+
+// isGenesis reports whether v names no parent
+func isGenesis(v *Vertex) bool {
+	return !slices.ContainsFunc(v.ParentIDs(), func(id ids.ID) bool { return id != ids.Empty })
+}
+
+// Ready returns the undecided vertices whose dependencies are all accepted,
+// which can be processed concurrently, sorted by ID
+func (d *DAGConsensus) Ready() []ids.ID {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var ready []ids.ID
+	for id := range d.processing {
+		if v := d.vertices[id]; v.IsProcessing() {
+			ready = append(ready, id)
+		}
+	}
+	slices.SortFunc(ready, func(a, b ids.ID) int { return a.Compare(b) })
+	return ready
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// fanDAG is a root, a layer of independent vertices, and a second layer
+// where every vertex structurally references the whole first layer but
+// only uses the output of its own column
+type fanDAG struct {
+	root   *Vertex
+	first  []*Vertex
+	second []*Vertex
+}
+
+func newFanDAG(t *testing.T, e *dagEngine, width int, hinted bool) fanDAG {
+	ctx := context.Background()
+	e.consensus.SetMaxParents(width)
+	root := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+	require.NoError(t, e.AddVertex(ctx, root, nil))
+
+	m := fanDAG{root: root}
+	firstIDs := make([]ids.ID, width)
+	for i := 0; i < width; i++ {
+		v := NewVertex(ids.GenerateTestID(), []ids.ID{root.ID()}, 2, 0, nil)
+		v.SetOutputs([]UTXO{{TxID: v.ID()}})
+		require.NoError(t, e.AddVertex(ctx, v, nil))
+		m.first = append(m.first, v)
+		firstIDs[i] = v.ID()
+	}
+	for _, dep := range m.first {
+		v := NewVertexWithInputs(ids.GenerateTestID(), firstIDs, 3, 0, nil, []UTXO{{TxID: dep.ID()}})
+		if hinted {
+			v.SetDependencyHint([]ids.ID{dep.ID()})
+		}
+		require.NoError(t, e.AddVertex(ctx, v, nil))
+		m.second = append(m.second, v)
+	}
+	return m
+}
+
+func TestDependencyHintsWidenReadyFrontier(t *testing.T) {
+	require := require.New(t)
+
+	const width = 4
+	plain, hinted := newConflictTestEngine(2), newConflictTestEngine(2)
+	p := newFanDAG(t, plain, width, false)
+	h := newFanDAG(t, hinted, width, true)
+
+	// Accept the root and half of the first layer
+	accept(t, plain, append([]*Vertex{p.root}, p.first[:width/2]...)...)
+	accept(t, hinted, append([]*Vertex{h.root}, h.first[:width/2]...)...)
+
+	// Without hints the second layer waits for the whole first layer
+	require.ElementsMatch([]ids.ID{p.first[2].ID(), p.first[3].ID()}, plain.Ready())
+
+	// With hints the columns whose dependency is accepted proceed
+	require.ElementsMatch([]ids.ID{
+		h.first[2].ID(), h.first[3].ID(),
+		h.second[0].ID(), h.second[1].ID(),
+	}, hinted.Ready())
+	require.Greater(len(hinted.Ready()), len(plain.Ready()))
+
+	// Hints do not change the structural finalized order
+	accept(t, hinted, h.second...)
+	order, err := hinted.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{h.root.ID()}, order[:1])
+	require.Len(order, 1, "second layer must wait for its structural parents")
+
+	// A merge vertex closes the horizon once the first layer is accepted
+	secondIDs := make([]ids.ID, width)
+	for i, v := range h.second {
+		secondIDs[i] = v.ID()
+	}
+	merge := NewVertex(ids.GenerateTestID(), secondIDs, 4, 0, nil)
+	require.NoError(hinted.AddVertex(context.Background(), merge, nil))
+	accept(t, hinted, h.first[width/2:]...)
+	accept(t, hinted, merge)
+	order, err = hinted.FinalizedOrder(0)
+	require.NoError(err)
+	require.Len(order, 2+2*width)
+	require.Empty(hinted.Ready())
+	require.Zero(hinted.consensus.Stats()["invalid_hints"])
+}
+
+func TestDependencyHintInvalidIgnored(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	e := newConflictTestEngine(2)
+
+	root := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+	require.NoError(e.AddVertex(ctx, root, nil))
+	a := NewVertex(ids.GenerateTestID(), []ids.ID{root.ID()}, 2, 0, nil)
+	b := NewVertex(ids.GenerateTestID(), []ids.ID{root.ID()}, 2, 0, nil)
+	spent := UTXO{TxID: a.ID()}
+	a.SetOutputs([]UTXO{spent})
+	require.NoError(e.AddVertex(ctx, a, nil))
+	require.NoError(e.AddVertex(ctx, b, nil))
+
+	// Claims independence from a, whose output it spends
+	lying := NewVertexWithInputs(ids.GenerateTestID(), []ids.ID{a.ID(), b.ID()}, 3, 0, nil, []UTXO{spent})
+	lying.SetDependencyHint([]ids.ID{b.ID()})
+	require.NoError(e.AddVertex(ctx, lying, nil))
+
+	// Names a vertex that is not a parent
+	stranger := NewVertex(ids.GenerateTestID(), []ids.ID{b.ID()}, 3, 0, nil)
+	stranger.SetDependencyHint([]ids.ID{root.ID()})
+	require.NoError(e.AddVertex(ctx, stranger, nil))
+
+	for _, v := range []*Vertex{lying, stranger} {
+		_, ok := v.DependencyHint()
+		require.False(ok, "invalid hint must be dropped")
+	}
+	require.Equal(2, e.consensus.Stats()["invalid_hints"])
+
+	// Accepting b alone readies neither: both still wait on all parents
+	accept(t, e, root, b)
+	require.NotContains(e.Ready(), lying.ID())
+	accept(t, e, a)
+	require.Contains(e.Ready(), lying.ID())
+
+	// Once a is accepted its output is settled, so leaving it out is valid
+	late := NewVertexWithInputs(ids.GenerateTestID(), []ids.ID{a.ID(), lying.ID()}, 4, 0, nil, []UTXO{spent})
+	late.SetDependencyHint([]ids.ID{})
+	require.NoError(e.AddVertex(ctx, late, nil))
+	_, ok := late.DependencyHint()
+	require.True(ok)
+	require.Contains(e.Ready(), late.ID())
+}
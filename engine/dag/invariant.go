@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/luxfi/ids"
+)
+
+// ErrOrderShifted is returned by Poll when order checking is enabled and a
+// vertex already in the finalized order has moved or disappeared
+var ErrOrderShifted = errors.New("finalized order prefix changed")
+
+// SetCheckOrder sets whether Poll verifies, once it has finalized what it
+// can, that the finalized order is canonical: every entry seen by the
+// previous check is still at the same position, and the entries appended
+// since are the ones the checkpoint rule yields when recomputed from the
+// stored vertices alone. The check keeps a copy of the order and walks it
+// each poll, so it is meant for tests and debugging. It is off by default.
+func (d *DAGConsensus) SetCheckOrder(check bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.checkOrder = check
+	d.checkedOrder = nil
+	if check {
+		d.checkedOrder = slices.Clone(d.order)
+	}
+}
+
+// verifyOrder checks the finalized order against the prefix recorded by
+// the previous check and against the canonical order recomputed for the
+// entries appended since, then extends the record
+// Must be called with d.mu held
+func (d *DAGConsensus) verifyOrder() error {
+	if !d.checkOrder {
+		return nil
+	}
+	if len(d.order) < len(d.checkedOrder) {
+		return fmt.Errorf("%w: shrank from %d to %d entries", ErrOrderShifted, len(d.checkedOrder), len(d.order))
+	}
+	for i, id := range d.checkedOrder {
+		if d.order[i] != id {
+			return fmt.Errorf("%w: position %d was %s, now %s", ErrOrderShifted, i, id, d.order[i])
+		}
+	}
+
+	appended := d.order[len(d.checkedOrder):]
+	canonical, err := d.canonicalOrder(appended)
+	if err != nil {
+		return err
+	}
+	for i, id := range appended {
+		pos := len(d.checkedOrder) + i
+		if i >= len(canonical) {
+			return fmt.Errorf("%w: position %d holds %s, which is not canonically ordered", ErrOrderShifted, pos, id)
+		}
+		if canonical[i] != id {
+			return fmt.Errorf("%w: position %d holds %s, canonically %s", ErrOrderShifted, pos, id, canonical[i])
+		}
+	}
+	d.checkedOrder = append(d.checkedOrder, appended...)
+	return nil
+}
+
+// canonicalOrder recomputes the order in which the checkpoint rule appends
+// the vertices of batch on top of the vertices already ordered. It works
+// from the store records, independently of the in-memory links order.go
+// walks: batch's vertices must be accepted, and history outside batch must
+// already be ordered.
+// Must be called with d.mu held
+func (d *DAGConsensus) canonicalOrder(batch []ids.ID) ([]ids.ID, error) {
+	recs := make(map[ids.ID]VertexRecord, len(batch))
+	for _, id := range batch {
+		rec, ok := d.store.Get(id)
+		if !ok || rec.Status != VertexAccepted {
+			return nil, fmt.Errorf("%w: %s is ordered but not stored as accepted", ErrOrderShifted, id)
+		}
+		recs[id] = rec
+	}
+
+	done := make(map[ids.ID]bool, len(batch))
+	ordered := func(id ids.ID) bool {
+		if _, ok := recs[id]; ok {
+			return done[id]
+		}
+		if _, ok := d.settled[id]; ok || id == ids.Empty {
+			return true
+		}
+		rec, ok := d.store.Get(id)
+		return ok && rec.Ordered
+	}
+	less := func(a, b VertexRecord) int {
+		return cmp.Or(cmp.Compare(a.Height, b.Height), a.ID.Compare(b.ID))
+	}
+
+	// history returns the unordered history of checkpoint, checkpoint
+	// included, or false if checkpoint cannot close a horizon
+	history := func(checkpoint VertexRecord) ([]VertexRecord, bool) {
+		for _, id := range d.byHeight[checkpoint.Height] {
+			if sibling, ok := d.store.Get(id); id != checkpoint.ID && (!ok || sibling.Status != VertexRejected) {
+				return nil, false
+			}
+		}
+		collected := []VertexRecord{checkpoint}
+		seen := map[ids.ID]bool{checkpoint.ID: true}
+		for i := 0; i < len(collected); i++ {
+			for _, parentID := range collected[i].Parents {
+				if seen[parentID] || ordered(parentID) {
+					continue
+				}
+				parent, ok := recs[parentID]
+				if !ok {
+					return nil, false
+				}
+				seen[parentID] = true
+				collected = append(collected, parent)
+			}
+		}
+		slices.SortFunc(collected, less)
+		return topoSort(collected, func(rec VertexRecord) []ids.ID { return rec.Parents },
+			func(rec VertexRecord) ids.ID { return rec.ID }), true
+	}
+
+	var out []ids.ID
+	for len(out) < len(batch) {
+		var next []VertexRecord
+		for _, id := range batch {
+			rec := recs[id]
+			if done[id] || (next != nil && less(rec, next[len(next)-1]) >= 0) {
+				continue
+			}
+			if h, ok := history(rec); ok {
+				next = h
+			}
+		}
+		if next == nil {
+			break
+		}
+		for _, rec := range next {
+			done[rec.ID] = true
+			out = append(out, rec.ID)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOrderNeverFires(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+
+	// Every arrival order of the merge DAG only ever extends the order
+	for _, seq := range [][]*Vertex{
+		{m.root, m.l1, m.l2, m.r1, m.r2, m.merge},
+		{m.merge, m.r2, m.l2, m.r1, m.l1, m.root},
+		{m.l2, m.root, m.r2, m.merge, m.l1, m.r1},
+	} {
+		e := m.node(t)
+		e.consensus.SetCheckOrder(true)
+		accept(t, e, seq...)
+
+		next := NewVertex(ids.GenerateTestID(), []ids.ID{m.merge.ID()}, 5, 0, nil)
+		require.NoError(e.AddVertex(context.Background(), next, nil))
+		accept(t, e, next)
+
+		order, err := e.FinalizedOrder(0)
+		require.NoError(err)
+		require.Len(order, 7)
+	}
+}
+
+func TestCheckOrderCatchesShift(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+	e := m.node(t)
+	e.consensus.SetCheckOrder(true)
+
+	// The merge finalizes the whole DAG
+	accept(t, e, m.root, m.l1, m.r1, m.l2, m.r2, m.merge)
+	order, err := e.FinalizedOrder(0)
+	require.NoError(err)
+	require.Len(order, 6)
+
+	// An ordering bug swaps two finalized branch vertices when the next
+	// vertex arrives
+	next := NewVertex(ids.GenerateTestID(), []ids.ID{m.merge.ID()}, 5, 0, nil)
+	require.NoError(e.AddVertex(context.Background(), next, nil))
+	e.consensus.mu.Lock()
+	e.consensus.order[1], e.consensus.order[2] = e.consensus.order[2], e.consensus.order[1]
+	e.consensus.mu.Unlock()
+
+	err = e.Poll(context.Background(), map[ids.ID]int{next.ID(): 1})
+	require.ErrorIs(err, ErrOrderShifted)
+
+	// Without the check the shift goes unnoticed
+	e = m.node(t)
+	accept(t, e, m.root, m.l1, m.r1, m.l2, m.r2, m.merge)
+	e.consensus.mu.Lock()
+	e.consensus.order[1], e.consensus.order[2] = e.consensus.order[2], e.consensus.order[1]
+	e.consensus.mu.Unlock()
+	require.NoError(e.Poll(context.Background(), map[ids.ID]int{m.merge.ID(): 1}))
+}
+
+func TestCheckOrderRecomputesAppended(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+	e := m.node(t)
+	e.consensus.SetCheckOrder(true)
+	accept(t, e, m.root, m.l1, m.r1, m.l2, m.r2, m.merge)
+
+	// An ordering bug that appends the branch vertices in the wrong order
+	// keeps the checked prefix, but not the canonical order
+	e.consensus.mu.Lock()
+	defer e.consensus.mu.Unlock()
+	e.consensus.checkedOrder = e.consensus.checkedOrder[:1]
+	require.NoError(e.consensus.verifyOrder())
+
+	e.consensus.checkedOrder = e.consensus.checkedOrder[:1]
+	e.consensus.order[1], e.consensus.order[2] = e.consensus.order[2], e.consensus.order[1]
+	require.ErrorIs(e.consensus.verifyOrder(), ErrOrderShifted)
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+)
+
+// VertexID identifies a vertex in the finalized order
+type VertexID = ids.ID
+
+// orderChunk is how many entries EachFinalized copies per lock acquisition
+const orderChunk = 256
+
+// ErrOrderPosition is returned when a finalized order is requested from a
+// position beyond the current end of the order
+var ErrOrderPosition = errors.New("order position beyond finalized order")
+
+// The finalized order is built horizon by horizon. A checkpoint is an
+// accepted vertex whose whole causal history is accepted and which has no
+// non-rejected sibling at its height, so every vertex below it is settled.
+// When a checkpoint is found, its not yet ordered history is appended in
+// topological order, ties broken by (height, ID), followed by the
+// checkpoint itself. The result depends only on the DAG and the checkpoints,
+// not on the order vertices were accepted in, so every node that finalizes
+// the same checkpoints produces the same sequence. Entries are never
+// reordered or removed.
+//
+// Each vertex is marked ordered in the store before it is appended, so a
+// restarted node resumes with the accepted vertices that were not yet
+// ordered. Once ordered and off the frontier, a vertex is evicted from
+// memory: its record stays in the store, where GetVertex, IsAccepted and
+// new children find it, and its ID stays in the height index, where it
+// still counts as an accepted sibling.
+
+// addToHeight indexes v under its height
+// Must be called with d.mu held
+func (d *DAGConsensus) addToHeight(v *Vertex) {
+	d.byHeight[v.Height()] = append(d.byHeight[v.Height()], v.ID())
+}
+
+// isCheckpoint reports whether v can close a horizon
+// Must be called with d.mu held
+func (d *DAGConsensus) isCheckpoint(v *Vertex) bool {
+	for _, id := range d.byHeight[v.Height()] {
+		if id == v.ID() {
+			continue
+		}
+		// A sibling that is no longer in memory was ordered and evicted
+		if sibling, ok := d.vertices[id]; !ok || !sibling.IsRejected() {
+			return false
+		}
+	}
+
+	stack := []*Vertex{v}
+	seen := map[ids.ID]bool{v.ID(): true}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range cur.Parents() {
+			if seen[p.ID()] || d.ordered[p.ID()] {
+				continue
+			}
+			if !p.IsAccepted() {
+				return false
+			}
+			seen[p.ID()] = true
+			stack = append(stack, p)
+		}
+	}
+	return true
+}
+
+// advanceOrder appends the history of every checkpoint among the accepted
+// but unordered vertices, lowest height first, until none remains. Only a
+// decision can create a checkpoint, so it does nothing when no vertex was
+// decided or restored since it last ran.
+// Must be called with d.mu held
+func (d *DAGConsensus) advanceOrder() error {
+	if !d.orderStale {
+		return nil
+	}
+	d.orderStale = false
+	for {
+		var next *Vertex
+		for id := range d.unordered {
+			v := d.vertices[id]
+			if next != nil && !lessVertex(v, next) {
+				continue
+			}
+			if d.isCheckpoint(v) {
+				next = v
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		if err := d.appendHistory(next); err != nil {
+			return err
+		}
+	}
+}
+
+// appendHistory appends the unordered causal history of checkpoint in
+// topological order, ties broken by (height, ID)
+// Must be called with d.mu held
+func (d *DAGConsensus) appendHistory(checkpoint *Vertex) error {
+	// Collect the unordered history and count unordered parents
+	pending := make(map[ids.ID]int)
+	stack := []*Vertex{checkpoint}
+	pending[checkpoint.ID()] = 0
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range cur.Parents() {
+			if d.ordered[p.ID()] {
+				continue
+			}
+			pending[cur.ID()]++
+			if _, ok := pending[p.ID()]; !ok {
+				pending[p.ID()] = 0
+				stack = append(stack, p)
+			}
+		}
+	}
+
+	var ready []*Vertex
+	for id, n := range pending {
+		if n == 0 {
+			ready = append(ready, d.vertices[id])
+		}
+	}
+	for len(ready) > 0 {
+		best := 0
+		for i := range ready {
+			if lessVertex(ready[i], ready[best]) {
+				best = i
+			}
+		}
+		v := ready[best]
+		ready = append(ready[:best], ready[best+1:]...)
+
+		rec := record(v, d.vertexConflicts[v.ID()])
+		rec.Ordered = true
+		if err := d.store.Put(rec); err != nil {
+			return fmt.Errorf("failed to store vertex %s: %w", v.ID(), err)
+		}
+		d.order = append(d.order, v.ID())
+		d.orderHeight = max(d.orderHeight, v.Height())
+		d.ordered[v.ID()] = true
+		delete(d.unordered, v.ID())
+		d.tracer.Trace(engine.Event{
+			Kind:   engine.EventFinalized,
+			Round:  d.round,
+			Item:   v.ID(),
+			Height: uint64(len(d.order) - 1),
+		})
+
+		for _, child := range v.Children() {
+			n, ok := pending[child.ID()]
+			if !ok {
+				continue
+			}
+			pending[child.ID()] = n - 1
+			if n == 1 {
+				ready = append(ready, child)
+			}
+		}
+	}
+	return nil
+}
+
+// evictOrdered drops the ordered vertices that are off the frontier from
+// memory, unlinking them from their children and the conflict indexes
+// Must be called with d.mu held
+func (d *DAGConsensus) evictOrdered() {
+	for id := range d.ordered {
+		if d.frontier[id] {
+			continue
+		}
+		v := d.vertices[id]
+		for _, child := range v.Children() {
+			child.removeParent(id)
+		}
+		for rival := range d.conflictSets[id] {
+			delete(d.conflictSets[rival], id)
+		}
+		for _, conflictID := range d.vertexConflicts[id] {
+			if set := d.conflicts[conflictID]; set.winner != id {
+				delete(set.members, id)
+			}
+		}
+		for _, input := range v.Inputs() {
+			key := input.String()
+			d.inputIndex[key] = slices.DeleteFunc(d.inputIndex[key], func(spender ids.ID) bool { return spender == id })
+			if len(d.inputIndex[key]) == 0 {
+				delete(d.inputIndex, key)
+			}
+		}
+		delete(d.conflictSets, id)
+		delete(d.vertexConflicts, id)
+		delete(d.processing, id)
+		delete(d.ordered, id)
+		delete(d.vertices, id)
+	}
+}
+
+// lessVertex orders vertices by height, then by ID
+func lessVertex(a, b *Vertex) bool {
+	if a.Height() != b.Height() {
+		return a.Height() < b.Height()
+	}
+	aID, bID := a.ID(), b.ID()
+	return bytes.Compare(aID[:], bID[:]) < 0
+}
+
+// lastFinalizedHeight returns the highest height in the finalized order,
+// or the imported watermark if nothing was finalized since
+func (d *DAGConsensus) lastFinalizedHeight() uint64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return max(d.watermark, d.orderHeight)
+}
+
+// FinalizedOrder returns the canonical order of finalized vertices from
+// position from onwards. Positions count entries in the order, not vertex
+// heights. The order only grows, so a caller that has consumed n entries
+// passes n to receive the rest.
+func (d *DAGConsensus) FinalizedOrder(from uint64) ([]VertexID, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if from > uint64(len(d.order)) {
+		return nil, fmt.Errorf("%w: %d > %d", ErrOrderPosition, from, len(d.order))
+	}
+	return append([]VertexID(nil), d.order[from:]...), nil
+}
+
+// EachFinalized calls fn with the position and ID of every finalized vertex
+// from position from onwards, in canonical order, until fn returns false.
+// The order is copied in small chunks, so walking a long history does not
+// materialize it, and fn runs without the consensus lock held.
+func (d *DAGConsensus) EachFinalized(from uint64, fn func(pos uint64, id VertexID) bool) error {
+	var buf [orderChunk]VertexID
+	for {
+		d.mu.RLock()
+		end := uint64(len(d.order))
+		if from > end {
+			d.mu.RUnlock()
+			return fmt.Errorf("%w: %d > %d", ErrOrderPosition, from, end)
+		}
+		n := copy(buf[:], d.order[from:])
+		d.mu.RUnlock()
+
+		if n == 0 {
+			return nil
+		}
+		for i := 0; i < n; i++ {
+			if !fn(from, buf[i]) {
+				return nil
+			}
+			from++
+		}
+	}
+}
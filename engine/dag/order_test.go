@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// mergeDAG is a root, two parallel branches of two vertices each, and a
+// vertex merging them:
+//
+//	  root
+//	 /    \
+//	l1    r1
+//	|      |
+//	l2    r2
+//	 \    /
+//	 merge
+type mergeDAG struct {
+	root, l1, r1, l2, r2, merge *Vertex
+}
+
+func newMergeDAG() mergeDAG {
+	root := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+	l1 := NewVertex(ids.GenerateTestID(), []ids.ID{root.ID()}, 2, 0, nil)
+	r1 := NewVertex(ids.GenerateTestID(), []ids.ID{root.ID()}, 2, 0, nil)
+	l2 := NewVertex(ids.GenerateTestID(), []ids.ID{l1.ID()}, 3, 0, nil)
+	r2 := NewVertex(ids.GenerateTestID(), []ids.ID{r1.ID()}, 3, 0, nil)
+	merge := NewVertex(ids.GenerateTestID(), []ids.ID{l2.ID(), r2.ID()}, 4, 0, nil)
+	return mergeDAG{root, l1, r1, l2, r2, merge}
+}
+
+// node adds a fresh copy of the DAG's vertices to a new engine
+func (m mergeDAG) node(t *testing.T) *dagEngine {
+	e := newConflictTestEngine(2)
+	for _, v := range []*Vertex{m.root, m.l1, m.r1, m.l2, m.r2, m.merge} {
+		require.NoError(t, e.AddVertex(context.Background(), NewVertex(v.ID(), v.ParentIDs(), v.Height(), 0, nil), nil))
+	}
+	return e
+}
+
+// accept drives each vertex to acceptance, one at a time in the given order
+func accept(t *testing.T, e *dagEngine, vs ...*Vertex) {
+	for _, v := range vs {
+		for i := 0; i < 2; i++ {
+			require.NoError(t, e.Poll(context.Background(), map[ids.ID]int{v.ID(): 1}))
+		}
+		require.True(t, e.IsAccepted(v.ID()))
+	}
+}
+
+func TestFinalizedOrderMergeDAG(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+
+	// The branches are finalized in different orders on each node
+	left, right, reverse := m.node(t), m.node(t), m.node(t)
+	accept(t, left, m.root, m.l1, m.l2, m.r1, m.r2, m.merge)
+	accept(t, right, m.root, m.r1, m.r2, m.l1, m.l2, m.merge)
+	accept(t, reverse, m.merge, m.r2, m.l2, m.r1, m.l1, m.root)
+
+	want, err := left.FinalizedOrder(0)
+	require.NoError(err)
+	require.Len(want, 6)
+	require.Equal(m.root.ID(), want[0])
+	require.Equal(m.merge.ID(), want[5])
+
+	// Each branch vertex follows its parent
+	pos := make(map[ids.ID]int)
+	for i, id := range want {
+		pos[id] = i
+	}
+	require.Less(pos[m.l1.ID()], pos[m.l2.ID()])
+	require.Less(pos[m.r1.ID()], pos[m.r2.ID()])
+
+	for _, e := range []*dagEngine{right, reverse} {
+		got, err := e.FinalizedOrder(0)
+		require.NoError(err)
+		require.Equal(want, got)
+	}
+}
+
+func TestFinalizedOrderAppendOnly(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+	e := m.node(t)
+
+	// Only the root is settled until the branches merge
+	accept(t, e, m.root, m.l1, m.r1, m.l2)
+	order, err := e.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{m.root.ID()}, order)
+
+	accept(t, e, m.r2, m.merge)
+	full, err := e.FinalizedOrder(0)
+	require.NoError(err)
+	require.Len(full, 6)
+	require.Equal(order, full[:1])
+
+	// A later vertex extends the order without changing the prefix
+	next := NewVertex(ids.GenerateTestID(), []ids.ID{m.merge.ID()}, 5, 0, nil)
+	require.NoError(e.AddVertex(context.Background(), next, nil))
+	accept(t, e, next)
+
+	tail, err := e.FinalizedOrder(uint64(len(full)))
+	require.NoError(err)
+	require.Equal([]VertexID{next.ID()}, tail)
+
+	again, err := e.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal(append(full, next.ID()), again)
+
+	_, err = e.FinalizedOrder(uint64(len(again) + 1))
+	require.ErrorIs(err, ErrOrderPosition)
+
+	// Streaming from an old position yields the same sequence, also through
+	// the engine's optional FinalizedOrderer interface
+	var orderer FinalizedOrderer = e
+	var streamed []VertexID
+	require.NoError(orderer.EachFinalized(2, func(pos uint64, id VertexID) bool {
+		require.Equal(uint64(2+len(streamed)), pos)
+		streamed = append(streamed, id)
+		return true
+	}))
+	require.Equal(again[2:], streamed)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+)
+
+var (
+	// ErrTooManyParents is returned when a vertex references more parents
+	// than the configured limit
+	ErrTooManyParents = errors.New("too many parents")
+
+	// ErrUnknownParent is returned when a vertex references a parent that
+	// has not been added; it is an engine.ErrUnknownVertex
+	ErrUnknownParent = fmt.Errorf("%w: parent vertex not found", engine.ErrUnknownVertex)
+
+	// ErrRejectedParent is returned when a vertex references a rejected
+	// parent, which can never be finalized
+	ErrRejectedParent = errors.New("parent vertex rejected")
+)
+
+// DefaultMaxParents is the mainnet parent limit
+var DefaultMaxParents = config.MainnetParams().Parents
+
+// SetMaxParents bounds how many parents a vertex may reference, so a
+// proposer cannot build fan-in bombs that blow up reachability walks.
+// n <= 0 restores DefaultMaxParents.
+func (d *DAGConsensus) SetMaxParents(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if n <= 0 {
+		n = DefaultMaxParents
+	}
+	d.maxParents = n
+}
+
+// MaxParents returns the parent limit
+func (d *DAGConsensus) MaxParents() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.maxParents
+}
+
+// checkParents rejects vertices with too many parents or with a parent that
+// is unknown or rejected. ids.Empty marks the genesis and is not looked up,
+// nor is settled history imported from a frontier snapshot. Parents no
+// longer in memory are looked up in the store.
+// Must be called with d.mu held
+func (d *DAGConsensus) checkParents(v *Vertex) error {
+	parentIDs := v.ParentIDs()
+	if len(parentIDs) > d.maxParents {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyParents, len(parentIDs), d.maxParents)
+	}
+	for _, parentID := range parentIDs {
+		if parentID == ids.Empty {
+			continue
+		}
+		parent, ok := d.vertices[parentID]
+		if !ok {
+			if _, settled := d.settled[parentID]; settled {
+				continue
+			}
+			// Decided history evicted from memory is only in the store
+			rec, ok := d.store.Get(parentID)
+			switch {
+			case ok && rec.Status == VertexAccepted:
+				continue
+			case ok && rec.Status == VertexRejected:
+				return fmt.Errorf("%w: %s", ErrRejectedParent, parentID)
+			}
+			return fmt.Errorf("%w: %s", ErrUnknownParent, parentID)
+		}
+		if parent.IsRejected() {
+			return fmt.Errorf("%w: %s", ErrRejectedParent, parentID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// addLayer adds n root vertices and returns their IDs
+func addLayer(t *testing.T, dc *DAGConsensus, n int) []ids.ID {
+	layer := make([]ids.ID, n)
+	for i := range layer {
+		v := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+		require.NoError(t, dc.AddVertex(context.Background(), v))
+		layer[i] = v.ID()
+	}
+	return layer
+}
+
+func TestParentLimit(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dc := NewDAGConsensus(5, 3, 10)
+	require.Equal(config.MainnetParams().Parents, dc.MaxParents())
+
+	dc.SetMaxParents(3)
+	parents := addLayer(t, dc, 4)
+
+	// At the limit
+	atLimit := NewVertex(ids.GenerateTestID(), parents[:3], 2, 0, nil)
+	require.NoError(dc.AddVertex(ctx, atLimit))
+
+	// Over the limit, rejected without touching the DAG
+	over := NewVertex(ids.GenerateTestID(), parents, 2, 0, nil)
+	require.ErrorIs(dc.AddVertex(ctx, over), ErrTooManyParents)
+	_, exists := dc.GetVertex(over.ID())
+	require.False(exists)
+	require.Contains(dc.Frontier(), parents[3])
+
+	dc.SetMaxParents(0)
+	require.Equal(DefaultMaxParents, dc.MaxParents())
+}
+
+func TestParentLimitFromParams(t *testing.T) {
+	require := require.New(t)
+
+	params := config.DefaultParams()
+	params.Parents = 5
+	e := NewWithParams(params).(*dagEngine)
+	require.Equal(5, e.consensus.MaxParents())
+
+	// BuildVtx never references more parents than the limit
+	e.consensus.SetMaxParents(2)
+	layer := addLayer(t, e.consensus, 4)
+	e.QueueData([]byte("tx"))
+	built, err := e.BuildVtx(context.Background())
+	require.NoError(err)
+
+	// The lowest IDs are kept, whatever order the frontier was built in
+	slices.SortFunc(layer, func(a, b ids.ID) int { return a.Compare(b) })
+	require.Equal(layer[:2], built.(*Vertex).ParentIDs())
+}
+
+func TestDanglingParent(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dc := NewDAGConsensus(1, 1, 1)
+	known := addLayer(t, dc, 1)
+
+	dangling := NewVertex(ids.GenerateTestID(), []ids.ID{known[0], ids.GenerateTestID()}, 2, 0, nil)
+	require.ErrorIs(dc.AddVertex(ctx, dangling), ErrUnknownParent)
+	_, exists := dc.GetVertex(dangling.ID())
+	require.False(exists)
+	require.Contains(dc.Frontier(), known[0])
+
+	// A rejected parent can never be finalized, so neither can its child
+	v, _ := dc.GetVertex(known[0])
+	require.NoError(v.Reject(ctx))
+	child := NewVertex(ids.GenerateTestID(), known, 2, 0, nil)
+	require.ErrorIs(dc.AddVertex(ctx, child), ErrRejectedParent)
+}
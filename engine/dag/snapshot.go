@@ -0,0 +1,356 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+)
+
+// ErrInconsistentSnapshot is returned by ImportFrontier for a snapshot
+// whose vertices do not form a DAG resting on finalized history
+var ErrInconsistentSnapshot = errors.New("dag: inconsistent frontier snapshot")
+
+// VertexRef names a vertex and its height
+type VertexRef struct {
+	ID     ids.ID
+	Height uint64
+}
+
+// FrontierTip is one vertex of a FrontierSnapshot
+type FrontierTip struct {
+	VertexRef
+
+	// Parents are the vertex's parents; each is either another tip of the
+	// snapshot or finalized history at or below the watermark. A rejected
+	// tip carries none, since nothing beneath it is needed.
+	Parents []VertexRef
+
+	// Conflicts are the conflict IDs the vertex declared
+	Conflicts []ConflictID
+
+	// Status is the vertex's decision
+	Status VertexStatus
+
+	// Finalized is set for an accepted vertex already in the finalized
+	// order
+	Finalized bool
+}
+
+// FrontierSnapshot is the state a restarted node needs to rejoin the DAG
+// without re-finalizing old history: the frontier, every vertex not yet
+// finalized beneath it, and the watermark at or below which history is
+// final. It carries consensus metadata, not vertex payloads.
+type FrontierSnapshot struct {
+	// Tips are ordered so every tip follows the tips it references
+	Tips []FrontierTip
+
+	// Watermark is the height of the highest finalized vertex
+	Watermark uint64
+}
+
+// ExportFrontier snapshots the frontier and the vertices beneath it that
+// are not yet in the finalized order, walking down to the finalized
+// vertices they rest on. Each tip carries its decision, so a vertex accepted
+// but not yet ordered is restored accepted. The walk stops at rejected
+// vertices: they are exported so their children's parents resolve, but
+// their history is not.
+func (d *DAGConsensus) ExportFrontier() FrontierSnapshot {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	watermark := max(d.watermark, d.orderHeight)
+
+	// Collect the frontier and its unfinalized history. A finalized vertex
+	// is included only when it is itself a frontier vertex, and a rejected
+	// one only when a collected vertex references it.
+	seen := make(map[ids.ID]bool)
+	var stack, collected []*Vertex
+	for id := range d.frontier {
+		if v := d.vertices[id]; !v.IsRejected() {
+			seen[id] = true
+			stack = append(stack, v)
+		}
+	}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		collected = append(collected, v)
+		if d.ordered[v.ID()] || v.IsRejected() {
+			continue
+		}
+		for _, p := range v.Parents() {
+			if !seen[p.ID()] && !d.ordered[p.ID()] {
+				seen[p.ID()] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+
+	// Parents before children, ties broken by (height, ID)
+	slices.SortFunc(collected, func(a, b *Vertex) int {
+		return cmp.Or(cmp.Compare(a.Height(), b.Height()), a.ID().Compare(b.ID()))
+	})
+	collected = topoSort(collected, func(v *Vertex) []ids.ID {
+		var in []ids.ID
+		for _, p := range v.Parents() {
+			if seen[p.ID()] {
+				in = append(in, p.ID())
+			}
+		}
+		return in
+	}, (*Vertex).ID)
+
+	snap := FrontierSnapshot{
+		Tips:      make([]FrontierTip, len(collected)),
+		Watermark: watermark,
+	}
+	for i, v := range collected {
+		tip := FrontierTip{
+			VertexRef: VertexRef{ID: v.ID(), Height: v.Height()},
+			Conflicts: slices.Clone(d.vertexConflicts[v.ID()]),
+			Status:    v.status(),
+			Finalized: d.ordered[v.ID()],
+		}
+		if tip.Status == VertexRejected {
+			snap.Tips[i] = tip
+			continue
+		}
+		for _, parentID := range v.ParentIDs() {
+			if parent, ok := d.vertices[parentID]; ok {
+				tip.Parents = append(tip.Parents, VertexRef{ID: parentID, Height: parent.Height()})
+			} else if height, ok := d.settled[parentID]; ok {
+				tip.Parents = append(tip.Parents, VertexRef{ID: parentID, Height: height})
+			} else if rec, ok := d.store.Get(parentID); ok {
+				// Ordered and evicted
+				tip.Parents = append(tip.Parents, VertexRef{ID: parentID, Height: rec.Height})
+			}
+		}
+		snap.Tips[i] = tip
+	}
+	return snap
+}
+
+// ImportFrontier restores a snapshot taken by ExportFrontier into an empty
+// DAG. Finalized tips are settled without being re-finalized and the others
+// re-enter consensus; the finalized order resumes from the first vertex
+// finalized after the import. The snapshot is rejected with
+// ErrInconsistentSnapshot if a parent is neither in it nor at or below its
+// watermark.
+func (d *DAGConsensus) ImportFrontier(snap FrontierSnapshot) error {
+	tips, err := snap.validate()
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.vertices) > 0 {
+		return fmt.Errorf("%w: frontier import needs an empty DAG, have %d vertices", engine.ErrConflict, len(d.vertices))
+	}
+
+	for _, tip := range tips {
+		v := NewVertex(tip.ID, tip.parentIDs(), tip.Height, 0, nil)
+		v.accepted = tip.Status == VertexAccepted
+		v.rejected = tip.Status == VertexRejected
+		if !d.store.Has(tip.ID) {
+			rec := record(v, tip.Conflicts)
+			rec.Ordered = tip.Finalized
+			if err := d.store.Put(rec); err != nil {
+				return fmt.Errorf("failed to store vertex %s: %w", tip.ID, err)
+			}
+		}
+		d.restoreVertex(v, tip.Parents, tip.Conflicts, tip.Finalized)
+	}
+
+	d.watermark = snap.Watermark
+	return nil
+}
+
+// restoreVertex adds v, accepted, rejected or undecided, to a DAG being
+// rebuilt from a snapshot or store. parents are the refs of v's parents
+// that are known; those not yet in the DAG are recorded as settled history.
+// ordered marks an accepted vertex that is already in the finalized order;
+// other accepted vertices wait to be ordered.
+// Must be called with d.mu held
+func (d *DAGConsensus) restoreVertex(v *Vertex, parents []VertexRef, conflicts []ConflictID, ordered bool) {
+	id := v.ID()
+	finalized, rejected := v.IsAccepted(), v.IsRejected()
+	pending := !finalized && !rejected
+	switch {
+	case finalized && ordered:
+		d.ordered[id] = true
+	case finalized:
+		d.unordered[id] = struct{}{}
+	}
+	if pending {
+		v.SetLuxConsensus(engine.NewLuxConsensus(d.k, d.alpha, d.beta, d.driverOpts...))
+	}
+
+	// Link the parents already restored; the rest are settled
+	for _, p := range parents {
+		if parent, ok := d.vertices[p.ID]; ok {
+			parent.AddChild(v)
+			v.AddParent(parent)
+			delete(d.frontier, p.ID)
+		} else {
+			d.settled[p.ID] = p.Height
+		}
+	}
+
+	if pending {
+		for _, input := range v.Inputs() {
+			key := input.String()
+			for _, spenderID := range d.inputIndex[key] {
+				if spender := d.vertices[spenderID]; !spender.IsAccepted() && !spender.IsRejected() {
+					d.addConflict(id, spenderID)
+				}
+			}
+			d.inputIndex[key] = append(d.inputIndex[key], id)
+		}
+	}
+
+	for _, conflictID := range conflicts {
+		set, ok := d.conflicts[conflictID]
+		if !ok {
+			set = newConflictSet(conflictID)
+			d.conflicts[conflictID] = set
+		}
+		if finalized {
+			set.winner = id
+		}
+		for memberID := range set.members {
+			if !d.vertices[memberID].IsAccepted() && pending {
+				d.addConflict(id, memberID)
+			}
+		}
+		set.members[id] = true
+		d.vertexConflicts[id] = append(d.vertexConflicts[id], conflictID)
+	}
+
+	d.vertices[id] = v
+	d.addToHeight(v)
+	d.markContested(id)
+	d.frontier[id] = true
+	d.orderStale = true
+	if finalized {
+		d.lastAccepted = id
+	}
+	d.markReady(v)
+}
+
+// validate checks that the snapshot's tips are unique and acyclic, agree on
+// each other's heights, and reference only each other or finalized history.
+// It returns the tips ordered parents first.
+func (s FrontierSnapshot) validate() ([]FrontierTip, error) {
+	tips := make(map[ids.ID]FrontierTip, len(s.Tips))
+	for _, tip := range s.Tips {
+		if tip.ID == ids.Empty {
+			return nil, fmt.Errorf("%w: empty tip ID", ErrInconsistentSnapshot)
+		}
+		if _, dup := tips[tip.ID]; dup {
+			return nil, fmt.Errorf("%w: duplicate tip %s", ErrInconsistentSnapshot, tip.ID)
+		}
+		switch tip.Status {
+		case VertexProcessing, VertexAccepted, VertexRejected:
+		default:
+			return nil, fmt.Errorf("%w: tip %s has unknown status %d", ErrInconsistentSnapshot, tip.ID, tip.Status)
+		}
+		if tip.Finalized && tip.Status != VertexAccepted {
+			return nil, fmt.Errorf("%w: finalized tip %s is not accepted", ErrInconsistentSnapshot, tip.ID)
+		}
+		if tip.Finalized && tip.Height > s.Watermark {
+			return nil, fmt.Errorf("%w: finalized tip %s at height %d above watermark %d",
+				ErrInconsistentSnapshot, tip.ID, tip.Height, s.Watermark)
+		}
+		tips[tip.ID] = tip
+	}
+
+	for _, tip := range s.Tips {
+		for _, p := range tip.Parents {
+			parent, ok := tips[p.ID]
+			switch {
+			case ok && parent.Height != p.Height:
+				return nil, fmt.Errorf("%w: tip %s references parent %s at height %d, snapshot has %d",
+					ErrInconsistentSnapshot, tip.ID, p.ID, p.Height, parent.Height)
+			case ok && tip.Finalized && !parent.Finalized:
+				return nil, fmt.Errorf("%w: finalized tip %s has undecided parent %s",
+					ErrInconsistentSnapshot, tip.ID, p.ID)
+			case ok && tip.Status == VertexAccepted && parent.Status == VertexRejected:
+				return nil, fmt.Errorf("%w: accepted tip %s has rejected parent %s",
+					ErrInconsistentSnapshot, tip.ID, p.ID)
+			case !ok && p.Height > s.Watermark:
+				return nil, fmt.Errorf("%w: tip %s references dangling parent %s at height %d above watermark %d",
+					ErrInconsistentSnapshot, tip.ID, p.ID, p.Height, s.Watermark)
+			}
+		}
+	}
+
+	sorted := topoSort(s.Tips, FrontierTip.parentIDs, func(t FrontierTip) ids.ID { return t.ID })
+	if len(sorted) != len(s.Tips) {
+		return nil, fmt.Errorf("%w: tips form a cycle", ErrInconsistentSnapshot)
+	}
+	return sorted, nil
+}
+
+// parentIDs returns the IDs of the tip's parents
+func (t FrontierTip) parentIDs() []ids.ID {
+	out := make([]ids.ID, len(t.Parents))
+	for i, p := range t.Parents {
+		out[i] = p.ID
+	}
+	return out
+}
+
+// topoSort returns items ordered so each follows the items it references,
+// keeping the input order among independent items. References to IDs not
+// among items are ignored. Items on a cycle are dropped.
+func topoSort[T any](items []T, refs func(T) []ids.ID, id func(T) ids.ID) []T {
+	index := make(map[ids.ID]int, len(items))
+	for i, item := range items {
+		index[id(item)] = i
+	}
+	pending := make([]int, len(items))
+	children := make([][]int, len(items))
+	for i, item := range items {
+		for _, ref := range refs(item) {
+			if j, ok := index[ref]; ok {
+				pending[i]++
+				children[j] = append(children[j], i)
+			}
+		}
+	}
+
+	var ready []int
+	for i, n := range pending {
+		if n == 0 {
+			ready = append(ready, i)
+		}
+	}
+	out := make([]T, 0, len(items))
+	for len(ready) > 0 {
+		// Take the earliest ready item so the result is deterministic
+		best := 0
+		for k := range ready {
+			if ready[k] < ready[best] {
+				best = k
+			}
+		}
+		i := ready[best]
+		ready = append(ready[:best], ready[best+1:]...)
+		out = append(out, items[i])
+		for _, c := range children[i] {
+			if pending[c]--; pending[c] == 0 {
+				ready = append(ready, c)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontierSnapshotRoundTrip(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	m := newMergeDAG()
+
+	// Only the root is finalized: l1 and r1 are accepted siblings at one
+	// height, so neither closes a horizon yet
+	src := m.node(t)
+	accept(t, src, m.root, m.l1, m.r1)
+	order, err := src.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{m.root.ID()}, order)
+
+	snap := src.ExportFrontier()
+	require.Equal(m.root.Height(), snap.Watermark)
+	require.Len(snap.Tips, 5, "the finalized root stays behind the watermark")
+	for _, tip := range snap.Tips {
+		require.NotEqual(m.root.ID(), tip.ID)
+		require.False(tip.Finalized)
+		if tip.ID == m.l1.ID() || tip.ID == m.r1.ID() {
+			require.Equal(VertexAccepted, tip.Status, "accepted but not yet ordered")
+		} else {
+			require.Equal(VertexProcessing, tip.Status)
+		}
+	}
+
+	dst := newConflictTestEngine(2)
+	require.NoError(dst.ImportFrontier(snap))
+	require.Equal(snap, dst.ExportFrontier())
+	require.Equal(src.consensus.Frontier(), dst.consensus.Frontier())
+	require.True(dst.IsAccepted(m.l1.ID()))
+	require.True(dst.IsAccepted(m.r1.ID()))
+
+	health, err := dst.HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(m.root.Height(), health.(map[string]interface{})["last_finalized_height"])
+
+	// The restarted node finalizes the rest without revisiting the root
+	accept(t, dst, m.l2, m.r2, m.merge)
+	order, err = dst.FinalizedOrder(0)
+	require.NoError(err)
+	require.Len(order, 5)
+	require.NotContains(order, m.root.ID())
+
+	// New vertices may still build on the settled root
+	late := NewVertex(ids.GenerateTestID(), []ids.ID{m.root.ID()}, 2, 0, nil)
+	require.NoError(dst.AddVertex(ctx, late, nil))
+}
+
+func TestFrontierSnapshotFinalizedTip(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+
+	src := m.node(t)
+	accept(t, src, m.root, m.l1, m.r1, m.l2, m.r2, m.merge)
+
+	snap := src.ExportFrontier()
+	require.Equal(m.merge.Height(), snap.Watermark)
+	require.Len(snap.Tips, 1)
+	require.Equal(m.merge.ID(), snap.Tips[0].ID)
+	require.True(snap.Tips[0].Finalized)
+
+	dst := newConflictTestEngine(2)
+	require.NoError(dst.ImportFrontier(snap))
+	require.True(dst.IsAccepted(m.merge.ID()))
+	order, err := dst.FinalizedOrder(0)
+	require.NoError(err)
+	require.Empty(order, "an imported finalized tip is not finalized again")
+}
+
+func TestFrontierSnapshotRejectsDanglingParent(t *testing.T) {
+	require := require.New(t)
+	m := newMergeDAG()
+
+	src := m.node(t)
+	accept(t, src, m.root)
+	snap := src.ExportFrontier()
+
+	// Drop l1: l2 now references a parent above the watermark that the
+	// snapshot does not hold
+	var dangling FrontierSnapshot
+	dangling.Watermark = snap.Watermark
+	for _, tip := range snap.Tips {
+		if tip.ID != m.l1.ID() {
+			dangling.Tips = append(dangling.Tips, tip)
+		}
+	}
+
+	dst := newConflictTestEngine(2)
+	require.ErrorIs(dst.ImportFrontier(dangling), ErrInconsistentSnapshot)
+	require.Empty(dst.consensus.Frontier(), "a rejected snapshot leaves no state behind")
+
+	// A mismatched parent height is inconsistent too
+	mismatched := FrontierSnapshot{Watermark: snap.Watermark}
+	for _, tip := range snap.Tips {
+		if tip.ID == m.l2.ID() {
+			tip.Parents = []VertexRef{{ID: m.l1.ID(), Height: m.l1.Height() + 1}}
+		}
+		mismatched.Tips = append(mismatched.Tips, tip)
+	}
+	require.ErrorIs(dst.ImportFrontier(mismatched), ErrInconsistentSnapshot)
+
+	require.NoError(dst.ImportFrontier(snap))
+	require.ErrorIs(dst.ImportFrontier(snap), engine.ErrConflict, "import needs an empty DAG")
+}
+
+func TestFrontierSnapshotStopsAtRejected(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	spent := UTXOConflictID(UTXO{TxID: ids.GenerateTestID()})
+
+	// b double spends a and has a child; a wins, so b is rejected with
+	// undecided history beneath it
+	base := NewVertex(ids.GenerateTestID(), nil, 1, 0, nil)
+	a := NewVertex(ids.GenerateTestID(), nil, 2, 0, nil)
+	b := NewVertex(ids.GenerateTestID(), []ids.ID{base.ID()}, 2, 0, nil)
+	c := NewVertex(ids.GenerateTestID(), []ids.ID{b.ID()}, 3, 0, nil)
+
+	src := newConflictTestEngine(2)
+	require.NoError(src.AddVertex(ctx, base, nil))
+	require.NoError(src.AddVertex(ctx, a, []ConflictID{spent}))
+	require.NoError(src.AddVertex(ctx, b, []ConflictID{spent}))
+	require.NoError(src.AddVertex(ctx, c, nil))
+	accept(t, src, a)
+	require.True(src.consensus.IsRejected(b.ID()))
+
+	snap := src.ExportFrontier()
+	status := make(map[ids.ID]VertexStatus)
+	for _, tip := range snap.Tips {
+		status[tip.ID] = tip.Status
+		if tip.ID == b.ID() {
+			require.Empty(tip.Parents)
+		}
+	}
+	require.Equal(map[ids.ID]VertexStatus{
+		a.ID(): VertexAccepted,
+		b.ID(): VertexRejected,
+		c.ID(): VertexProcessing,
+	}, status, "the walk does not descend beneath the rejected vertex")
+
+	dst := newConflictTestEngine(2)
+	require.NoError(dst.ImportFrontier(snap))
+	require.True(dst.IsAccepted(a.ID()))
+	require.True(dst.consensus.IsRejected(b.ID()))
+	require.Equal(snap, dst.ExportFrontier())
+}
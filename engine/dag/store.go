@@ -0,0 +1,267 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+)
+
+// VertexStatus is the decision recorded for a stored vertex
+type VertexStatus uint8
+
+const (
+	VertexProcessing VertexStatus = iota
+	VertexAccepted
+	VertexRejected
+)
+
+// VertexRecord is the persisted form of a vertex: its content, links,
+// decision and whether it is in the finalized order, without the in-memory
+// consensus state
+type VertexRecord struct {
+	ID        ids.ID       `json:"id"`
+	Parents   []ids.ID     `json:"parents"`
+	Height    uint64       `json:"height"`
+	Timestamp int64        `json:"timestamp"`
+	Data      []byte       `json:"data"`
+	Inputs    []UTXO       `json:"inputs,omitempty"`
+	Conflicts []ConflictID `json:"conflicts,omitempty"`
+	Status    VertexStatus `json:"status"`
+	Ordered   bool         `json:"ordered,omitempty"`
+}
+
+// VertexStore persists the vertices of a DAG by ID. Like the order-theory
+// Store of core/dag, it answers Get, Children and Head; the engine writes
+// each vertex with Put when it is added and again when it is decided.
+type VertexStore interface {
+	// Get returns the record of vertex id
+	Get(id ids.ID) (VertexRecord, bool)
+
+	// Put stores rec. Storing a known ID again replaces its record (e.g. to
+	// record a decision); its parent links are fixed by the first Put.
+	Put(rec VertexRecord) error
+
+	// Has reports whether vertex id is stored
+	Has(id ids.ID) bool
+
+	// Children returns the stored vertices naming id as a parent, by ID
+	Children(id ids.ID) []ids.ID
+
+	// Head returns the stored vertices without stored children, by ID
+	Head() []ids.ID
+}
+
+// MemoryVertexStore is a VertexStore held in memory. It is the default
+// store of NewDAGConsensus.
+type MemoryVertexStore struct {
+	mu       sync.RWMutex
+	records  map[ids.ID]VertexRecord
+	children map[ids.ID][]ids.ID
+	head     map[ids.ID]struct{}
+}
+
+// NewMemoryVertexStore returns an empty in-memory store
+func NewMemoryVertexStore() *MemoryVertexStore {
+	return &MemoryVertexStore{
+		records:  make(map[ids.ID]VertexRecord),
+		children: make(map[ids.ID][]ids.ID),
+		head:     make(map[ids.ID]struct{}),
+	}
+}
+
+// Get returns the record of vertex id
+func (s *MemoryVertexStore) Get(id ids.ID) (VertexRecord, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.records[id]
+	return rec, ok
+}
+
+// Put stores rec
+func (s *MemoryVertexStore) Put(rec VertexRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[rec.ID]; ok {
+		s.records[rec.ID] = rec
+		return nil
+	}
+	s.records[rec.ID] = rec
+	for _, parentID := range rec.Parents {
+		if parentID == ids.Empty {
+			continue
+		}
+		s.children[parentID] = append(s.children[parentID], rec.ID)
+		delete(s.head, parentID)
+	}
+	if len(s.children[rec.ID]) == 0 {
+		s.head[rec.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Has reports whether vertex id is stored
+func (s *MemoryVertexStore) Has(id ids.ID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.records[id]
+	return ok
+}
+
+// Children returns the stored children of id, by ID
+func (s *MemoryVertexStore) Children(id ids.ID) []ids.ID {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.SortedFunc(slices.Values(s.children[id]), ids.ID.Compare)
+}
+
+// Head returns the stored vertices without stored children, by ID
+func (s *MemoryVertexStore) Head() []ids.ID {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]ids.ID, 0, len(s.head))
+	for id := range s.head {
+		out = append(out, id)
+	}
+	slices.SortFunc(out, ids.ID.Compare)
+	return out
+}
+
+// record returns the persisted form of v
+func record(v *Vertex, conflicts []ConflictID) VertexRecord {
+	return VertexRecord{
+		ID:        v.ID(),
+		Parents:   slices.Clone(v.ParentIDs()),
+		Height:    v.Height(),
+		Timestamp: v.timestamp,
+		Data:      v.Bytes(),
+		Inputs:    v.Inputs(),
+		Conflicts: slices.Clone(conflicts),
+		Status:    v.status(),
+	}
+}
+
+// vertex returns a detached vertex holding rec's content and decision
+func (rec VertexRecord) vertex() *Vertex {
+	v := NewVertexWithInputs(rec.ID, slices.Clone(rec.Parents), rec.Height, rec.Timestamp, rec.Data, rec.Inputs)
+	v.accepted = rec.Status == VertexAccepted
+	v.rejected = rec.Status == VertexRejected
+	return v
+}
+
+// status returns the decision of v
+func (v *Vertex) status() VertexStatus {
+	switch {
+	case v.IsAccepted():
+		return VertexAccepted
+	case v.IsRejected():
+		return VertexRejected
+	default:
+		return VertexProcessing
+	}
+}
+
+// decide records v's decision in the store and only then applies it, so a
+// failed write leaves v undecided in memory as well as on disk
+// Must be called with d.mu held
+func (d *DAGConsensus) decide(ctx context.Context, v *Vertex, accept bool) error {
+	rec := record(v, d.vertexConflicts[v.ID()])
+	apply := v.Reject
+	rec.Status = VertexRejected
+	if accept {
+		rec.Status, apply = VertexAccepted, v.Accept
+	}
+	if err := d.store.Put(rec); err != nil {
+		return fmt.Errorf("failed to store vertex %s: %w", v.ID(), err)
+	}
+	if err := apply(ctx); err != nil {
+		rec.Status = VertexProcessing
+		return errors.Join(fmt.Errorf("failed to decide vertex %s: %w", v.ID(), err), d.store.Put(rec))
+	}
+	d.orderStale = true
+	return nil
+}
+
+// Store returns the store the DAG persists its vertices in
+func (d *DAGConsensus) Store() VertexStore {
+	return d.store
+}
+
+// Recover rebuilds an empty DAG from its store after a restart, as
+// ImportFrontier would from a snapshot: the store's head and every
+// undecided vertex beneath it re-enter consensus, and the accepted vertices
+// not yet in the finalized order are restored accepted, to be ordered again.
+// They rest on the ordered vertices they reference, which are settled
+// without being re-finalized. Rejected vertices on the head are restored as
+// rejected, so the frontier is the one before the restart. Older history
+// stays in the store, where GetVertex, IsAccepted and IsRejected still find
+// it.
+func (d *DAGConsensus) Recover() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.vertices) > 0 {
+		return fmt.Errorf("%w: recovery needs an empty DAG, have %d vertices", engine.ErrConflict, len(d.vertices))
+	}
+
+	// Walk down from the head to the ordered vertices
+	seen := make(map[ids.ID]VertexRecord)
+	var stack, collected []VertexRecord
+	for _, id := range d.store.Head() {
+		if rec, ok := d.store.Get(id); ok {
+			seen[id] = rec
+			stack = append(stack, rec)
+		}
+	}
+	for len(stack) > 0 {
+		rec := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		collected = append(collected, rec)
+		if rec.Status == VertexRejected || rec.Ordered {
+			continue
+		}
+		for _, parentID := range rec.Parents {
+			if _, ok := seen[parentID]; ok {
+				continue
+			}
+			if parent, ok := d.store.Get(parentID); ok && parent.Status != VertexRejected {
+				seen[parentID] = parent
+				stack = append(stack, parent)
+			}
+		}
+	}
+
+	// Parents before children, ties broken by (height, ID)
+	slices.SortFunc(collected, func(a, b VertexRecord) int {
+		return cmp.Or(cmp.Compare(a.Height, b.Height), a.ID.Compare(b.ID))
+	})
+	collected = topoSort(collected, func(rec VertexRecord) []ids.ID { return rec.Parents },
+		func(rec VertexRecord) ids.ID { return rec.ID })
+
+	for _, rec := range collected {
+		var parents []VertexRef
+		for _, parentID := range rec.Parents {
+			parent, ok := seen[parentID]
+			if !ok {
+				if parent, ok = d.store.Get(parentID); !ok {
+					continue
+				}
+			}
+			parents = append(parents, VertexRef{ID: parentID, Height: parent.Height})
+		}
+		if rec.Ordered {
+			d.watermark = max(d.watermark, rec.Height)
+		}
+		d.restoreVertex(rec.vertex(), parents, rec.Conflicts, rec.Ordered)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+)
+
+// Key prefixes of a DatabaseVertexStore
+const (
+	recordPrefix = 'v' // v || id -> record
+	childPrefix  = 'c' // c || parent || child -> nil
+	headPrefix   = 'h' // h || id -> nil
+)
+
+// DatabaseVertexStore is a VertexStore persisted in a database.Database,
+// such as one opened with badgerdb.New, so a restarted node can Recover
+// its DAG. Reads that fail are reported as missing; Err returns the first
+// such failure.
+type DatabaseVertexStore struct {
+	db database.Database
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewDatabaseVertexStore returns a store over db. The store does not own
+// db; the caller closes it.
+func NewDatabaseVertexStore(db database.Database) *DatabaseVertexStore {
+	return &DatabaseVertexStore{db: db}
+}
+
+func prefixed(prefix byte, parts ...ids.ID) []byte {
+	key := make([]byte, 1, 1+len(parts)*ids.IDLen)
+	key[0] = prefix
+	for _, id := range parts {
+		key = append(key, id[:]...)
+	}
+	return key
+}
+
+// fail records the first read error
+func (s *DatabaseVertexStore) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the first read error, if any
+func (s *DatabaseVertexStore) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Get returns the record of vertex id
+func (s *DatabaseVertexStore) Get(id ids.ID) (VertexRecord, bool) {
+	data, err := s.db.Get(prefixed(recordPrefix, id))
+	if err != nil {
+		if !errors.Is(err, database.ErrNotFound) {
+			s.fail(err)
+		}
+		return VertexRecord{}, false
+	}
+	var rec VertexRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		s.fail(err)
+		return VertexRecord{}, false
+	}
+	return rec, true
+}
+
+// Put stores rec, linking it to its parents in the same batch
+func (s *DatabaseVertexStore) Put(rec VertexRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	known, err := s.db.Has(prefixed(recordPrefix, rec.ID))
+	if err != nil {
+		return err
+	}
+	if known {
+		return s.db.Put(prefixed(recordPrefix, rec.ID), data)
+	}
+
+	batch := s.db.NewBatch()
+	if err := batch.Put(prefixed(recordPrefix, rec.ID), data); err != nil {
+		return err
+	}
+	for _, parentID := range rec.Parents {
+		if parentID == ids.Empty {
+			continue
+		}
+		if err := batch.Put(prefixed(childPrefix, parentID, rec.ID), nil); err != nil {
+			return err
+		}
+		if err := batch.Delete(prefixed(headPrefix, parentID)); err != nil {
+			return err
+		}
+	}
+	if len(s.Children(rec.ID)) == 0 {
+		if err := batch.Put(prefixed(headPrefix, rec.ID), nil); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// Has reports whether vertex id is stored
+func (s *DatabaseVertexStore) Has(id ids.ID) bool {
+	ok, err := s.db.Has(prefixed(recordPrefix, id))
+	if err != nil {
+		s.fail(err)
+		return false
+	}
+	return ok
+}
+
+// Children returns the stored children of id, by ID
+func (s *DatabaseVertexStore) Children(id ids.ID) []ids.ID {
+	return s.scan(prefixed(childPrefix, id))
+}
+
+// Head returns the stored vertices without stored children, by ID
+func (s *DatabaseVertexStore) Head() []ids.ID {
+	return s.scan(prefixed(headPrefix))
+}
+
+// scan returns the IDs that follow prefix in its keys, in key order
+func (s *DatabaseVertexStore) scan(prefix []byte) []ids.ID {
+	it := s.db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var out []ids.ID
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+ids.IDLen {
+			continue
+		}
+		var id ids.ID
+		copy(id[:], key[len(prefix):])
+		out = append(out, id)
+	}
+	if err := it.Error(); err != nil {
+		s.fail(err)
+	}
+	return out
+}
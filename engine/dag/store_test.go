@@ -0,0 +1,282 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/badgerdb"
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/metric"
+	"github.com/stretchr/testify/require"
+)
+
+// storeScript is a small DAG with a double spend: a and b spend the same
+// output, c builds on a and d is added after the polls and stays undecided
+type storeScript struct {
+	root, a, b, c, d ids.ID
+}
+
+func openBadger(t *testing.T, dir string) database.Database {
+	db, err := badgerdb.New(dir, nil, "", metric.NewNoOpRegistry())
+	require.NoError(t, err)
+	return db
+}
+
+// runStoreScript drives a DAG over store through the script
+func runStoreScript(t *testing.T, store VertexStore) (*DAGConsensus, storeScript) {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+	dc := NewDAGConsensusWithStore(1, 1, 2, store)
+
+	add := func(parents []ids.ID, height uint64, payload string, conflicts ...ConflictID) ids.ID {
+		v := NewVertex(DeriveVertexID(parents, []byte(payload)), parents, height, 0, []byte(payload))
+		require.NoError(dc.AddVertexWithConflicts(ctx, v, conflicts))
+		return v.ID()
+	}
+	spent := UTXOConflictID(UTXO{TxID: ids.ID{0x01}})
+
+	var s storeScript
+	s.root = add(nil, 0, "genesis")
+	s.a = add([]ids.ID{s.root}, 1, "pay alice", spent)
+	s.b = add([]ids.ID{s.root}, 1, "pay bob", spent)
+	s.c = add([]ids.ID{s.a}, 2, "tx c")
+	for i := 0; i < 3; i++ {
+		require.NoError(dc.Poll(ctx, map[ids.ID]int{s.root: 1, s.a: 1, s.b: 1, s.c: 1}))
+	}
+	s.d = add([]ids.ID{s.c}, 3, "tx d")
+	return dc, s
+}
+
+// outcome is what a node finalized: the order, each vertex's decision and
+// the frontier
+type outcome struct {
+	order    []VertexID
+	accepted map[ids.ID]bool
+	rejected map[ids.ID]bool
+	frontier []ids.ID
+}
+
+func outcomeOf(t *testing.T, dc *DAGConsensus, s storeScript) outcome {
+	order, err := dc.FinalizedOrder(0)
+	require.NoError(t, err)
+	o := outcome{
+		order:    order,
+		accepted: make(map[ids.ID]bool),
+		rejected: make(map[ids.ID]bool),
+		frontier: dc.Frontier(),
+	}
+	for _, id := range []ids.ID{s.root, s.a, s.b, s.c, s.d} {
+		o.accepted[id] = dc.IsAccepted(id)
+		o.rejected[id] = dc.IsRejected(id)
+	}
+	return o
+}
+
+func TestVertexStoresFinalizeIdentically(t *testing.T) {
+	require := require.New(t)
+
+	mem, s := runStoreScript(t, NewMemoryVertexStore())
+	want := outcomeOf(t, mem, s)
+	require.NotEqual(want.accepted[s.a], want.accepted[s.b], "exactly one spend finalizes")
+	require.True(want.accepted[s.root])
+	require.False(want.accepted[s.d] || want.rejected[s.d])
+
+	memDB := NewDatabaseVertexStore(memdb.New())
+	dc, _ := runStoreScript(t, memDB)
+	require.Equal(want, outcomeOf(t, dc, s))
+	require.NoError(memDB.Err())
+
+	db := openBadger(t, t.TempDir())
+	defer db.Close()
+	badger := NewDatabaseVertexStore(db)
+	dc, _ = runStoreScript(t, badger)
+	require.Equal(want, outcomeOf(t, dc, s))
+	require.NoError(badger.Err())
+
+	// Every store holds the same links and decisions
+	for _, store := range []VertexStore{mem.Store(), memDB, badger} {
+		require.Equal(want.frontier, store.Head())
+		require.ElementsMatch([]ids.ID{s.a, s.b}, store.Children(s.root))
+		rec, ok := store.Get(s.c)
+		require.True(ok)
+		require.Equal([]byte("tx c"), rec.Data)
+		require.Equal(VertexAccepted, rec.Status)
+		require.False(store.Has(ids.ID{0xFF}))
+	}
+}
+
+func TestRecoverFromBadgerStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db := openBadger(t, dir)
+	before, s := runStoreScript(t, NewDatabaseVertexStore(db))
+	want := outcomeOf(t, before, s)
+	require.NoError(db.Close())
+
+	// Restart over the same directory
+	db = openBadger(t, dir)
+	defer db.Close()
+	store := NewDatabaseVertexStore(db)
+	after := NewDAGConsensusWithStore(1, 1, 2, store)
+	require.NoError(after.Recover())
+	require.NoError(store.Err())
+
+	require.Equal(want.frontier, after.Frontier())
+	for id, accepted := range want.accepted {
+		require.Equal(accepted, after.IsAccepted(id), "accepted %s", id)
+		require.Equal(want.rejected[id], after.IsRejected(id), "rejected %s", id)
+	}
+
+	// History beneath the frontier is served from the store, and is not
+	// accepted again
+	v, ok := after.GetVertex(s.root)
+	require.True(ok)
+	require.Equal([]byte("genesis"), v.Bytes())
+	err := after.AddVertex(ctx, NewVertex(s.root, nil, 0, 0, []byte("genesis")))
+	require.ErrorIs(err, engine.ErrConflict)
+
+	// The undecided vertex resumes consensus and finalizes
+	for i := 0; i < 2; i++ {
+		require.NoError(after.Poll(ctx, map[ids.ID]int{s.d: 1}))
+	}
+	require.True(after.IsAccepted(s.d))
+	order, err := after.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{s.d}, order)
+	rec, ok := store.Get(s.d)
+	require.True(ok)
+	require.Equal(VertexAccepted, rec.Status)
+}
+
+func TestRecoverNeedsEmptyDAG(t *testing.T) {
+	dc, _ := runStoreScript(t, NewMemoryVertexStore())
+	require.ErrorIs(t, dc.Recover(), engine.ErrConflict)
+}
+
+// addTo adds a vertex with the given parents to dc
+func addTo(t *testing.T, dc *DAGConsensus, parents []ids.ID, height uint64, payload string) ids.ID {
+	t.Helper()
+	v := NewVertex(DeriveVertexID(parents, []byte(payload)), parents, height, 0, []byte(payload))
+	require.NoError(t, dc.AddVertex(context.Background(), v))
+	return v.ID()
+}
+
+func TestRecoverResumesUnorderedVertices(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := NewMemoryVertexStore()
+	before := NewDAGConsensusWithStore(1, 1, 2, store)
+
+	// a is accepted but cannot be ordered while its sibling b is undecided
+	root := addTo(t, before, nil, 0, "genesis")
+	a := addTo(t, before, []ids.ID{root}, 1, "a")
+	b := addTo(t, before, []ids.ID{root}, 1, "b")
+	for i := 0; i < 2; i++ {
+		require.NoError(before.Poll(ctx, map[ids.ID]int{root: 1, a: 1}))
+	}
+	require.True(before.IsAccepted(a))
+	order, err := before.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{root}, order)
+	rec, ok := store.Get(a)
+	require.True(ok)
+	require.Equal(VertexAccepted, rec.Status)
+	require.False(rec.Ordered)
+
+	after := NewDAGConsensusWithStore(1, 1, 2, store)
+	require.NoError(after.Recover())
+	require.True(after.IsAccepted(a))
+
+	// Once b and a vertex above both are accepted, a is ordered after the
+	// restart
+	c := addTo(t, after, []ids.ID{a, b}, 2, "c")
+	for i := 0; i < 2; i++ {
+		require.NoError(after.Poll(ctx, map[ids.ID]int{b: 1, c: 1}))
+	}
+	order, err = after.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{a, b, c}, order)
+	rec, ok = store.Get(a)
+	require.True(ok)
+	require.True(rec.Ordered)
+}
+
+// failingStore refuses to record decisions
+type failingStore struct {
+	VertexStore
+}
+
+var errStoreFull = errors.New("store full")
+
+func (s failingStore) Put(rec VertexRecord) error {
+	if rec.Status != VertexProcessing {
+		return errStoreFull
+	}
+	return s.VertexStore.Put(rec)
+}
+
+func TestDecisionWaitsForStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := failingStore{NewMemoryVertexStore()}
+	dc := NewDAGConsensusWithStore(1, 1, 2, store)
+
+	root := addTo(t, dc, nil, 0, "genesis")
+	var err error
+	for i := 0; i < 2 && err == nil; i++ {
+		err = dc.Poll(ctx, map[ids.ID]int{root: 1})
+	}
+	require.ErrorIs(err, errStoreFull)
+
+	// Neither memory nor the store holds a decision
+	v, ok := dc.GetVertex(root)
+	require.True(ok)
+	require.False(v.IsAccepted())
+	rec, ok := store.Get(root)
+	require.True(ok)
+	require.Equal(VertexProcessing, rec.Status)
+}
+
+func TestOrderedVerticesAreEvicted(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dc := NewDAGConsensus(1, 1, 2)
+
+	root := addTo(t, dc, nil, 0, "genesis")
+	a := addTo(t, dc, []ids.ID{root}, 1, "a")
+	b := addTo(t, dc, []ids.ID{a}, 2, "b")
+	for i := 0; i < 2; i++ {
+		require.NoError(dc.Poll(ctx, map[ids.ID]int{root: 1, a: 1, b: 1}))
+	}
+
+	// Only the frontier vertex stays in memory
+	require.Equal(1, dc.Stats()["total_vertices"])
+	order, err := dc.FinalizedOrder(0)
+	require.NoError(err)
+	require.Equal([]VertexID{root, a, b}, order)
+	v, ok := dc.GetVertex(root)
+	require.True(ok)
+	require.True(v.IsAccepted())
+	require.True(dc.IsAccepted(a))
+	require.Equal(uint64(2), dc.lastFinalizedHeight())
+
+	// An evicted vertex can still be built on
+	c := addTo(t, dc, []ids.ID{a, b}, 3, "c")
+	require.Equal([]ids.ID{c}, dc.Ready())
+	for i := 0; i < 2; i++ {
+		require.NoError(dc.Poll(ctx, map[ids.ID]int{c: 1}))
+	}
+	order, err = dc.FinalizedOrder(3)
+	require.NoError(err)
+	require.Equal([]VertexID{c}, order)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/consensus/protocol/prism"
+	"github.com/luxfi/ids"
+)
+
+var (
+	// ErrSyncMissingVertex is returned when a peer advertises a vertex it
+	// cannot serve.
+	ErrSyncMissingVertex = errors.New("dag: peer missing advertised vertex")
+
+	// ErrSyncCausalGap is returned when a fetched vertex references a parent
+	// the local DAG does not hold.
+	ErrSyncCausalGap = errors.New("dag: vertex parent missing locally")
+)
+
+// Store is the vertex storage a node exposes to anti-entropy sync.
+// DAGConsensus implements Store.
+type Store interface {
+	Frontier() []ids.ID
+	GetVertex(ids.ID) (*Vertex, bool)
+	AddVertex(context.Context, *Vertex) error
+}
+
+var _ Store = (*DAGConsensus)(nil)
+
+// SyncRound runs one anti-entropy round: it diffs the peer frontier against
+// the local DAG and inserts every vertex the peer has and local lacks, in
+// causal order. Each vertex's parents must already be present locally when it
+// is inserted. Returns the number of vertices fetched.
+func SyncRound(local, peer Store) (fetched int, err error) {
+	has := func(id ids.ID) bool {
+		_, ok := local.GetVertex(id)
+		return ok
+	}
+	parents := func(id ids.ID) []ids.ID {
+		if v, ok := peer.GetVertex(id); ok {
+			return v.ParentIDs()
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	for _, id := range prism.FrontierDiff(peer.Frontier(), parents, has) {
+		src, ok := peer.GetVertex(id)
+		if !ok {
+			return fetched, fmt.Errorf("%w: %s", ErrSyncMissingVertex, id)
+		}
+		for _, parentID := range src.ParentIDs() {
+			if parentID != ids.Empty && !has(parentID) {
+				return fetched, fmt.Errorf("%w: %s parent %s", ErrSyncCausalGap, id, parentID)
+			}
+		}
+		if err := local.AddVertex(ctx, src.clone()); err != nil {
+			return fetched, fmt.Errorf("failed to insert vertex %s: %w", id, err)
+		}
+		fetched++
+	}
+	return fetched, nil
+}
+
+// clone returns a detached copy of the vertex's immutable content, without
+// the consensus state or parent/child links of the DAG it came from.
+func (v *Vertex) clone() *Vertex {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	parentIDs := make([]ids.ID, len(v.parentIDs))
+	copy(parentIDs, v.parentIDs)
+	c := NewVertexWithInputs(v.id, parentIDs, v.height, v.timestamp, v.data, v.inputs)
+	c.outputs = make([]UTXO, len(v.outputs))
+	copy(c.outputs, v.outputs)
+	return c
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRoundCatchesUp(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	local := NewDAGConsensus(20, 15, 20)
+	peer := NewDAGConsensus(20, 15, 20)
+
+	genesis := ids.GenerateTestID()
+	require.NoError(local.AddVertex(ctx, NewVertex(genesis, []ids.ID{}, 0, 0, nil)))
+	require.NoError(peer.AddVertex(ctx, NewVertex(genesis, []ids.ID{}, 0, 0, nil)))
+
+	// Two interleaved lanes that periodically merge, so vertices have one or
+	// two parents.
+	lanes := [2]ids.ID{genesis, genesis}
+	for i := 0; i < 100; i++ {
+		id := ids.GenerateTestID()
+		parents := []ids.ID{lanes[i%2]}
+		if i%10 == 9 && lanes[0] != lanes[1] {
+			parents = append(parents, lanes[(i+1)%2])
+		}
+		require.NoError(peer.AddVertex(ctx, NewVertex(id, parents, uint64(i+1), int64(i), []byte{byte(i)})))
+		lanes[i%2] = id
+	}
+
+	fetched, err := SyncRound(local, peer)
+	require.NoError(err)
+	require.Equal(100, fetched)
+
+	require.Equal(peer.Frontier(), local.Frontier())
+	for id, pv := range peer.vertices {
+		lv, ok := local.GetVertex(id)
+		require.True(ok)
+		require.Equal(pv.ParentIDs(), lv.ParentIDs())
+		require.Equal(pv.Height(), lv.Height())
+		require.Equal(pv.Bytes(), lv.Bytes())
+	}
+
+	// A second round has nothing left to fetch.
+	fetched, err = SyncRound(local, peer)
+	require.NoError(err)
+	require.Zero(fetched)
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import "github.com/luxfi/consensus/engine"
+
+// SetTracer sets the tracer that receives the DAG's consensus transitions:
+// vertices added, per-vertex poll tallies, votes, preference changes and
+// vertices appended to the finalized order. Poll visits vertices in ID
+// order, so two DAGs fed the same calls emit identical traces. A nil
+// tracer restores the no-op default.
+func (d *DAGConsensus) SetTracer(t engine.EventTracer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t == nil {
+		t = engine.NopTracer{}
+	}
+	d.tracer = t
+}
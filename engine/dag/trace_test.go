@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// runScript drives a fresh DAG through a fixed sequence of calls and
+// returns its JSONL trace. divergent swaps the payload of the last
+// vertex, as a node that received different input would.
+func runScript(t *testing.T, divergent bool) []byte {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	tracer := engine.NewJSONLTracer(&buf)
+	dc := NewDAGConsensus(1, 1, 2)
+	dc.SetTracer(tracer)
+
+	add := func(parents []ids.ID, height uint64, payload string) ids.ID {
+		v := NewVertex(DeriveVertexID(parents, []byte(payload)), parents, height, 0, []byte(payload))
+		require.NoError(dc.AddVertex(ctx, v))
+		return v.ID()
+	}
+	root := add(nil, 0, "genesis")
+	tx1 := add([]ids.ID{root}, 1, "tx1")
+	last := "tx2"
+	if divergent {
+		last = "tx2'"
+	}
+	tx2 := add([]ids.ID{root, tx1}, 2, last)
+
+	require.NoError(dc.ProcessVote(ctx, tx1, true))
+	require.NoError(dc.ProcessVote(ctx, tx2, false))
+	for i := 0; i < 3; i++ {
+		require.NoError(dc.Poll(ctx, map[ids.ID]int{root: 1, tx1: 1, tx2: 1}))
+	}
+	require.NoError(tracer.Err())
+	return buf.Bytes()
+}
+
+func TestTraceIdenticalAcrossNodes(t *testing.T) {
+	require := require.New(t)
+
+	a, b := runScript(t, false), runScript(t, false)
+	require.NotEmpty(a)
+	require.True(bytes.Equal(a, b), "identical input produced different traces")
+
+	kinds := make(map[engine.EventKind]int)
+	scanner := bufio.NewScanner(bytes.NewReader(a))
+	for scanner.Scan() {
+		var ev engine.Event
+		require.NoError(json.Unmarshal(scanner.Bytes(), &ev))
+		kinds[ev.Kind]++
+	}
+	require.Equal(3, kinds[engine.EventVertexAdded])
+	require.Equal(2, kinds[engine.EventVoteRecorded])
+	require.Positive(kinds[engine.EventPollIssued])
+	require.Positive(kinds[engine.EventPreferenceChanged])
+	require.Equal(3, kinds[engine.EventFinalized])
+
+	// Different input shows up in the trace
+	require.False(bytes.Equal(a, runScript(t, true)))
+}
+
+func TestSetTracerNil(t *testing.T) {
+	dc := NewDAGConsensus(1, 1, 1)
+	dc.SetTracer(nil)
+	require.NoError(t, dc.AddVertex(context.Background(), NewVertex(ids.GenerateTestID(), nil, 0, 0, nil)))
+}
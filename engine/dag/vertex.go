@@ -6,6 +6,7 @@ package dag
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/luxfi/consensus/engine"
@@ -35,6 +36,10 @@ type Vertex struct {
 	inputs  []UTXO // UTXOs consumed by this vertex's transactions
 	outputs []UTXO // UTXOs created by this vertex's transactions
 
+	// hint is the subset of parents the vertex actually depends on; nil
+	// means all of them (see hints.go)
+	hint []ids.ID
+
 	// Consensus state - using Lux consensus with Prism DAG protocol
 	mu         sync.RWMutex
 	driver     *engine.Driver
@@ -226,6 +231,13 @@ func (v *Vertex) AddParent(parent *Vertex) {
 	v.parents = append(v.parents, parent)
 }
 
+// removeParent unlinks the parent id once it is evicted from memory
+func (v *Vertex) removeParent(id ids.ID) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.parents = slices.DeleteFunc(v.parents, func(p *Vertex) bool { return p.ID() == id })
+}
+
 // Parents returns all parent vertices
 func (v *Vertex) Parents() []*Vertex {
 	v.mu.RLock()
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrVertexIDMismatch is returned, when vertex ID verification is enabled,
+// for a vertex whose ID is not derived from its parents and payload
+var ErrVertexIDMismatch = errors.New("vertex ID does not match content")
+
+// vertexIDDomain separates vertex IDs from other SHA-256 uses
+const vertexIDDomain = "lux/dag/vertex-id/v1"
+
+// DeriveVertexID returns the content-addressed ID of a vertex:
+//
+//	H(domain || len(parents) || sorted parents || len(payload) || payload)
+//
+// with lengths as 8-byte big-endian integers. Parents are sorted first, so
+// nodes that list the same parents in a different order derive the same
+// ID, and the lengths keep a parent from being passed off as the start of
+// the payload.
+func DeriveVertexID(parents []VertexID, payload []byte) VertexID {
+	sorted := slices.Clone(parents)
+	slices.SortFunc(sorted, func(a, b VertexID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	h := sha256.New()
+	h.Write([]byte(vertexIDDomain))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(sorted))))
+	for _, parent := range sorted {
+		h.Write(parent[:])
+	}
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(payload))))
+	h.Write(payload)
+	var id VertexID
+	h.Sum(id[:0])
+	return id
+}
+
+// SetVerifyVertexIDs sets whether AddVertex rejects a vertex whose ID is not
+// DeriveVertexID of its parents and payload. It is off by default, for
+// callers that assign IDs some other way.
+func (d *DAGConsensus) SetVerifyVertexIDs(verify bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.verifyIDs = verify
+}
+
+// VerifyVertexIDs reports whether vertex ID verification is enabled
+func (d *DAGConsensus) VerifyVertexIDs() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.verifyIDs
+}
+
+// checkVertexID rejects a vertex whose ID is not derived from its content
+// when verification is enabled.
+// Must be called with d.mu held
+func (d *DAGConsensus) checkVertexID(v *Vertex) error {
+	if !d.verifyIDs {
+		return nil
+	}
+	if want := DeriveVertexID(v.ParentIDs(), v.Bytes()); v.ID() != want {
+		return fmt.Errorf("%w: got %s, derived %s", ErrVertexIDMismatch, v.ID(), want)
+	}
+	return nil
+}
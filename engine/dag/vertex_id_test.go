@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveVertexID(t *testing.T) {
+	require := require.New(t)
+
+	a, b := ids.GenerateTestID(), ids.GenerateTestID()
+	payload := []byte("tx")
+
+	// Same content, same ID, whatever order the parents are listed in
+	id := DeriveVertexID([]VertexID{a, b}, payload)
+	require.Equal(id, DeriveVertexID([]VertexID{a, b}, payload))
+	require.Equal(id, DeriveVertexID([]VertexID{b, a}, payload))
+
+	// Different content, different ID
+	require.NotEqual(id, DeriveVertexID([]VertexID{a}, payload))
+	require.NotEqual(id, DeriveVertexID([]VertexID{a, b}, []byte("tx2")))
+
+	// A parent cannot be moved into the payload, or the payload into a
+	// parent, without changing the ID
+	require.NotEqual(DeriveVertexID([]VertexID{a}, payload), DeriveVertexID(nil, append(a[:], payload...)))
+	require.NotEqual(DeriveVertexID([]VertexID{a}, nil), DeriveVertexID(nil, a[:]))
+
+	// Sorting does not reorder the caller's slice
+	parents := []VertexID{{2}, {1}}
+	DeriveVertexID(parents, payload)
+	require.Equal([]VertexID{{2}, {1}}, parents)
+}
+
+func TestVerifyVertexIDs(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dc := NewDAGConsensus(1, 1, 1)
+	require.False(dc.VerifyVertexIDs())
+	dc.SetVerifyVertexIDs(true)
+	require.True(dc.VerifyVertexIDs())
+
+	root := NewVertex(DeriveVertexID(nil, []byte("root")), nil, 1, 0, []byte("root"))
+	require.NoError(dc.AddVertex(ctx, root))
+
+	parents := []ids.ID{root.ID()}
+	honest := NewVertex(DeriveVertexID(parents, []byte("tx")), parents, 2, 0, []byte("tx"))
+	require.NoError(dc.AddVertex(ctx, honest))
+
+	// A forged ID is rejected without touching the DAG
+	forged := NewVertex(ids.GenerateTestID(), parents, 2, 0, []byte("tx2"))
+	require.ErrorIs(dc.AddVertex(ctx, forged), ErrVertexIDMismatch)
+	_, exists := dc.GetVertex(forged.ID())
+	require.False(exists)
+
+	// So is a derived ID with a swapped payload
+	swapped := NewVertex(DeriveVertexID(parents, []byte("tx3")), parents, 2, 0, []byte("evil"))
+	require.ErrorIs(dc.AddVertex(ctx, swapped), ErrVertexIDMismatch)
+
+	// With verification off any ID is accepted
+	dc.SetVerifyVertexIDs(false)
+	require.NoError(dc.AddVertex(ctx, forged))
+}
+
+func TestBuildVtxDerivesID(t *testing.T) {
+	require := require.New(t)
+
+	e := New().(*dagEngine)
+	addLayer(t, e.consensus, 1)
+	e.consensus.SetVerifyVertexIDs(true)
+	frontier := e.consensus.Frontier()
+
+	e.QueueData([]byte("tx"))
+	built, err := e.BuildVtx(context.Background())
+	require.NoError(err)
+	require.Equal(DeriveVertexID(frontier, []byte("tx")), built.ID())
+}
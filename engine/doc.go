@@ -7,4 +7,9 @@
 //
 // All engines share the same algorithmic pipeline but differ in their
 // data structures and finality mechanisms.
+//
+// The engines report common failures with the shared errors ErrNotStarted,
+// ErrAlreadyStarted, ErrUnknownVertex, ErrBufferFull and ErrConflict,
+// wrapped with detail, so callers classify them with errors.Is whichever
+// engine returned them.
 package engine
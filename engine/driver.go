@@ -39,6 +39,7 @@ type Driver struct {
 	preference ids.ID
 	decided    map[ids.ID]bool
 	decisions  map[ids.ID]types.Decision
+	final      map[ids.ID]focus.Explanation // focus snapshot of each decided item, without history
 
 	// Confidence tracking
 	consecutiveSuccesses map[ids.ID]uint32
@@ -85,16 +86,28 @@ func NewLuxConsensus(k int, alpha int, beta int, opts ...Option) *Driver {
 		panic("failed to generate FPC seed: " + err.Error())
 	}
 
-	// Create Wave configuration with FPC enabled for dynamic thresholds
+	// Items that split a poll or are marked contested need betaRogue
+	betaRogue := betaU32
+	if o.betaRogue > beta {
+		betaRogue = uint32(o.betaRogue) // #nosec G115 -- o.betaRogue > beta >= 0
+	}
+
+	// Create Wave configuration with FPC enabled for dynamic thresholds.
+	// Wave decides whatever Focus has not, so it waits for betaRogue: a
+	// contested item is never decided there early.
 	waveCfg := wave.Config{
 		K:         k,
 		Alpha:     alphaRatio,
-		Beta:      betaU32,
+		Beta:      betaRogue,
 		RoundTO:   1 * time.Second,
 		EnableFPC: true, // Enable Fast Probabilistic Consensus
 		ThetaMin:  0.5,  // FPC minimum threshold
 		ThetaMax:  0.8,  // FPC maximum threshold
 		FPCSeed:   fpcSeed[:],
+
+		ConcurrentPolls:     o.polls,
+		ConcurrentRepolls:   o.repolls,
+		MaxOutstandingItems: o.maxOutstanding,
 	}
 
 	// Create consensus components
@@ -102,7 +115,7 @@ func NewLuxConsensus(k int, alpha int, beta int, opts ...Option) *Driver {
 	if err != nil {
 		panic("failed to create wave: " + err.Error())
 	}
-	f := focus.NewConfidence[ids.ID](beta, alphaRatio)
+	f := focus.NewDualConfidence[ids.ID](beta, int(betaRogue), alphaRatio)
 
 	return &Driver{
 		k:                    k,
@@ -114,6 +127,7 @@ func NewLuxConsensus(k int, alpha int, beta int, opts ...Option) *Driver {
 		transport:            transport,
 		decided:              make(map[ids.ID]bool),
 		decisions:            make(map[ids.ID]types.Decision),
+		final:                make(map[ids.ID]focus.Explanation),
 		consecutiveSuccesses: make(map[ids.ID]uint32),
 	}
 }
@@ -124,6 +138,11 @@ type Option func(*options)
 type options struct {
 	cut       prism.Cut[ids.ID]
 	transport wave.Transport[ids.ID]
+	betaRogue int
+
+	polls          int
+	repolls        int
+	maxOutstanding int
 }
 
 // WithCut sets the peer sampling strategy.
@@ -136,6 +155,40 @@ func WithTransport(transport wave.Transport[ids.ID]) Option {
 	return func(o *options) { o.transport = transport }
 }
 
+// WithBetaRogue sets the confidence needed to decide a contested item. It
+// defaults to beta, and a value below beta is raised to beta.
+func WithBetaRogue(betaRogue int) Option {
+	return func(o *options) { o.betaRogue = betaRogue }
+}
+
+// WithMaxOutstandingItems caps how many items wave polls undecided at
+// once (0 = no limit); see wave.Config.MaxOutstandingItems
+func WithMaxOutstandingItems(n int) Option {
+	return func(o *options) { o.maxOutstanding = n }
+}
+
+// WithConcurrentPolls caps the polls and re-polls wave keeps in flight
+// (0 = no limit and no re-polling); see wave.Config.ConcurrentPolls and
+// wave.Config.ConcurrentRepolls
+func WithConcurrentPolls(polls, repolls int) Option {
+	return func(o *options) { o.polls, o.repolls = polls, repolls }
+}
+
+// ParamsOptions returns the options that carry p's wave settings into a
+// Driver built with p's K, alpha and beta
+func ParamsOptions(p config.Parameters) []Option {
+	return []Option{
+		WithConcurrentPolls(p.ConcurrentPolls, p.ConcurrentRepolls),
+		WithMaxOutstandingItems(p.MaxOutstandingItems),
+	}
+}
+
+// MarkContested marks items as conflicting with another item, so they need
+// betaRogue rather than beta to be decided
+func (lc *Driver) MarkContested(items ...ids.ID) {
+	lc.focus.MarkContested(items...)
+}
+
 // RecordVote records a vote for an item
 func (lc *Driver) RecordVote(item ids.ID) {
 	lc.mu.Lock()
@@ -157,6 +210,24 @@ func (lc *Driver) Poll(responses map[ids.ID]int) bool {
 
 	ctx := context.Background()
 
+	// Items splitting the same poll conflict with each other
+	var split []ids.ID
+	for item, votes := range responses {
+		if votes > 0 {
+			split = append(split, item)
+		}
+	}
+	if len(split) > 1 {
+		// Decided items are forgotten by focus and stay that way
+		undecided := split[:0]
+		for _, item := range split {
+			if !lc.decided[item] {
+				undecided = append(undecided, item)
+			}
+		}
+		lc.focus.MarkContested(undecided...)
+	}
+
 	for item, votes := range responses {
 		// Skip if already decided
 		if lc.decided[item] {
@@ -189,6 +260,7 @@ func (lc *Driver) Poll(responses map[ids.ID]int) bool {
 			} else {
 				lc.decisions[item] = types.DecideReject
 			}
+			lc.forget(item)
 			return false // Stop polling, decision made
 		}
 
@@ -201,6 +273,7 @@ func (lc *Driver) Poll(responses map[ids.ID]int) bool {
 			if state.Result == types.DecideAccept {
 				lc.preference = item
 			}
+			lc.forget(item)
 			return false // Stop polling, decision made
 		}
 
@@ -214,6 +287,18 @@ func (lc *Driver) Poll(responses map[ids.ID]int) bool {
 	return true
 }
 
+// forget drops a decided item from wave and focus, keeping only a snapshot
+// of its focus counter and threshold. The decision is recorded here; the
+// protocols no longer need it. Caller holds lc.mu.
+func (lc *Driver) forget(item ids.ID) {
+	snapshot := lc.focus.Explain(item)
+	snapshot.Decided = true
+	snapshot.History = nil
+	lc.final[item] = snapshot
+	lc.focus.Forget(item)
+	lc.wave.Forget(item)
+}
+
 // Decided returns whether consensus has been reached
 func (lc *Driver) Decided() bool {
 	lc.mu.RLock()
@@ -230,6 +315,32 @@ func (lc *Driver) Preference() ids.ID {
 	return lc.preference
 }
 
+// Confidence returns the focus confidence accumulated for an item, or the
+// confidence it was decided with
+func (lc *Driver) Confidence(item ids.ID) int {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	if final, ok := lc.final[item]; ok {
+		return final.Confidence
+	}
+	confidence, _ := lc.focus.State(item)
+	return confidence
+}
+
+// Explain returns the focus counter, threshold and per-round confidence
+// history for an item. A decided item's history is dropped once its
+// decision is recorded, so only its final counter and threshold remain.
+func (lc *Driver) Explain(item ids.ID) focus.Explanation {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	if final, ok := lc.final[item]; ok {
+		return final
+	}
+	return lc.focus.Explain(item)
+}
+
 // Decision returns the decision for an item
 func (lc *Driver) Decision(item ids.ID) (types.Decision, bool) {
 	lc.mu.RLock()
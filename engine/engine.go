@@ -5,9 +5,11 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/luxfi/consensus/metrics"
 	"github.com/luxfi/consensus/types"
 	"github.com/luxfi/ids"
 )
@@ -31,6 +33,24 @@ type Engine interface {
 
 	// Stop the consensus engine
 	Stop() error
+
+	// ActiveItems returns the status of every undecided block. It is safe
+	// to call while blocks and votes are being processed.
+	ActiveItems() []ItemStatus
+}
+
+// MetricsProvider is implemented by engines that export Prometheus metrics
+type MetricsProvider interface {
+	Metrics() *metrics.Collector
+}
+
+// MetricsOf returns the engine's metrics collector, or nil if the engine
+// doesn't export metrics
+func MetricsOf(e Engine) *metrics.Collector {
+	if p, ok := e.(MetricsProvider); ok {
+		return p.Metrics()
+	}
+	return nil
 }
 
 // Chain represents a linear blockchain consensus engine
@@ -44,6 +64,7 @@ type Chain struct {
 	blocks map[types.ID]*types.Block
 	votes  map[types.ID][]types.Vote
 	status map[types.ID]types.Status
+	added  map[types.ID]time.Time // when each undecided block was first added
 
 	// Consensus state
 	lastAccepted types.ID
@@ -51,6 +72,33 @@ type Chain struct {
 
 	// Network
 	validators []types.NodeID
+
+	// Committees sampled per round; votes for a round with a registered
+	// committee must carry its commitment and come from a member. Once any
+	// committee is registered, sampled is set and every vote must name a
+	// registered round. A round's committee is evicted once the round
+	// decides a block.
+	committees map[uint64]*roundCommittee
+	sampled    bool
+
+	// verifyVote, if set, checks each vote's signature over its
+	// SigningBytes
+	verifyVote func(voter types.NodeID, msg, sig []byte) bool
+
+	// onAccept is called once per block when it is finalized
+	onAccept func(*types.Block)
+
+	// tracer receives blocks added, votes recorded and blocks finalized
+	tracer EventTracer
+
+	// draining is set once the engine has handed its state to a successor
+	draining bool
+}
+
+// roundCommittee is the committee sampled for a single round
+type roundCommittee struct {
+	commitment types.Hash
+	members    map[types.NodeID]struct{}
 }
 
 // NewChain creates a new chain consensus engine
@@ -60,46 +108,166 @@ func NewChain(config types.Config) *Chain {
 		blocks:       make(map[types.ID]*types.Block),
 		votes:        make(map[types.ID][]types.Vote),
 		status:       make(map[types.ID]types.Status),
+		added:        make(map[types.ID]time.Time),
 		lastAccepted: types.GenesisID,
+		committees:   make(map[uint64]*roundCommittee),
+		tracer:       NopTracer{},
 	}
 }
 
+// SetCommittee registers the committee sampled for a round and returns its
+// commitment. Subsequent votes for that round are rejected unless they carry
+// the same commitment and come from a committee member, and votes for rounds
+// without a registered committee are rejected with types.ErrUnknownRound.
+func (c *Chain) SetCommittee(round uint64, committee []types.NodeID) types.Hash {
+	rc := &roundCommittee{
+		commitment: types.CommitteeCommitment(round, committee),
+		members:    make(map[types.NodeID]struct{}, len(committee)),
+	}
+	for _, id := range committee {
+		rc.members[id] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.committees[round] = rc
+	c.sampled = true
+	return rc.commitment
+}
+
+// SetVoteVerifier registers fn to check each vote's signature over
+// vote.SigningBytes(), which covers its round and committee commitment.
+// Votes failing the check are rejected with types.ErrInvalidVote.
+func (c *Chain) SetVoteVerifier(fn func(voter types.NodeID, msg, sig []byte) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.verifyVote = fn
+}
+
+// OnAccept registers fn to be called once for each block the engine
+// finalizes. fn runs without the engine lock held.
+func (c *Chain) OnAccept(fn func(*types.Block)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onAccept = fn
+}
+
+// SetTracer sets the tracer that receives the chain's consensus
+// transitions: blocks added, votes recorded with their running tally, and
+// blocks finalized. Events carry the vote's round and no wall-clock time,
+// so two chains fed the same calls emit identical traces. A nil tracer
+// restores the no-op default.
+func (c *Chain) SetTracer(t EventTracer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t == nil {
+		t = NopTracer{}
+	}
+	c.tracer = t
+}
+
 // Add adds a new block to the chain
 func (c *Chain) Add(ctx context.Context, block *types.Block) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.draining {
+		return ErrDraining
+	}
+	// Re-adding a finalized block must not reopen its decision
+	if c.status[block.ID] == types.StatusAccepted {
+		return nil
+	}
+
 	// Store the block
 	c.blocks[block.ID] = block
 	c.status[block.ID] = types.StatusProcessing
+	if _, ok := c.added[block.ID]; !ok {
+		c.added[block.ID] = time.Now()
+	}
 
 	// Initialize vote tracking
 	if c.votes[block.ID] == nil {
 		c.votes[block.ID] = []types.Vote{}
 	}
 
+	c.tracer.Trace(Event{
+		Kind:   EventVertexAdded,
+		Item:   block.ID,
+		Height: block.Height,
+	})
 	return nil
 }
 
 // RecordVote records a vote for a block
 func (c *Chain) RecordVote(ctx context.Context, vote *types.Vote) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	accepted, err := c.recordVoteLocked(vote)
+	onAccept := c.onAccept
+	c.mu.Unlock()
+
+	if accepted != nil && onAccept != nil {
+		onAccept(accepted)
+	}
+	return err
+}
+
+// recordVoteLocked records vote and returns the block it finalized, if any.
+// Caller holds c.mu.
+func (c *Chain) recordVoteLocked(vote *types.Vote) (*types.Block, error) {
+	if c.draining {
+		return nil, ErrDraining
+	}
 
 	// Check if block exists
 	if _, exists := c.blocks[vote.BlockID]; !exists {
-		return types.ErrBlockNotFound
+		return nil, types.ErrBlockNotFound
+	}
+
+	// Check the vote against its round's committee once committees are
+	// sampled
+	if c.sampled {
+		rc, ok := c.committees[vote.Round]
+		if !ok {
+			return nil, fmt.Errorf("%w: round %d", types.ErrUnknownRound, vote.Round)
+		}
+		if vote.Committee != rc.commitment {
+			return nil, types.ErrCommitteeMismatch
+		}
+		if _, member := rc.members[vote.Voter]; !member {
+			return nil, types.ErrNotInCommittee
+		}
+	}
+	if c.verifyVote != nil && !c.verifyVote(vote.Voter, vote.SigningBytes(), vote.Signature) {
+		return nil, fmt.Errorf("%w: bad signature from %s", types.ErrInvalidVote, vote.Voter)
 	}
 
 	// Add vote
 	c.votes[vote.BlockID] = append(c.votes[vote.BlockID], *vote)
+	c.tracer.Trace(Event{
+		Kind:  EventVoteRecorded,
+		Round: vote.Round,
+		Item:  vote.BlockID,
+		Yes:   len(c.votes[vote.BlockID]),
+		Total: c.config.Alpha,
+	})
 
 	// Check if we have quorum
 	if len(c.votes[vote.BlockID]) >= c.config.Alpha {
-		c.acceptBlock(vote.BlockID)
+		accepted := c.acceptBlock(vote.BlockID)
+		if accepted != nil {
+			c.evictCommittees(vote.Round)
+			c.tracer.Trace(Event{
+				Kind:   EventFinalized,
+				Round:  vote.Round,
+				Item:   accepted.ID,
+				Height: accepted.Height,
+			})
+		}
+		return accepted, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 // IsAccepted returns whether a block has been accepted
@@ -122,6 +290,27 @@ func (c *Chain) GetStatus(id types.ID) types.Status {
 	return status
 }
 
+// FinalizedHeight returns the height of the last accepted block
+func (c *Chain) FinalizedHeight() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.height
+}
+
+// AcceptedAt returns the accepted block at the given height, if any
+func (c *Chain) AcceptedAt(height uint64) (*types.Block, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for id, block := range c.blocks {
+		if block.Height == height && c.status[id] == types.StatusAccepted {
+			return block, true
+		}
+	}
+	return nil, false
+}
+
 // Start starts the consensus engine
 func (c *Chain) Start(ctx context.Context) error {
 	// Initialize genesis block
@@ -147,14 +336,30 @@ func (c *Chain) Stop() error {
 	return nil
 }
 
-// acceptBlock marks a block as accepted
-func (c *Chain) acceptBlock(id types.ID) {
+// acceptBlock marks a block as accepted and returns it, or nil if it was
+// already accepted
+func (c *Chain) acceptBlock(id types.ID) *types.Block {
+	if c.status[id] == types.StatusAccepted {
+		return nil
+	}
 	c.status[id] = types.StatusAccepted
+	delete(c.added, id)
+
+	block, exists := c.blocks[id]
+	if exists && block.Height > c.height {
+		c.height = block.Height
+		c.lastAccepted = id
+	}
+	return block
+}
 
-	if block, exists := c.blocks[id]; exists {
-		if block.Height > c.height {
-			c.height = block.Height
-			c.lastAccepted = id
+// evictCommittees drops the committees of round and every earlier round,
+// which are decided; late votes for them fail as unknown rounds.
+// Caller holds c.mu.
+func (c *Chain) evictCommittees(round uint64) {
+	for r := range c.committees {
+		if r <= round {
+			delete(c.committees, r)
 		}
 	}
 }
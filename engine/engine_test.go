@@ -5,6 +5,7 @@ package engine
 
 import (
 	"context"
+	"crypto/ed25519"
 	"testing"
 	"time"
 
@@ -149,6 +150,111 @@ func TestChainRecordVoteBlockNotFound(t *testing.T) {
 	require.Equal(types.ErrBlockNotFound, err)
 }
 
+// TestChainRecordVoteCommittee tests that votes are checked against the
+// committee sampled for their round
+func TestChainRecordVoteCommittee(t *testing.T) {
+	require := require.New(t)
+
+	config := types.Config{Alpha: 2, K: 2}
+	chain := NewChain(config)
+
+	block := &types.Block{
+		ID:       ids.GenerateTestID(),
+		ParentID: types.GenesisID,
+		Height:   1,
+		Time:     time.Now(),
+	}
+	require.NoError(chain.Add(context.Background(), block))
+
+	member := ids.GenerateTestNodeID()
+	committee := []types.NodeID{member, ids.GenerateTestNodeID()}
+	commitment := chain.SetCommittee(7, committee)
+	require.Equal(types.CommitteeCommitment(7, []types.NodeID{committee[1], committee[0]}), commitment)
+
+	// A voter outside the committee is rejected even with the right commitment
+	outsider := &types.Vote{
+		BlockID:   block.ID,
+		VoteType:  types.VotePreference,
+		Voter:     ids.GenerateTestNodeID(),
+		Round:     7,
+		Committee: commitment,
+	}
+	require.ErrorIs(chain.RecordVote(context.Background(), outsider), types.ErrNotInCommittee)
+
+	// A member claiming a different committee is rejected
+	forged := &types.Vote{
+		BlockID:   block.ID,
+		VoteType:  types.VotePreference,
+		Voter:     member,
+		Round:     7,
+		Committee: types.CommitteeCommitment(7, []types.NodeID{member, outsider.Voter}),
+	}
+	require.ErrorIs(chain.RecordVote(context.Background(), forged), types.ErrCommitteeMismatch)
+
+	// A member with the right commitment is counted
+	honest := &types.Vote{
+		BlockID:   block.ID,
+		VoteType:  types.VotePreference,
+		Voter:     member,
+		Round:     7,
+		Committee: commitment,
+	}
+	require.NoError(chain.RecordVote(context.Background(), honest))
+
+	chain.mu.RLock()
+	require.Len(chain.votes[block.ID], 1)
+	chain.mu.RUnlock()
+	require.False(chain.IsAccepted(block.ID))
+}
+
+// TestChainRecordVoteRounds tests that once committees are sampled, votes for
+// unregistered or decided rounds are rejected and signatures cover the round
+func TestChainRecordVoteRounds(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	chain := NewChain(types.Config{Alpha: 1, K: 1})
+	b1 := &types.Block{ID: ids.GenerateTestID(), ParentID: types.GenesisID, Height: 1, Time: time.Now()}
+	b2 := &types.Block{ID: ids.GenerateTestID(), ParentID: b1.ID, Height: 2, Time: time.Now()}
+	require.NoError(chain.Add(ctx, b1))
+	require.NoError(chain.Add(ctx, b2))
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(err)
+	member := ids.GenerateTestNodeID()
+	chain.SetVoteVerifier(func(voter types.NodeID, msg, sig []byte) bool {
+		return voter == member && ed25519.Verify(pub, msg, sig)
+	})
+	sign := func(v *types.Vote) *types.Vote {
+		v.Signature = ed25519.Sign(priv, v.SigningBytes())
+		return v
+	}
+
+	c1 := chain.SetCommittee(1, []types.NodeID{member})
+	c2 := chain.SetCommittee(2, []types.NodeID{member})
+
+	// A round without a committee fails closed
+	unknown := sign(&types.Vote{BlockID: b1.ID, Voter: member, Round: 3, Committee: c1})
+	require.ErrorIs(chain.RecordVote(ctx, unknown), types.ErrUnknownRound)
+
+	// A round-1 signature replayed into round 2 does not verify
+	replayed := sign(&types.Vote{BlockID: b1.ID, Voter: member, Round: 1, Committee: c1})
+	replayed.Round, replayed.Committee = 2, c2
+	require.ErrorIs(chain.RecordVote(ctx, replayed), types.ErrInvalidVote)
+
+	// Deciding round 1 evicts its committee, so late votes for it fail
+	require.NoError(chain.RecordVote(ctx, sign(&types.Vote{BlockID: b1.ID, Voter: member, Round: 1, Committee: c1})))
+	require.True(chain.IsAccepted(b1.ID))
+	late := sign(&types.Vote{BlockID: b2.ID, Voter: member, Round: 1, Committee: c1})
+	require.ErrorIs(chain.RecordVote(ctx, late), types.ErrUnknownRound)
+
+	require.NoError(chain.RecordVote(ctx, sign(&types.Vote{BlockID: b2.ID, Voter: member, Round: 2, Committee: c2})))
+	require.True(chain.IsAccepted(b2.ID))
+	chain.mu.RLock()
+	require.Empty(chain.committees)
+	chain.mu.RUnlock()
+}
+
 // TestChainIsAccepted tests the IsAccepted method
 func TestChainIsAccepted(t *testing.T) {
 	require := require.New(t)
@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import "errors"
+
+// Engine errors shared by the chain, dag and pq engines. Engines wrap them
+// with the failing block or vertex, so match them with errors.Is.
+var (
+	// ErrNotStarted is returned by operations that need a running engine
+	ErrNotStarted = errors.New("engine not started")
+
+	// ErrAlreadyStarted is returned by Start on an engine that is running
+	ErrAlreadyStarted = errors.New("engine already started")
+
+	// ErrUnknownVertex is returned for a block or vertex the engine does
+	// not track, including a missing parent or ancestor
+	ErrUnknownVertex = errors.New("unknown vertex")
+
+	// ErrBufferFull is returned when an engine's intake is full; the caller
+	// should back off and resubmit
+	ErrBufferFull = errors.New("buffer full, backpressure applied")
+
+	// ErrConflict is returned for a block or vertex that conflicts with one
+	// the engine already holds or has finalized
+	ErrConflict = errors.New("conflict")
+)
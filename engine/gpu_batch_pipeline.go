@@ -33,7 +33,6 @@ var (
 	ErrBatchTooLarge      = errors.New("batch exceeds maximum size")
 	ErrGPUUnavailable     = errors.New("GPU unavailable, using CPU fallback")
 	ErrInvalidTransaction = errors.New("invalid transaction in batch")
-	ErrBufferFull         = errors.New("buffer full, backpressure applied")
 )
 
 // SignatureType identifies cryptographic signature algorithms.
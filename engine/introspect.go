@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/luxfi/consensus/types"
+)
+
+// ItemStatus is the progress of a block the engine has not decided yet
+type ItemStatus struct {
+	ID     types.ID
+	Height uint64
+
+	// Preference is the block the engine currently prefers among the
+	// undecided blocks at this height: the one with the most votes, ties
+	// broken by lower ID
+	Preference types.ID
+
+	// Confidence is how many votes the block has, of the Alpha it needs
+	Confidence int
+
+	// Rounds is how many distinct polling rounds those votes were cast in
+	Rounds int
+
+	// Age is how long ago the block was first added
+	Age time.Duration
+}
+
+// ActiveItems implements Engine. Items are ordered by height, then ID.
+func (c *Chain) ActiveItems() []ItemStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now()
+	var items []ItemStatus
+	preferred := make(map[uint64]types.ID)
+	for id, block := range c.blocks {
+		if c.status[id] != types.StatusProcessing {
+			continue
+		}
+
+		rounds := make(map[uint64]struct{})
+		for _, vote := range c.votes[id] {
+			rounds[vote.Round] = struct{}{}
+		}
+		items = append(items, ItemStatus{
+			ID:         id,
+			Height:     block.Height,
+			Confidence: len(c.votes[id]),
+			Rounds:     len(rounds),
+			Age:        now.Sub(c.added[id]),
+		})
+
+		if best, ok := preferred[block.Height]; !ok || c.prefers(id, best) {
+			preferred[block.Height] = id
+		}
+	}
+
+	slices.SortFunc(items, func(a, b ItemStatus) int {
+		return cmp.Or(cmp.Compare(a.Height, b.Height), a.ID.Compare(b.ID))
+	})
+	for i := range items {
+		items[i].Preference = preferred[items[i].Height]
+	}
+	return items
+}
+
+// prefers reports whether block a is preferred over block b
+// Caller holds c.mu.
+func (c *Chain) prefers(a, b types.ID) bool {
+	if va, vb := len(c.votes[a]), len(c.votes[b]); va != vb {
+		return va > vb
+	}
+	return a.Compare(b) < 0
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/types"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainActiveItems(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	chain := NewChain(types.Config{Alpha: 3, K: 5})
+	require.NoError(chain.Start(ctx))
+	require.Empty(chain.ActiveItems())
+
+	// a and b compete at height 1; c is at height 2
+	a := &types.Block{ID: ids.GenerateTestID(), ParentID: types.GenesisID, Height: 1}
+	b := &types.Block{ID: ids.GenerateTestID(), ParentID: types.GenesisID, Height: 1}
+	c := &types.Block{ID: ids.GenerateTestID(), ParentID: a.ID, Height: 2}
+	for _, block := range []*types.Block{a, b, c} {
+		require.NoError(chain.Add(ctx, block))
+	}
+
+	vote := func(block *types.Block, round uint64) {
+		require.NoError(chain.RecordVote(ctx, &types.Vote{
+			BlockID: block.ID,
+			Voter:   ids.GenerateTestNodeID(),
+			Round:   round,
+		}))
+	}
+	vote(b, 1)
+	vote(b, 2)
+	vote(a, 1)
+
+	items := chain.ActiveItems()
+	require.Len(items, 3)
+	byID := make(map[types.ID]ItemStatus)
+	for _, item := range items {
+		byID[item.ID] = item
+		require.GreaterOrEqual(item.Age, time.Duration(0))
+	}
+	require.Equal(uint64(2), items[2].Height, "items are ordered by height")
+
+	require.Equal(2, byID[b.ID].Confidence)
+	require.Equal(2, byID[b.ID].Rounds)
+	require.Equal(1, byID[a.ID].Confidence)
+	require.Equal(1, byID[a.ID].Rounds)
+	require.Zero(byID[c.ID].Confidence)
+	require.Zero(byID[c.ID].Rounds)
+
+	// b leads at height 1, and c is alone at height 2
+	require.Equal(b.ID, byID[a.ID].Preference)
+	require.Equal(b.ID, byID[b.ID].Preference)
+	require.Equal(c.ID, byID[c.ID].Preference)
+
+	// Finalizing b drops it, and a is the only block left at height 1
+	vote(b, 3)
+	require.True(chain.IsAccepted(b.ID))
+	require.NotContains(chain.added, b.ID)
+	items = chain.ActiveItems()
+	require.Len(items, 2)
+	for _, item := range items {
+		require.NotEqual(b.ID, item.ID)
+		require.Equal(item.ID, item.Preference)
+	}
+
+	// Re-adding an undecided block keeps its age
+	before := chain.ActiveItems()[0].Age
+	require.NoError(chain.Add(ctx, a))
+	require.GreaterOrEqual(chain.ActiveItems()[0].Age, before)
+}
+
+func TestChainActiveItemsConcurrent(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	chain := NewChain(types.Config{Alpha: 2, K: 3})
+	require.NoError(chain.Start(ctx))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			block := &types.Block{ID: ids.GenerateTestID(), ParentID: types.GenesisID, Height: uint64(i + 1)}
+			_ = chain.Add(ctx, block)
+			_ = chain.RecordVote(ctx, &types.Vote{BlockID: block.ID, Voter: ids.GenerateTestNodeID()})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			for _, item := range chain.ActiveItems() {
+				_ = item.Preference
+			}
+		}
+	}()
+	wg.Wait()
+
+	require.Len(chain.ActiveItems(), 200)
+}
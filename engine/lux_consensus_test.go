@@ -7,7 +7,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/core/types"
 	"github.com/luxfi/consensus/protocol/prism"
+	"github.com/luxfi/consensus/protocol/wave"
 	"github.com/luxfi/ids"
 	"github.com/stretchr/testify/require"
 )
@@ -145,6 +148,70 @@ func TestLuxConsensusPollMultipleItems(t *testing.T) {
 	require.NotNil(lc)
 }
 
+// TestLuxConsensusBetaRogue tests that contested items need betaRogue
+// successes while uncontested items decide at beta
+func TestLuxConsensusBetaRogue(t *testing.T) {
+	require := require.New(t)
+
+	lc := NewLuxConsensus(5, 4, 1, WithBetaRogue(3))
+	virtuous := ids.GenerateTestID()
+	require.False(lc.Poll(map[ids.ID]int{virtuous: 5}))
+	decision, ok := lc.Decision(virtuous)
+	require.True(ok)
+	require.Equal(types.DecideAccept, decision)
+
+	// Items splitting a poll conflict
+	winner, loser := ids.GenerateTestID(), ids.GenerateTestID()
+	require.True(lc.Poll(map[ids.ID]int{winner: 4, loser: 1}))
+	require.True(lc.Explain(winner).Contested)
+	require.True(lc.Explain(loser).Contested)
+	require.Equal(3, lc.Explain(winner).Threshold)
+
+	// Callers that know of a conflict mark it directly
+	marked := ids.GenerateTestID()
+	lc.MarkContested(marked)
+	require.True(lc.Poll(map[ids.ID]int{marked: 5}))
+	require.True(lc.Poll(map[ids.ID]int{marked: 5}))
+	require.False(lc.Poll(map[ids.ID]int{marked: 5}))
+	decision, ok = lc.Decision(marked)
+	require.True(ok)
+	require.Equal(types.DecideAccept, decision)
+}
+
+// TestLuxConsensusMaxOutstandingItems tests that the parameters' limit
+// reaches wave and that decided items are evicted from it
+func TestLuxConsensusMaxOutstandingItems(t *testing.T) {
+	require := require.New(t)
+
+	lc := NewLuxConsensus(5, 4, 3, ParamsOptions(config.Parameters{MaxOutstandingItems: 1})...)
+
+	item := ids.GenerateTestID()
+	require.True(lc.Poll(map[ids.ID]int{item: 5}))
+	require.Equal(1, lc.wave.Outstanding())
+	require.ErrorIs(lc.wave.Initialize(ids.GenerateTestID()), wave.ErrTooManyOutstanding)
+
+	require.True(lc.Poll(map[ids.ID]int{item: 5}))
+	require.False(lc.Poll(map[ids.ID]int{item: 5}))
+	decision, ok := lc.Decision(item)
+	require.True(ok)
+	require.Equal(types.DecideAccept, decision)
+
+	_, ok = lc.wave.State(item)
+	require.False(ok)
+	require.Zero(lc.wave.Outstanding())
+}
+
+// TestLuxConsensusConcurrentPolls tests that the parameters' poll limits
+// reach wave
+func TestLuxConsensusConcurrentPolls(t *testing.T) {
+	require := require.New(t)
+
+	lc := NewLuxConsensus(5, 4, 3, ParamsOptions(config.Parameters{ConcurrentPolls: 8, ConcurrentRepolls: 2})...)
+	cfg := lc.wave.Config()
+	require.Equal(8, cfg.ConcurrentPolls)
+	require.Equal(2, cfg.ConcurrentRepolls)
+}
+
 // TestLuxConsensusDecided tests the Decided method
 func TestLuxConsensusDecided(t *testing.T) {
 	require := require.New(t)
@@ -499,3 +566,34 @@ func TestLuxConsensusPollFocusDecideReject(t *testing.T) {
 	require.NotNil(lc)
 	_ = continuePolling
 }
+
+// TestLuxConsensusForgetsDecidedItems tests that a decided item's focus
+// state is dropped while its decision and final confidence remain
+func TestLuxConsensusForgetsDecidedItems(t *testing.T) {
+	require := require.New(t)
+
+	lc := NewLuxConsensus(5, 4, 2)
+	item := ids.GenerateTestID()
+	require.True(lc.Poll(map[ids.ID]int{item: 5}))
+	require.False(lc.Poll(map[ids.ID]int{item: 5}))
+
+	decision, ok := lc.Decision(item)
+	require.True(ok)
+	require.Equal(types.DecideAccept, decision)
+	require.Empty(lc.focus.ConfidenceHistory(item))
+	state, decided := lc.focus.State(item)
+	require.Zero(state)
+	require.False(decided)
+
+	require.Equal(2, lc.Confidence(item))
+	explanation := lc.Explain(item)
+	require.True(explanation.Decided)
+	require.Equal(2, explanation.Confidence)
+	require.Nil(explanation.History)
+
+	// Later polls naming the decided item neither revive nor contest it
+	other := ids.GenerateTestID()
+	require.True(lc.Poll(map[ids.ID]int{item: 4, other: 1}))
+	require.False(lc.focus.Contested(item))
+	require.Empty(lc.focus.ConfidenceHistory(item))
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/luxfi/consensus/types"
+)
+
+// ErrDraining is returned by an engine that has handed its state to a
+// successor and no longer accepts blocks or votes
+var ErrDraining = errors.New("engine is draining")
+
+// Handoff is the state a draining engine passes to its successor
+type Handoff struct {
+	// FinalizedHeight is the highest accepted height; the successor never
+	// reports a lower one
+	FinalizedHeight uint64
+
+	// Preference is the block the engine last finalized and builds on
+	Preference types.ID
+
+	// Accepted holds every finalized block, so the successor treats them
+	// as decided and never finalizes them again
+	Accepted []*types.Block
+
+	// Processing holds the undecided blocks in height order, to be
+	// re-submitted to the successor
+	Processing []*types.Block
+}
+
+// Swappable is implemented by engines that can be hot-swapped for another
+// engine without losing decisions
+type Swappable interface {
+	// Drain stops the engine accepting blocks and votes and returns its
+	// state. Subsequent Add and RecordVote calls return ErrDraining.
+	Drain() Handoff
+
+	// Resume adopts the decided state of a drained predecessor. It never
+	// lowers the engine's finalized height.
+	Resume(h Handoff) error
+
+	// Reopen undoes Drain after a failed hand-over, so the engine accepts
+	// blocks and votes again
+	Reopen()
+}
+
+var _ Swappable = (*Chain)(nil)
+
+// Drain implements Swappable
+func (c *Chain) Drain() Handoff {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.draining = true
+
+	h := Handoff{
+		FinalizedHeight: c.height,
+		Preference:      c.lastAccepted,
+	}
+	for id, block := range c.blocks {
+		switch c.status[id] {
+		case types.StatusAccepted:
+			h.Accepted = append(h.Accepted, block)
+		case types.StatusProcessing:
+			h.Processing = append(h.Processing, block)
+		}
+	}
+	sort.Slice(h.Accepted, func(i, j int) bool {
+		return h.Accepted[i].Height < h.Accepted[j].Height
+	})
+	sort.Slice(h.Processing, func(i, j int) bool {
+		return h.Processing[i].Height < h.Processing[j].Height
+	})
+	return h
+}
+
+// Reopen implements Swappable
+func (c *Chain) Reopen() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draining = false
+}
+
+// Resume implements Swappable. Accepted blocks are recorded as decided
+// without invoking the OnAccept callback; they were reported by the
+// predecessor.
+func (c *Chain) Resume(h Handoff) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.draining {
+		return ErrDraining
+	}
+
+	for _, block := range h.Accepted {
+		c.blocks[block.ID] = block
+		c.status[block.ID] = types.StatusAccepted
+		delete(c.votes, block.ID)
+		delete(c.added, block.ID)
+	}
+	if h.FinalizedHeight > c.height {
+		c.height = h.FinalizedHeight
+		c.lastAccepted = h.Preference
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/luxfi/ids"
+)
+
+// EventKind names a consensus transition reported to an EventTracer
+type EventKind string
+
+const (
+	EventVertexAdded       EventKind = "vertex_added"
+	EventPollIssued        EventKind = "poll_issued"
+	EventVoteRecorded      EventKind = "vote_recorded"
+	EventPreferenceChanged EventKind = "preference_changed"
+	EventFinalized         EventKind = "finalized"
+)
+
+// Event is one consensus transition. It carries no wall-clock time, so two
+// nodes fed the same inputs produce identical events and the first
+// differing event pinpoints where they diverged.
+type Event struct {
+	Kind  EventKind `json:"kind"`
+	Round uint64    `json:"round"` // poll the event happened in (0 = before the first)
+	Item  ids.ID    `json:"item"`
+
+	// Height is the item's height (vertex_added) or its position in the
+	// finalized order (finalized)
+	Height uint64 `json:"height,omitempty"`
+
+	// Yes and Total are the tally behind a poll or vote
+	Yes   int `json:"yes,omitempty"`
+	Total int `json:"total,omitempty"`
+
+	// Confidence is the item's confidence after a preference change
+	Confidence int `json:"confidence,omitempty"`
+}
+
+// EventTracer receives consensus transitions in the order an engine makes
+// them. Engines call it while holding their own locks, so an implementation
+// must not call back into the engine.
+type EventTracer interface {
+	Trace(Event)
+}
+
+// NopTracer discards every event; engines use it when no tracer is set
+type NopTracer struct{}
+
+// Trace does nothing
+func (NopTracer) Trace(Event) {}
+
+// JSONLTracer writes each event as one line of JSON
+type JSONLTracer struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+	err    error
+}
+
+// NewJSONLTracer returns a tracer writing to w
+func NewJSONLTracer(w io.Writer) *JSONLTracer {
+	return &JSONLTracer{enc: json.NewEncoder(w)}
+}
+
+// NewJSONLFileTracer creates or truncates the file at path and returns a
+// tracer writing to it; Close closes the file
+func NewJSONLFileTracer(path string) (*JSONLTracer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	t := NewJSONLTracer(f)
+	t.closer = f
+	return t, nil
+}
+
+// Trace writes ev. After the first write error further events are dropped;
+// Err reports it.
+func (t *JSONLTracer) Trace(ev Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == nil {
+		t.err = t.enc.Encode(ev)
+	}
+}
+
+// Err returns the first write error
+func (t *JSONLTracer) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// Close closes the trace file of a tracer made by NewJSONLFileTracer and
+// returns the first write error, if any
+func (t *JSONLTracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closer != nil {
+		if err := t.closer.Close(); err != nil && t.err == nil {
+			t.err = err
+		}
+		t.closer = nil
+	}
+	return t.err
+}
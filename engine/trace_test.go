@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/types"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLFileTracer(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	tracer, err := NewJSONLFileTracer(path)
+	require.NoError(err)
+
+	events := []Event{
+		{Kind: EventVertexAdded, Item: ids.ID{1}, Height: 1},
+		{Kind: EventPollIssued, Round: 1, Item: ids.ID{1}, Yes: 3, Total: 4},
+		{Kind: EventFinalized, Round: 1, Item: ids.ID{1}},
+	}
+	for _, ev := range events {
+		tracer.Trace(ev)
+	}
+	require.NoError(tracer.Close())
+
+	f, err := os.Open(path)
+	require.NoError(err)
+	defer f.Close()
+
+	var got []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev Event
+		require.NoError(json.Unmarshal(scanner.Bytes(), &ev))
+		got = append(got, ev)
+	}
+	require.NoError(scanner.Err())
+	require.Equal(events, got)
+}
+
+func TestChainTraceIsDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	block1 := &types.Block{ID: ids.ID{1}, ParentID: types.GenesisID, Height: 1, Time: time.Now()}
+	block2 := &types.Block{ID: ids.ID{2}, ParentID: block1.ID, Height: 2, Time: time.Now()}
+	voters := []ids.NodeID{{1}, {2}}
+
+	run := func() []byte {
+		var buf bytes.Buffer
+		tracer := NewJSONLTracer(&buf)
+		chain := NewChain(types.Config{Alpha: 2, K: 3})
+		chain.SetTracer(tracer)
+
+		ctx := context.Background()
+		for _, block := range []*types.Block{block1, block2} {
+			require.NoError(chain.Add(ctx, block))
+			for _, voter := range voters {
+				require.NoError(chain.RecordVote(ctx, &types.Vote{
+					BlockID:  block.ID,
+					VoteType: types.VotePreference,
+					Voter:    voter,
+					Round:    block.Height,
+				}))
+			}
+		}
+		require.NoError(tracer.Err())
+		return buf.Bytes()
+	}
+
+	first := run()
+	require.Equal(first, run())
+
+	var kinds []EventKind
+	scanner := bufio.NewScanner(bytes.NewReader(first))
+	for scanner.Scan() {
+		var ev Event
+		require.NoError(json.Unmarshal(scanner.Bytes(), &ev))
+		kinds = append(kinds, ev.Kind)
+	}
+	require.Equal([]EventKind{
+		EventVertexAdded, EventVoteRecorded, EventVoteRecorded, EventFinalized,
+		EventVertexAdded, EventVoteRecorded, EventVoteRecorded, EventFinalized,
+	}, kinds)
+}
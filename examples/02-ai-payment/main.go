@@ -170,9 +170,14 @@ func validatePayment(ctx context.Context, agent *ai.Agent[ai.TransactionData], p
 		},
 	}
 
-	// AI makes decision
-	decision, err := agent.ProposeDecision(ctx, txData, map[string]interface{}{
+	// AI makes decision; a model that stalls sends the payment to review
+	decision, err := agent.ProposeDecisionWithPolicy(ctx, txData, map[string]interface{}{
 		"type": "payment_validation",
+	}, ai.RetryPolicy{
+		Timeout:    5 * time.Second,
+		MaxRetries: 2,
+		Backoff:    100 * time.Millisecond,
+		Default:    ai.DefaultReview,
 	})
 
 	if err != nil {
@@ -68,6 +68,11 @@ const (
 	MsgTypeToolResult   uint16 = 21 // Tool result
 )
 
+// SendQueueSize bounds the frames queued per peer. A peer whose queue is
+// full when a broadcast reaches it has fallen too far behind and is
+// disconnected, so it never delays delivery to other peers.
+const SendQueueSize = 64
+
 // Capability represents an agent's exposed tool/capability
 type Capability struct {
 	Name        string
@@ -146,6 +151,16 @@ type AgentConn struct {
 	conn   net.Conn
 	caps   []Capability
 	mu     sync.Mutex
+
+	sendq     chan outFrame // drained by writeLoop
+	done      chan struct{} // closed when the connection is dropped
+	closeOnce sync.Once
+}
+
+// outFrame is a queued frame; it is skipped if ctx is done before it is written
+type outFrame struct {
+	ctx  context.Context
+	data []byte
 }
 
 // NewAgent creates a new ZAP-enabled agent
@@ -229,18 +244,13 @@ func (a *Agent) handleConn(netConn net.Conn, initiator bool) {
 		return
 	}
 
-	ac := &AgentConn{nodeID: peerID, conn: netConn}
-	a.connsMu.Lock()
-	a.conns[peerID] = ac
-	a.connsMu.Unlock()
+	ac := a.addConn(peerID, netConn)
 
 	// Exchange capabilities
 	a.sendCapabilities(ac)
 
 	defer func() {
-		a.connsMu.Lock()
-		delete(a.conns, peerID)
-		a.connsMu.Unlock()
+		a.removeConn(ac)
 		a.capsMu.Lock()
 		delete(a.peerCaps, peerID)
 		a.capsMu.Unlock()
@@ -265,6 +275,63 @@ func (a *Agent) handleConn(netConn net.Conn, initiator bool) {
 	}
 }
 
+// addConn registers a connection to peerID and starts its writer
+func (a *Agent) addConn(peerID string, netConn net.Conn) *AgentConn {
+	ac := &AgentConn{
+		nodeID: peerID,
+		conn:   netConn,
+		sendq:  make(chan outFrame, SendQueueSize),
+		done:   make(chan struct{}),
+	}
+	a.connsMu.Lock()
+	a.conns[peerID] = ac
+	a.connsMu.Unlock()
+
+	a.wg.Add(1)
+	go a.writeLoop(ac)
+	return ac
+}
+
+// removeConn unregisters ac, stops its writer and closes the connection,
+// which also ends its read loop. It is safe to call more than once.
+func (a *Agent) removeConn(ac *AgentConn) {
+	a.connsMu.Lock()
+	if a.conns[ac.nodeID] == ac {
+		delete(a.conns, ac.nodeID)
+	}
+	a.connsMu.Unlock()
+	ac.closeOnce.Do(func() {
+		close(ac.done)
+		ac.conn.Close()
+	})
+}
+
+// writeLoop writes queued frames to one peer, so a slow peer only holds up
+// its own queue. Frames whose context was cancelled while queued are dropped.
+func (a *Agent) writeLoop(ac *AgentConn) {
+	defer a.wg.Done()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ac.done:
+			return
+		case f := <-ac.sendq:
+			if f.ctx.Err() != nil {
+				continue
+			}
+			ac.mu.Lock()
+			err := a.writeFrame(ac.conn, f.data)
+			ac.mu.Unlock()
+			if err != nil {
+				a.logger.Debug("Write failed", "peer", ac.nodeID, "error", err)
+				a.removeConn(ac)
+				return
+			}
+		}
+	}
+}
+
 // ConnectTo establishes connection to another agent
 func (a *Agent) ConnectTo(addr string) error {
 	netConn, err := net.DialTimeout("tcp", addr, 5*time.Second)
@@ -572,7 +639,7 @@ func (a *Agent) broadcastResponse(queryID uint64, response string) {
 	binary.LittleEndian.PutUint32(buf[28:32], uint32(len(respBytes)))
 	copy(buf[32:], respBytes)
 
-	a.broadcast(buf[:32+len(respBytes)])
+	a.broadcast(a.ctx, buf[:32+len(respBytes)])
 
 	// Store own response
 	a.stateMu.Lock()
@@ -613,7 +680,7 @@ func (a *Agent) castVote(queryID uint64) {
 	binary.LittleEndian.PutUint32(buf[24:28], uint32(a.config.ID))
 	binary.LittleEndian.PutUint32(buf[28:32], uint32(bestAgent))
 
-	a.broadcast(buf[:32])
+	a.broadcast(a.ctx, buf[:32])
 
 	// Record own vote
 	a.stateMu.Lock()
@@ -692,7 +759,7 @@ func (a *Agent) synthesizeConsensus(queryID uint64) {
 	binary.LittleEndian.PutUint32(buf[24:28], uint32(len(synthBytes)))
 	copy(buf[28:], synthBytes)
 
-	a.broadcast(buf[:28+len(synthBytes)])
+	a.broadcast(a.ctx, buf[:28+len(synthBytes)])
 }
 
 // BroadcastQuery sends a query to all connected agents
@@ -708,10 +775,13 @@ func (a *Agent) BroadcastQuery(queryID uint64, query string) {
 	binary.LittleEndian.PutUint32(buf[24:28], uint32(len(queryBytes)))
 	copy(buf[28:], queryBytes)
 
-	a.broadcast(buf[:28+len(queryBytes)])
+	a.broadcast(a.ctx, buf[:28+len(queryBytes)])
 }
 
-func (a *Agent) broadcast(data []byte) {
+// broadcast queues data for every connected peer without blocking. A peer
+// whose queue is full is disconnected rather than waited on. Frames still
+// queued when ctx is cancelled are never written.
+func (a *Agent) broadcast(ctx context.Context, data []byte) {
 	a.connsMu.RLock()
 	conns := make([]*AgentConn, 0, len(a.conns))
 	for _, c := range a.conns {
@@ -719,10 +789,14 @@ func (a *Agent) broadcast(data []byte) {
 	}
 	a.connsMu.RUnlock()
 
+	f := outFrame{ctx: ctx, data: data}
 	for _, c := range conns {
-		c.mu.Lock()
-		a.writeFrame(c.conn, data)
-		c.mu.Unlock()
+		select {
+		case c.sendq <- f:
+		default:
+			a.logger.Warn("Send queue full, disconnecting slow peer", "peer", c.nodeID)
+			a.removeConn(c)
+		}
 	}
 }
 
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAgent(t *testing.T) *Agent {
+	a := NewAgent(DefaultAgents[0], 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(a.Stop)
+	return a
+}
+
+// pipePeer registers an in-memory peer and returns the far end of its
+// connection. Nothing is read from it unless the test does so.
+func pipePeer(a *Agent, id string) net.Conn {
+	local, remote := net.Pipe()
+	a.addConn(id, local)
+	return remote
+}
+
+// readFrames reads frames from conn onto a channel until it fails
+func readFrames(a *Agent, conn net.Conn) <-chan []byte {
+	frames := make(chan []byte, 2*SendQueueSize)
+	go func() {
+		defer close(frames)
+		for {
+			f, err := a.readFrame(conn)
+			if err != nil {
+				return
+			}
+			frames <- f
+		}
+	}()
+	return frames
+}
+
+func TestBroadcastDisconnectsSlowPeer(t *testing.T) {
+	require := require.New(t)
+	a := newTestAgent(t)
+
+	slow := pipePeer(a, "slow") // never read
+	fast := readFrames(a, pipePeer(a, "fast"))
+
+	// At most one frame is held by the slow peer's writer and SendQueueSize
+	// fill its queue; a later broadcast finds it full and drops the peer
+	// rather than blocking, while the fast peer receives every frame.
+	for i := 0; i < SendQueueSize+2; i++ {
+		broadcastDone := make(chan struct{})
+		go func() {
+			defer close(broadcastDone)
+			a.broadcast(context.Background(), []byte{byte(i)})
+		}()
+		select {
+		case <-broadcastDone:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("broadcast %d blocked on the slow peer", i)
+		}
+		select {
+		case f := <-fast:
+			require.Equal([]byte{byte(i)}, f)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("fast peer stalled after %d frames", i)
+		}
+	}
+
+	a.connsMu.RLock()
+	_, connected := a.conns["slow"]
+	_, fastConnected := a.conns["fast"]
+	a.connsMu.RUnlock()
+	require.False(connected)
+	require.True(fastConnected)
+
+	// The slow peer's connection is closed
+	_, err := slow.Read(make([]byte, 1))
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestBroadcastCancelAbortsPendingSends(t *testing.T) {
+	require := require.New(t)
+	a := newTestAgent(t)
+	slow := pipePeer(a, "slow")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 1; i <= 5; i++ {
+		a.broadcast(ctx, []byte{byte(i)})
+	}
+	cancel()
+	a.broadcast(context.Background(), []byte("after"))
+
+	// At most the frame already being written when ctx was cancelled gets
+	// through; the rest of the cancelled frames are dropped.
+	frames := readFrames(a, slow)
+	first := <-frames
+	if string(first) != "after" {
+		require.Equal([]byte{1}, first)
+		require.Equal([]byte("after"), <-frames)
+	}
+}
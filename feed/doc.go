@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package feed serves finalized consensus decisions as a server stream.
+//
+// DecisionStream is the DecisionStream service: a client subscribes with a
+// starting height and receives every finalized block or vertex from that
+// height on, first replayed from retained history and then live as the
+// engine finalizes them. A client that reconnects passes the height after
+// the last decision it saw and misses nothing still retained.
+//
+// Service serves a DecisionStream as the gRPC DecisionStream service
+// defined in feedpb/decision.proto:
+//
+//	stream := feed.NewDecisionStream(feed.StreamConfig{})
+//	go stream.Run(ctx, engine.Finalized())
+//	feed.NewService(stream).Register(grpcServer)
+//
+// The engine never blocks on subscribers: a subscriber that falls further
+// behind than the retained history is ended with ErrSlowSubscriber and
+// resumes by resubscribing.
+package feed
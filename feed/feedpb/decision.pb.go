@@ -0,0 +1,317 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: feed/feedpb/decision.proto
+
+package feedpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SubscribeRequest opens a subscription.
+type SubscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lowest height to deliver; 0 starts at the oldest retained decision.
+	FromHeight    uint64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_feed_feedpb_decision_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_feed_feedpb_decision_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_feed_feedpb_decision_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeRequest) GetFromHeight() uint64 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+// Decision is one finalized block or vertex.
+type Decision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 32-byte block or vertex ID.
+	Id     []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Finality certificate, unset when the engine attached none.
+	Certificate   *Certificate `protobuf:"bytes,3,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decision) Reset() {
+	*x = Decision{}
+	mi := &file_feed_feedpb_decision_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decision) ProtoMessage() {}
+
+func (x *Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_feed_feedpb_decision_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decision.ProtoReflect.Descriptor instead.
+func (*Decision) Descriptor() ([]byte, []int) {
+	return file_feed_feedpb_decision_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Decision) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Decision) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Decision) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+// Certificate is a Quasar finality certificate.
+type Certificate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bls           []byte                 `protobuf:"bytes,1,opt,name=bls,proto3" json:"bls,omitempty"`
+	Corona        []byte                 `protobuf:"bytes,2,opt,name=corona,proto3" json:"corona,omitempty"`
+	Pulsar        []byte                 `protobuf:"bytes,3,opt,name=pulsar,proto3" json:"pulsar,omitempty"`
+	Magnetar      []byte                 `protobuf:"bytes,4,opt,name=magnetar,proto3" json:"magnetar,omitempty"`
+	MldsaRollup   []byte                 `protobuf:"bytes,5,opt,name=mldsa_rollup,json=mldsaRollup,proto3" json:"mldsa_rollup,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Finality      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finality,proto3" json:"finality,omitempty"`
+	Validators    int64                  `protobuf:"varint,8,opt,name=validators,proto3" json:"validators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Certificate) Reset() {
+	*x = Certificate{}
+	mi := &file_feed_feedpb_decision_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Certificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Certificate) ProtoMessage() {}
+
+func (x *Certificate) ProtoReflect() protoreflect.Message {
+	mi := &file_feed_feedpb_decision_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
+func (*Certificate) Descriptor() ([]byte, []int) {
+	return file_feed_feedpb_decision_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Certificate) GetBls() []byte {
+	if x != nil {
+		return x.Bls
+	}
+	return nil
+}
+
+func (x *Certificate) GetCorona() []byte {
+	if x != nil {
+		return x.Corona
+	}
+	return nil
+}
+
+func (x *Certificate) GetPulsar() []byte {
+	if x != nil {
+		return x.Pulsar
+	}
+	return nil
+}
+
+func (x *Certificate) GetMagnetar() []byte {
+	if x != nil {
+		return x.Magnetar
+	}
+	return nil
+}
+
+func (x *Certificate) GetMldsaRollup() []byte {
+	if x != nil {
+		return x.MldsaRollup
+	}
+	return nil
+}
+
+func (x *Certificate) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Certificate) GetFinality() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Finality
+	}
+	return nil
+}
+
+func (x *Certificate) GetValidators() int64 {
+	if x != nil {
+		return x.Validators
+	}
+	return 0
+}
+
+var File_feed_feedpb_decision_proto protoreflect.FileDescriptor
+
+const file_feed_feedpb_decision_proto_rawDesc = "" +
+	"\n" +
+	"\x1afeed/feedpb/decision.proto\x12\x15lux.consensus.feed.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"3\n" +
+	"\x10SubscribeRequest\x12\x1f\n" +
+	"\vfrom_height\x18\x01 \x01(\x04R\n" +
+	"fromHeight\"x\n" +
+	"\bDecision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12D\n" +
+	"\vcertificate\x18\x03 \x01(\v2\".lux.consensus.feed.v1.CertificateR\vcertificate\"\xfc\x01\n" +
+	"\vCertificate\x12\x10\n" +
+	"\x03bls\x18\x01 \x01(\fR\x03bls\x12\x16\n" +
+	"\x06corona\x18\x02 \x01(\fR\x06corona\x12\x16\n" +
+	"\x06pulsar\x18\x03 \x01(\fR\x06pulsar\x12\x1a\n" +
+	"\bmagnetar\x18\x04 \x01(\fR\bmagnetar\x12!\n" +
+	"\fmldsa_rollup\x18\x05 \x01(\fR\vmldsaRollup\x12\x14\n" +
+	"\x05epoch\x18\x06 \x01(\x04R\x05epoch\x126\n" +
+	"\bfinality\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfinality\x12\x1e\n" +
+	"\n" +
+	"validators\x18\b \x01(\x03R\n" +
+	"validators2i\n" +
+	"\x0eDecisionStream\x12W\n" +
+	"\tSubscribe\x12'.lux.consensus.feed.v1.SubscribeRequest\x1a\x1f.lux.consensus.feed.v1.Decision0\x01B(Z&github.com/luxfi/consensus/feed/feedpbb\x06proto3"
+
+var (
+	file_feed_feedpb_decision_proto_rawDescOnce sync.Once
+	file_feed_feedpb_decision_proto_rawDescData []byte
+)
+
+func file_feed_feedpb_decision_proto_rawDescGZIP() []byte {
+	file_feed_feedpb_decision_proto_rawDescOnce.Do(func() {
+		file_feed_feedpb_decision_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_feed_feedpb_decision_proto_rawDesc), len(file_feed_feedpb_decision_proto_rawDesc)))
+	})
+	return file_feed_feedpb_decision_proto_rawDescData
+}
+
+var file_feed_feedpb_decision_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_feed_feedpb_decision_proto_goTypes = []any{
+	(*SubscribeRequest)(nil),      // 0: lux.consensus.feed.v1.SubscribeRequest
+	(*Decision)(nil),              // 1: lux.consensus.feed.v1.Decision
+	(*Certificate)(nil),           // 2: lux.consensus.feed.v1.Certificate
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_feed_feedpb_decision_proto_depIdxs = []int32{
+	2, // 0: lux.consensus.feed.v1.Decision.certificate:type_name -> lux.consensus.feed.v1.Certificate
+	3, // 1: lux.consensus.feed.v1.Certificate.finality:type_name -> google.protobuf.Timestamp
+	0, // 2: lux.consensus.feed.v1.DecisionStream.Subscribe:input_type -> lux.consensus.feed.v1.SubscribeRequest
+	1, // 3: lux.consensus.feed.v1.DecisionStream.Subscribe:output_type -> lux.consensus.feed.v1.Decision
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_feed_feedpb_decision_proto_init() }
+func file_feed_feedpb_decision_proto_init() {
+	if File_feed_feedpb_decision_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_feed_feedpb_decision_proto_rawDesc), len(file_feed_feedpb_decision_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_feed_feedpb_decision_proto_goTypes,
+		DependencyIndexes: file_feed_feedpb_decision_proto_depIdxs,
+		MessageInfos:      file_feed_feedpb_decision_proto_msgTypes,
+	}.Build()
+	File_feed_feedpb_decision_proto = out.File
+	file_feed_feedpb_decision_proto_goTypes = nil
+	file_feed_feedpb_decision_proto_depIdxs = nil
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: feed/feedpb/decision.proto
+
+package feedpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DecisionStream_Subscribe_FullMethodName = "/lux.consensus.feed.v1.DecisionStream/Subscribe"
+)
+
+// DecisionStreamClient is the client API for DecisionStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DecisionStream serves finalized consensus decisions.
+type DecisionStreamClient interface {
+	// Subscribe streams every decision at or above from_height, first from
+	// retained history and then live. It fails with OUT_OF_RANGE when
+	// from_height is no longer retained and ends with ABORTED when the
+	// client reads too slowly to keep up; the client resumes by subscribing
+	// again from the height after the last decision it received.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Decision], error)
+}
+
+type decisionStreamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDecisionStreamClient(cc grpc.ClientConnInterface) DecisionStreamClient {
+	return &decisionStreamClient{cc}
+}
+
+func (c *decisionStreamClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Decision], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DecisionStream_ServiceDesc.Streams[0], DecisionStream_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Decision]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DecisionStream_SubscribeClient = grpc.ServerStreamingClient[Decision]
+
+// DecisionStreamServer is the server API for DecisionStream service.
+// All implementations must embed UnimplementedDecisionStreamServer
+// for forward compatibility.
+//
+// DecisionStream serves finalized consensus decisions.
+type DecisionStreamServer interface {
+	// Subscribe streams every decision at or above from_height, first from
+	// retained history and then live. It fails with OUT_OF_RANGE when
+	// from_height is no longer retained and ends with ABORTED when the
+	// client reads too slowly to keep up; the client resumes by subscribing
+	// again from the height after the last decision it received.
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Decision]) error
+	mustEmbedUnimplementedDecisionStreamServer()
+}
+
+// UnimplementedDecisionStreamServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDecisionStreamServer struct{}
+
+func (UnimplementedDecisionStreamServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Decision]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedDecisionStreamServer) mustEmbedUnimplementedDecisionStreamServer() {}
+func (UnimplementedDecisionStreamServer) testEmbeddedByValue()                        {}
+
+// UnsafeDecisionStreamServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DecisionStreamServer will
+// result in compilation errors.
+type UnsafeDecisionStreamServer interface {
+	mustEmbedUnimplementedDecisionStreamServer()
+}
+
+func RegisterDecisionStreamServer(s grpc.ServiceRegistrar, srv DecisionStreamServer) {
+	// If the following call pancis, it indicates UnimplementedDecisionStreamServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DecisionStream_ServiceDesc, srv)
+}
+
+func _DecisionStream_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DecisionStreamServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Decision]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DecisionStream_SubscribeServer = grpc.ServerStreamingServer[Decision]
+
+// DecisionStream_ServiceDesc is the grpc.ServiceDesc for DecisionStream service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DecisionStream_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lux.consensus.feed.v1.DecisionStream",
+	HandlerType: (*DecisionStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _DecisionStream_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "feed/feedpb/decision.proto",
+}
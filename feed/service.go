@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/luxfi/consensus/feed/feedpb"
+	"github.com/luxfi/consensus/protocol/quasar"
+)
+
+// Service is the gRPC DecisionStream service, serving a DecisionStream
+type Service struct {
+	feedpb.UnimplementedDecisionStreamServer
+
+	stream *DecisionStream
+}
+
+var _ feedpb.DecisionStreamServer = (*Service)(nil)
+
+// NewService returns the service for s
+func NewService(s *DecisionStream) *Service {
+	return &Service{stream: s}
+}
+
+// Register registers the service on a gRPC server
+func (s *Service) Register(server grpc.ServiceRegistrar) {
+	feedpb.RegisterDecisionStreamServer(server, s)
+}
+
+// Subscribe implements feedpb.DecisionStreamServer. ErrHistoryPruned is
+// reported as OutOfRange, ErrSlowSubscriber as Aborted and ErrStreamClosed
+// as Unavailable.
+func (s *Service) Subscribe(req *feedpb.SubscribeRequest, stream feedpb.DecisionStream_SubscribeServer) error {
+	err := s.stream.Subscribe(&SubscribeRequest{FromHeight: req.GetFromHeight()}, protoStream{stream})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrHistoryPruned):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, ErrSlowSubscriber):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, ErrStreamClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		// Send errors are already statuses
+		return err
+	}
+}
+
+// protoStream adapts a generated server stream to DecisionStreamServer
+type protoStream struct {
+	stream feedpb.DecisionStream_SubscribeServer
+}
+
+func (p protoStream) Send(d *Decision) error {
+	return p.stream.Send(DecisionToProto(d))
+}
+
+func (p protoStream) Context() context.Context {
+	return p.stream.Context()
+}
+
+// DecisionToProto converts d to its wire message
+func DecisionToProto(d *Decision) *feedpb.Decision {
+	msg := &feedpb.Decision{
+		Id:     d.ID[:],
+		Height: d.Height,
+	}
+	if c := d.Certificate; c != nil {
+		msg.Certificate = &feedpb.Certificate{
+			Bls:         c.BLS,
+			Corona:      c.Corona,
+			Pulsar:      c.Pulsar,
+			Magnetar:    c.Magnetar,
+			MldsaRollup: c.MLDSARollup,
+			Epoch:       c.Epoch,
+			Validators:  int64(c.Validators),
+		}
+		if !c.Finality.IsZero() {
+			msg.Certificate.Finality = timestamppb.New(c.Finality)
+		}
+	}
+	return msg
+}
+
+// DecisionFromProto converts a wire message back to a Decision. It fails
+// if the ID is not 32 bytes.
+func DecisionFromProto(msg *feedpb.Decision) (Decision, error) {
+	d := Decision{Height: msg.GetHeight()}
+	if len(msg.GetId()) != len(d.ID) {
+		return Decision{}, fmt.Errorf("feed: decision ID is %d bytes, want %d", len(msg.GetId()), len(d.ID))
+	}
+	copy(d.ID[:], msg.GetId())
+	if c := msg.GetCertificate(); c != nil {
+		d.Certificate = &quasar.QuasarCert{
+			BLS:         c.GetBls(),
+			Corona:      c.GetCorona(),
+			Pulsar:      c.GetPulsar(),
+			Magnetar:    c.GetMagnetar(),
+			MLDSARollup: c.GetMldsaRollup(),
+			Epoch:       c.GetEpoch(),
+			Validators:  int(c.GetValidators()),
+		}
+		if c.GetFinality() != nil {
+			d.Certificate.Finality = c.GetFinality().AsTime()
+		}
+	}
+	return d, nil
+}
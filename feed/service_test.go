@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feed
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/luxfi/consensus/feed/feedpb"
+	"github.com/luxfi/consensus/protocol/quasar"
+)
+
+// windowSize is the client's fixed flow-control window. Fixing it turns off
+// window growth, so a client that stops reading stalls the server quickly.
+const windowSize = 64 << 10
+
+// serve runs the service for s on an in-memory gRPC server and returns a
+// client connected to it
+func serve(t *testing.T, s *DecisionStream) feedpb.DecisionStreamClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	NewService(s).Register(server)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithInitialWindowSize(windowSize),
+		grpc.WithInitialConnWindowSize(windowSize),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return feedpb.NewDecisionStreamClient(conn)
+}
+
+func recvDecision(t *testing.T, stream grpc.ServerStreamingClient[feedpb.Decision]) Decision {
+	t.Helper()
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	d, err := DecisionFromProto(msg)
+	require.NoError(t, err)
+	return d
+}
+
+func TestServiceResume(t *testing.T) {
+	require := require.New(t)
+
+	s := NewDecisionStream(StreamConfig{})
+	client := serve(t, s)
+	cert := &quasar.QuasarCert{
+		BLS:        []byte{1, 2, 3},
+		Pulsar:     []byte{4},
+		Epoch:      9,
+		Finality:   time.Unix(1700000000, 5).UTC(),
+		Validators: 21,
+	}
+	for h := uint64(1); h <= 3; h++ {
+		d := decision(h)
+		d.Certificate = cert
+		s.Publish(d)
+	}
+
+	// First connection: replay, then live, then the client drops mid-stream
+	ctx, cancel := context.WithCancel(context.Background())
+	first, err := client.Subscribe(ctx, &feedpb.SubscribeRequest{})
+	require.NoError(err)
+	d := recvDecision(t, first)
+	require.Equal(decision(1).ID, d.ID)
+	require.Equal(uint64(1), d.Height)
+	require.Equal(cert, d.Certificate)
+	require.Equal(uint64(2), recvDecision(t, first).Height)
+	require.Equal(uint64(3), recvDecision(t, first).Height)
+	s.Publish(decision(4))
+	require.Equal(uint64(4), recvDecision(t, first).Height)
+	cancel()
+	_, err = first.Recv()
+	require.Equal(codes.Canceled, status.Code(err))
+
+	// Decisions finalized while the client was away are replayed when it
+	// resumes from the height after the last one it saw
+	s.Publish(decision(5))
+	s.Publish(decision(6))
+	resumed, err := client.Subscribe(context.Background(), &feedpb.SubscribeRequest{FromHeight: 5})
+	require.NoError(err)
+	require.Equal(uint64(5), recvDecision(t, resumed).Height)
+	require.Equal(uint64(6), recvDecision(t, resumed).Height)
+	s.Publish(decision(7))
+	d = recvDecision(t, resumed)
+	require.Equal(uint64(7), d.Height)
+	require.Nil(d.Certificate)
+
+	s.Close()
+	_, err = resumed.Recv()
+	require.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestServiceHistoryPruned(t *testing.T) {
+	require := require.New(t)
+
+	s := NewDecisionStream(StreamConfig{History: 2})
+	client := serve(t, s)
+	for h := uint64(1); h <= 4; h++ {
+		s.Publish(decision(h))
+	}
+
+	stream, err := client.Subscribe(context.Background(), &feedpb.SubscribeRequest{FromHeight: 1})
+	require.NoError(err)
+	_, err = stream.Recv()
+	require.Equal(codes.OutOfRange, status.Code(err))
+}
+
+func TestServiceSlowClient(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		history   = 8
+		published = 200
+	)
+	s := NewDecisionStream(StreamConfig{History: history})
+	client := serve(t, s)
+	s.Publish(decision(1))
+
+	stream, err := client.Subscribe(context.Background(), &feedpb.SubscribeRequest{})
+	require.NoError(err)
+	require.Equal(uint64(1), recvDecision(t, stream).Height)
+
+	// The client stops reading. Flow control soon blocks the server's sends,
+	// but publishing never waits for them.
+	payload := make([]byte, 16<<10)
+	start := time.Now()
+	for h := uint64(2); h <= published; h++ {
+		d := decision(h)
+		d.Certificate = &quasar.QuasarCert{BLS: payload}
+		s.Publish(d)
+	}
+	require.Less(time.Since(start), time.Second)
+
+	// Reading again delivers what was already in flight, in order, and then
+	// the subscription ends for having fallen behind the retained history
+	want := uint64(2)
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			require.Equal(codes.Aborted, status.Code(err), err)
+			break
+		}
+		require.Equal(want, msg.GetHeight())
+		want++
+	}
+	require.Less(want, uint64(published-history))
+
+	// The client resumes from the oldest retained height
+	resumed, err := client.Subscribe(context.Background(), &feedpb.SubscribeRequest{FromHeight: published - history + 1})
+	require.NoError(err)
+	for h := uint64(published - history + 1); h <= published; h++ {
+		require.Equal(h, recvDecision(t, resumed).Height)
+	}
+	s.Close()
+}
+
+func TestDecisionFromProto(t *testing.T) {
+	_, err := DecisionFromProto(&feedpb.Decision{Id: []byte{1, 2}, Height: 1})
+	require.Error(t, err)
+	_, err = DecisionFromProto(&feedpb.Decision{Id: make([]byte, 33), Height: 1})
+	require.Error(t, err)
+}
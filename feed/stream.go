@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/luxfi/consensus/protocol/quasar"
+)
+
+// DefaultHistory is how many decisions a DecisionStream retains for resuming
+// subscribers when StreamConfig.History is unset
+const DefaultHistory = 4096
+
+var (
+	// ErrHistoryPruned is returned by Subscribe for a starting height whose
+	// decisions are no longer retained
+	ErrHistoryPruned = errors.New("feed: decisions below the retained history")
+
+	// ErrSlowSubscriber ends a subscription that fell further behind than
+	// the retained history; the client resumes from its last height
+	ErrSlowSubscriber = errors.New("feed: subscriber fell behind the retained history")
+
+	// ErrStreamClosed ends subscriptions when the DecisionStream closes
+	ErrStreamClosed = errors.New("feed: decision stream closed")
+)
+
+// Decision is one finalized block or vertex
+type Decision struct {
+	ID          [32]byte
+	Height      uint64
+	Certificate *quasar.QuasarCert
+}
+
+// SubscribeRequest opens a subscription
+type SubscribeRequest struct {
+	// FromHeight is the lowest height to deliver; 0 starts at the oldest
+	// decision still retained, even once older ones were pruned. Decisions
+	// sharing a height are all delivered, so a client resuming at the
+	// height it last saw should skip IDs it already has.
+	FromHeight uint64
+}
+
+// DecisionStreamServer is the server side of one subscription. Service
+// adapts the generated gRPC stream to it; tests and in-process consumers
+// may implement it directly.
+type DecisionStreamServer interface {
+	Send(*Decision) error
+	Context() context.Context
+}
+
+// StreamConfig configures a DecisionStream
+type StreamConfig struct {
+	// History is how many of the latest decisions are retained for replay,
+	// which also bounds how far a subscriber may lag (0 = DefaultHistory)
+	History int
+}
+
+// DecisionStream fans finalized decisions out to subscribers. Decisions are
+// published in finalization order, so heights never decrease.
+type DecisionStream struct {
+	history int
+
+	mu sync.Mutex
+	// log is a ring of the retained decisions: it grows to history entries,
+	// then the decision with sequence number seq is at log[seq%history]
+	log     []Decision
+	base    uint64        // sequence number of the oldest retained decision
+	pruned  bool          // whether any decision has left the log
+	lost    uint64        // highest height that has left the log
+	updated chan struct{} // closed and replaced on every publish
+	closed  bool
+}
+
+// NewDecisionStream returns an empty stream
+func NewDecisionStream(cfg StreamConfig) *DecisionStream {
+	if cfg.History <= 0 {
+		cfg.History = DefaultHistory
+	}
+	return &DecisionStream{
+		history: cfg.History,
+		updated: make(chan struct{}),
+	}
+}
+
+// Publish appends d and wakes subscribers. It never blocks on them.
+func (s *DecisionStream) Publish(d Decision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if len(s.log) < s.history {
+		s.log = append(s.log, d)
+	} else {
+		// Overwrite the oldest decision in place
+		oldest := s.at(s.base)
+		s.lost = oldest.Height
+		*oldest = d
+		s.base++
+		s.pruned = true
+	}
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+// Run publishes every block the engine finalizes until finalized is closed
+// or ctx is done. Pass it an engine's Finalized channel.
+func (s *DecisionStream) Run(ctx context.Context, finalized <-chan *quasar.Block) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case b, ok := <-finalized:
+			if !ok {
+				return nil
+			}
+			s.Publish(Decision{ID: b.ID, Height: b.Height, Certificate: b.Cert})
+		}
+	}
+}
+
+// Close ends every subscription with ErrStreamClosed and drops later
+// publishes
+func (s *DecisionStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.updated)
+	}
+}
+
+// Subscribe streams decisions at or above req.FromHeight until the client
+// goes away, the subscriber falls behind the retained history or the
+// stream closes. It fails with ErrHistoryPruned if decisions at a nonzero
+// req.FromHeight are no longer retained.
+func (s *DecisionStream) Subscribe(req *SubscribeRequest, stream DecisionStreamServer) error {
+	ctx := stream.Context()
+
+	s.mu.Lock()
+	next, err := s.startLocked(req.FromHeight)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return ErrStreamClosed
+		}
+		if next < s.base {
+			base := s.base
+			s.mu.Unlock()
+			return fmt.Errorf("%w: next decision %d, oldest retained %d", ErrSlowSubscriber, next, base)
+		}
+		batch := make([]Decision, 0, s.end()-next)
+		for seq := next; seq < s.end(); seq++ {
+			batch = append(batch, *s.at(seq))
+		}
+		updated := s.updated
+		s.mu.Unlock()
+
+		for i := range batch {
+			if err := stream.Send(&batch[i]); err != nil {
+				return err
+			}
+			next++
+		}
+		if len(batch) > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// startLocked returns the sequence number of the first decision at or
+// above height, or of the oldest retained one for height 0. Caller holds
+// s.mu.
+func (s *DecisionStream) startLocked(height uint64) (uint64, error) {
+	if s.pruned && height > 0 && height <= s.lost {
+		return 0, fmt.Errorf("%w: height %d", ErrHistoryPruned, height)
+	}
+	for seq := s.base; seq < s.end(); seq++ {
+		if s.at(seq).Height >= height {
+			return seq, nil
+		}
+	}
+	return s.end(), nil
+}
+
+// at returns the retained decision with sequence number seq. Caller holds
+// s.mu.
+func (s *DecisionStream) at(seq uint64) *Decision {
+	return &s.log[seq%uint64(s.history)]
+}
+
+// end returns the sequence number the next published decision gets.
+// Caller holds s.mu.
+func (s *DecisionStream) end() uint64 {
+	return s.base + uint64(len(s.log))
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/consensus/protocol/quasar"
+)
+
+// chanStream is an in-memory DecisionStreamServer. When gated, each Send
+// signals waiting and blocks until release, modelling a client that reads
+// slowly.
+type chanStream struct {
+	ctx     context.Context
+	out     chan *Decision
+	waiting chan struct{}
+	release chan struct{}
+}
+
+func newChanStream(ctx context.Context, gated bool) *chanStream {
+	s := &chanStream{ctx: ctx, out: make(chan *Decision, 64)}
+	if gated {
+		s.waiting = make(chan struct{}, 1)
+		s.release = make(chan struct{})
+	}
+	return s
+}
+
+func (s *chanStream) Send(d *Decision) error {
+	if s.release != nil {
+		select {
+		case s.waiting <- struct{}{}:
+		default:
+		}
+		select {
+		case <-s.release:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+	select {
+	case s.out <- d:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *chanStream) Context() context.Context { return s.ctx }
+
+func (s *chanStream) next(t *testing.T) *Decision {
+	t.Helper()
+	select {
+	case d := <-s.out:
+		return d
+	case <-time.After(time.Second):
+		require.FailNow(t, "no decision delivered")
+		return nil
+	}
+}
+
+func subscribe(s *DecisionStream, from uint64, stream *chanStream) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- s.Subscribe(&SubscribeRequest{FromHeight: from}, stream) }()
+	return done
+}
+
+func decision(height uint64) Decision {
+	return Decision{ID: [32]byte{byte(height)}, Height: height}
+}
+
+func TestDecisionStreamResume(t *testing.T) {
+	require := require.New(t)
+
+	s := NewDecisionStream(StreamConfig{})
+	for h := uint64(1); h <= 3; h++ {
+		s.Publish(decision(h))
+	}
+
+	// First connection: replay, then live, then the client drops
+	ctx, cancel := context.WithCancel(context.Background())
+	first := newChanStream(ctx, false)
+	done := subscribe(s, 0, first)
+	for h := uint64(1); h <= 3; h++ {
+		require.Equal(h, first.next(t).Height)
+	}
+	s.Publish(decision(4))
+	require.Equal(uint64(4), first.next(t).Height)
+	cancel()
+	require.ErrorIs(<-done, context.Canceled)
+
+	// Decisions finalized while the client was away are replayed on resume
+	s.Publish(decision(5))
+	s.Publish(decision(6))
+
+	resumed := newChanStream(context.Background(), false)
+	done = subscribe(s, 5, resumed)
+	require.Equal(uint64(5), resumed.next(t).Height)
+	require.Equal(uint64(6), resumed.next(t).Height)
+	s.Publish(decision(7))
+	require.Equal(uint64(7), resumed.next(t).Height)
+
+	s.Close()
+	require.ErrorIs(<-done, ErrStreamClosed)
+}
+
+func TestDecisionStreamSlowSubscriber(t *testing.T) {
+	require := require.New(t)
+
+	s := NewDecisionStream(StreamConfig{History: 4})
+	s.Publish(decision(1))
+
+	slow := newChanStream(context.Background(), true)
+	slowDone := subscribe(s, 0, slow)
+	<-slow.waiting
+	fast := newChanStream(context.Background(), false)
+	fastDone := subscribe(s, 0, fast)
+	require.Equal(uint64(1), fast.next(t).Height)
+
+	// Publishing never waits for the slow reader; the fast one keeps up
+	for h := uint64(2); h <= 10; h++ {
+		s.Publish(decision(h))
+		require.Equal(h, fast.next(t).Height)
+	}
+
+	// The slow reader finishes the batch it had, then is cut off for having
+	// fallen behind the retained history
+	close(slow.release)
+	require.Equal(uint64(1), slow.next(t).Height)
+	require.ErrorIs(<-slowDone, ErrSlowSubscriber)
+
+	// Its old position is gone; the oldest retained height still resumes
+	err := s.Subscribe(&SubscribeRequest{FromHeight: 2}, newChanStream(context.Background(), false))
+	require.ErrorIs(err, ErrHistoryPruned)
+	resumed := newChanStream(context.Background(), false)
+	done := subscribe(s, 7, resumed)
+	for h := uint64(7); h <= 10; h++ {
+		require.Equal(h, resumed.next(t).Height)
+	}
+
+	// Height 0 starts at the oldest retained decision rather than failing
+	oldest := newChanStream(context.Background(), false)
+	oldestDone := subscribe(s, 0, oldest)
+	require.Equal(uint64(7), oldest.next(t).Height)
+
+	s.Close()
+	require.ErrorIs(<-done, ErrStreamClosed)
+	require.ErrorIs(<-oldestDone, ErrStreamClosed)
+	require.ErrorIs(<-fastDone, ErrStreamClosed)
+}
+
+func TestDecisionStreamRun(t *testing.T) {
+	require := require.New(t)
+
+	s := NewDecisionStream(StreamConfig{})
+	finalized := make(chan *quasar.Block, 2)
+	cert := &quasar.QuasarCert{Epoch: 3}
+	finalized <- &quasar.Block{ID: [32]byte{1}, Height: 1, Cert: cert}
+	finalized <- &quasar.Block{ID: [32]byte{2}, Height: 2, Cert: cert}
+	close(finalized)
+	require.NoError(s.Run(context.Background(), finalized))
+
+	stream := newChanStream(context.Background(), false)
+	done := subscribe(s, 2, stream)
+	d := stream.next(t)
+	require.Equal([32]byte{2}, d.ID)
+	require.Equal(uint64(2), d.Height)
+	require.Same(cert, d.Certificate)
+
+	s.Close()
+	require.ErrorIs(<-done, ErrStreamClosed)
+}
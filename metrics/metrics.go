@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package metrics exposes Prometheus instrumentation for consensus engines
+// and the servers that front them.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Collector holds the consensus metrics and the registry they live on.
+type Collector struct {
+	registry *prometheus.Registry
+
+	RoundsTotal     prometheus.Counter
+	VotesReceived   prometheus.Counter
+	FinalityLatency prometheus.Histogram
+	EngineErrors    prometheus.Counter
+}
+
+// NewCollector creates a collector registered on a fresh registry.
+func NewCollector() (*Collector, error) {
+	c := &Collector{
+		registry: prometheus.NewRegistry(),
+		RoundsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rounds_total",
+			Help: "Number of consensus rounds processed",
+		}),
+		VotesReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "votes_received",
+			Help: "Number of votes received across all rounds",
+		}),
+		FinalityLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "finality_latency_seconds",
+			Help:    "Time taken for a round to reach finality",
+			Buckets: prometheus.DefBuckets,
+		}),
+		EngineErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "engine_errors_total",
+			Help: "Number of rounds that failed with an error",
+		}),
+	}
+
+	err := errors.Join(
+		c.registry.Register(c.RoundsTotal),
+		c.registry.Register(c.VotesReceived),
+		c.registry.Register(c.FinalityLatency),
+		c.registry.Register(c.EngineErrors),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Registry returns the registry the collector's metrics are registered on.
+func (c *Collector) Registry() *prometheus.Registry {
+	return c.registry
+}
+
+// Handler returns an HTTP handler serving the collector's metrics.
+func (c *Collector) Handler() http.Handler {
+	return promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRound records a processed round with the given number of votes.
+// A finalized round also records its latency.
+func (c *Collector) ObserveRound(votes uint64, finalized bool, latency time.Duration) {
+	c.RoundsTotal.Inc()
+	c.VotesReceived.Add(float64(votes))
+	if finalized {
+		c.FinalityLatency.Observe(latency.Seconds())
+	}
+}
+
+// ObserveError records a round that failed with an error.
+func (c *Collector) ObserveError() {
+	c.EngineErrors.Inc()
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/luxfi/ids"
+	log "github.com/luxfi/log"
+)
+
+var (
+	// ErrGenesisMismatch is returned when a peer advertises a genesis other
+	// than ours; such a peer can never converge with this node
+	ErrGenesisMismatch = errors.New("peer genesis mismatch")
+
+	// ErrNoGenesis is returned for a peer that connects or sends messages
+	// before advertising a matching genesis
+	ErrNoGenesis = errors.New("peer has not advertised a matching genesis")
+)
+
+var _ Handler = (*GenesisGuard)(nil)
+
+// GenesisGuard is a Handler that only admits peers sharing this node's
+// genesis. The transport passes the genesis hash each peer advertises in
+// its handshake and heartbeats to CheckGenesis; a peer is refused until it
+// matches, and an admitted peer advertising a different genesis later is
+// disconnected.
+type GenesisGuard struct {
+	next    Handler
+	genesis ids.ID
+	log     log.Logger
+
+	lock     sync.RWMutex
+	admitted map[ids.NodeID]struct{}
+}
+
+// NewGenesisGuard wraps next so it only sees peers with the given genesis
+func NewGenesisGuard(next Handler, genesis ids.ID, logger log.Logger) *GenesisGuard {
+	if logger == nil {
+		logger = log.Noop()
+	}
+	return &GenesisGuard{
+		next:     next,
+		genesis:  genesis,
+		log:      logger,
+		admitted: make(map[ids.NodeID]struct{}),
+	}
+}
+
+// Genesis returns the genesis hash this node advertises
+func (g *GenesisGuard) Genesis() ids.ID {
+	return g.genesis
+}
+
+// CheckGenesis admits nodeID if peerGenesis matches ours. On a mismatch it
+// logs an error, drops the peer if it was admitted, and returns
+// ErrGenesisMismatch.
+func (g *GenesisGuard) CheckGenesis(ctx context.Context, nodeID ids.NodeID, peerGenesis ids.ID) error {
+	g.lock.Lock()
+	_, wasAdmitted := g.admitted[nodeID]
+	if peerGenesis == g.genesis {
+		g.admitted[nodeID] = struct{}{}
+		g.lock.Unlock()
+		return nil
+	}
+	delete(g.admitted, nodeID)
+	g.lock.Unlock()
+
+	g.log.Error("refusing peer with mismatched genesis",
+		log.Stringer("nodeID", nodeID),
+		log.Stringer("localGenesis", g.genesis),
+		log.Stringer("peerGenesis", peerGenesis),
+	)
+	if wasAdmitted {
+		if err := g.next.Disconnected(ctx, nodeID); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: peer %s has genesis %s, want %s", ErrGenesisMismatch, nodeID, peerGenesis, g.genesis)
+}
+
+// Admitted reports whether nodeID has advertised a matching genesis
+func (g *GenesisGuard) Admitted(nodeID ids.NodeID) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	_, ok := g.admitted[nodeID]
+	return ok
+}
+
+// HandleInbound forwards msg if its sender has been admitted
+func (g *GenesisGuard) HandleInbound(ctx context.Context, msg Message) error {
+	if !g.Admitted(msg.NodeID) {
+		return fmt.Errorf("%w: %s", ErrNoGenesis, msg.NodeID)
+	}
+	return g.next.HandleInbound(ctx, msg)
+}
+
+// HandleOutbound forwards msg if its recipient has been admitted
+func (g *GenesisGuard) HandleOutbound(ctx context.Context, msg Message) error {
+	if !g.Admitted(msg.NodeID) {
+		return fmt.Errorf("%w: %s", ErrNoGenesis, msg.NodeID)
+	}
+	return g.next.HandleOutbound(ctx, msg)
+}
+
+// Connected forwards the connection if nodeID has been admitted
+func (g *GenesisGuard) Connected(ctx context.Context, nodeID ids.NodeID) error {
+	if !g.Admitted(nodeID) {
+		return fmt.Errorf("%w: %s", ErrNoGenesis, nodeID)
+	}
+	return g.next.Connected(ctx, nodeID)
+}
+
+// Disconnected forgets nodeID; it must advertise its genesis again to
+// reconnect
+func (g *GenesisGuard) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	g.lock.Lock()
+	delete(g.admitted, nodeID)
+	g.lock.Unlock()
+	return g.next.Disconnected(ctx, nodeID)
+}
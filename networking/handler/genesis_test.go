@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// peerSet is a Handler recording which peers are connected and what they sent
+type peerSet struct {
+	mu        sync.Mutex
+	connected map[ids.NodeID]bool
+	inbound   []Message
+}
+
+func newPeerSet() *peerSet {
+	return &peerSet{connected: make(map[ids.NodeID]bool)}
+}
+
+func (p *peerSet) HandleInbound(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inbound = append(p.inbound, msg)
+	return nil
+}
+
+func (*peerSet) HandleOutbound(context.Context, Message) error { return nil }
+
+func (p *peerSet) Connected(_ context.Context, nodeID ids.NodeID) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.connected[nodeID] = true
+	return nil
+}
+
+func (p *peerSet) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.connected, nodeID)
+	return nil
+}
+
+func (p *peerSet) isConnected(nodeID ids.NodeID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.connected[nodeID]
+}
+
+// inprocNode is one end of an in-process transport
+type inprocNode struct {
+	id    ids.NodeID
+	peers *peerSet
+	guard *GenesisGuard
+}
+
+func newInprocNode(genesis ids.ID) *inprocNode {
+	peers := newPeerSet()
+	return &inprocNode{
+		id:    ids.GenerateTestNodeID(),
+		peers: peers,
+		guard: NewGenesisGuard(peers, genesis, nil),
+	}
+}
+
+// dial runs the handshake in both directions: each side checks the genesis
+// the other advertises before reporting the connection.
+func dial(ctx context.Context, a, b *inprocNode) (errA, errB error) {
+	handshake := func(local, remote *inprocNode) error {
+		if err := local.guard.CheckGenesis(ctx, remote.id, remote.guard.Genesis()); err != nil {
+			return err
+		}
+		return local.guard.Connected(ctx, remote.id)
+	}
+	return handshake(a, b), handshake(b, a)
+}
+
+func TestGenesisGuardMatchingPeersConnect(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	genesis := ids.GenerateTestID()
+	a, b := newInprocNode(genesis), newInprocNode(genesis)
+
+	errA, errB := dial(ctx, a, b)
+	require.NoError(errA)
+	require.NoError(errB)
+	require.True(a.peers.isConnected(b.id))
+	require.True(b.peers.isConnected(a.id))
+
+	msg := Message{NodeID: a.id, Op: Gossip, Message: []byte("hello")}
+	require.NoError(b.guard.HandleInbound(ctx, msg))
+	require.Len(b.peers.inbound, 1)
+}
+
+func TestGenesisGuardMismatchedPeersRefuse(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	a, b := newInprocNode(ids.GenerateTestID()), newInprocNode(ids.GenerateTestID())
+
+	errA, errB := dial(ctx, a, b)
+	require.ErrorIs(errA, ErrGenesisMismatch)
+	require.ErrorIs(errB, ErrGenesisMismatch)
+	require.False(a.peers.isConnected(b.id))
+	require.False(b.peers.isConnected(a.id))
+
+	// Skipping the handshake does not help
+	require.ErrorIs(b.guard.Connected(ctx, a.id), ErrNoGenesis)
+	require.ErrorIs(b.guard.HandleInbound(ctx, Message{NodeID: a.id, Op: Gossip}), ErrNoGenesis)
+	require.ErrorIs(a.guard.HandleOutbound(ctx, Message{NodeID: b.id, Op: Gossip}), ErrNoGenesis)
+	require.Empty(b.peers.inbound)
+}
+
+// TestGenesisGuardHeartbeatMismatch drops an admitted peer whose heartbeat
+// advertises a different genesis.
+func TestGenesisGuardHeartbeatMismatch(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	genesis := ids.GenerateTestID()
+	a, b := newInprocNode(genesis), newInprocNode(genesis)
+	errA, errB := dial(ctx, a, b)
+	require.NoError(errA)
+	require.NoError(errB)
+
+	// Heartbeat with the same genesis keeps the peer
+	require.NoError(a.guard.CheckGenesis(ctx, b.id, genesis))
+	require.True(a.peers.isConnected(b.id))
+
+	require.ErrorIs(a.guard.CheckGenesis(ctx, b.id, ids.GenerateTestID()), ErrGenesisMismatch)
+	require.False(a.guard.Admitted(b.id))
+	require.False(a.peers.isConnected(b.id))
+}
@@ -37,6 +37,7 @@ Usage:
     data = vote.to_json()
 """
 
+import base64
 import hashlib
 import json
 import time
@@ -281,16 +282,22 @@ class Certificate:
     proof: bytes           # Policy-specific proof
     signers: Optional[bytes] = None  # Who attested
     timestamp_ms: int = field(default_factory=lambda: int(time.time() * 1000))
+    hash_suite_id: int = 0  # Hash family the cert was produced under
 
     def to_dict(self) -> dict:
-        return {
+        # Matches Go wire.Certificate.MarshalJSON: hex candidate_id,
+        # base64 proof/signers, signers omitted when empty.
+        d = {
             "candidate_id": self.candidate_id.hex(),
             "height": self.height,
             "policy_id": int(self.policy_id),
-            "proof": self.proof.hex(),
-            "signers": self.signers.hex() if self.signers else None,
-            "timestamp_ms": self.timestamp_ms,
+            "hash_suite_id": self.hash_suite_id,
+            "proof": base64.b64encode(self.proof).decode(),
         }
+        if self.signers:
+            d["signers"] = base64.b64encode(self.signers).decode()
+        d["timestamp_ms"] = self.timestamp_ms
+        return d
 
     def to_json(self) -> str:
         return json.dumps(self.to_dict())
@@ -301,9 +308,10 @@ class Certificate:
             candidate_id=bytes.fromhex(d["candidate_id"]),
             height=d["height"],
             policy_id=PolicyID(d["policy_id"]),
-            proof=bytes.fromhex(d["proof"]),
-            signers=bytes.fromhex(d["signers"]) if d.get("signers") else None,
+            proof=base64.b64decode(d["proof"]),
+            signers=base64.b64decode(d["signers"]) if d.get("signers") else None,
             timestamp_ms=d.get("timestamp_ms", int(time.time() * 1000)),
+            hash_suite_id=d.get("hash_suite_id", 0),
         )
 
     @classmethod
@@ -30,7 +30,8 @@ type Candidate struct {
 	// ID is the content-addressed identifier (computed from Domain + Payload)
 	ID CandidateID `json:"id"`
 
-	// ParentID links to the previous candidate (optional for DAG/genesis)
+	// ParentID links to the previous candidate. EmptyCandidateID is
+	// reserved for the genesis candidate at Height 0.
 	ParentID CandidateID `json:"parent_id,omitempty"`
 
 	// Height is the sequence number / slot / round
@@ -97,6 +98,24 @@ func (c *Candidate) Verify() bool {
 	return c.ID == c.ComputeID()
 }
 
+// Candidate validation errors
+var (
+	ErrCandidateIDMismatch = errors.New("candidate ID does not match content")
+	ErrEmptyParent         = errors.New("only a height-0 genesis candidate may have an empty parent")
+)
+
+// Validate checks that the ID matches the content and that only a genesis
+// candidate (Height 0) uses EmptyCandidateID as its parent.
+func (c *Candidate) Validate() error {
+	if !c.Verify() {
+		return ErrCandidateIDMismatch
+	}
+	if c.ParentID == EmptyCandidateID && c.Height != 0 {
+		return ErrEmptyParent
+	}
+	return nil
+}
+
 // =============================================================================
 // ATTESTATIONS: Who agrees
 // =============================================================================
@@ -184,6 +203,10 @@ const (
 	// PolicyPZ - parallel-witness finality, witness set {P, Z}.
 	// P-Chain BLS + Z-Chain MLDSAStark rollup. No Q-Chain ceremony.
 	PolicyPZ PolicyID = 6
+
+	// PolicyWeightedQuorum - stake-weighted threshold: finalizes when the
+	// sum of accepting signer weights reaches a fraction of total weight.
+	PolicyWeightedQuorum PolicyID = 7
 )
 
 // =============================================================================
@@ -4,6 +4,7 @@
 package wire
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -105,6 +106,28 @@ func TestCandidateVerifyTampered(t *testing.T) {
 	}
 }
 
+func TestCandidateValidateEmptyParent(t *testing.T) {
+	genesis := NewCandidate([]byte("domain"), []byte("genesis"), EmptyCandidateID, 0)
+	if err := genesis.Validate(); err != nil {
+		t.Errorf("genesis candidate should validate, got %v", err)
+	}
+
+	c := NewCandidate([]byte("domain"), []byte("orphan"), EmptyCandidateID, 5)
+	if err := c.Validate(); !errors.Is(err, ErrEmptyParent) {
+		t.Errorf("height-5 candidate with empty parent: got %v, want %v", err, ErrEmptyParent)
+	}
+
+	child := NewCandidate([]byte("domain"), []byte("child"), genesis.ID, 5)
+	if err := child.Validate(); err != nil {
+		t.Errorf("height-5 candidate with a parent should validate, got %v", err)
+	}
+
+	child.Payload = []byte("tampered")
+	if err := child.Validate(); !errors.Is(err, ErrCandidateIDMismatch) {
+		t.Errorf("tampered candidate: got %v, want %v", err, ErrCandidateIDMismatch)
+	}
+}
+
 func TestResultSerialization(t *testing.T) {
 	r := &Result{
 		ItemID:     DeriveItemID([]byte("test")),
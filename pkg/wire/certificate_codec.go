@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// =============================================================================
+// CERTIFICATE CODEC
+// =============================================================================
+//
+// Binary layout (big-endian, version 1):
+//
+//	version      (uint8,     1 B) = CertificateCodecVersion
+//	policy_tag   (uint8,     1 B) = PolicyID
+//	candidate_id (32 B)
+//	height       (uint64,    8 B)
+//	hash_suite   (uint8,     1 B)
+//	timestamp_ms (int64,     8 B)
+//	proof_len    (uint32,    4 B) || proof
+//	signers_len  (uint32,    4 B) || signers
+//
+// The policy tag sits in the fixed header so a decoder can dispatch on the
+// finality policy before touching the policy-specific proof. Unknown policy
+// tags decode as opaque bytes, so a new policy does not need a new version.
+//
+// JSON uses lower-case hex for candidate_id (matching the Python client) and
+// standard padded base64 for proof and signers.
+// =============================================================================
+
+// CertificateCodecVersion is the current binary certificate layout version.
+const CertificateCodecVersion byte = 1
+
+const certHeaderLen = 1 + 1 + 32 + 8 + 1 + 8
+
+// Certificate codec errors
+var (
+	ErrCertCodecVersion   = errors.New("certificate: unsupported codec version")
+	ErrCertCodecTruncated = errors.New("certificate: truncated encoding")
+	ErrCertCodecTrailing  = errors.New("certificate: trailing bytes after encoding")
+	ErrCertPolicyOverflow = errors.New("certificate: policy id does not fit header tag")
+)
+
+// MarshalBinary encodes the certificate using the versioned binary layout.
+func (c Certificate) MarshalBinary() ([]byte, error) {
+	if c.PolicyID > 0xFF {
+		return nil, ErrCertPolicyOverflow
+	}
+
+	buf := make([]byte, certHeaderLen, certHeaderLen+4+len(c.Proof)+4+len(c.Signers))
+	buf[0] = CertificateCodecVersion
+	buf[1] = byte(c.PolicyID)
+	copy(buf[2:34], c.CandidateID[:])
+	binary.BigEndian.PutUint64(buf[34:42], c.Height)
+	buf[42] = byte(c.HashSuiteID)
+	binary.BigEndian.PutUint64(buf[43:51], uint64(c.TimestampMs))
+
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(c.Proof)))
+	buf = append(buf, c.Proof...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(c.Signers)))
+	buf = append(buf, c.Signers...)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a certificate produced by MarshalBinary.
+func (c *Certificate) UnmarshalBinary(data []byte) error {
+	if len(data) < certHeaderLen {
+		return ErrCertCodecTruncated
+	}
+	if data[0] != CertificateCodecVersion {
+		return fmt.Errorf("%w: %d", ErrCertCodecVersion, data[0])
+	}
+
+	var out Certificate
+	out.PolicyID = PolicyID(data[1])
+	copy(out.CandidateID[:], data[2:34])
+	out.Height = binary.BigEndian.Uint64(data[34:42])
+	out.HashSuiteID = HashSuiteID(data[42])
+	out.TimestampMs = int64(binary.BigEndian.Uint64(data[43:51]))
+
+	rest := data[certHeaderLen:]
+	var err error
+	if out.Proof, rest, err = readLengthPrefixed(rest); err != nil {
+		return err
+	}
+	if out.Signers, rest, err = readLengthPrefixed(rest); err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return ErrCertCodecTrailing
+	}
+
+	*c = out
+	return nil
+}
+
+// readLengthPrefixed reads a uint32 length followed by that many bytes.
+// A zero length decodes as nil so empty fields round-trip to the zero value.
+func readLengthPrefixed(data []byte) (field, rest []byte, err error) {
+	if len(data) < 4 {
+		return nil, nil, ErrCertCodecTruncated
+	}
+	n := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint64(len(data)) < uint64(n) {
+		return nil, nil, ErrCertCodecTruncated
+	}
+	if n == 0 {
+		return nil, data, nil
+	}
+	field = make([]byte, n)
+	copy(field, data[:n])
+	return field, data[n:], nil
+}
+
+// certificateJSON is the explicit JSON shape of a Certificate.
+type certificateJSON struct {
+	CandidateID string      `json:"candidate_id"`
+	Height      uint64      `json:"height"`
+	PolicyID    PolicyID    `json:"policy_id"`
+	HashSuiteID HashSuiteID `json:"hash_suite_id"`
+	Proof       string      `json:"proof"`
+	Signers     string      `json:"signers,omitempty"`
+	TimestampMs int64       `json:"timestamp_ms"`
+}
+
+// MarshalJSON encodes the certificate with hex candidate_id and base64 byte
+// fields so Go and Python produce identical documents.
+func (c Certificate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(certificateJSON{
+		CandidateID: hex.EncodeToString(c.CandidateID[:]),
+		Height:      c.Height,
+		PolicyID:    c.PolicyID,
+		HashSuiteID: c.HashSuiteID,
+		Proof:       base64.StdEncoding.EncodeToString(c.Proof),
+		Signers:     base64.StdEncoding.EncodeToString(c.Signers),
+		TimestampMs: c.TimestampMs,
+	})
+}
+
+// UnmarshalJSON decodes a certificate produced by MarshalJSON.
+func (c *Certificate) UnmarshalJSON(data []byte) error {
+	var raw certificateJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	id, err := hex.DecodeString(raw.CandidateID)
+	if err != nil {
+		return fmt.Errorf("certificate: candidate_id: %w", err)
+	}
+	if len(id) != len(CandidateID{}) {
+		return fmt.Errorf("certificate: candidate_id must be %d bytes, got %d", len(CandidateID{}), len(id))
+	}
+	proof, err := decodeBase64Field(raw.Proof)
+	if err != nil {
+		return fmt.Errorf("certificate: proof: %w", err)
+	}
+	signers, err := decodeBase64Field(raw.Signers)
+	if err != nil {
+		return fmt.Errorf("certificate: signers: %w", err)
+	}
+
+	var out Certificate
+	copy(out.CandidateID[:], id)
+	out.Height = raw.Height
+	out.PolicyID = raw.PolicyID
+	out.HashSuiteID = raw.HashSuiteID
+	out.Proof = proof
+	out.Signers = signers
+	out.TimestampMs = raw.TimestampMs
+
+	*c = out
+	return nil
+}
+
+// decodeBase64Field decodes a base64 JSON field; empty decodes as nil.
+func decodeBase64Field(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite certificate codec golden files")
+
+var registeredPolicies = []PolicyID{
+	PolicyNone,
+	PolicyQuorum,
+	PolicySampleConvergence,
+	PolicyL1Inclusion,
+	PolicyQuantum,
+	PolicyPQ,
+	PolicyPZ,
+	PolicyWeightedQuorum,
+}
+
+func goldenCertificate() Certificate {
+	return Certificate{
+		CandidateID: DeriveItemID([]byte("golden-certificate")),
+		Height:      42,
+		PolicyID:    PolicyQuantum,
+		HashSuiteID: HashSuiteSHA3NIST,
+		Proof:       []byte("proof-bytes"),
+		Signers:     []byte{0x0f},
+		TimestampMs: 1700000000000,
+	}
+}
+
+func requireCertEqual(t *testing.T, want, got Certificate) {
+	t.Helper()
+	if got.CandidateID != want.CandidateID ||
+		got.Height != want.Height ||
+		got.PolicyID != want.PolicyID ||
+		got.HashSuiteID != want.HashSuiteID ||
+		got.TimestampMs != want.TimestampMs ||
+		!bytes.Equal(got.Proof, want.Proof) ||
+		!bytes.Equal(got.Signers, want.Signers) {
+		t.Fatalf("certificate mismatch:\nwant %+v\ngot  %+v", want, got)
+	}
+}
+
+func TestCertificateBinaryRoundTrip(t *testing.T) {
+	for _, policy := range registeredPolicies {
+		cert := goldenCertificate()
+		cert.PolicyID = policy
+
+		data, err := cert.MarshalBinary()
+		if err != nil {
+			t.Fatalf("policy %d: MarshalBinary: %v", policy, err)
+		}
+		if data[1] != byte(policy) {
+			t.Fatalf("policy %d: header tag = %d", policy, data[1])
+		}
+
+		var got Certificate
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("policy %d: UnmarshalBinary: %v", policy, err)
+		}
+		requireCertEqual(t, cert, got)
+	}
+}
+
+func TestCertificateJSONRoundTrip(t *testing.T) {
+	for _, policy := range registeredPolicies {
+		cert := goldenCertificate()
+		cert.PolicyID = policy
+
+		data, err := json.Marshal(cert)
+		if err != nil {
+			t.Fatalf("policy %d: MarshalJSON: %v", policy, err)
+		}
+
+		var got Certificate
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("policy %d: UnmarshalJSON: %v", policy, err)
+		}
+		requireCertEqual(t, cert, got)
+	}
+}
+
+func TestCertificateEmptyFieldsRoundTrip(t *testing.T) {
+	cert := Certificate{CandidateID: DeriveItemID([]byte("empty")), PolicyID: PolicyNone}
+
+	data, err := cert.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	var got Certificate
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	requireCertEqual(t, cert, got)
+}
+
+func TestCertificateUnmarshalBinaryRejectsMalformed(t *testing.T) {
+	cert := goldenCertificate()
+	data, err := cert.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var got Certificate
+	if err := got.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrCertCodecTruncated) {
+		t.Fatalf("truncated: got %v", err)
+	}
+	if err := got.UnmarshalBinary(append(data, 0)); !errors.Is(err, ErrCertCodecTrailing) {
+		t.Fatalf("trailing: got %v", err)
+	}
+
+	bad := bytes.Clone(data)
+	bad[0] = CertificateCodecVersion + 1
+	if err := got.UnmarshalBinary(bad); !errors.Is(err, ErrCertCodecVersion) {
+		t.Fatalf("version: got %v", err)
+	}
+
+	cert.PolicyID = 0x100
+	if _, err := cert.MarshalBinary(); !errors.Is(err, ErrCertPolicyOverflow) {
+		t.Fatalf("policy overflow: got %v", err)
+	}
+}
+
+// TestCertificateGolden pins both encodings byte-for-byte. A failure here
+// means the wire format changed and the Python client must be updated in
+// lockstep; regenerate with `go test -run TestCertificateGolden -update`.
+func TestCertificateGolden(t *testing.T) {
+	cert := goldenCertificate()
+
+	bin, err := cert.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	js, err := json.Marshal(cert)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	js = append(js, '\n')
+
+	for _, tc := range []struct {
+		file string
+		got  []byte
+	}{
+		{"certificate_v1.golden", bin},
+		{"certificate_v1.json", js},
+	} {
+		path := filepath.Join("testdata", tc.file)
+		if *updateGolden {
+			if err := os.WriteFile(path, tc.got, 0o644); err != nil {
+				t.Fatalf("write %s: %v", path, err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if !bytes.Equal(want, tc.got) {
+			t.Fatalf("%s drifted:\nwant %q\ngot  %q", tc.file, want, tc.got)
+		}
+	}
+
+	var got Certificate
+	if err := got.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary golden: %v", err)
+	}
+	requireCertEqual(t, cert, got)
+}
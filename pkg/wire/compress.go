@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/compress"
+)
+
+// Payload compression errors
+var (
+	ErrUnknownCodec    = errors.New("unknown payload codec")
+	ErrNoCodecHeader   = errors.New("transported payload has no codec header")
+	ErrPayloadTooLarge = errors.New("payload exceeds the maximum size")
+)
+
+// DefaultMaxPayload is the largest payload a PayloadCodec compresses or
+// decompresses when CompressionConfig.MaxPayload is unset
+const DefaultMaxPayload = 16 << 20
+
+// CodecID is the one-byte header that precedes a transported payload and
+// names how the rest of it is encoded
+type CodecID uint8
+
+const (
+	CodecNone CodecID = iota // payload carried as is
+	CodecGzip
+	CodecZstd
+)
+
+func (id CodecID) String() string {
+	switch id {
+	case CodecNone:
+		return "none"
+	case CodecGzip:
+		return "gzip"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("codec(%d)", uint8(id))
+	}
+}
+
+// Codec compresses candidate payloads for transport
+type Codec interface {
+	ID() CodecID
+	Compress(payload []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// NewCodec returns the codec id, refusing payloads larger than maxPayload
+// in either direction
+func NewCodec(id CodecID, maxPayload int64) (Codec, error) {
+	var (
+		c   compress.Compressor
+		err error
+	)
+	switch id {
+	case CodecNone:
+		c = compress.NewNoCompressor()
+	case CodecGzip:
+		c, err = compress.NewGzipCompressor(maxPayload)
+	case CodecZstd:
+		c, err = compress.NewZstdCompressor(maxPayload)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return codec{Compressor: c, id: id}, nil
+}
+
+type codec struct {
+	compress.Compressor
+	id CodecID
+}
+
+func (c codec) ID() CodecID { return c.id }
+
+// CompressionConfig selects how candidate payloads are compressed for
+// transport
+type CompressionConfig struct {
+	// Codec compresses outgoing payloads (CodecNone = uncompressed).
+	// Incoming payloads are decoded by whichever codec their header names.
+	Codec CodecID `json:"codec"`
+
+	// MaxPayload bounds an uncompressed payload (0 = DefaultMaxPayload)
+	MaxPayload int64 `json:"max_payload"`
+}
+
+// PayloadCodec encodes candidate payloads for transport as a codec header
+// byte followed by the payload compressed with the configured codec. A
+// payload that does not shrink is sent uncompressed under CodecNone. It
+// decodes payloads from every known codec, so meshes whose nodes
+// configure different codecs interoperate.
+type PayloadCodec struct {
+	maxPayload int64
+	codec      Codec
+	codecs     map[CodecID]Codec
+}
+
+// NewPayloadCodec returns a PayloadCodec for cfg
+func NewPayloadCodec(cfg CompressionConfig) (*PayloadCodec, error) {
+	if cfg.MaxPayload <= 0 {
+		cfg.MaxPayload = DefaultMaxPayload
+	}
+	p := &PayloadCodec{
+		maxPayload: cfg.MaxPayload,
+		codecs:     make(map[CodecID]Codec),
+	}
+	for _, id := range []CodecID{CodecNone, CodecGzip, CodecZstd} {
+		c, err := NewCodec(id, cfg.MaxPayload)
+		if err != nil {
+			return nil, err
+		}
+		p.codecs[id] = c
+	}
+	codec, ok := p.codecs[cfg.Codec]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, cfg.Codec)
+	}
+	p.codec = codec
+	return p, nil
+}
+
+// Encode returns payload prefixed with its codec header
+func (p *PayloadCodec) Encode(payload []byte) ([]byte, error) {
+	if int64(len(payload)) > p.maxPayload {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrPayloadTooLarge, len(payload), p.maxPayload)
+	}
+	if p.codec.ID() != CodecNone {
+		compressed, err := p.codec.Compress(payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(compressed) < len(payload) {
+			return append([]byte{byte(p.codec.ID())}, compressed...), nil
+		}
+	}
+	return append([]byte{byte(CodecNone)}, payload...), nil
+}
+
+// Decode reverses Encode under whichever codec data's header names
+func (p *PayloadCodec) Decode(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrNoCodecHeader
+	}
+	id := CodecID(data[0])
+	c, ok := p.codecs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, id)
+	}
+	if id == CodecNone && int64(len(data)-1) > p.maxPayload {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrPayloadTooLarge, len(data)-1, p.maxPayload)
+	}
+	payload, err := c.Decompress(data[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%s payload: %w", id, err)
+	}
+	return append([]byte{}, payload...), nil
+}
+
+// CompressCandidate returns a copy of c whose payload is encoded for
+// transport. The ID is unchanged: it commits to the uncompressed payload,
+// so the copy no longer passes Verify until DecompressCandidate restores
+// it. An offloaded candidate carries no payload and is copied as is.
+func (p *PayloadCodec) CompressCandidate(c *Candidate) (*Candidate, error) {
+	out := *c
+	if c.Offloaded() {
+		return &out, nil
+	}
+	payload, err := p.Encode(c.Payload)
+	if err != nil {
+		return nil, err
+	}
+	out.Payload = payload
+	return &out, nil
+}
+
+// DecompressCandidate returns a copy of c with the payload decoded from
+// its transport encoding, returning ErrCandidateIDMismatch if the restored
+// payload does not match the ID
+func (p *PayloadCodec) DecompressCandidate(c *Candidate) (*Candidate, error) {
+	out := *c
+	if c.Offloaded() {
+		return &out, nil
+	}
+	payload, err := p.Decode(c.Payload)
+	if err != nil {
+		return nil, err
+	}
+	out.Payload = payload
+	if !out.Verify() {
+		return nil, fmt.Errorf("%w: %x", ErrCandidateIDMismatch, c.ID[:])
+	}
+	return &out, nil
+}
+
+// EncodeRequest returns a copy of r for transport, with its Data encoded.
+// Empty Data is sent as is.
+func (p *PayloadCodec) EncodeRequest(r *Request) (*Request, error) {
+	out := *r
+	if len(r.Data) == 0 {
+		return &out, nil
+	}
+	data, err := p.Encode(r.Data)
+	if err != nil {
+		return nil, err
+	}
+	out.Data = data
+	return &out, nil
+}
+
+// DecodeRequest reverses EncodeRequest on receipt
+func (p *PayloadCodec) DecodeRequest(r *Request) (*Request, error) {
+	out := *r
+	if len(r.Data) == 0 {
+		return &out, nil
+	}
+	data, err := p.Decode(r.Data)
+	if err != nil {
+		return nil, err
+	}
+	out.Data = data
+	return &out, nil
+}
+
+// EncodeResponse returns a copy of r for transport, with the payload of
+// its candidate, if any, encoded by CompressCandidate
+func (p *PayloadCodec) EncodeResponse(r *Response) (*Response, error) {
+	out := *r
+	if r.Candidate == nil {
+		return &out, nil
+	}
+	c, err := p.CompressCandidate(r.Candidate)
+	if err != nil {
+		return nil, err
+	}
+	out.Candidate = c
+	return &out, nil
+}
+
+// DecodeResponse reverses EncodeResponse on receipt, failing if the
+// candidate's restored payload does not match its ID
+func (p *PayloadCodec) DecodeResponse(r *Response) (*Response, error) {
+	out := *r
+	if r.Candidate == nil {
+		return &out, nil
+	}
+	c, err := p.DecompressCandidate(r.Candidate)
+	if err != nil {
+		return nil, err
+	}
+	out.Candidate = c
+	return &out, nil
+}
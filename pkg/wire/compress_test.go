@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+var allCodecs = []CodecID{CodecNone, CodecGzip, CodecZstd}
+
+func newTestPayloadCodec(t *testing.T, id CodecID) *PayloadCodec {
+	t.Helper()
+	p, err := NewPayloadCodec(CompressionConfig{Codec: id})
+	if err != nil {
+		t.Fatalf("NewPayloadCodec(%s): %v", id, err)
+	}
+	return p
+}
+
+// txList is a compressible payload, like a batch of similar transactions
+func txList() []byte {
+	return bytes.Repeat([]byte(`{"from":"0xabc","to":"0xdef","value":1000},`), 200)
+}
+
+func TestPayloadCodecRoundTrip(t *testing.T) {
+	payload := txList()
+	for _, id := range allCodecs {
+		p := newTestPayloadCodec(t, id)
+		c := NewCandidate([]byte("ai-mesh"), payload, testCandidateID(1), 1)
+
+		sent, err := p.CompressCandidate(c)
+		if err != nil {
+			t.Fatalf("%s: compress: %v", id, err)
+		}
+		if got := CodecID(sent.Payload[0]); got != id {
+			t.Fatalf("%s: header names %s", id, got)
+		}
+		if id != CodecNone && len(sent.Payload) >= len(payload) {
+			t.Fatalf("%s: %d bytes did not shrink to %d", id, len(payload), len(sent.Payload))
+		}
+		if sent.ID != c.ID {
+			t.Fatalf("%s: compression changed the candidate ID", id)
+		}
+		if !bytes.Equal(c.Payload, payload) {
+			t.Fatalf("%s: compression modified the original candidate", id)
+		}
+
+		got, err := p.DecompressCandidate(sent)
+		if err != nil {
+			t.Fatalf("%s: decompress: %v", id, err)
+		}
+		if !bytes.Equal(got.Payload, payload) || !got.Verify() {
+			t.Fatalf("%s: round trip did not restore the payload", id)
+		}
+	}
+}
+
+func TestPayloadCodecMixedMesh(t *testing.T) {
+	payload := txList()
+	c := NewCandidate([]byte("ai-mesh"), payload, testCandidateID(1), 1)
+
+	// Whatever codec a sender uses, every receiver decodes the payload
+	// and sees the same candidate ID
+	for _, from := range allCodecs {
+		sent, err := newTestPayloadCodec(t, from).CompressCandidate(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, to := range allCodecs {
+			got, err := newTestPayloadCodec(t, to).DecompressCandidate(sent)
+			if err != nil {
+				t.Fatalf("%s -> %s: %v", from, to, err)
+			}
+			if got.ID != c.ID || got.ComputeID() != c.ID {
+				t.Fatalf("%s -> %s: candidate ID not stable", from, to)
+			}
+		}
+	}
+}
+
+func TestPayloadCodecIncompressibleFallback(t *testing.T) {
+	payload := make([]byte, 4096)
+	if _, err := rand.Read(payload); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []CodecID{CodecGzip, CodecZstd} {
+		p := newTestPayloadCodec(t, id)
+		data, err := p.Encode(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if CodecID(data[0]) != CodecNone || !bytes.Equal(data[1:], payload) {
+			t.Fatalf("%s: incompressible payload not sent uncompressed", id)
+		}
+		got, err := p.Decode(data)
+		if err != nil || !bytes.Equal(got, payload) {
+			t.Fatalf("%s: decode fallback: %v", id, err)
+		}
+	}
+}
+
+func TestPayloadCodecRejects(t *testing.T) {
+	p := newTestPayloadCodec(t, CodecZstd)
+
+	if _, err := p.Decode(nil); !errors.Is(err, ErrNoCodecHeader) {
+		t.Fatalf("empty payload: %v, want %v", err, ErrNoCodecHeader)
+	}
+	if _, err := p.Decode([]byte{0xEE, 1, 2}); !errors.Is(err, ErrUnknownCodec) {
+		t.Fatalf("unknown header: %v, want %v", err, ErrUnknownCodec)
+	}
+	if _, err := NewPayloadCodec(CompressionConfig{Codec: 0xEE}); !errors.Is(err, ErrUnknownCodec) {
+		t.Fatalf("unknown config codec: %v, want %v", err, ErrUnknownCodec)
+	}
+
+	small, err := NewPayloadCodec(CompressionConfig{Codec: CodecGzip, MaxPayload: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := small.Encode(make([]byte, 17)); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("oversized payload: %v, want %v", err, ErrPayloadTooLarge)
+	}
+
+	// A payload swapped in transit no longer matches the ID
+	c := NewCandidate([]byte("ai-mesh"), txList(), testCandidateID(1), 1)
+	sent, err := p.CompressCandidate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent.Payload, err = p.Encode([]byte("forged"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.DecompressCandidate(sent); !errors.Is(err, ErrCandidateIDMismatch) {
+		t.Fatalf("forged payload: %v, want %v", err, ErrCandidateIDMismatch)
+	}
+}
+
+func TestPayloadCodecRequestResponse(t *testing.T) {
+	p := newTestPayloadCodec(t, CodecZstd)
+
+	req := &Request{Type: "candidate", Data: txList()}
+	sent, err := p.EncodeRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if CodecID(sent.Data[0]) != CodecZstd || len(sent.Data) >= len(req.Data) {
+		t.Fatalf("request data was not compressed: %d bytes under %s", len(sent.Data), CodecID(sent.Data[0]))
+	}
+	got, err := p.DecodeRequest(sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != req.Type || !bytes.Equal(got.Data, req.Data) {
+		t.Fatal("request did not round-trip")
+	}
+
+	// Requests without data carry no header
+	empty, err := p.EncodeRequest(&Request{Type: "vote_request"})
+	if err != nil || len(empty.Data) != 0 {
+		t.Fatalf("empty request encoded to %d bytes: %v", len(empty.Data), err)
+	}
+
+	c := NewCandidate([]byte("ai-mesh"), txList(), testCandidateID(1), 1)
+	resp, err := p.EncodeResponse(&Response{Type: "candidate", Candidate: c})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Candidate.Payload) >= len(c.Payload) {
+		t.Fatal("response candidate was not compressed")
+	}
+	back, err := p.DecodeResponse(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back.Candidate.Payload, c.Payload) || !back.Candidate.Verify() {
+		t.Fatal("response candidate did not round-trip")
+	}
+
+	// A candidate whose restored payload does not match its ID is refused
+	resp.Candidate.ID = testCandidateID(9)
+	if _, err := p.DecodeResponse(resp); !errors.Is(err, ErrCandidateIDMismatch) {
+		t.Fatalf("expected ErrCandidateIDMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DA backend errors
+var (
+	ErrDANotFound     = errors.New("payload not found in data availability layer")
+	ErrDARefType      = errors.New("data availability reference has the wrong type")
+	ErrDARefMalformed = errors.New("malformed data availability reference")
+	ErrDAHashMismatch = errors.New("data availability payload does not match candidate ID")
+	ErrNotOffloaded   = errors.New("candidate payload is not offloaded")
+)
+
+// DABackend stores payload bytes in a data availability layer: local,
+// IPFS, blob, P2P, MCP mesh. It is the byte store beneath a
+// DataAvailability implementation.
+type DABackend interface {
+	// Put stores payload and returns where it lives
+	Put(ctx context.Context, payload []byte) (DARef, error)
+
+	// Get fetches the payload at ref. Callers must not trust the bytes
+	// before checking them; see FetchPayload.
+	Get(ctx context.Context, ref DARef) ([]byte, error)
+}
+
+// String encodes the reference as "type:ref", the form stored in
+// Candidate.DARef
+func (r DARef) String() string {
+	return r.Type + ":" + r.Ref
+}
+
+// ParseDARef decodes a reference produced by DARef.String
+func ParseDARef(s string) (DARef, error) {
+	typ, ref, ok := strings.Cut(s, ":")
+	if !ok || typ == "" || ref == "" {
+		return DARef{}, fmt.Errorf("%w: %q", ErrDARefMalformed, s)
+	}
+	return DARef{Type: typ, Ref: ref}, nil
+}
+
+// LocalDABackend keeps payloads in memory, addressed by their SHA-256
+type LocalDABackend struct {
+	mu       sync.RWMutex
+	payloads map[string][]byte
+}
+
+// NewLocalDABackend returns an empty in-memory backend
+func NewLocalDABackend() *LocalDABackend {
+	return &LocalDABackend{payloads: make(map[string][]byte)}
+}
+
+func (b *LocalDABackend) Put(ctx context.Context, payload []byte) (DARef, error) {
+	sum := sha256.Sum256(payload)
+	key := hex.EncodeToString(sum[:])
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.payloads[key] = append([]byte(nil), payload...)
+	return DARef{Type: DATypeLocal, Ref: key, Size: uint64(len(payload))}, nil
+}
+
+func (b *LocalDABackend) Get(ctx context.Context, ref DARef) ([]byte, error) {
+	if ref.Type != DATypeLocal {
+		return nil, fmt.Errorf("%w: %q", ErrDARefType, ref.Type)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	payload, ok := b.payloads[ref.Ref]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDANotFound, ref.Ref)
+	}
+	return append([]byte(nil), payload...), nil
+}
+
+// NewCandidateWithDA creates a candidate whose payload is stored in backend
+// rather than carried inline. The ID still commits to the full payload.
+func NewCandidateWithDA(ctx context.Context, backend DABackend, domain, payload []byte, parent CandidateID, height uint64) (*Candidate, error) {
+	c := NewCandidate(domain, payload, parent, height)
+	if err := c.Offload(ctx, backend); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Offload moves the candidate's payload into backend, keeping only the
+// reference. An offloaded candidate no longer passes Verify on its own;
+// fetch its payload with FetchPayload first.
+func (c *Candidate) Offload(ctx context.Context, backend DABackend) error {
+	ref, err := backend.Put(ctx, c.Payload)
+	if err != nil {
+		return err
+	}
+	c.DARef = ref.String()
+	c.Payload = nil
+	return nil
+}
+
+// Offloaded reports whether the payload lives in a DA layer
+func (c *Candidate) Offloaded() bool {
+	return c.Payload == nil && c.DARef != ""
+}
+
+// FetchPayload fetches an offloaded candidate's payload from backend and
+// checks it against the candidate ID, so a certificate verifier never
+// accepts bytes the DA layer tampered with
+func FetchPayload(ctx context.Context, backend DABackend, c *Candidate) ([]byte, error) {
+	if !c.Offloaded() {
+		return nil, ErrNotOffloaded
+	}
+	ref, err := ParseDARef(c.DARef)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := backend.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	full := *c
+	full.Payload = payload
+	if full.ComputeID() != c.ID {
+		return nil, fmt.Errorf("%w: %s", ErrDAHashMismatch, c.DARef)
+	}
+	return payload, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+// tamperingDA returns altered bytes for every Get
+type tamperingDA struct{ *LocalDABackend }
+
+func (t tamperingDA) Get(ctx context.Context, ref DARef) ([]byte, error) {
+	payload, err := t.LocalDABackend.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return append(payload, '!'), nil
+}
+
+func TestLocalDABackendRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	da := NewLocalDABackend()
+	payload := []byte("block body")
+
+	c, err := NewCandidateWithDA(ctx, da, []byte("chain"), payload, testCandidateID(1), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Offloaded() || c.Payload != nil {
+		t.Fatal("expected the payload to be offloaded")
+	}
+	if c.ID != NewCandidate([]byte("chain"), payload, testCandidateID(1), 1).ID {
+		t.Fatal("offloading changed the candidate ID")
+	}
+
+	ref, err := ParseDARef(c.DARef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Type != DATypeLocal {
+		t.Fatalf("expected a local reference, got %q", ref.Type)
+	}
+
+	got, err := FetchPayload(ctx, da, c)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("expected %q, got %q", payload, got)
+	}
+
+	// Rehydrated, the candidate verifies as usual
+	c.Payload = got
+	if err := c.Validate(); err != nil {
+		t.Fatalf("rehydrated candidate: %v", err)
+	}
+	if _, err := FetchPayload(ctx, da, c); !errors.Is(err, ErrNotOffloaded) {
+		t.Fatalf("expected ErrNotOffloaded, got %v", err)
+	}
+}
+
+func TestFetchPayloadRejectsTamperedBytes(t *testing.T) {
+	ctx := context.Background()
+	da := NewLocalDABackend()
+
+	c, err := NewCandidateWithDA(ctx, da, []byte("chain"), []byte("honest"), testCandidateID(1), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FetchPayload(ctx, tamperingDA{da}, c); !errors.Is(err, ErrDAHashMismatch) {
+		t.Fatalf("expected ErrDAHashMismatch, got %v", err)
+	}
+}
+
+func TestFetchPayloadBadReference(t *testing.T) {
+	ctx := context.Background()
+	da := NewLocalDABackend()
+	c := &Candidate{Domain: []byte("chain")}
+
+	for ref, want := range map[string]error{
+		"no-separator":   ErrDARefMalformed,
+		"ipfs:bafy":      ErrDARefType,
+		"local:deadbeef": ErrDANotFound,
+	} {
+		c.DARef = ref
+		if _, err := FetchPayload(ctx, da, c); !errors.Is(err, want) {
+			t.Errorf("%s: expected %v, got %v", ref, want, err)
+		}
+	}
+}
@@ -111,8 +111,8 @@ All messages use the same format regardless of K:
 	candidate := wire.NewCandidate(
 	    cfg.Domain,
 	    []byte("What's the best approach?"),
-	    wire.EmptyCandidateID,
-	    1,
+	    wire.EmptyCandidateID, // genesis: only valid at height 0
+	    0,
 	)
 
 	// Collect votes
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// EPOCH POLICY: Validator Set Rotation
+// =============================================================================
+
+// Epoch policy errors
+var (
+	ErrNoValidatorSet   = errors.New("no validator set covers candidate height")
+	ErrEpochOrder       = errors.New("validator set epochs must increase with their start height")
+	ErrUnknownCandidate = errors.New("vote for a candidate the policy has not observed")
+)
+
+// ValidatorRotator is implemented by sequencers and policies whose voter set
+// can rotate without a restart
+type ValidatorRotator interface {
+	// SetValidatorSet installs the voters of epoch for candidates at or
+	// above fromHeight. Candidates below it keep finalizing under the set
+	// they were observed with. A voter missing from weights has weight 1.
+	SetValidatorSet(epoch, fromHeight uint64, voters []VoterID, weights map[VoterID]uint64) error
+}
+
+// EpochPolicy is a stake-weighted quorum whose validator set rotates by
+// epoch. Each candidate is bound to the epoch covering its height when it
+// is observed, and only that epoch's voters count toward it, so in-flight
+// candidates finalize under the set they started with.
+type EpochPolicy struct {
+	voteVerification
+
+	mu         sync.RWMutex
+	fraction   float64
+	epochs     []*policyEpoch // ascending by fromHeight
+	candidates map[CandidateID]*policyEpoch
+}
+
+// policyEpoch is one validator set and the quorum counting its votes
+type policyEpoch struct {
+	epoch      uint64
+	fromHeight uint64
+	quorum     *WeightedQuorumPolicy
+}
+
+// NewEpochPolicy creates an epoch policy that finalizes a candidate once
+// fraction of its epoch's weight accepts it. It has no validator set until
+// the first SetValidatorSet.
+func NewEpochPolicy(fraction float64) *EpochPolicy {
+	return &EpochPolicy{
+		fraction:   fraction,
+		candidates: make(map[CandidateID]*policyEpoch),
+	}
+}
+
+var _ ValidatorRotator = (*EpochPolicy)(nil)
+
+func (p *EpochPolicy) SetValidatorSet(epoch, fromHeight uint64, voters []VoterID, weights map[VoterID]uint64) error {
+	table := make(map[VoterID]uint64, len(voters))
+	for _, id := range voters {
+		w, ok := weights[id]
+		if !ok {
+			w = 1
+		}
+		table[id] = w
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := len(p.epochs); n > 0 {
+		last := p.epochs[n-1]
+		if epoch <= last.epoch || fromHeight <= last.fromHeight {
+			return fmt.Errorf("%w: epoch %d at height %d after epoch %d at height %d",
+				ErrEpochOrder, epoch, fromHeight, last.epoch, last.fromHeight)
+		}
+	}
+	p.epochs = append(p.epochs, &policyEpoch{
+		epoch:      epoch,
+		fromHeight: fromHeight,
+		quorum:     NewWeightedQuorumPolicy(table, p.fraction),
+	})
+	return nil
+}
+
+// EpochAt returns the epoch whose validator set covers height
+func (p *EpochPolicy) EpochAt(height uint64) (uint64, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e := p.epochAtLocked(height)
+	if e == nil {
+		return 0, false
+	}
+	return e.epoch, true
+}
+
+// epochAtLocked returns the latest epoch starting at or below height
+// Must be called with p.mu held
+func (p *EpochPolicy) epochAtLocked(height uint64) *policyEpoch {
+	i := sort.Search(len(p.epochs), func(i int) bool { return p.epochs[i].fromHeight > height })
+	if i == 0 {
+		return nil
+	}
+	return p.epochs[i-1]
+}
+
+func (p *EpochPolicy) PolicyID() PolicyID {
+	return PolicyWeightedQuorum
+}
+
+func (p *EpochPolicy) OnCandidate(ctx context.Context, candidate *Candidate) error {
+	p.mu.Lock()
+	e := p.epochAtLocked(candidate.Height)
+	if e == nil {
+		p.mu.Unlock()
+		return fmt.Errorf("%w: height %d", ErrNoValidatorSet, candidate.Height)
+	}
+	if _, ok := p.candidates[candidate.ID]; !ok && len(p.candidates) >= maxCandidates {
+		p.mu.Unlock()
+		return fmt.Errorf("candidate limit reached (%d)", maxCandidates)
+	}
+	p.candidates[candidate.ID] = e
+	p.mu.Unlock()
+
+	return e.quorum.OnCandidate(ctx, candidate)
+}
+
+// OnVote counts the vote under the candidate's epoch. Votes from voters
+// outside that epoch's set are rejected with ErrUnknownVoter.
+func (p *EpochPolicy) OnVote(ctx context.Context, vote *Vote) error {
+	if err := p.verifyVote(ctx, vote); err != nil {
+		return err
+	}
+
+	e, err := p.candidateEpoch(vote.CandidateID)
+	if err != nil {
+		return err
+	}
+	return e.quorum.OnVote(ctx, vote)
+}
+
+func (p *EpochPolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	e, err := p.candidateEpoch(candidateID)
+	if err != nil {
+		return nil, nil // Not observed yet
+	}
+	return e.quorum.MaybeFinalize(ctx, candidateID)
+}
+
+// Verify checks cert against the validator set its candidate was bound to,
+// or for a candidate this policy never observed, the set covering its height
+func (p *EpochPolicy) Verify(ctx context.Context, cert *Certificate) (bool, error) {
+	p.mu.RLock()
+	e, ok := p.candidates[cert.CandidateID]
+	if !ok {
+		e = p.epochAtLocked(cert.Height)
+	}
+	p.mu.RUnlock()
+	if e == nil {
+		return false, nil
+	}
+	return e.quorum.Verify(ctx, cert)
+}
+
+func (p *EpochPolicy) candidateEpoch(id CandidateID) (*policyEpoch, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.candidates[id]
+	if !ok {
+		return nil, ErrUnknownCandidate
+	}
+	return e, nil
+}
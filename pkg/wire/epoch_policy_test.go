@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func epochVoters(names ...string) []VoterID {
+	ids := make([]VoterID, len(names))
+	for i, n := range names {
+		ids[i] = DeriveVoterID("agent", []byte(n))
+	}
+	return ids
+}
+
+func acceptFrom(t *testing.T, p FinalityPolicy, c *Candidate, voters ...VoterID) {
+	t.Helper()
+	for _, v := range voters {
+		if err := p.OnVote(context.Background(), NewVote(c.ID, v, 0, true)); err != nil {
+			t.Fatalf("vote: %v", err)
+		}
+	}
+}
+
+func TestEpochPolicyRotatesMidStream(t *testing.T) {
+	ctx := context.Background()
+	old := epochVoters("a", "b", "c")
+	next := epochVoters("c", "d", "e")
+
+	p := NewEpochPolicy(2.0 / 3.0)
+	if err := p.SetValidatorSet(1, 0, old, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A candidate from epoch 1 is in flight when the set rotates at height 10
+	inFlight := NewCandidate([]byte("test"), []byte("old"), CandidateID{}, 9)
+	if err := p.OnCandidate(ctx, inFlight); err != nil {
+		t.Fatal(err)
+	}
+	acceptFrom(t, p, inFlight, old[0])
+
+	if err := p.SetValidatorSet(2, 10, next, map[VoterID]uint64{next[2]: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := p.EpochAt(9); e != 1 {
+		t.Fatalf("height 9: expected epoch 1, got %d", e)
+	}
+	if e, _ := p.EpochAt(10); e != 2 {
+		t.Fatalf("height 10: expected epoch 2, got %d", e)
+	}
+
+	fresh := NewCandidate([]byte("test"), []byte("new"), inFlight.ID, 10)
+	if err := p.OnCandidate(ctx, fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	// Epoch 2's voters do not count toward the epoch 1 candidate, and the
+	// old voters do not count toward the new one
+	if err := p.OnVote(ctx, NewVote(inFlight.ID, next[1], 0, true)); !errors.Is(err, ErrUnknownVoter) {
+		t.Fatalf("expected ErrUnknownVoter for a new voter on an old candidate, got %v", err)
+	}
+	if err := p.OnVote(ctx, NewVote(fresh.ID, old[0], 0, true)); !errors.Is(err, ErrUnknownVoter) {
+		t.Fatalf("expected ErrUnknownVoter for an old voter on a new candidate, got %v", err)
+	}
+
+	// The old candidate finalizes with 2 of 3 old voters
+	acceptFrom(t, p, inFlight, old[1])
+	oldCert, err := p.MaybeFinalize(ctx, inFlight.ID)
+	if err != nil || oldCert == nil {
+		t.Fatalf("expected the old candidate to finalize, got %v, %v", oldCert, err)
+	}
+
+	// The new set weighs e at 4 of 6: e alone reaches 2/3
+	if cert, _ := p.MaybeFinalize(ctx, fresh.ID); cert != nil {
+		t.Fatal("new candidate finalized without votes")
+	}
+	acceptFrom(t, p, fresh, next[2])
+	newCert, err := p.MaybeFinalize(ctx, fresh.ID)
+	if err != nil || newCert == nil {
+		t.Fatalf("expected the new candidate to finalize, got %v, %v", newCert, err)
+	}
+
+	for _, cert := range []*Certificate{oldCert, newCert} {
+		if ok, err := p.Verify(ctx, cert); err != nil || !ok {
+			t.Fatalf("certificate at height %d does not verify: %v", cert.Height, err)
+		}
+	}
+}
+
+func TestEpochPolicyRejectsBadRotation(t *testing.T) {
+	ctx := context.Background()
+	p := NewEpochPolicy(0.5)
+
+	c := NewCandidate([]byte("test"), []byte("early"), CandidateID{}, 5)
+	if err := p.OnCandidate(ctx, c); !errors.Is(err, ErrNoValidatorSet) {
+		t.Fatalf("expected ErrNoValidatorSet, got %v", err)
+	}
+	if err := p.OnVote(ctx, NewVote(c.ID, epochVoters("a")[0], 0, true)); !errors.Is(err, ErrUnknownCandidate) {
+		t.Fatalf("expected ErrUnknownCandidate, got %v", err)
+	}
+
+	if err := p.SetValidatorSet(1, 10, epochVoters("a"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OnCandidate(ctx, c); !errors.Is(err, ErrNoValidatorSet) {
+		t.Fatalf("height below the first epoch: expected ErrNoValidatorSet, got %v", err)
+	}
+	if err := p.SetValidatorSet(1, 20, epochVoters("b"), nil); !errors.Is(err, ErrEpochOrder) {
+		t.Fatalf("repeated epoch: expected ErrEpochOrder, got %v", err)
+	}
+	if err := p.SetValidatorSet(2, 10, epochVoters("b"), nil); !errors.Is(err, ErrEpochOrder) {
+		t.Fatalf("repeated start height: expected ErrEpochOrder, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrBackpressure is returned by SubmitCandidate when the proposer is over
+// its rate limit or its in-flight window is full. The caller should retry
+// after candidates finalize instead of buffering.
+var ErrBackpressure = errors.New("candidate pipeline backpressure")
+
+// ErrDraining is returned by SubmitCandidate once the sequencer has begun
+// shutting down
+var ErrDraining = errors.New("candidate pipeline draining")
+
+// DrainError is returned by Drain (and Sequencer.Shutdown) when candidates
+// were still awaiting finality at the deadline
+type DrainError struct {
+	Unfinalized []CandidateID // ordered by ID
+	Err         error         // the context error that ended the drain
+}
+
+func (e *DrainError) Error() string {
+	return fmt.Sprintf("%d candidates unfinalized at shutdown: %v", len(e.Unfinalized), e.Err)
+}
+
+func (e *DrainError) Unwrap() error {
+	return e.Err
+}
+
+// FlowConfig bounds how fast candidates enter the pipeline
+type FlowConfig struct {
+	// Rate is the sustained number of candidates admitted per second
+	// (0 = unlimited)
+	Rate float64 `json:"rate"`
+
+	// Burst is the token-bucket capacity; values below 1 are treated as 1
+	Burst int `json:"burst"`
+
+	// Window is the maximum number of submitted, not yet finalized
+	// candidates (0 = unbounded)
+	Window int `json:"window"`
+}
+
+// FlowControl admits candidates through a token-bucket rate limiter and a
+// bounded in-flight window. Sequencer implementations call Admit from
+// SubmitCandidate, Release once a candidate finalizes and Drain from
+// Shutdown.
+type FlowControl struct {
+	cfg FlowConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	tokens   float64
+	last     time.Time
+	inFlight map[CandidateID]struct{}
+
+	// drained is set by Drain and closed once the window empties
+	drained chan struct{}
+}
+
+// NewFlowControl returns a FlowControl starting with a full bucket
+func NewFlowControl(cfg FlowConfig) *FlowControl {
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	return &FlowControl{
+		cfg:      cfg,
+		now:      time.Now,
+		tokens:   float64(cfg.Burst),
+		inFlight: make(map[CandidateID]struct{}),
+	}
+}
+
+// Admit reserves a window slot and a rate token for id, or returns
+// ErrBackpressure. Admitting an id already in flight is a no-op.
+func (f *FlowControl) Admit(id CandidateID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.inFlight[id]; ok {
+		return nil
+	}
+	if f.drained != nil {
+		return ErrDraining
+	}
+	if f.cfg.Window > 0 && len(f.inFlight) >= f.cfg.Window {
+		return fmt.Errorf("%w: window full (%d in flight)", ErrBackpressure, len(f.inFlight))
+	}
+	if f.cfg.Rate > 0 {
+		f.refill()
+		if f.tokens < 1 {
+			return fmt.Errorf("%w: rate limit %.2f/s exceeded", ErrBackpressure, f.cfg.Rate)
+		}
+		f.tokens--
+	}
+	f.inFlight[id] = struct{}{}
+	return nil
+}
+
+// refill adds the tokens accrued since the last call
+// Must be called with f.mu held
+func (f *FlowControl) refill() {
+	now := f.now()
+	if !f.last.IsZero() {
+		f.tokens += now.Sub(f.last).Seconds() * f.cfg.Rate
+		if burst := float64(f.cfg.Burst); f.tokens > burst {
+			f.tokens = burst
+		}
+	}
+	f.last = now
+}
+
+// Release frees id's window slot when it finalizes or is abandoned
+func (f *FlowControl) Release(id CandidateID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.inFlight, id)
+	f.checkDrained()
+}
+
+// Drain stops admitting candidates and waits until every in-flight
+// candidate is released or ctx is done. It returns a *DrainError listing
+// the candidates still in flight if ctx ended the wait. Calling Drain
+// again waits on the same drain.
+func (f *FlowControl) Drain(ctx context.Context) error {
+	f.mu.Lock()
+	if f.drained == nil {
+		f.drained = make(chan struct{})
+		f.checkDrained()
+	}
+	drained := f.drained
+	f.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.inFlight) == 0 {
+		return nil
+	}
+	unfinalized := make([]CandidateID, 0, len(f.inFlight))
+	for id := range f.inFlight {
+		unfinalized = append(unfinalized, id)
+	}
+	slices.SortFunc(unfinalized, func(a, b CandidateID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return &DrainError{Unfinalized: unfinalized, Err: ctx.Err()}
+}
+
+// Draining reports whether Drain has been called
+func (f *FlowControl) Draining() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.drained != nil
+}
+
+// checkDrained closes drained once a draining window empties
+// Must be called with f.mu held
+func (f *FlowControl) checkDrained() {
+	if f.drained == nil || len(f.inFlight) > 0 {
+		return
+	}
+	select {
+	case <-f.drained:
+	default:
+		close(f.drained)
+	}
+}
+
+// InFlight returns the current window occupancy
+func (f *FlowControl) InFlight() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.inFlight)
+}
+
+// Window returns the window size (0 = unbounded)
+func (f *FlowControl) Window() int {
+	return f.cfg.Window
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func testCandidateID(i int) CandidateID {
+	return DeriveItemID([]byte{byte(i >> 8), byte(i)})
+}
+
+func TestFlowControlWindow(t *testing.T) {
+	f := NewFlowControl(FlowConfig{Window: 3})
+
+	for i := 0; i < 3; i++ {
+		if err := f.Admit(testCandidateID(i)); err != nil {
+			t.Fatalf("admit %d: %v", i, err)
+		}
+	}
+	if got := f.InFlight(); got != 3 {
+		t.Fatalf("expected 3 in flight, got %d", got)
+	}
+
+	// Window full: rejected rather than buffered
+	if err := f.Admit(testCandidateID(3)); !errors.Is(err, ErrBackpressure) {
+		t.Fatalf("expected ErrBackpressure at the limit, got %v", err)
+	}
+	if got := f.InFlight(); got != 3 {
+		t.Fatalf("rejected submission changed occupancy to %d", got)
+	}
+
+	// Re-submitting an in-flight candidate is not double counted
+	if err := f.Admit(testCandidateID(0)); err != nil {
+		t.Fatalf("resubmit in-flight candidate: %v", err)
+	}
+
+	// Finalization frees a slot
+	f.Release(testCandidateID(1))
+	if err := f.Admit(testCandidateID(3)); err != nil {
+		t.Fatalf("admit after release: %v", err)
+	}
+	if got := f.InFlight(); got != f.Window() {
+		t.Fatalf("expected full window %d, got %d", f.Window(), got)
+	}
+}
+
+func TestFlowControlRateLimit(t *testing.T) {
+	clock := time.Unix(0, 0)
+	f := NewFlowControl(FlowConfig{Rate: 2, Burst: 2})
+	f.now = func() time.Time { return clock }
+
+	for i := 0; i < 2; i++ {
+		if err := f.Admit(testCandidateID(i)); err != nil {
+			t.Fatalf("burst admit %d: %v", i, err)
+		}
+	}
+	if err := f.Admit(testCandidateID(2)); !errors.Is(err, ErrBackpressure) {
+		t.Fatalf("expected ErrBackpressure past the burst, got %v", err)
+	}
+
+	// Half a second at 2/s refills one token
+	clock = clock.Add(500 * time.Millisecond)
+	if err := f.Admit(testCandidateID(2)); err != nil {
+		t.Fatalf("admit after refill: %v", err)
+	}
+	if err := f.Admit(testCandidateID(3)); !errors.Is(err, ErrBackpressure) {
+		t.Fatalf("expected ErrBackpressure with empty bucket, got %v", err)
+	}
+
+	// A long idle period refills no more than the burst
+	clock = clock.Add(time.Hour)
+	for i := 3; i < 5; i++ {
+		if err := f.Admit(testCandidateID(i)); err != nil {
+			t.Fatalf("admit %d after idle: %v", i, err)
+		}
+	}
+	if err := f.Admit(testCandidateID(5)); !errors.Is(err, ErrBackpressure) {
+		t.Fatalf("expected bucket capped at burst, got %v", err)
+	}
+}
+
+func TestPresetConfigsHaveFlowControl(t *testing.T) {
+	domain := []byte("flow")
+	for name, cfg := range map[string]SequencerConfig{
+		"single":     SingleNodeConfig(domain),
+		"agent-mesh": AgentMeshConfig(domain, 5),
+		"blockchain": BlockchainConfig(domain),
+		"rollup":     RollupConfig(domain),
+	} {
+		if cfg.Flow.Window <= 0 {
+			t.Errorf("%s: expected a bounded window, got %d", name, cfg.Flow.Window)
+		}
+	}
+}
+
+func TestFlowControlDrain(t *testing.T) {
+	f := NewFlowControl(FlowConfig{})
+	for i := 0; i < 5; i++ {
+		if err := f.Admit(testCandidateID(i)); err != nil {
+			t.Fatalf("admit %d: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() { result <- f.Drain(ctx) }()
+
+	// New candidates are refused once draining begins
+	deadline := time.Now().Add(time.Second)
+	for !f.Draining() {
+		if time.Now().After(deadline) {
+			t.Fatal("drain did not begin")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := f.Admit(testCandidateID(9)); !errors.Is(err, ErrDraining) {
+		t.Fatalf("expected ErrDraining while draining, got %v", err)
+	}
+
+	// Two finalize and one is abandoned during the drain; two time out
+	f.Release(testCandidateID(0))
+	f.Release(testCandidateID(3))
+	f.Release(testCandidateID(4))
+
+	err := <-result
+	var drainErr *DrainError
+	if !errors.As(err, &drainErr) {
+		t.Fatalf("expected *DrainError, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be wrapped, got %v", err)
+	}
+	want := []CandidateID{testCandidateID(1), testCandidateID(2)}
+	slices.SortFunc(want, func(a, b CandidateID) int { return bytes.Compare(a[:], b[:]) })
+	if !slices.Equal(drainErr.Unfinalized, want) {
+		t.Fatalf("unfinalized = %x, want %x", drainErr.Unfinalized, want)
+	}
+}
+
+func TestFlowControlDrainCompletes(t *testing.T) {
+	f := NewFlowControl(FlowConfig{})
+	for i := 0; i < 3; i++ {
+		if err := f.Admit(testCandidateID(i)); err != nil {
+			t.Fatalf("admit %d: %v", i, err)
+		}
+	}
+
+	result := make(chan error, 1)
+	go func() { result <- f.Drain(context.Background()) }()
+	for i := 0; i < 3; i++ {
+		f.Release(testCandidateID(i))
+	}
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("drain: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("drain did not return once every candidate finalized")
+	}
+
+	// An empty window drains at once, and stays closed to new candidates
+	if err := f.Drain(context.Background()); err != nil {
+		t.Fatalf("second drain: %v", err)
+	}
+	if err := f.Admit(testCandidateID(7)); !errors.Is(err, ErrDraining) {
+		t.Fatalf("expected ErrDraining after drain, got %v", err)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package gateway relays wire protocol messages between WebSocket clients
+// and an in-process Sequencer.
+//
+// Every frame is a JSON-encoded Frame. On connect the gateway sends a
+// challenge nonce; the client's first frame must be a hello carrying its
+// VoterID, the public key it derives from and a signature over the nonce.
+// After the welcome frame the client may submit candidates and vote as the
+// VoterID it authenticated as. A vote for any other VoterID drops the
+// connection. Candidates entering the pipeline and certificates formed by
+// the sequencer's finality policy are pushed to every client.
+package gateway
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/luxfi/consensus/pkg/wire"
+)
+
+// Frame types
+const (
+	FrameChallenge   = "challenge"
+	FrameHello       = "hello"
+	FrameWelcome     = "welcome"
+	FrameCandidate   = "candidate"
+	FrameVote        = "vote"
+	FrameCertificate = "certificate"
+	FrameError       = "error"
+)
+
+// HelloDomain separates hello signatures from other signed messages
+const HelloDomain = "WireGatewayHello/v1"
+
+// NonceSize is the length of the challenge nonce
+const NonceSize = 32
+
+// SendQueueSize is how many frames may wait for a slow client before the
+// gateway drops it
+const SendQueueSize = 64
+
+var (
+	ErrBadHello      = errors.New("first frame must be a hello")
+	ErrUnauthorized  = errors.New("voter authentication failed")
+	ErrNotValidator  = errors.New("voter is not in the validator set")
+	ErrVoterMismatch = errors.New("vote for a voter the connection did not authenticate as")
+	ErrUnknownFrame  = errors.New("unknown frame type")
+)
+
+// Frame is the JSON envelope for every gateway message
+type Frame struct {
+	Type string `json:"type"`
+
+	// Nonce is set on challenge frames
+	Nonce []byte `json:"nonce,omitempty"`
+
+	// Hello is set on the client's first frame
+	Hello *Hello `json:"hello,omitempty"`
+
+	Candidate   *wire.Candidate   `json:"candidate,omitempty"`
+	Vote        *wire.Vote        `json:"vote,omitempty"`
+	Certificate *wire.Certificate `json:"certificate,omitempty"`
+
+	// Error is set on error frames
+	Error string `json:"error,omitempty"`
+}
+
+// Hello authenticates a client as VoterID
+type Hello struct {
+	VoterID   wire.VoterID `json:"voter_id"`
+	PublicKey []byte       `json:"public_key"`
+	Signature []byte       `json:"signature"`
+}
+
+// HelloMessage returns the bytes a client signs to answer nonce as voterID
+func HelloMessage(nonce []byte, voterID wire.VoterID) []byte {
+	msg := make([]byte, 0, len(HelloDomain)+len(nonce)+len(voterID))
+	msg = append(msg, HelloDomain...)
+	msg = append(msg, nonce...)
+	return append(msg, voterID[:]...)
+}
+
+// SignHello answers nonce with an Ed25519 key. The VoterID is derived from
+// the public key, so it matches the node ID for the same key.
+func SignHello(key ed25519.PrivateKey, nonce []byte) *Hello {
+	pub := key.Public().(ed25519.PublicKey)
+	id := wire.VoterIDFromPublicKey(pub)
+	return &Hello{
+		VoterID:   id,
+		PublicKey: pub,
+		Signature: ed25519.Sign(key, HelloMessage(nonce, id)),
+	}
+}
+
+// Authenticator checks a hello against the challenge nonce
+type Authenticator func(hello *Hello, nonce []byte) error
+
+// VerifyEd25519 accepts a hello signed by an Ed25519 key whose derived
+// VoterID matches the claimed one
+func VerifyEd25519(hello *Hello, nonce []byte) error {
+	if len(hello.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: bad public key size %d", ErrUnauthorized, len(hello.PublicKey))
+	}
+	if wire.VoterIDFromPublicKey(hello.PublicKey) != hello.VoterID {
+		return fmt.Errorf("%w: voter ID does not match public key", ErrUnauthorized)
+	}
+	if !ed25519.Verify(hello.PublicKey, HelloMessage(nonce, hello.VoterID), hello.Signature) {
+		return fmt.Errorf("%w: bad signature", ErrUnauthorized)
+	}
+	return nil
+}
+
+// Config configures a Gateway
+type Config struct {
+	// Authenticate checks the hello frame (default VerifyEd25519)
+	Authenticate Authenticator
+
+	// HelloTimeout bounds how long a client has to authenticate
+	// (default 10s)
+	HelloTimeout time.Duration
+
+	// PushWindow is how many heights below the highest certified one the
+	// gateway remembers which certificates it pushed. Certificates below
+	// the window are not pushed. (default DefaultPushWindow)
+	PushWindow uint64
+}
+
+// DefaultPushWindow is the PushWindow used when Config leaves it unset
+const DefaultPushWindow = 1024
+
+// Gateway serves WebSocket clients on behalf of a Sequencer
+type Gateway struct {
+	seq wire.Sequencer
+	cfg Config
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	pushed  map[wire.CandidateID]struct{} // certificates pushed in the window
+	heights map[uint64][]wire.CandidateID // pushed certificates by height
+	top     uint64                        // highest certified height
+	floor   uint64                        // lowest height in the window
+}
+
+// New returns a gateway relaying to seq
+func New(seq wire.Sequencer, cfg Config) *Gateway {
+	if cfg.Authenticate == nil {
+		cfg.Authenticate = VerifyEd25519
+	}
+	if cfg.HelloTimeout <= 0 {
+		cfg.HelloTimeout = 10 * time.Second
+	}
+	if cfg.PushWindow == 0 {
+		cfg.PushWindow = DefaultPushWindow
+	}
+	return &Gateway{
+		seq:     seq,
+		cfg:     cfg,
+		clients: make(map[*client]struct{}),
+		pushed:  make(map[wire.CandidateID]struct{}),
+		heights: make(map[uint64][]wire.CandidateID),
+	}
+}
+
+// Handler returns the HTTP handler that upgrades requests to WebSocket.
+// Origins are not checked: clients prove who they are with a signed hello.
+func (g *Gateway) Handler() http.Handler {
+	return websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   g.serve,
+	}
+}
+
+// Clients returns the number of authenticated clients
+func (g *Gateway) Clients() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.clients)
+}
+
+// Close disconnects every client
+func (g *Gateway) Close() {
+	g.mu.Lock()
+	clients := make([]*client, 0, len(g.clients))
+	for c := range g.clients {
+		clients = append(clients, c)
+	}
+	g.mu.Unlock()
+
+	for _, c := range clients {
+		g.drop(c)
+	}
+}
+
+// client is an authenticated connection
+type client struct {
+	conn  *websocket.Conn
+	voter wire.VoterID
+	send  chan *Frame
+	done  chan struct{}
+	once  sync.Once
+}
+
+func (g *Gateway) serve(conn *websocket.Conn) {
+	defer conn.Close()
+	ctx := conn.Request().Context()
+
+	voter, err := g.handshake(conn)
+	if err != nil {
+		_ = websocket.JSON.Send(conn, &Frame{Type: FrameError, Error: err.Error()})
+		return
+	}
+	if err := g.checkValidator(ctx, voter); err != nil {
+		_ = websocket.JSON.Send(conn, &Frame{Type: FrameError, Error: err.Error()})
+		return
+	}
+
+	c := &client{
+		conn:  conn,
+		voter: voter,
+		send:  make(chan *Frame, SendQueueSize),
+		done:  make(chan struct{}),
+	}
+	g.mu.Lock()
+	g.clients[c] = struct{}{}
+	g.mu.Unlock()
+	defer g.drop(c)
+
+	go g.writeLoop(c)
+	g.enqueue(c, &Frame{Type: FrameWelcome})
+
+	for {
+		var f Frame
+		if err := websocket.JSON.Receive(conn, &f); err != nil {
+			return
+		}
+		if err := g.handle(ctx, c, &f); err != nil {
+			if errors.Is(err, ErrVoterMismatch) || errors.Is(err, ErrUnknownFrame) {
+				return
+			}
+			g.enqueue(c, &Frame{Type: FrameError, Error: err.Error()})
+		}
+	}
+}
+
+// handshake sends the challenge and authenticates the first frame
+func (g *Gateway) handshake(conn *websocket.Conn) (wire.VoterID, error) {
+	nonce := make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return wire.EmptyVoterID, err
+	}
+	if err := websocket.JSON.Send(conn, &Frame{Type: FrameChallenge, Nonce: nonce}); err != nil {
+		return wire.EmptyVoterID, err
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(g.cfg.HelloTimeout)); err != nil {
+		return wire.EmptyVoterID, err
+	}
+	var f Frame
+	if err := websocket.JSON.Receive(conn, &f); err != nil {
+		return wire.EmptyVoterID, err
+	}
+	if f.Type != FrameHello || f.Hello == nil {
+		return wire.EmptyVoterID, ErrBadHello
+	}
+	if err := g.cfg.Authenticate(f.Hello, nonce); err != nil {
+		return wire.EmptyVoterID, err
+	}
+	return f.Hello.VoterID, conn.SetReadDeadline(time.Time{})
+}
+
+// checkValidator rejects voters outside the sequencer's membership, if it
+// has one
+func (g *Gateway) checkValidator(ctx context.Context, voter wire.VoterID) error {
+	m := g.seq.Membership()
+	if m == nil {
+		return nil
+	}
+	ok, err := m.IsValidator(ctx, voter)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotValidator
+	}
+	return nil
+}
+
+// handle relays one client frame to the sequencer
+func (g *Gateway) handle(ctx context.Context, c *client, f *Frame) error {
+	switch f.Type {
+	case FrameCandidate:
+		if f.Candidate == nil {
+			return errors.New("candidate frame without candidate")
+		}
+		if err := wire.ValidateSubmission(ctx, g.seq.Finality(), f.Candidate); err != nil {
+			return err
+		}
+		if err := g.seq.SubmitCandidate(ctx, f.Candidate); err != nil {
+			return err
+		}
+		g.broadcast(&Frame{Type: FrameCandidate, Candidate: f.Candidate})
+		return nil
+
+	case FrameVote:
+		if f.Vote == nil {
+			return errors.New("vote frame without vote")
+		}
+		if f.Vote.VoterID != c.voter {
+			return ErrVoterMismatch
+		}
+		finality := g.seq.Finality()
+		if err := finality.OnVote(ctx, f.Vote); err != nil {
+			return err
+		}
+		cert, err := finality.MaybeFinalize(ctx, f.Vote.CandidateID)
+		if err != nil || cert == nil {
+			return err
+		}
+		g.pushCertificate(cert)
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFrame, f.Type)
+	}
+}
+
+// pushCertificate sends cert to every client the first time it forms,
+// unless it is below the push window
+func (g *Gateway) pushCertificate(cert *wire.Certificate) {
+	g.mu.Lock()
+	if _, dup := g.pushed[cert.CandidateID]; dup || cert.Height < g.floor {
+		g.mu.Unlock()
+		return
+	}
+	g.pushed[cert.CandidateID] = struct{}{}
+	g.heights[cert.Height] = append(g.heights[cert.Height], cert.CandidateID)
+	if cert.Height > g.top {
+		g.top = cert.Height
+		g.slideLocked()
+	}
+	g.mu.Unlock()
+
+	g.broadcast(&Frame{Type: FrameCertificate, Certificate: cert})
+}
+
+// slideLocked moves the push window up to the highest certified height,
+// forgetting the certificates that fall out of it. Caller holds g.mu.
+func (g *Gateway) slideLocked() {
+	if g.top < g.cfg.PushWindow {
+		return
+	}
+	floor := g.top - g.cfg.PushWindow
+	if floor <= g.floor {
+		return
+	}
+	forget := func(h uint64) {
+		for _, id := range g.heights[h] {
+			delete(g.pushed, id)
+		}
+		delete(g.heights, h)
+	}
+	if floor-g.floor <= uint64(len(g.heights)) {
+		for h := g.floor; h < floor; h++ {
+			forget(h)
+		}
+	} else {
+		// The window jumped past more heights than are remembered
+		for h := range g.heights {
+			if h < floor {
+				forget(h)
+			}
+		}
+	}
+	g.floor = floor
+}
+
+// broadcast queues f for every client
+func (g *Gateway) broadcast(f *Frame) {
+	g.mu.Lock()
+	clients := make([]*client, 0, len(g.clients))
+	for c := range g.clients {
+		clients = append(clients, c)
+	}
+	g.mu.Unlock()
+
+	for _, c := range clients {
+		g.enqueue(c, f)
+	}
+}
+
+// enqueue queues f for c, dropping c if its queue is full
+func (g *Gateway) enqueue(c *client, f *Frame) {
+	select {
+	case <-c.done:
+	case c.send <- f:
+	default:
+		g.drop(c)
+	}
+}
+
+// writeLoop sends queued frames until c is dropped
+func (g *Gateway) writeLoop(c *client) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case f := <-c.send:
+			if err := websocket.JSON.Send(c.conn, f); err != nil {
+				g.drop(c)
+				return
+			}
+		}
+	}
+}
+
+// drop unregisters c and closes its connection
+func (g *Gateway) drop(c *client) {
+	c.once.Do(func() {
+		g.mu.Lock()
+		delete(g.clients, c)
+		g.mu.Unlock()
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
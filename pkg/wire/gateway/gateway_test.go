@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/luxfi/consensus/pkg/wire"
+)
+
+// testSequencer relays candidates and votes to a quorum policy. Methods the
+// gateway does not call are left to the nil embedded interface.
+type testSequencer struct {
+	wire.Sequencer
+	finality wire.FinalityPolicy
+}
+
+func (s *testSequencer) Membership() wire.Membership { return nil }
+
+func (s *testSequencer) Finality() wire.FinalityPolicy { return s.finality }
+
+func (s *testSequencer) SubmitCandidate(ctx context.Context, c *wire.Candidate) error {
+	return s.finality.OnCandidate(ctx, c)
+}
+
+func newTestGateway(t *testing.T, threshold, total int) (*Gateway, string) {
+	g := New(&testSequencer{finality: wire.NewQuorumPolicy(threshold, total)}, Config{})
+	srv := httptest.NewServer(g.Handler())
+	t.Cleanup(func() {
+		g.Close()
+		srv.Close()
+	})
+	return g, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// testClient is an authenticated WebSocket voter
+type testClient struct {
+	t     *testing.T
+	conn  *websocket.Conn
+	voter wire.VoterID
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	conn, err := websocket.Dial(url, "", "http://localhost/")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func connect(t *testing.T, url string) *testClient {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &testClient{t: t, conn: dial(t, url)}
+
+	challenge := c.expect(FrameChallenge)
+	hello := SignHello(key, challenge.Nonce)
+	c.voter = hello.VoterID
+	c.send(&Frame{Type: FrameHello, Hello: hello})
+	c.expect(FrameWelcome)
+	return c
+}
+
+func (c *testClient) send(f *Frame) {
+	if err := websocket.JSON.Send(c.conn, f); err != nil {
+		c.t.Fatalf("send %s: %v", f.Type, err)
+	}
+}
+
+func (c *testClient) expect(typ string) *Frame {
+	if err := c.conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		c.t.Fatal(err)
+	}
+	var f Frame
+	if err := websocket.JSON.Receive(c.conn, &f); err != nil {
+		c.t.Fatalf("waiting for %s: %v", typ, err)
+	}
+	if f.Type != typ {
+		c.t.Fatalf("expected %s frame, got %s (%s)", typ, f.Type, f.Error)
+	}
+	return &f
+}
+
+func (c *testClient) vote(id wire.CandidateID) {
+	c.send(&Frame{Type: FrameVote, Vote: &wire.Vote{
+		CandidateID: id,
+		VoterID:     c.voter,
+		Preference:  true,
+		Signature:   []byte{wire.SigEd25519, 1},
+	}})
+}
+
+func TestGatewayVotesFormCertificate(t *testing.T) {
+	g, url := newTestGateway(t, 2, 3)
+	clients := []*testClient{connect(t, url), connect(t, url), connect(t, url)}
+	if got := g.Clients(); got != 3 {
+		t.Fatalf("expected 3 clients, got %d", got)
+	}
+
+	candidate := wire.NewCandidate([]byte("gw"), []byte("payload"), wire.CandidateID{}, 1)
+	clients[0].send(&Frame{Type: FrameCandidate, Candidate: candidate})
+	for i, c := range clients {
+		if f := c.expect(FrameCandidate); f.Candidate.ID != candidate.ID {
+			t.Fatalf("client %d received the wrong candidate", i)
+		}
+	}
+
+	// The first vote is short of the quorum; the second forms the certificate
+	clients[0].vote(candidate.ID)
+	clients[1].vote(candidate.ID)
+	for i, c := range clients {
+		f := c.expect(FrameCertificate)
+		if f.Certificate.CandidateID != candidate.ID {
+			t.Fatalf("client %d received a certificate for the wrong candidate", i)
+		}
+		if f.Certificate.PolicyID != wire.PolicyQuorum {
+			t.Fatalf("client %d: expected a quorum certificate, got policy %d", i, f.Certificate.PolicyID)
+		}
+	}
+
+	// A late vote does not push the certificate again: the next frame the
+	// voter sees is the following candidate
+	clients[2].vote(candidate.ID)
+	next := wire.NewCandidate([]byte("gw"), []byte("next"), candidate.ID, 2)
+	clients[2].send(&Frame{Type: FrameCandidate, Candidate: next})
+	if f := clients[2].expect(FrameCandidate); f.Candidate.ID != next.ID {
+		t.Fatal("received the wrong candidate after a late vote")
+	}
+}
+
+func TestGatewayDropsForeignVote(t *testing.T) {
+	g, url := newTestGateway(t, 1, 1)
+	c := connect(t, url)
+
+	forged := wire.DeriveVoterID(wire.NodeIDDomain, []byte("someone else"))
+	c.send(&Frame{Type: FrameVote, Vote: &wire.Vote{VoterID: forged, Preference: true}})
+
+	if err := c.conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	var f Frame
+	if err := websocket.JSON.Receive(c.conn, &f); err == nil {
+		t.Fatalf("expected the connection to be dropped, got %s frame", f.Type)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for g.Clients() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("client was not unregistered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestGatewayRejectsBadHello(t *testing.T) {
+	_, url := newTestGateway(t, 1, 1)
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]func(nonce []byte) *Frame{
+		"not a hello": func([]byte) *Frame {
+			return &Frame{Type: FrameVote, Vote: &wire.Vote{}}
+		},
+		"wrong nonce": func([]byte) *Frame {
+			return &Frame{Type: FrameHello, Hello: SignHello(key, make([]byte, NonceSize))}
+		},
+		"claimed voter": func(nonce []byte) *Frame {
+			h := SignHello(key, nonce)
+			h.VoterID = wire.DeriveVoterID(wire.NodeIDDomain, []byte("someone else"))
+			return &Frame{Type: FrameHello, Hello: h}
+		},
+	}
+	for name, first := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &testClient{t: t, conn: dial(t, url)}
+			challenge := c.expect(FrameChallenge)
+			c.send(first(challenge.Nonce))
+			c.expect(FrameError)
+		})
+	}
+}
+
+func TestGatewayPushWindow(t *testing.T) {
+	g := New(&testSequencer{finality: wire.NewQuorumPolicy(1, 1)}, Config{PushWindow: 4})
+	cert := func(height uint64, n byte) *wire.Certificate {
+		return &wire.Certificate{CandidateID: wire.CandidateID{byte(height), byte(height >> 8), n}, Height: height}
+	}
+	remembered := func(c *wire.Certificate) bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		_, ok := g.pushed[c.CandidateID]
+		return ok
+	}
+
+	// Two certificates per height; only the window's are remembered
+	for h := uint64(1); h <= 100; h++ {
+		g.pushCertificate(cert(h, 0))
+		g.pushCertificate(cert(h, 1))
+	}
+	if n := len(g.pushed); n > 2*5 {
+		t.Fatalf("remembered %d certificates for a window of 4 heights", n)
+	}
+	if len(g.pushed) != 2*len(g.heights) {
+		t.Fatalf("%d certificates remembered across %d heights", len(g.pushed), len(g.heights))
+	}
+	if !remembered(cert(100, 0)) || !remembered(cert(96, 1)) {
+		t.Fatal("certificates in the window were forgotten")
+	}
+	if remembered(cert(50, 0)) {
+		t.Fatal("a certificate below the window is still remembered")
+	}
+
+	// A late certificate below the window is not pushed or remembered
+	g.pushCertificate(cert(10, 7))
+	if remembered(cert(10, 7)) {
+		t.Fatal("a certificate below the window was recorded")
+	}
+
+	// A jump far past the window forgets everything below it
+	g.pushCertificate(cert(1_000_000, 0))
+	if len(g.pushed) != 1 || len(g.heights) != 1 {
+		t.Fatalf("expected only the new certificate after the jump, got %d", len(g.pushed))
+	}
+}
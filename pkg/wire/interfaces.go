@@ -143,11 +143,14 @@ type FinalityPolicy interface {
 	// OnCandidate handles a new candidate observation
 	OnCandidate(ctx context.Context, candidate *Candidate) error
 
-	// OnVote handles a vote for a candidate
+	// OnVote handles a vote for a candidate. Policies with VoteVerifiers
+	// set reject a vote whose signature does not verify before counting it.
 	OnVote(ctx context.Context, vote *Vote) error
 
 	// MaybeFinalize checks if candidate can be finalized
-	// Returns certificate if finalized, nil otherwise
+	// Returns certificate if finalized, nil otherwise. If ctx is done
+	// before the certificate is formed it returns ctx.Err() and no
+	// certificate, leaving the candidate to finalize on a later call.
 	MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error)
 
 	// Verify checks if a certificate is valid
@@ -221,8 +224,24 @@ type Sequencer interface {
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
 
+	// Shutdown stops accepting candidates, then waits until every
+	// submitted candidate finalizes or is abandoned, or ctx is done. If ctx
+	// ends the wait it returns a *DrainError listing the candidates that
+	// did not reach finality, so a rolling restart can resubmit them.
+	Shutdown(ctx context.Context) error
+
 	// Operations
+
+	// Submit builds a candidate from payload and enters it like
+	// SubmitCandidate
 	Submit(ctx context.Context, payload []byte) (*Candidate, error)
+
+	// SubmitCandidate enters a proposed candidate into the pipeline,
+	// subject to the configured FlowConfig. It first checks the candidate
+	// with ValidateSubmission against Finality() and returns its error.
+	// Returns ErrBackpressure instead of buffering when over the rate
+	// limit or the window is full, and ErrDraining once Shutdown has begun.
+	SubmitCandidate(ctx context.Context, candidate *Candidate) error
 	GetCandidate(ctx context.Context, id CandidateID) (*Candidate, error)
 	GetCertificate(ctx context.Context, id CandidateID) (*Certificate, error)
 
@@ -231,6 +250,9 @@ type Sequencer interface {
 	Height(ctx context.Context) (uint64, error)
 	IsSoftFinalized(ctx context.Context, id CandidateID) (bool, error)
 	IsHardFinalized(ctx context.Context, id CandidateID) (bool, error)
+
+	// InFlight returns how many submitted candidates await finality
+	InFlight() int
 }
 
 // =============================================================================
@@ -257,6 +279,14 @@ type SequencerConfig struct {
 	// Timeouts
 	RoundTimeoutMs    int64 `json:"round_timeout_ms"`
 	FinalityTimeoutMs int64 `json:"finality_timeout_ms"`
+
+	// Flow control for SubmitCandidate
+	Flow FlowConfig `json:"flow"`
+
+	// Compression of candidate payloads in transport. Transports take it
+	// to build the PayloadCodec that encodes what they send and decodes
+	// what they receive.
+	Compression CompressionConfig `json:"compression"`
 }
 
 // Preset configurations
@@ -273,6 +303,7 @@ func SingleNodeConfig(domain []byte) SequencerConfig {
 		HardPolicy:        PolicyNone,
 		RoundTimeoutMs:    100,
 		FinalityTimeoutMs: 100,
+		Flow:              FlowConfig{Window: 1024}, // Local: no rate limit
 	}
 }
 
@@ -288,6 +319,7 @@ func AgentMeshConfig(domain []byte, k int) SequencerConfig {
 		HardPolicy:        PolicyQuorum,
 		RoundTimeoutMs:    5000,
 		FinalityTimeoutMs: 30000,
+		Flow:              FlowConfig{Rate: 10, Burst: 20, Window: 32},
 	}
 }
 
@@ -303,6 +335,7 @@ func BlockchainConfig(domain []byte) SequencerConfig {
 		HardPolicy:        PolicyQuantum,
 		RoundTimeoutMs:    1000,
 		FinalityTimeoutMs: 60000,
+		Flow:              FlowConfig{Rate: 100, Burst: 200, Window: 256},
 	}
 }
 
@@ -318,5 +351,6 @@ func RollupConfig(domain []byte) SequencerConfig {
 		HardPolicy:        PolicyL1Inclusion, // L1 is hard
 		RoundTimeoutMs:    2000,
 		FinalityTimeoutMs: 600000, // 10 minutes for L1 + challenge
+		Flow:              FlowConfig{Rate: 50, Burst: 100, Window: 4096},
 	}
 }
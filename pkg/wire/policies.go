@@ -28,6 +28,7 @@ const maxCandidates = 100000
 // Each policy implements FinalityPolicy for different K scenarios:
 // - NonePolicy: K=1 self-sequencing (immediate finality)
 // - QuorumPolicy: K=small threshold signature (3/5, 2/3)
+// - WeightedQuorumPolicy: stake-weighted threshold (fraction of total weight)
 // - SamplePolicy: K=large metastable sampling
 // - L1Policy: K=external chain inclusion (OP Stack)
 // - QuantumPolicy: BLS + Corona post-quantum
@@ -74,6 +75,9 @@ func (p *NonePolicy) OnVote(ctx context.Context, vote *Vote) error {
 func (p *NonePolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if cert, ok := p.certs[candidateID]; ok {
 		return cert, nil
@@ -105,6 +109,8 @@ func (p *NonePolicy) Verify(ctx context.Context, cert *Certificate) (bool, error
 
 // QuorumPolicy provides threshold-based finality
 type QuorumPolicy struct {
+	voteVerification
+
 	mu         sync.RWMutex
 	threshold  int // Number of votes needed (e.g., 3 of 5)
 	total      int // Total validators
@@ -142,6 +148,10 @@ func (p *QuorumPolicy) OnCandidate(ctx context.Context, candidate *Candidate) er
 }
 
 func (p *QuorumPolicy) OnVote(ctx context.Context, vote *Vote) error {
+	if err := p.verifyVote(ctx, vote); err != nil {
+		return err
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -155,6 +165,9 @@ func (p *QuorumPolicy) OnVote(ctx context.Context, vote *Vote) error {
 func (p *QuorumPolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if cert, ok := p.certs[candidateID]; ok {
 		return cert, nil
@@ -181,6 +194,9 @@ func (p *QuorumPolicy) MaybeFinalize(ctx context.Context, candidateID CandidateI
 	var proof []byte
 	var signers []byte
 	for voterID, vote := range votes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if vote.Preference && len(vote.Signature) > 0 {
 			proof = append(proof, vote.Signature...)
 			signers = append(signers, voterID[:]...)
@@ -213,6 +229,8 @@ func (p *QuorumPolicy) Verify(ctx context.Context, cert *Certificate) (bool, err
 
 // SamplePolicy provides metastable consensus for large validator sets
 type SamplePolicy struct {
+	voteVerification
+
 	mu         sync.RWMutex
 	k          int     // Sample size per round
 	alpha      float64 // Agreement threshold
@@ -259,6 +277,10 @@ func (p *SamplePolicy) OnCandidate(ctx context.Context, candidate *Candidate) er
 }
 
 func (p *SamplePolicy) OnVote(ctx context.Context, vote *Vote) error {
+	if err := p.verifyVote(ctx, vote); err != nil {
+		return err
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -301,6 +323,9 @@ func (p *SamplePolicy) OnVote(ctx context.Context, vote *Vote) error {
 func (p *SamplePolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if cert, ok := p.certs[candidateID]; ok {
 		return cert, nil
@@ -397,6 +422,9 @@ func (p *L1Policy) OnVote(ctx context.Context, vote *Vote) error {
 func (p *L1Policy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if cert, ok := p.certs[candidateID]; ok {
 		return cert, nil
@@ -409,6 +437,9 @@ func (p *L1Policy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (
 
 	// Get L1 inclusion proof
 	proof, err := p.l1Verifier.GetInclusionProof(ctx, candidateID)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
 	if err != nil || proof == nil {
 		return nil, nil // Not included yet
 	}
@@ -438,6 +469,8 @@ func (p *L1Policy) Verify(ctx context.Context, cert *Certificate) (bool, error)
 // SECURITY CRITICAL: All votes MUST include both BLS and Corona signatures.
 // Votes without dual signatures are rejected to ensure quantum-safe consensus.
 type QuantumPolicy struct {
+	voteVerification
+
 	mu         sync.RWMutex
 	threshold  int
 	requireRT  bool // When true, RT signature is REQUIRED on all votes
@@ -455,6 +488,10 @@ type QuantumPolicy struct {
 	// legs. The default (set by NewQuantumPolicy) is the Permissive-profile
 	// posture; strict-PQ chains construct with NewQuantumPolicyForProfile.
 	certPolicy config.CertPolicy
+
+	// mode is the chain's signing mode. In quasar.RingOnlyMode votes and
+	// certs carry the Corona leg alone and any BLS material is rejected.
+	mode quasar.SigningMode
 }
 
 // RTRequirementError is returned when Corona signature is missing but required
@@ -491,6 +528,17 @@ func NewQuantumPolicyForProfile(threshold int, profile *config.ChainSecurityProf
 	return newQuantumPolicy(threshold, cp.IsPostQuantum(), cp)
 }
 
+// NewQuantumPolicyForParams creates a quantum policy whose signing mode
+// follows p.RingOnly (see quasar.SigningModeFor). In RingOnlyMode only
+// SigCorona votes are accepted, a cert needs threshold Corona signatures
+// and carries a zero-filled BLS field, and Verify rejects any cert with
+// BLS bytes.
+func NewQuantumPolicyForParams(threshold int, p config.Parameters) *QuantumPolicy {
+	qp := NewQuantumPolicy(threshold)
+	qp.mode = quasar.SigningModeFor(p)
+	return qp
+}
+
 func newQuantumPolicy(threshold int, requireRT bool, cp config.CertPolicy) *QuantumPolicy {
 	return &QuantumPolicy{
 		threshold:  threshold,
@@ -527,6 +575,10 @@ func (p *QuantumPolicy) OnCandidate(ctx context.Context, candidate *Candidate) e
 }
 
 func (p *QuantumPolicy) OnVote(ctx context.Context, vote *Vote) error {
+	if err := p.verifyVote(ctx, vote); err != nil {
+		return err
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -543,6 +595,22 @@ func (p *QuantumPolicy) OnVote(ctx context.Context, vote *Vote) error {
 
 	scheme := vote.SignatureScheme()
 
+	// Ring-only chains take the Corona leg alone; BLS material in a vote
+	// would make the cert mixed
+	if p.mode == quasar.RingOnlyMode {
+		if scheme != SigCorona {
+			return fmt.Errorf("%w: vote scheme %s", quasar.ErrMixedCertificate, sigSchemeToString(scheme))
+		}
+		if len(vote.Signature) < 2 {
+			return &RTRequirementError{Reason: "ring-only vote missing Corona signature"}
+		}
+		if p.pqVotes[vote.CandidateID] == nil {
+			p.pqVotes[vote.CandidateID] = make(map[VoterID][]byte)
+		}
+		p.pqVotes[vote.CandidateID][vote.VoterID] = vote.Signature[1:]
+		return nil
+	}
+
 	// SECURITY: Enforce dual BLS+Corona requirement for quantum safety
 	if p.requireRT && scheme != SigQuasar {
 		return &RTRequirementError{
@@ -620,6 +688,9 @@ func sigSchemeToString(scheme byte) string {
 func (p *QuantumPolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if cert, ok := p.certs[candidateID]; ok {
 		return cert, nil
@@ -630,6 +701,10 @@ func (p *QuantumPolicy) MaybeFinalize(ctx context.Context, candidateID Candidate
 		return nil, nil
 	}
 
+	if p.mode == quasar.RingOnlyMode {
+		return p.finalizeRingOnlyLocked(ctx, candidate)
+	}
+
 	// Need threshold of BOTH BLS and PQ signatures
 	blsCount := len(p.blsVotes[candidateID])
 	pqCount := len(p.pqVotes[candidateID])
@@ -642,10 +717,19 @@ func (p *QuantumPolicy) MaybeFinalize(ctx context.Context, candidateID Candidate
 	// into a single 48-byte aggregate requires the BLS scheme/aggregator
 	// (held by the signer at a higher layer); here we concatenate the
 	// per-validator share bytes so the wire layer is self-contained, and
-	// embed individual ML-DSA-65 signatures in MLDSAProof.
+	// embed individual ML-DSA-65 signatures in MLDSAProof. Aggregation
+	// stops at the first signature after ctx is done.
+	blsAgg, err := concatSignatures(ctx, p.blsVotes[candidateID])
+	if err != nil {
+		return nil, err
+	}
+	pqAgg, err := concatSignatures(ctx, p.pqVotes[candidateID])
+	if err != nil {
+		return nil, err
+	}
 	qc := &quasar.QuasarCert{
-		BLS:         concatSignatures(p.blsVotes[candidateID]),
-		Corona:      concatSignatures(p.pqVotes[candidateID]),
+		BLS:         blsAgg,
+		Corona:      pqAgg,
 		MLDSARollup: nil,
 		Epoch:       candidate.Height,
 		Finality:    time.Now(),
@@ -661,6 +745,9 @@ func (p *QuantumPolicy) MaybeFinalize(ctx context.Context, candidateID Candidate
 	for voter := range p.blsVotes[candidateID] {
 		signers = append(signers, voter[:]...)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	cert := &Certificate{
 		CandidateID: candidateID,
@@ -673,12 +760,53 @@ func (p *QuantumPolicy) MaybeFinalize(ctx context.Context, candidateID Candidate
 	return cert, nil
 }
 
+// finalizeRingOnlyLocked builds a RingOnlyMode cert once threshold Corona
+// signatures are in. The BLS field is zero-filled to bls.SignatureLen so
+// the cert layout matches a dual cert.
+// Caller holds p.mu.
+func (p *QuantumPolicy) finalizeRingOnlyLocked(ctx context.Context, candidate *Candidate) (*Certificate, error) {
+	votes := p.pqVotes[candidate.ID]
+	if len(votes) < p.threshold {
+		return nil, nil
+	}
+	pqAgg, err := concatSignatures(ctx, votes)
+	if err != nil {
+		return nil, err
+	}
+	qc := &quasar.QuasarCert{
+		BLS:        make([]byte, bls.SignatureLen),
+		Corona:     pqAgg,
+		Epoch:      candidate.Height,
+		Finality:   time.Now(),
+		Validators: len(votes),
+	}
+	proofBytes, err := qc.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("quantum: marshal QuasarCert: %w", err)
+	}
+
+	var signers []byte
+	for voter := range votes {
+		signers = append(signers, voter[:]...)
+	}
+	cert := &Certificate{
+		CandidateID: candidate.ID,
+		Height:      candidate.Height,
+		PolicyID:    PolicyQuantum,
+		Proof:       proofBytes,
+		Signers:     signers,
+	}
+	p.certs[candidate.ID] = cert
+	return cert, nil
+}
+
 // concatSignatures concatenates the values of a voter→signature map into a
 // single byte slice. Ordering is undefined (the map is not ordered) but each
-// component preserves byte-for-byte its individual signature.
-func concatSignatures(m map[VoterID][]byte) []byte {
+// component preserves byte-for-byte its individual signature. It returns
+// ctx.Err() and no bytes if ctx is done before every signature is added.
+func concatSignatures(ctx context.Context, m map[VoterID][]byte) ([]byte, error) {
 	if len(m) == 0 {
-		return nil
+		return nil, nil
 	}
 	total := 0
 	for _, s := range m {
@@ -686,9 +814,12 @@ func concatSignatures(m map[VoterID][]byte) []byte {
 	}
 	out := make([]byte, 0, total)
 	for _, s := range m {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		out = append(out, s...)
 	}
-	return out
+	return out, nil
 }
 
 // Verify checks that the QuasarCert in cert.Proof is well-formed. Real
@@ -703,8 +834,17 @@ func (p *QuantumPolicy) Verify(ctx context.Context, cert *Certificate) (bool, er
 	if err := qc.UnmarshalBinary(cert.Proof); err != nil {
 		return false, nil
 	}
-	// Structural gate: BLS + Corona must be present for SigQuasar.
-	if len(qc.BLS) == 0 || len(qc.Corona) == 0 {
+	p.mu.RLock()
+	mode := p.mode
+	p.mu.RUnlock()
+
+	// Structural gate: a ring-only chain takes only ring-only certs, any
+	// other needs both BLS and Corona. A zero-filled BLS field is no BLS
+	// leg, so neither mode accepts the other's certs.
+	if mode == quasar.RingOnlyMode {
+		return qc.IsRingOnly(), nil
+	}
+	if !qc.HasClassicalFastPath() || len(qc.Corona) == 0 {
 		return false, nil
 	}
 	return true, nil
@@ -5,8 +5,12 @@ package wire
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/protocol/quasar"
 )
 
 // --- NonePolicy edge cases ---
@@ -676,6 +680,58 @@ func TestQuantumPolicyCoronaOnlyRTNotRequired(t *testing.T) {
 	}
 }
 
+func TestQuantumPolicyRingOnly(t *testing.T) {
+	ctx := context.Background()
+	policy := NewQuantumPolicyForParams(2, config.Parameters{RingOnly: true})
+	c := NewCandidate([]byte("d"), []byte("p"), EmptyCandidateID, 1)
+	policy.OnCandidate(ctx, c)
+
+	// A vote carrying BLS makes the cert mixed
+	mixed := NewVote(c.ID, DeriveVoterID("a", []byte{9}), 0, true)
+	mixed.Signature = []byte{SigQuasar, 0, 1, 7, 8}
+	if err := policy.OnVote(ctx, mixed); !errors.Is(err, quasar.ErrMixedCertificate) {
+		t.Fatalf("expected ErrMixedCertificate, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		vote := NewVote(c.ID, DeriveVoterID("a", []byte{byte(i)}), 0, true)
+		vote.Signature = []byte{SigCorona, byte(i + 1), 5, 6}
+		if err := policy.OnVote(ctx, vote); err != nil {
+			t.Fatalf("vote %d failed: %v", i, err)
+		}
+	}
+	cert, err := policy.MaybeFinalize(ctx, c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert == nil {
+		t.Fatal("should finalize with 2 Corona votes")
+	}
+	qc := &quasar.QuasarCert{}
+	if err := qc.UnmarshalBinary(cert.Proof); err != nil {
+		t.Fatal(err)
+	}
+	if !qc.IsRingOnly() {
+		t.Fatal("ring-only policy produced a cert with BLS bytes")
+	}
+	if ok, _ := policy.Verify(ctx, cert); !ok {
+		t.Error("should verify own ring-only certificate")
+	}
+
+	// Neither mode accepts the other's certs
+	if ok, _ := NewQuantumPolicy(2).Verify(ctx, cert); ok {
+		t.Error("dual policy accepted a ring-only certificate")
+	}
+	qc.BLS = []byte{1}
+	proof, err := qc.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := policy.Verify(ctx, &Certificate{PolicyID: PolicyQuantum, Proof: proof}); ok {
+		t.Error("ring-only policy accepted a certificate with BLS bytes")
+	}
+}
+
 // --- sigSchemeToString ---
 
 func TestSigSchemeToString(t *testing.T) {
@@ -792,3 +848,169 @@ func TestFinalityWitnessesHas(t *testing.T) {
 		t.Error("P must not contain Q")
 	}
 }
+
+// --- Cancellation during MaybeFinalize ---
+
+// expiringContext reports itself cancelled from its checks+1'th Err call,
+// so a test can cancel at every point a policy checks
+type expiringContext struct {
+	context.Context
+	checks int
+}
+
+func (c *expiringContext) Err() error {
+	if c.checks <= 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+// quasarVote returns an accepting vote carrying a BLS and a Corona share
+func quasarVote(c *Candidate, i int) *Vote {
+	sig := []byte{SigQuasar, 0, 48}
+	sig = append(sig, make([]byte, 48)...)
+	sig = append(sig, make([]byte, 32)...)
+	sig[3], sig[3+48] = byte(i), byte(i)
+	vote := NewVote(c.ID, DeriveVoterID("a", []byte{byte(i)}), 0, true)
+	vote.Signature = sig
+	return vote
+}
+
+// readyPolicy is a policy holding a candidate it can finalize
+type readyPolicy struct {
+	policy    FinalityPolicy
+	candidate *Candidate
+}
+
+// readyPolicies returns each shipped policy, by name, ready to finalize
+func readyPolicies(t *testing.T) map[string]readyPolicy {
+	t.Helper()
+	ctx := context.Background()
+	c := NewCandidate([]byte("d"), []byte("cancel"), EmptyCandidateID, 1)
+	voters := []VoterID{DeriveVoterID("a", []byte{0}), DeriveVoterID("a", []byte{1}), DeriveVoterID("a", []byte{2})}
+
+	quorum := NewQuorumPolicy(3, 3)
+	weighted := NewWeightedQuorumPolicy(map[VoterID]uint64{voters[0]: 1, voters[1]: 1, voters[2]: 1}, 2.0/3.0)
+	epoch := NewEpochPolicy(2.0 / 3.0)
+	if err := epoch.SetValidatorSet(1, 0, voters, nil); err != nil {
+		t.Fatal(err)
+	}
+	sample := NewSamplePolicy(1, 1.0, 1)
+	l1 := NewL1Policy(&mockL1Verifier{proofs: map[CandidateID][]byte{c.ID: []byte("merkle-proof")}})
+	quantum := NewQuantumPolicy(3)
+
+	policies := map[string]FinalityPolicy{
+		"none": NewNonePolicy(), "quorum": quorum, "weighted": weighted, "epoch": epoch,
+		"sample": sample, "l1": l1, "quantum": quantum,
+	}
+	ready := make(map[string]readyPolicy, len(policies))
+	for name, p := range policies {
+		if err := p.OnCandidate(ctx, c); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for i, v := range voters {
+			vote := NewVote(c.ID, v, 0, true)
+			vote.Signature = []byte{SigBLS, byte(i)}
+			if p == quantum {
+				vote = quasarVote(c, i)
+			}
+			if err := p.OnVote(ctx, vote); err != nil {
+				t.Fatalf("%s: vote %d: %v", name, i, err)
+			}
+		}
+		ready[name] = readyPolicy{p, c}
+	}
+	return ready
+}
+
+func TestMaybeFinalizeCancelledBeforeStart(t *testing.T) {
+	for name, r := range readyPolicies(t) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cert, err := r.policy.MaybeFinalize(ctx, r.candidate.ID)
+		if !errors.Is(err, context.Canceled) || cert != nil {
+			t.Fatalf("%s: cancelled finalize returned %v, %v", name, cert, err)
+		}
+
+		// Nothing was cached, so a retry finalizes
+		cert, err = r.policy.MaybeFinalize(context.Background(), r.candidate.ID)
+		if err != nil || cert == nil {
+			t.Fatalf("%s: retry returned %v, %v", name, cert, err)
+		}
+		if ok, err := r.policy.Verify(context.Background(), cert); err != nil || !ok {
+			t.Fatalf("%s: retried certificate does not verify: %v", name, err)
+		}
+	}
+}
+
+func TestMaybeFinalizeCancelledMidProof(t *testing.T) {
+	for _, name := range []string{"quorum", "weighted", "quantum"} {
+		r := readyPolicies(t)[name]
+
+		// Cancel at each check in turn until one finalize runs to the end
+		checks := 0
+		for ; ; checks++ {
+			cert, err := r.policy.MaybeFinalize(&expiringContext{Context: context.Background(), checks: checks}, r.candidate.ID)
+			if err == nil {
+				if cert == nil {
+					t.Fatalf("%s: no certificate with %d checks", name, checks)
+				}
+				break
+			}
+			if !errors.Is(err, context.Canceled) || cert != nil {
+				t.Fatalf("%s: cancelled after %d checks returned %v, %v", name, checks, cert, err)
+			}
+		}
+		// One check before the proof and at least one per vote during it
+		if checks <= 3 {
+			t.Fatalf("%s: proof construction checked ctx %d times", name, checks)
+		}
+
+		cert, err := r.policy.MaybeFinalize(context.Background(), r.candidate.ID)
+		if err != nil || cert == nil {
+			t.Fatalf("%s: retry returned %v, %v", name, cert, err)
+		}
+		if len(cert.Signers) != 3*len(VoterID{}) {
+			t.Fatalf("%s: certificate has %d signer bytes, want %d", name, len(cert.Signers), 3*len(VoterID{}))
+		}
+		if ok, err := r.policy.Verify(context.Background(), cert); err != nil || !ok {
+			t.Fatalf("%s: certificate does not verify: %v", name, err)
+		}
+	}
+}
+
+// cancellingL1Verifier cancels the finalize that asks it for a proof
+type cancellingL1Verifier struct {
+	mockL1Verifier
+	cancel context.CancelFunc
+}
+
+func (m *cancellingL1Verifier) GetInclusionProof(ctx context.Context, id CandidateID) ([]byte, error) {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	return m.mockL1Verifier.GetInclusionProof(ctx, id)
+}
+
+func TestL1PolicyCancelledDuringProofFetch(t *testing.T) {
+	c := NewCandidate([]byte("d"), []byte("p"), EmptyCandidateID, 1)
+	verifier := &cancellingL1Verifier{mockL1Verifier: mockL1Verifier{proofs: map[CandidateID][]byte{c.ID: []byte("merkle-proof")}}}
+	policy := NewL1Policy(verifier)
+	if err := policy.OnCandidate(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	verifier.cancel = cancel
+	cert, err := policy.MaybeFinalize(ctx, c.ID)
+	if !errors.Is(err, context.Canceled) || cert != nil {
+		t.Fatalf("cancelled finalize returned %v, %v", cert, err)
+	}
+
+	verifier.cancel = nil
+	cert, err = policy.MaybeFinalize(context.Background(), c.ID)
+	if err != nil || cert == nil {
+		t.Fatalf("retry returned %v, %v", cert, err)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidPayload is returned when a PayloadValidator rejects a
+	// candidate payload before it enters consensus.
+	ErrInvalidPayload = errors.New("invalid candidate payload")
+
+	// ErrRotationUnsupported is returned when rotating the validator set of
+	// a policy that is not a ValidatorRotator
+	ErrRotationUnsupported = errors.New("policy does not rotate validator sets")
+)
+
+// PayloadValidator checks a payload before it becomes a candidate, so that
+// malformed payloads are dropped before they consume consensus rounds.
+// Implementations must be deterministic: every node must reach the same
+// verdict for the same domain and payload.
+type PayloadValidator interface {
+	ValidatePayload(ctx context.Context, domain, payload []byte) error
+}
+
+// PayloadValidatorFunc adapts a function to a PayloadValidator
+type PayloadValidatorFunc func(ctx context.Context, domain, payload []byte) error
+
+// ValidatePayload calls f(ctx, domain, payload)
+func (f PayloadValidatorFunc) ValidatePayload(ctx context.Context, domain, payload []byte) error {
+	return f(ctx, domain, payload)
+}
+
+// AcceptAllPayloads is the default validator; it accepts every payload.
+var AcceptAllPayloads PayloadValidator = PayloadValidatorFunc(func(context.Context, []byte, []byte) error {
+	return nil
+})
+
+// validatePayload runs v against the payload, wrapping any rejection in
+// ErrInvalidPayload. A nil validator accepts everything.
+func validatePayload(ctx context.Context, v PayloadValidator, domain, payload []byte) error {
+	if v == nil {
+		return nil
+	}
+	if err := v.ValidatePayload(ctx, domain, payload); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	return nil
+}
+
+// NewValidatedCandidate is NewCandidate guarded by a payload validator.
+// It returns an error wrapping ErrInvalidPayload if v rejects the payload.
+func NewValidatedCandidate(ctx context.Context, v PayloadValidator, domain, payload []byte, parent CandidateID, height uint64) (*Candidate, error) {
+	if err := validatePayload(ctx, v, domain, payload); err != nil {
+		return nil, err
+	}
+	return NewCandidate(domain, payload, parent, height), nil
+}
+
+// CandidateValidator is implemented by finality policies that screen
+// candidates at submission, such as ValidatedPolicy. Sequencers check
+// submitted candidates with ValidateSubmission before accepting them.
+type CandidateValidator interface {
+	ValidateCandidate(ctx context.Context, candidate *Candidate) error
+}
+
+// ValidateSubmission checks candidate against p if p is a
+// CandidateValidator, and accepts it otherwise. Sequencers call it from
+// Submit and SubmitCandidate so an invalid candidate is refused before it
+// is stored, broadcast or voted on.
+func ValidateSubmission(ctx context.Context, p FinalityPolicy, candidate *Candidate) error {
+	if v, ok := p.(CandidateValidator); ok {
+		return v.ValidateCandidate(ctx, candidate)
+	}
+	return nil
+}
+
+// ValidatedPolicy wraps a FinalityPolicy so that candidates whose payload
+// fails validation are refused at submission and rejected in OnCandidate,
+// and never reach the inner policy's voting pipeline.
+//
+// The inner policy's optional interfaces are forwarded explicitly:
+// SetValidatorSet reaches a ValidatorRotator and SetVoteVerifiers a policy
+// that verifies votes. Unwrap returns the inner policy for any other.
+type ValidatedPolicy struct {
+	inner     FinalityPolicy
+	validator PayloadValidator
+}
+
+var (
+	_ CandidateValidator = (*ValidatedPolicy)(nil)
+	_ ValidatorRotator   = (*ValidatedPolicy)(nil)
+	_ VoteVerifierSetter = (*ValidatedPolicy)(nil)
+)
+
+// NewValidatedPolicy wraps inner with v. A nil v accepts every payload.
+func NewValidatedPolicy(inner FinalityPolicy, v PayloadValidator) *ValidatedPolicy {
+	if v == nil {
+		v = AcceptAllPayloads
+	}
+	return &ValidatedPolicy{inner: inner, validator: v}
+}
+
+// Unwrap returns the wrapped policy
+func (p *ValidatedPolicy) Unwrap() FinalityPolicy {
+	return p.inner
+}
+
+// ValidateCandidate checks the candidate's payload, returning an error
+// wrapping ErrInvalidPayload if the validator rejects it
+func (p *ValidatedPolicy) ValidateCandidate(ctx context.Context, candidate *Candidate) error {
+	return validatePayload(ctx, p.validator, candidate.Domain, candidate.Payload)
+}
+
+// PolicyID returns the inner policy's identifier
+func (p *ValidatedPolicy) PolicyID() PolicyID {
+	return p.inner.PolicyID()
+}
+
+// OnCandidate validates the candidate's payload before handing it to the
+// inner policy.
+func (p *ValidatedPolicy) OnCandidate(ctx context.Context, candidate *Candidate) error {
+	if err := p.ValidateCandidate(ctx, candidate); err != nil {
+		return err
+	}
+	return p.inner.OnCandidate(ctx, candidate)
+}
+
+// OnVote forwards to the inner policy
+func (p *ValidatedPolicy) OnVote(ctx context.Context, vote *Vote) error {
+	return p.inner.OnVote(ctx, vote)
+}
+
+// MaybeFinalize forwards to the inner policy
+func (p *ValidatedPolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
+	return p.inner.MaybeFinalize(ctx, candidateID)
+}
+
+// Verify forwards to the inner policy
+func (p *ValidatedPolicy) Verify(ctx context.Context, cert *Certificate) (bool, error) {
+	return p.inner.Verify(ctx, cert)
+}
+
+// SetValidatorSet forwards to the inner policy, failing with
+// ErrRotationUnsupported if it is not a ValidatorRotator
+func (p *ValidatedPolicy) SetValidatorSet(epoch, fromHeight uint64, voters []VoterID, weights map[VoterID]uint64) error {
+	r, ok := p.inner.(ValidatorRotator)
+	if !ok {
+		return fmt.Errorf("%w: policy %v", ErrRotationUnsupported, p.inner.PolicyID())
+	}
+	return r.SetValidatorSet(epoch, fromHeight, voters, weights)
+}
+
+// SetVoteVerifiers forwards to the inner policy. It has no effect on a
+// policy that counts no votes, such as NonePolicy.
+func (p *ValidatedPolicy) SetVoteVerifiers(r *VoteVerifiers) {
+	if s, ok := p.inner.(VoteVerifierSetter); ok {
+		s.SetVoteVerifiers(r)
+	}
+}
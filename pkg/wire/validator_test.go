@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+var errEmptyPayload = errors.New("empty payload")
+
+// nonEmptyPayloads rejects empty payloads and payloads starting with 0xFF
+var nonEmptyPayloads = PayloadValidatorFunc(func(_ context.Context, _, payload []byte) error {
+	if len(payload) == 0 || payload[0] == 0xFF {
+		return errEmptyPayload
+	}
+	return nil
+})
+
+// recordingPolicy counts the candidates that reach the wrapped policy
+type recordingPolicy struct {
+	*QuorumPolicy
+	seen []CandidateID
+}
+
+func (p *recordingPolicy) OnCandidate(ctx context.Context, c *Candidate) error {
+	p.seen = append(p.seen, c.ID)
+	return p.QuorumPolicy.OnCandidate(ctx, c)
+}
+
+func TestNewValidatedCandidateRejectsInvalidPayload(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := NewValidatedCandidate(ctx, nonEmptyPayloads, []byte("d"), nil, EmptyCandidateID, 1); !errors.Is(err, ErrInvalidPayload) || !errors.Is(err, errEmptyPayload) {
+		t.Fatalf("expected ErrInvalidPayload wrapping errEmptyPayload, got %v", err)
+	}
+
+	c, err := NewValidatedCandidate(ctx, nonEmptyPayloads, []byte("d"), []byte("ok"), EmptyCandidateID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(c.Payload, []byte("ok")) || !c.Verify() {
+		t.Fatalf("unexpected candidate %+v", c)
+	}
+}
+
+func TestValidatedCandidateDefaultAcceptsAll(t *testing.T) {
+	ctx := context.Background()
+	for _, v := range []PayloadValidator{nil, AcceptAllPayloads} {
+		if _, err := NewValidatedCandidate(ctx, v, []byte("d"), nil, EmptyCandidateID, 1); err != nil {
+			t.Fatalf("default validator rejected payload: %v", err)
+		}
+	}
+}
+
+func TestValidatedPolicyKeepsInvalidPayloadsOutOfVoting(t *testing.T) {
+	ctx := context.Background()
+	inner := &recordingPolicy{QuorumPolicy: NewQuorumPolicy(1, 1)}
+	p := NewValidatedPolicy(inner, nonEmptyPayloads)
+	voter := DeriveVoterID("agent", []byte{1})
+
+	bad := NewCandidate([]byte("d"), []byte{0xFF, 0x00}, EmptyCandidateID, 1)
+	if err := p.OnCandidate(ctx, bad); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+	if len(inner.seen) != 0 {
+		t.Fatalf("invalid candidate reached the inner policy")
+	}
+	if err := p.OnVote(ctx, NewVote(bad.ID, voter, 0, true)); err != nil {
+		t.Fatal(err)
+	}
+	if cert, err := p.MaybeFinalize(ctx, bad.ID); err != nil || cert != nil {
+		t.Fatalf("invalid candidate finalized: %v, %v", cert, err)
+	}
+
+	good := NewCandidate([]byte("d"), []byte("ok"), EmptyCandidateID, 2)
+	if err := p.OnCandidate(ctx, good); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OnVote(ctx, NewVote(good.ID, voter, 0, true)); err != nil {
+		t.Fatal(err)
+	}
+	if cert, err := p.MaybeFinalize(ctx, good.ID); err != nil || cert == nil {
+		t.Fatalf("expected cert for valid candidate, got %v, %v", cert, err)
+	}
+	if len(inner.seen) != 1 || inner.seen[0] != good.ID {
+		t.Fatalf("inner policy saw %v, want only the valid candidate", inner.seen)
+	}
+}
+
+func TestValidatedPolicyValidatesSubmission(t *testing.T) {
+	ctx := context.Background()
+	p := NewValidatedPolicy(NewQuorumPolicy(1, 1), nonEmptyPayloads)
+
+	bad := NewCandidate([]byte("d"), nil, EmptyCandidateID, 1)
+	if err := ValidateSubmission(ctx, p, bad); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+	good := NewCandidate([]byte("d"), []byte("ok"), EmptyCandidateID, 1)
+	if err := ValidateSubmission(ctx, p, good); err != nil {
+		t.Fatal(err)
+	}
+
+	// A policy without a validator accepts every submission
+	if err := ValidateSubmission(ctx, NewQuorumPolicy(1, 1), bad); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidatedPolicyForwardsOptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+
+	// Rotation reaches an inner EpochPolicy
+	voter := DeriveVoterID("agent", []byte{1})
+	epoch := NewValidatedPolicy(NewEpochPolicy(0.5), nonEmptyPayloads)
+	if err := epoch.SetValidatorSet(1, 0, []VoterID{voter}, nil); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCandidate([]byte("d"), []byte("ok"), EmptyCandidateID, 1)
+	if err := epoch.OnCandidate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if err := epoch.OnVote(ctx, NewVote(c.ID, voter, 0, true)); err != nil {
+		t.Fatal(err)
+	}
+	if cert, err := epoch.MaybeFinalize(ctx, c.ID); err != nil || cert == nil {
+		t.Fatalf("expected cert under the rotated set, got %v, %v", cert, err)
+	}
+	if _, ok := epoch.Unwrap().(*EpochPolicy); !ok {
+		t.Fatalf("Unwrap returned %T", epoch.Unwrap())
+	}
+
+	// A policy that cannot rotate says so
+	quorum := NewValidatedPolicy(NewQuorumPolicy(1, 1), nil)
+	if err := quorum.SetValidatorSet(1, 0, []VoterID{voter}, nil); !errors.Is(err, ErrRotationUnsupported) {
+		t.Fatalf("expected ErrRotationUnsupported, got %v", err)
+	}
+
+	// Vote verification reaches the inner policy: an unsigned vote from an
+	// unknown voter is refused
+	quorum.SetVoteVerifiers(NewVoteVerifiers(ValidatorKeys{}, Ed25519VoteVerifier{}))
+	if err := quorum.OnCandidate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if err := quorum.OnVote(ctx, NewVote(c.ID, voter, 0, true)); err == nil {
+		t.Fatal("unsigned vote counted after SetVoteVerifiers")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/luxfi/crypto/bls"
+)
+
+// =============================================================================
+// VOTE SIGNATURES
+// =============================================================================
+//
+// A vote's Signature is scheme-tagged: Signature[0] selects the verifier and
+// the rest is the scheme's signature over VoteMessage. The voter's public
+// key is looked up by VoterID and is tagged the same way (Validator.PublicKey),
+// so a vote only verifies under a key registered for its scheme.
+// =============================================================================
+
+// VoteDomain separates vote signatures from other signed messages
+const VoteDomain = "WireVote/v1"
+
+// Vote verification errors
+var (
+	ErrBadVoteSignature = errors.New("vote signature does not verify")
+	ErrUnknownSigScheme = errors.New("no verifier for vote signature scheme")
+	ErrNoVoterKey       = errors.New("no public key for voter")
+	ErrVoterKeyScheme   = errors.New("voter key scheme does not match vote signature")
+)
+
+// VoteMessage returns the bytes a voter signs: the domain, candidate, voter,
+// round and preference. The timestamp is not signed.
+func VoteMessage(v *Vote) []byte {
+	msg := make([]byte, 0, len(VoteDomain)+32+32+8+1)
+	msg = append(msg, VoteDomain...)
+	msg = append(msg, v.CandidateID[:]...)
+	msg = append(msg, v.VoterID[:]...)
+	msg = binary.BigEndian.AppendUint64(msg, v.Round)
+	if v.Preference {
+		return append(msg, 1)
+	}
+	return append(msg, 0)
+}
+
+// VoteVerifier checks vote signatures of one scheme
+type VoteVerifier interface {
+	// Scheme returns the signature scheme tag this verifier handles
+	Scheme() byte
+
+	// Verify checks the vote's signature, without its tag byte, against
+	// the voter's untagged public key
+	Verify(vote *Vote, publicKey []byte) error
+}
+
+// VoterKeys looks up a voter's scheme-tagged public key
+type VoterKeys interface {
+	PublicKey(ctx context.Context, voterID VoterID) ([]byte, error)
+}
+
+// ValidatorKeys is a VoterKeys over a fixed table of scheme-tagged keys
+type ValidatorKeys map[VoterID][]byte
+
+// NewValidatorKeys returns the public keys of every validator in set
+func NewValidatorKeys(set *ValidatorSet) ValidatorKeys {
+	keys := make(ValidatorKeys, len(set.Validators))
+	for _, v := range set.Validators {
+		if len(v.PublicKey) > 0 {
+			keys[v.ID] = v.PublicKey
+		}
+	}
+	return keys
+}
+
+func (k ValidatorKeys) PublicKey(ctx context.Context, voterID VoterID) ([]byte, error) {
+	key, ok := k[voterID]
+	if !ok {
+		return nil, ErrNoVoterKey
+	}
+	return key, nil
+}
+
+// TagPublicKey prefixes key with its scheme tag, the form stored in
+// Validator.PublicKey
+func TagPublicKey(scheme byte, key []byte) []byte {
+	return append([]byte{scheme}, key...)
+}
+
+// VoteVerifiers is a registry of VoteVerifier keyed by scheme tag
+type VoteVerifiers struct {
+	keys VoterKeys
+
+	mu        sync.RWMutex
+	verifiers map[byte]VoteVerifier
+}
+
+// NewVoteVerifiers returns a registry that looks up voter keys in keys and
+// verifies with the given verifiers
+func NewVoteVerifiers(keys VoterKeys, verifiers ...VoteVerifier) *VoteVerifiers {
+	r := &VoteVerifiers{
+		keys:      keys,
+		verifiers: make(map[byte]VoteVerifier, len(verifiers)),
+	}
+	for _, v := range verifiers {
+		r.Register(v)
+	}
+	return r
+}
+
+// Register adds v, replacing any verifier for the same scheme
+func (r *VoteVerifiers) Register(v VoteVerifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.verifiers[v.Scheme()] = v
+}
+
+// VerifyVote checks vote's signature against its voter's public key. Votes
+// with no signature, an unregistered scheme, or a key of another scheme are
+// rejected.
+func (r *VoteVerifiers) VerifyVote(ctx context.Context, vote *Vote) error {
+	scheme := vote.SignatureScheme()
+	r.mu.RLock()
+	verifier, ok := r.verifiers[scheme]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSigScheme, sigSchemeToString(scheme))
+	}
+
+	key, err := r.keys.PublicKey(ctx, vote.VoterID)
+	if err != nil {
+		return err
+	}
+	if len(key) == 0 || key[0] != scheme {
+		return ErrVoterKeyScheme
+	}
+	return verifier.Verify(vote, key[1:])
+}
+
+// VoteVerifierSetter is implemented by policies that verify vote
+// signatures before counting them
+type VoteVerifierSetter interface {
+	SetVoteVerifiers(r *VoteVerifiers)
+}
+
+// voteVerification is embedded by policies that count votes. With no
+// registry set, votes are counted unverified.
+type voteVerification struct {
+	verifiers atomic.Pointer[VoteVerifiers]
+}
+
+// SetVoteVerifiers makes the policy reject votes whose signature does not
+// verify under r. A nil r turns verification off.
+func (v *voteVerification) SetVoteVerifiers(r *VoteVerifiers) {
+	v.verifiers.Store(r)
+}
+
+// verifyVote is called by OnVote before the vote is counted
+func (v *voteVerification) verifyVote(ctx context.Context, vote *Vote) error {
+	r := v.verifiers.Load()
+	if r == nil {
+		return nil
+	}
+	return r.VerifyVote(ctx, vote)
+}
+
+// Ed25519VoteVerifier verifies SigEd25519 votes
+type Ed25519VoteVerifier struct{}
+
+func (Ed25519VoteVerifier) Scheme() byte { return SigEd25519 }
+
+func (Ed25519VoteVerifier) Verify(vote *Vote, publicKey []byte) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: bad Ed25519 public key size %d", ErrBadVoteSignature, len(publicKey))
+	}
+	if !ed25519.Verify(publicKey, VoteMessage(vote), vote.Signature[1:]) {
+		return ErrBadVoteSignature
+	}
+	return nil
+}
+
+// SignVoteEd25519 sets vote's signature using an Ed25519 key
+func SignVoteEd25519(vote *Vote, key ed25519.PrivateKey) {
+	vote.Signature = append([]byte{SigEd25519}, ed25519.Sign(key, VoteMessage(vote))...)
+}
+
+// BLSVoteVerifier verifies SigBLS votes against compressed BLS public keys
+type BLSVoteVerifier struct{}
+
+func (BLSVoteVerifier) Scheme() byte { return SigBLS }
+
+func (BLSVoteVerifier) Verify(vote *Vote, publicKey []byte) error {
+	pk, err := bls.PublicKeyFromCompressedBytes(publicKey)
+	if err != nil {
+		return fmt.Errorf("%w: bad BLS public key: %v", ErrBadVoteSignature, err)
+	}
+	sig, err := bls.SignatureFromBytes(vote.Signature[1:])
+	if err != nil {
+		return fmt.Errorf("%w: bad BLS signature: %v", ErrBadVoteSignature, err)
+	}
+	if !bls.Verify(pk, sig, VoteMessage(vote)) {
+		return ErrBadVoteSignature
+	}
+	return nil
+}
+
+// SignVoteBLS sets vote's signature using a BLS key
+func SignVoteBLS(vote *Vote, key *bls.SecretKey) error {
+	sig, err := key.Sign(VoteMessage(vote))
+	if err != nil {
+		return err
+	}
+	vote.Signature = append([]byte{SigBLS}, bls.SignatureToBytes(sig)...)
+	return nil
+}
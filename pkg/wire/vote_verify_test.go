@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/luxfi/crypto/bls"
+)
+
+type ed25519Voter struct {
+	id  VoterID
+	key ed25519.PrivateKey
+}
+
+func newEd25519Voter(t *testing.T) ed25519Voter {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ed25519Voter{id: VoterIDFromPublicKey(pub), key: key}
+}
+
+func (v ed25519Voter) validator() Validator {
+	return Validator{
+		ID:        v.id,
+		Weight:    1,
+		PublicKey: TagPublicKey(SigEd25519, v.key.Public().(ed25519.PublicKey)),
+	}
+}
+
+func TestVoteVerifiersEd25519(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := newEd25519Voter(t), newEd25519Voter(t)
+	keys := NewValidatorKeys(&ValidatorSet{Validators: []Validator{alice.validator(), bob.validator()}})
+	r := NewVoteVerifiers(keys, Ed25519VoteVerifier{}, BLSVoteVerifier{})
+
+	vote := NewVote(CandidateID{1}, alice.id, 0, true)
+	SignVoteEd25519(vote, alice.key)
+	if err := r.VerifyVote(ctx, vote); err != nil {
+		t.Fatalf("valid vote rejected: %v", err)
+	}
+
+	// The signature covers the preference
+	vote.Preference = false
+	if err := r.VerifyVote(ctx, vote); !errors.Is(err, ErrBadVoteSignature) {
+		t.Fatalf("expected ErrBadVoteSignature for a flipped vote, got %v", err)
+	}
+
+	// Bob signs a vote claiming to be Alice
+	forged := NewVote(CandidateID{1}, alice.id, 0, true)
+	SignVoteEd25519(forged, bob.key)
+	if err := r.VerifyVote(ctx, forged); !errors.Is(err, ErrBadVoteSignature) {
+		t.Fatalf("expected ErrBadVoteSignature for the wrong key, got %v", err)
+	}
+
+	unknown := NewVote(CandidateID{1}, DeriveVoterID("agent", []byte("mallory")), 0, true)
+	SignVoteEd25519(unknown, bob.key)
+	if err := r.VerifyVote(ctx, unknown); !errors.Is(err, ErrNoVoterKey) {
+		t.Fatalf("expected ErrNoVoterKey, got %v", err)
+	}
+}
+
+func TestVoteVerifiersBLS(t *testing.T) {
+	ctx := context.Background()
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := bls.NewSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := DeriveVoterID(NodeIDDomain, []byte("bls voter"))
+	keys := ValidatorKeys{id: TagPublicKey(SigBLS, bls.PublicKeyToCompressedBytes(sk.PublicKey()))}
+	r := NewVoteVerifiers(keys, BLSVoteVerifier{})
+
+	vote := NewVote(CandidateID{2}, id, 3, true)
+	if err := SignVoteBLS(vote, sk); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.VerifyVote(ctx, vote); err != nil {
+		t.Fatalf("valid vote rejected: %v", err)
+	}
+
+	if err := SignVoteBLS(vote, other); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.VerifyVote(ctx, vote); !errors.Is(err, ErrBadVoteSignature) {
+		t.Fatalf("expected ErrBadVoteSignature for the wrong key, got %v", err)
+	}
+}
+
+func TestVoteVerifiersRejectUnknownScheme(t *testing.T) {
+	ctx := context.Background()
+	alice := newEd25519Voter(t)
+	keys := ValidatorKeys{alice.id: alice.validator().PublicKey}
+	r := NewVoteVerifiers(keys, Ed25519VoteVerifier{})
+
+	for _, sig := range [][]byte{nil, {SigCorona, 1, 2, 3}, {0xEE, 1}} {
+		vote := NewVote(CandidateID{1}, alice.id, 0, true)
+		vote.Signature = sig
+		if err := r.VerifyVote(ctx, vote); !errors.Is(err, ErrUnknownSigScheme) {
+			t.Fatalf("signature %x: expected ErrUnknownSigScheme, got %v", sig, err)
+		}
+	}
+
+	// A BLS verifier is registered but Alice's key is Ed25519
+	r.Register(BLSVoteVerifier{})
+	vote := NewVote(CandidateID{1}, alice.id, 0, true)
+	vote.Signature = []byte{SigBLS, 1, 2, 3}
+	if err := r.VerifyVote(ctx, vote); !errors.Is(err, ErrVoterKeyScheme) {
+		t.Fatalf("expected ErrVoterKeyScheme, got %v", err)
+	}
+}
+
+func TestQuorumPolicyRejectsForgedVotes(t *testing.T) {
+	ctx := context.Background()
+	voters := []ed25519Voter{newEd25519Voter(t), newEd25519Voter(t)}
+	set := &ValidatorSet{Validators: []Validator{voters[0].validator(), voters[1].validator()}}
+
+	p := NewQuorumPolicy(2, 2)
+	p.SetVoteVerifiers(NewVoteVerifiers(NewValidatorKeys(set), Ed25519VoteVerifier{}))
+	candidate := NewCandidate([]byte("test"), []byte("payload"), CandidateID{}, 1)
+	if err := p.OnCandidate(ctx, candidate); err != nil {
+		t.Fatal(err)
+	}
+
+	// Voter 0 forges voter 1's vote: it is not counted
+	good := NewVote(candidate.ID, voters[0].id, 0, true)
+	SignVoteEd25519(good, voters[0].key)
+	forged := NewVote(candidate.ID, voters[1].id, 0, true)
+	SignVoteEd25519(forged, voters[0].key)
+	if err := p.OnVote(ctx, good); err != nil {
+		t.Fatalf("valid vote rejected: %v", err)
+	}
+	if err := p.OnVote(ctx, forged); !errors.Is(err, ErrBadVoteSignature) {
+		t.Fatalf("expected ErrBadVoteSignature, got %v", err)
+	}
+	if cert, _ := p.MaybeFinalize(ctx, candidate.ID); cert != nil {
+		t.Fatal("finalized on a forged vote")
+	}
+
+	signed := NewVote(candidate.ID, voters[1].id, 0, true)
+	SignVoteEd25519(signed, voters[1].key)
+	if err := p.OnVote(ctx, signed); err != nil {
+		t.Fatalf("valid vote rejected: %v", err)
+	}
+	if cert, _ := p.MaybeFinalize(ctx, candidate.ID); cert == nil {
+		t.Fatal("expected a certificate from two valid votes")
+	}
+}
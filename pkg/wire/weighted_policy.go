@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// =============================================================================
+// WEIGHTED QUORUM POLICY: Stake-Weighted Threshold
+// =============================================================================
+
+// weightedProofLen is the size of a WeightedQuorumPolicy proof:
+// weight_sum (uint64 BE) || total_weight (uint64 BE).
+const weightedProofLen = 16
+
+// ErrUnknownVoter is returned when a vote comes from a voter with no weight.
+var ErrUnknownVoter = errors.New("voter has no weight in policy")
+
+// WeightedQuorumPolicy finalizes a candidate once the summed weight of
+// accepting voters reaches a fraction of the total weight.
+//
+// The certificate Proof carries the weight sum and total weight that were
+// used, and Signers lists the voter IDs (sorted, 32 bytes each), so any
+// holder of the weight table can recompute and check the sum.
+type WeightedQuorumPolicy struct {
+	voteVerification
+
+	mu         sync.RWMutex
+	weights    map[VoterID]uint64
+	total      uint64
+	threshold  uint64 // Minimum accepting weight: ceil(fraction * total)
+	candidates map[CandidateID]*Candidate
+	votes      map[CandidateID]map[VoterID]*Vote
+	certs      map[CandidateID]*Certificate
+}
+
+// NewWeightedQuorumPolicy creates a stake-weighted quorum policy. fraction is
+// clamped to [0, 1], with NaN treated as 0; a candidate finalizes when the
+// accepting weight is at least ceil(fraction * total weight), and never
+// below a weight of 1.
+func NewWeightedQuorumPolicy(weights map[VoterID]uint64, fraction float64) *WeightedQuorumPolicy {
+	w := make(map[VoterID]uint64, len(weights))
+	var total uint64
+	for id, weight := range weights {
+		if weight == 0 {
+			continue
+		}
+		w[id] = weight
+		total += weight
+	}
+
+	switch {
+	case !(fraction > 0): // negative or NaN
+		fraction = 0
+	case fraction > 1:
+		fraction = 1
+	}
+	threshold := uint64(math.Ceil(fraction * float64(total)))
+	if threshold == 0 {
+		threshold = 1
+	}
+
+	return &WeightedQuorumPolicy{
+		weights:    w,
+		total:      total,
+		threshold:  threshold,
+		candidates: make(map[CandidateID]*Candidate),
+		votes:      make(map[CandidateID]map[VoterID]*Vote),
+		certs:      make(map[CandidateID]*Certificate),
+	}
+}
+
+func (p *WeightedQuorumPolicy) PolicyID() PolicyID {
+	return PolicyWeightedQuorum
+}
+
+// Threshold returns the minimum accepting weight required for finality.
+func (p *WeightedQuorumPolicy) Threshold() uint64 {
+	return p.threshold
+}
+
+func (p *WeightedQuorumPolicy) OnCandidate(ctx context.Context, candidate *Candidate) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.candidates) >= maxCandidates {
+		return fmt.Errorf("candidate limit reached (%d)", maxCandidates)
+	}
+	p.candidates[candidate.ID] = candidate
+	if _, ok := p.votes[candidate.ID]; !ok {
+		p.votes[candidate.ID] = make(map[VoterID]*Vote)
+	}
+	return nil
+}
+
+// OnVote records a vote. Votes that arrive after the candidate finalized are
+// still recorded but never change the issued certificate.
+func (p *WeightedQuorumPolicy) OnVote(ctx context.Context, vote *Vote) error {
+	if err := p.verifyVote(ctx, vote); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.weights[vote.VoterID]; !ok {
+		return ErrUnknownVoter
+	}
+	if _, ok := p.votes[vote.CandidateID]; !ok {
+		p.votes[vote.CandidateID] = make(map[VoterID]*Vote)
+	}
+	p.votes[vote.CandidateID][vote.VoterID] = vote
+	return nil
+}
+
+// MaybeFinalize issues a certificate the first time the accepting weight
+// reaches the threshold. Once issued the certificate is returned unchanged,
+// so late votes cannot re-finalize at a higher weight.
+func (p *WeightedQuorumPolicy) MaybeFinalize(ctx context.Context, candidateID CandidateID) (*Certificate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if cert, ok := p.certs[candidateID]; ok {
+		return cert, nil
+	}
+
+	candidate, ok := p.candidates[candidateID]
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		sum     uint64
+		signers []VoterID
+	)
+	for voterID, vote := range p.votes[candidateID] {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if vote.Preference {
+			sum += p.weights[voterID]
+			signers = append(signers, voterID)
+		}
+	}
+	if sum < p.threshold {
+		return nil, nil // Not enough weight
+	}
+
+	slices.SortFunc(signers, func(a, b VoterID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	signerBytes := make([]byte, 0, len(signers)*len(VoterID{}))
+	for _, id := range signers {
+		signerBytes = append(signerBytes, id[:]...)
+	}
+
+	cert := &Certificate{
+		CandidateID: candidateID,
+		Height:      candidate.Height,
+		PolicyID:    PolicyWeightedQuorum,
+		Proof:       encodeWeightedProof(sum, p.total),
+		Signers:     signerBytes,
+	}
+	p.certs[candidateID] = cert
+	return cert, nil
+}
+
+// Verify recomputes the signer weight from the policy's weight table and
+// checks it matches the proof and reaches the threshold.
+func (p *WeightedQuorumPolicy) Verify(ctx context.Context, cert *Certificate) (bool, error) {
+	if cert.PolicyID != PolicyWeightedQuorum {
+		return false, nil
+	}
+	sum, total, err := DecodeWeightedProof(cert.Proof)
+	if err != nil {
+		return false, err
+	}
+	if len(cert.Signers)%len(VoterID{}) != 0 {
+		return false, nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if total != p.total {
+		return false, nil
+	}
+	var recomputed uint64
+	seen := make(map[VoterID]struct{}, len(cert.Signers)/len(VoterID{}))
+	for off := 0; off < len(cert.Signers); off += len(VoterID{}) {
+		var id VoterID
+		copy(id[:], cert.Signers[off:])
+		if _, dup := seen[id]; dup {
+			return false, nil
+		}
+		seen[id] = struct{}{}
+
+		weight, ok := p.weights[id]
+		if !ok {
+			return false, nil
+		}
+		recomputed += weight
+	}
+	return recomputed == sum && sum >= p.threshold, nil
+}
+
+func encodeWeightedProof(sum, total uint64) []byte {
+	proof := make([]byte, weightedProofLen)
+	binary.BigEndian.PutUint64(proof[0:8], sum)
+	binary.BigEndian.PutUint64(proof[8:16], total)
+	return proof
+}
+
+// DecodeWeightedProof extracts the weight sum and total weight from a
+// WeightedQuorumPolicy certificate proof.
+func DecodeWeightedProof(proof []byte) (sum, total uint64, err error) {
+	if len(proof) != weightedProofLen {
+		return 0, 0, fmt.Errorf("weighted proof must be %d bytes, got %d", weightedProofLen, len(proof))
+	}
+	return binary.BigEndian.Uint64(proof[0:8]), binary.BigEndian.Uint64(proof[8:16]), nil
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wire
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func weightedVoters(weights ...uint64) ([]VoterID, map[VoterID]uint64) {
+	ids := make([]VoterID, len(weights))
+	table := make(map[VoterID]uint64, len(weights))
+	for i, w := range weights {
+		ids[i] = DeriveVoterID("agent", []byte{byte(i)})
+		table[ids[i]] = w
+	}
+	return ids, table
+}
+
+func TestWeightedQuorumSkewedWeights(t *testing.T) {
+	ctx := context.Background()
+	// Total 100; 2/3 threshold is 67.
+	voters, weights := weightedVoters(40, 25, 20, 10, 5)
+	p := NewWeightedQuorumPolicy(weights, 2.0/3.0)
+	if p.Threshold() != 67 {
+		t.Fatalf("threshold = %d, want 67", p.Threshold())
+	}
+
+	c := NewCandidate([]byte("test"), []byte("skewed"), EmptyCandidateID, 1)
+	if err := p.OnCandidate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Four small voters total 60: below the bar despite a head-count majority.
+	for _, v := range voters[1:] {
+		if err := p.OnVote(ctx, NewVote(c.ID, v, 0, true)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cert, err := p.MaybeFinalize(ctx, c.ID)
+	if err != nil || cert != nil {
+		t.Fatalf("expected no cert at weight 60, got %v, %v", cert, err)
+	}
+
+	// The 40-weight voter pushes the sum to 100.
+	if err := p.OnVote(ctx, NewVote(c.ID, voters[0], 0, true)); err != nil {
+		t.Fatal(err)
+	}
+	cert, err = p.MaybeFinalize(ctx, c.ID)
+	if err != nil || cert == nil {
+		t.Fatalf("expected cert, got %v, %v", cert, err)
+	}
+
+	sum, total, err := DecodeWeightedProof(cert.Proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != 100 || total != 100 {
+		t.Fatalf("proof sum/total = %d/%d, want 100/100", sum, total)
+	}
+	if ok, err := p.Verify(ctx, cert); err != nil || !ok {
+		t.Fatalf("Verify = %v, %v", ok, err)
+	}
+}
+
+func TestWeightedQuorumWhale(t *testing.T) {
+	ctx := context.Background()
+	// A single whale holds 70% of the stake.
+	voters, weights := weightedVoters(70, 10, 10, 10)
+	p := NewWeightedQuorumPolicy(weights, 2.0/3.0)
+
+	c := NewCandidate([]byte("test"), []byte("whale"), EmptyCandidateID, 1)
+	if err := p.OnCandidate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OnVote(ctx, NewVote(c.ID, voters[0], 0, true)); err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := p.MaybeFinalize(ctx, c.ID)
+	if err != nil || cert == nil {
+		t.Fatalf("whale alone should finalize, got %v, %v", cert, err)
+	}
+	if len(cert.Signers) != len(VoterID{}) {
+		t.Fatalf("expected exactly one signer, got %d bytes", len(cert.Signers))
+	}
+
+	// Everyone else voting against cannot undo it, and the remaining three
+	// together (30) could never have finalized.
+	for _, v := range voters[1:] {
+		if err := p.OnVote(ctx, NewVote(c.ID, v, 0, false)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	again, _ := p.MaybeFinalize(ctx, c.ID)
+	if again != cert {
+		t.Fatal("finalized cert should be stable")
+	}
+}
+
+func TestWeightedQuorumLateVotesDoNotRefinalize(t *testing.T) {
+	ctx := context.Background()
+	voters, weights := weightedVoters(50, 30, 20)
+	p := NewWeightedQuorumPolicy(weights, 0.5)
+
+	c := NewCandidate([]byte("test"), []byte("late"), EmptyCandidateID, 1)
+	if err := p.OnCandidate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OnVote(ctx, NewVote(c.ID, voters[0], 0, true)); err != nil {
+		t.Fatal(err)
+	}
+	first, err := p.MaybeFinalize(ctx, c.ID)
+	if err != nil || first == nil {
+		t.Fatalf("expected cert, got %v, %v", first, err)
+	}
+
+	for _, v := range voters[1:] {
+		if err := p.OnVote(ctx, NewVote(c.ID, v, 0, true)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	second, err := p.MaybeFinalize(ctx, c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Fatal("late votes must not produce a new certificate")
+	}
+	if sum, _, _ := DecodeWeightedProof(second.Proof); sum != 50 {
+		t.Fatalf("proof sum = %d, want the weight that first crossed (50)", sum)
+	}
+}
+
+func TestWeightedQuorumRejectsUnknownVoterAndForgedProof(t *testing.T) {
+	ctx := context.Background()
+	voters, weights := weightedVoters(60, 40)
+	p := NewWeightedQuorumPolicy(weights, 0.5)
+
+	c := NewCandidate([]byte("test"), []byte("forged"), EmptyCandidateID, 1)
+	if err := p.OnCandidate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	outsider := DeriveVoterID("agent", []byte("outsider"))
+	if err := p.OnVote(ctx, NewVote(c.ID, outsider, 0, true)); !errors.Is(err, ErrUnknownVoter) {
+		t.Fatalf("expected ErrUnknownVoter, got %v", err)
+	}
+
+	// A proof claiming more weight than its signers hold fails verification.
+	cert := NewCertificate(c.ID, 1, PolicyWeightedQuorum, encodeWeightedProof(100, 100))
+	cert.Signers = voters[1][:]
+	if ok, _ := p.Verify(ctx, cert); ok {
+		t.Fatal("forged weight sum should not verify")
+	}
+}
+
+func TestWeightedQuorumClampsFraction(t *testing.T) {
+	_, weights := weightedVoters(40, 30, 30)
+	for _, tc := range []struct {
+		fraction float64
+		want     uint64
+	}{
+		{-0.5, 1},
+		{math.NaN(), 1},
+		{0, 1},
+		{0.5, 50},
+		{1.5, 100},
+	} {
+		if got := NewWeightedQuorumPolicy(weights, tc.fraction).threshold; got != tc.want {
+			t.Fatalf("fraction %v: threshold = %d, want %d", tc.fraction, got, tc.want)
+		}
+	}
+}
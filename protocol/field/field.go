@@ -2,8 +2,10 @@ package field
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/luxfi/consensus/config"
 	"github.com/luxfi/consensus/core/types"
 	"github.com/luxfi/consensus/protocol/prism"
 	"github.com/luxfi/consensus/protocol/wave"
@@ -41,19 +43,97 @@ type Config struct {
 	Alpha    float64
 	Beta     uint32
 	RoundTO  time.Duration
+
+	// RoundTOBackoff, when set, grows the round timeout while rounds fail
+	// to reach quorum; nil keeps RoundTO fixed.
+	RoundTOBackoff *wave.RoundTOBackoff
+
+	// ConcurrentRepolls is how many fresh committees are polled in parallel
+	// after a round fails to reach quorum (0 = no re-polling)
+	ConcurrentRepolls int
+
+	// ConcurrentPolls caps the polls in flight across all items
+	// (0 = no limit)
+	ConcurrentPolls int
+
+	// MinRoundInterval is the minimum gap between polls of the same item
+	// (0 = no limit)
+	MinRoundInterval time.Duration
+
+	// MaxItemProcessingTime bounds how long an item may stay undecided
+	// before it is timed out (0 = no limit)
+	MaxItemProcessingTime time.Duration
+
+	// MaxOutstandingItems caps how many items may be undecided at once;
+	// new items beyond it are not polled until some decide (0 = no limit)
+	MaxOutstandingItems int
+
+	// CommitRetries is how many times a failed commit is retried before
+	// the failure is reported (0 = no retries)
+	CommitRetries int
+
+	// CommitBackoff is the wait before the first retry, doubling for each
+	// one after (0 = DefaultCommitBackoff)
+	CommitBackoff time.Duration
+
+	// Clock times rounds, deadlines and commit retries
+	// (nil = wave.SystemClock)
+	Clock wave.Clock
 }
 
+// DefaultCommitBackoff is the wait before the first commit retry when
+// Config.CommitBackoff is unset
+const DefaultCommitBackoff = 10 * time.Millisecond
+
+// commitErrorBuffer is the capacity of the CommitErrors channel
+const commitErrorBuffer = 16
+
+// CommitError reports a prefix the Committer still rejected after every
+// retry. The prefix stays uncommitted and is retried on the next Tick.
+type CommitError[V VID] struct {
+	Vertices []V
+	Attempts int
+	Err      error
+}
+
+func (e *CommitError[V]) Error() string {
+	return fmt.Sprintf("field: commit of %d vertices failed after %d attempts: %v", len(e.Vertices), e.Attempts, e.Err)
+}
+
+func (e *CommitError[V]) Unwrap() error { return e.Err }
+
 type Driver[V VID] struct {
 	cfg            Config
 	wv             *wave.Wave[V]
+	timer          *wave.RoundTimer
+	clock          wave.Clock
 	cut            prism.Cut[V]
 	str            Store[V]
 	prop           Proposer[V]
 	com            Committer[V]
 	committed      []V // Track committed vertices in order
+	committedSet   map[V]bool
+	pending        []V // prefix whose commit failed, retried before any other
+	commitErrs     chan *CommitError[V]
 	finalizedCache map[V]bool
 }
 
+// ConfigFor returns a Config with the sampling, polling and admission
+// settings of the parameters
+func ConfigFor(p config.Parameters) Config {
+	return Config{
+		PollSize:              p.K,
+		Alpha:                 p.Alpha,
+		Beta:                  p.Beta,
+		RoundTO:               p.RoundTO,
+		ConcurrentRepolls:     p.ConcurrentRepolls,
+		ConcurrentPolls:       p.ConcurrentPolls,
+		MinRoundInterval:      p.MinRoundInterval,
+		MaxItemProcessingTime: p.MaxItemProcessingTime,
+		MaxOutstandingItems:   p.MaxOutstandingItems,
+	}
+}
+
 func NewDriver[V VID](cfg Config, cut prism.Cut[V], tx wave.Transport[V], store Store[V], prop Proposer[V], com Committer[V]) *Driver[V] {
 	if cfg.PollSize == 0 {
 		cfg.PollSize = 20
@@ -67,16 +147,38 @@ func NewDriver[V VID](cfg Config, cut prism.Cut[V], tx wave.Transport[V], store
 	if cfg.RoundTO == 0 {
 		cfg.RoundTO = 250 * time.Millisecond
 	}
+	if cfg.CommitBackoff == 0 {
+		cfg.CommitBackoff = DefaultCommitBackoff
+	}
+	clock := cfg.Clock
+	if clock == nil {
+		clock = wave.SystemClock{}
+	}
 
-	wvVal, _ := wave.New[V](wave.Config{K: cfg.PollSize, Alpha: cfg.Alpha, Beta: cfg.Beta, RoundTO: cfg.RoundTO}, cut, tx)
+	wvVal, _ := wave.New[V](wave.Config{
+		K:                     cfg.PollSize,
+		Alpha:                 cfg.Alpha,
+		Beta:                  cfg.Beta,
+		RoundTO:               cfg.RoundTO,
+		ConcurrentRepolls:     cfg.ConcurrentRepolls,
+		ConcurrentPolls:       cfg.ConcurrentPolls,
+		MinRoundInterval:      cfg.MinRoundInterval,
+		MaxItemProcessingTime: cfg.MaxItemProcessingTime,
+		MaxOutstandingItems:   cfg.MaxOutstandingItems,
+		Clock:                 cfg.Clock,
+	}, cut, tx)
 	return &Driver[V]{
 		cfg:            cfg,
 		wv:             &wvVal,
+		timer:          wave.NewRoundTimer(cfg.RoundTO, cfg.RoundTOBackoff),
+		clock:          clock,
 		cut:            cut,
 		str:            store,
 		prop:           prop,
 		com:            com,
 		committed:      make([]V, 0),
+		committedSet:   make(map[V]bool),
+		commitErrs:     make(chan *CommitError[V], commitErrorBuffer),
 		finalizedCache: make(map[V]bool),
 	}
 }
@@ -97,29 +199,82 @@ func (d *Driver[V]) Tick(ctx context.Context) error {
 	}
 
 	// Drive thresholding on frontier candidates
+	quorum := false
+	roundTO := d.timer.Timeout()
 	for _, v := range frontier {
-		d.wv.Tick(ctx, v)
+		if d.wv.TickTimeout(ctx, v, roundTO) {
+			quorum = true
+		}
+	}
+	if ctx.Err() == nil {
+		d.timer.Observe(quorum)
+	}
+
+	// A prefix that failed to commit goes first; nothing after it is
+	// committed until it is
+	if len(d.pending) > 0 {
+		if err := d.commit(ctx, d.pending); err != nil {
+			return err
+		}
+		d.pending = nil
 	}
 
 	// Compute safe prefix: vertices that are finalized (decided accept) with all ancestors also finalized
 	ordered := d.computeSafePrefix(frontier)
 	if len(ordered) > 0 {
-		if err := d.com.Commit(ctx, ordered); err != nil {
+		if err := d.commit(ctx, ordered); err != nil {
+			d.pending = ordered
 			return err
 		}
-		// Track committed vertices
-		d.committed = append(d.committed, ordered...)
 	}
 
 	return nil
 }
 
-// computeSafePrefix returns vertices from the frontier that are finalized with all ancestors also finalized.
+// commit hands ordered to the Committer, retrying failures with backoff up
+// to CommitRetries times. On success the vertices join the committed
+// order; otherwise they do not, and the failure is returned as a
+// *CommitError and reported on CommitErrors.
+func (d *Driver[V]) commit(ctx context.Context, ordered []V) error {
+	backoff := d.cfg.CommitBackoff
+	attempts := 0
+	var err error
+	for {
+		attempts++
+		if err = d.com.Commit(ctx, ordered); err == nil {
+			break
+		}
+		if attempts > d.cfg.CommitRetries {
+			cerr := &CommitError[V]{Vertices: append([]V(nil), ordered...), Attempts: attempts, Err: err}
+			select {
+			case d.commitErrs <- cerr:
+			default:
+				// Consumer is behind; Tick still returns the error
+			}
+			return cerr
+		}
+		select {
+		case <-d.clock.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	// Track committed vertices
+	for _, v := range ordered {
+		d.committedSet[v] = true
+	}
+	d.committed = append(d.committed, ordered...)
+	return nil
+}
+
+// computeSafePrefix returns uncommitted vertices from the frontier that are finalized with all ancestors also finalized.
 // This ensures we only commit vertices whose causal history is completely decided.
 func (d *Driver[V]) computeSafePrefix(frontier []V) []V {
 	var safe []V
 	for _, v := range frontier {
-		if d.isFullyFinalized(v) {
+		if !d.committedSet[v] && d.isFullyFinalized(v) {
 			safe = append(safe, v)
 		}
 	}
@@ -127,28 +282,26 @@ func (d *Driver[V]) computeSafePrefix(frontier []V) []V {
 }
 
 // isFullyFinalized checks if a vertex and all its ancestors are finalized.
-// Results are memoized in finalizedCache to avoid O(n^2) repeated DFS.
+// Positive results are memoized in finalizedCache to avoid O(n^2) repeated
+// DFS; negative ones are not, since an undecided vertex may finalize later.
 func (d *Driver[V]) isFullyFinalized(v V) bool {
-	if cached, ok := d.finalizedCache[v]; ok {
-		return cached
+	if d.finalizedCache[v] {
+		return true
 	}
 
 	// Check this vertex is finalized
 	state, exists := d.wv.State(v)
 	if !exists || !state.Decided || state.Result != types.DecideAccept {
-		d.finalizedCache[v] = false
 		return false
 	}
 
 	// Check all parents are finalized (recursively)
 	block, exists := d.str.Get(v)
 	if !exists {
-		d.finalizedCache[v] = false
 		return false
 	}
 	for _, parent := range block.Parents() {
 		if !d.isFullyFinalized(parent) {
-			d.finalizedCache[v] = false
 			return false
 		}
 	}
@@ -180,6 +333,22 @@ func (d *Driver[V]) Propose(ctx context.Context, parents []V) (V, error) {
 	return d.prop.Propose(ctx, parents)
 }
 
+// RoundTimeout returns the timeout the next round will wait for votes
+func (d *Driver[V]) RoundTimeout() time.Duration {
+	return d.timer.Timeout()
+}
+
+// Timeouts delivers items that exceeded MaxItemProcessingTime undecided
+func (d *Driver[V]) Timeouts() <-chan V {
+	return d.wv.Timeouts()
+}
+
+// CommitErrors delivers commits that still failed after every retry. It
+// is buffered; if the consumer falls behind further reports are dropped.
+func (d *Driver[V]) CommitErrors() <-chan *CommitError[V] {
+	return d.commitErrs
+}
+
 // GetFrontier returns the current DAG frontier (tips)
 func (d *Driver[V]) GetFrontier() []V {
 	return d.str.Head()
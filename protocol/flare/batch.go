@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package flare
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/luxfi/consensus/core/dag"
+)
+
+// ErrBatcherClosed is returned by Accept after Close
+var ErrBatcherClosed = errors.New("flare: batcher closed")
+
+// Committer applies accepted vertices, one at a time in causal order
+type Committer interface {
+	Commit(ctx context.Context, v dag.Meta) error
+}
+
+// BatchCommitter is a Committer that can also apply a run of causally
+// consecutive vertices in one call. The Batcher uses CommitBatch when the
+// committer implements it.
+type BatchCommitter interface {
+	Committer
+	CommitBatch(ctx context.Context, vs []dag.Meta) error
+}
+
+// BatchConfig configures a Batcher
+type BatchConfig struct {
+	// MaxBatch flushes once this many vertices are pending (<= 1 commits
+	// every vertex as it is accepted)
+	MaxBatch int
+
+	// MaxDelay flushes a partial batch this long after its first vertex
+	// was accepted (0 = only size and explicit flushes)
+	MaxDelay time.Duration
+}
+
+// Batcher groups vertices accepted in causal order and hands them to a
+// Committer in order-preserving batches. Batches are committed one after
+// another, so the order across batches is the acceptance order too.
+type Batcher struct {
+	cfg BatchConfig
+	com Committer
+
+	// flushMu serializes flushes so batches reach the committer in order
+	flushMu sync.Mutex
+
+	mu      sync.Mutex
+	pending []dag.Meta
+	timer   *time.Timer
+	err     error // first error from a timer flush, reported by the next call
+	closed  bool
+}
+
+// NewBatcher returns a batcher committing to com
+func NewBatcher(cfg BatchConfig, com Committer) *Batcher {
+	return &Batcher{cfg: cfg, com: com}
+}
+
+// Accept queues v, which the caller accepts in causal order: after all of
+// its parents. It flushes when the batch is full. If an earlier timer flush
+// failed, Accept returns that error without queuing v.
+func (b *Batcher) Accept(ctx context.Context, v dag.Meta) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	if err := b.takeErrLocked(); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	b.pending = append(b.pending, v)
+	full := len(b.pending) >= b.cfg.MaxBatch
+	if !full {
+		b.armLocked()
+	}
+	b.mu.Unlock()
+
+	if full {
+		return b.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush commits every pending vertex. Vertices the committer fails to
+// apply stay pending, and the timer retries them after MaxDelay. If an
+// earlier timer flush failed, Flush returns that error without flushing.
+func (b *Batcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	err := b.takeErrLocked()
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return b.flush(ctx)
+}
+
+// flush commits every pending vertex and re-arms the timer for any that
+// remain
+func (b *Batcher) flush(ctx context.Context) error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+
+	n, err := b.commit(ctx, batch)
+	if err != nil {
+		// Requeue what was not committed ahead of anything accepted since,
+		// so a retry keeps causal order
+		b.mu.Lock()
+		b.pending = append(batch[n:len(batch):len(batch)], b.pending...)
+		b.armLocked()
+		b.mu.Unlock()
+	}
+	return err
+}
+
+// Close flushes the pending vertices and refuses further ones
+func (b *Batcher) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	return b.Flush(ctx)
+}
+
+// Pending returns how many accepted vertices await commit
+func (b *Batcher) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// armLocked starts the flush timer if vertices are pending, it is not
+// already running and the batcher is open
+// Must be called with b.mu held
+func (b *Batcher) armLocked() {
+	if b.timer == nil && b.cfg.MaxDelay > 0 && len(b.pending) > 0 && !b.closed {
+		b.timer = time.AfterFunc(b.cfg.MaxDelay, b.flushOnTimer)
+	}
+}
+
+// flushOnTimer flushes on the timer. Its first error is kept for the next
+// Accept or Flush to report; it does not stop later timer flushes from
+// retrying.
+func (b *Batcher) flushOnTimer() {
+	if err := b.flush(context.Background()); err != nil {
+		b.mu.Lock()
+		if b.err == nil {
+			b.err = err
+		}
+		b.mu.Unlock()
+	}
+}
+
+// takeErrLocked returns and clears a timer flush error
+// Must be called with b.mu held
+func (b *Batcher) takeErrLocked() error {
+	err := b.err
+	b.err = nil
+	return err
+}
+
+// commit hands batch to the committer and returns how many vertices it
+// committed. A failed CommitBatch commits none of the batch.
+// Must be called with b.flushMu held
+func (b *Batcher) commit(ctx context.Context, batch []dag.Meta) (int, error) {
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	if bc, ok := b.com.(BatchCommitter); ok {
+		if err := bc.CommitBatch(ctx, batch); err != nil {
+			return 0, err
+		}
+		return len(batch), nil
+	}
+	for i, v := range batch {
+		if err := b.com.Commit(ctx, v); err != nil {
+			return i, err
+		}
+	}
+	return len(batch), nil
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package flare
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/dag"
+)
+
+// recordingCommitter records every Commit call
+type recordingCommitter struct {
+	mu      sync.Mutex
+	commits []dag.VertexID
+	failAt  int // fail the commit of this many-th vertex (0 = never)
+}
+
+func (c *recordingCommitter) Commit(_ context.Context, v dag.Meta) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failAt > 0 && len(c.commits)+1 == c.failAt {
+		c.failAt = 0
+		return errors.New("commit failed")
+	}
+	c.commits = append(c.commits, v.ID())
+	return nil
+}
+
+// recordingBatchCommitter records the batches it receives
+type recordingBatchCommitter struct {
+	recordingCommitter
+	batches chan []dag.VertexID
+}
+
+func (c *recordingBatchCommitter) CommitBatch(_ context.Context, vs []dag.Meta) error {
+	ids := make([]dag.VertexID, len(vs))
+	for i, v := range vs {
+		ids[i] = v.ID()
+	}
+	c.batches <- ids
+	return nil
+}
+
+// chainDAG returns n vertices accepted in causal order: each round has two
+// vertices referencing both of the previous round
+func chainDAG(n int) []dag.Meta {
+	var out []dag.Meta
+	var prev []dag.VertexID
+	for i := 0; len(out) < n; i++ {
+		round := uint64(i / 2)
+		v := &testVertex{id: dag.VertexID{byte(i + 1)}, author: string(rune('a' + i%2)), round: round}
+		if i%2 == 0 && i > 0 {
+			prev = []dag.VertexID{out[i-2].ID(), out[i-1].ID()}
+		}
+		v.parents = prev
+		out = append(out, v)
+	}
+	return out
+}
+
+// requireCausal checks every vertex in order comes after its parents
+func requireCausal(t *testing.T, vs []dag.Meta, order []dag.VertexID) {
+	t.Helper()
+	pos := make(map[dag.VertexID]int, len(order))
+	for i, id := range order {
+		pos[id] = i
+	}
+	for _, v := range vs {
+		for _, p := range v.Parents() {
+			if pos[p] >= pos[v.ID()] {
+				t.Fatalf("vertex %x committed before its parent %x", v.ID(), p)
+			}
+		}
+	}
+}
+
+func TestBatcherBatchBoundaries(t *testing.T) {
+	ctx := context.Background()
+	com := &recordingBatchCommitter{batches: make(chan []dag.VertexID, 10)}
+	b := NewBatcher(BatchConfig{MaxBatch: 3}, com)
+
+	vs := chainDAG(7)
+	for _, v := range vs {
+		if err := b.Accept(ctx, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Pending() != 1 {
+		t.Fatalf("expected 1 pending vertex, got %d", b.Pending())
+	}
+	if err := b.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Accept(ctx, vs[0]); !errors.Is(err, ErrBatcherClosed) {
+		t.Fatalf("expected ErrBatcherClosed, got %v", err)
+	}
+	close(com.batches)
+
+	var sizes []int
+	var order []dag.VertexID
+	for batch := range com.batches {
+		sizes = append(sizes, len(batch))
+		order = append(order, batch...)
+	}
+	if len(sizes) != 3 || sizes[0] != 3 || sizes[1] != 3 || sizes[2] != 1 {
+		t.Fatalf("expected batches of 3, 3, 1, got %v", sizes)
+	}
+	for i, v := range vs {
+		if order[i] != v.ID() {
+			t.Fatalf("position %d: batches reordered acceptance", i)
+		}
+	}
+	requireCausal(t, vs, order)
+	if len(com.commits) != 0 {
+		t.Fatal("batch committer received single commits")
+	}
+}
+
+func TestBatcherFlushesPartialBatchOnTimer(t *testing.T) {
+	ctx := context.Background()
+	com := &recordingBatchCommitter{batches: make(chan []dag.VertexID, 10)}
+	b := NewBatcher(BatchConfig{MaxBatch: 100, MaxDelay: 20 * time.Millisecond}, com)
+
+	vs := chainDAG(4)
+	for _, v := range vs[:2] {
+		if err := b.Accept(ctx, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case batch := <-com.batches:
+		if len(batch) != 2 || batch[0] != vs[0].ID() || batch[1] != vs[1].ID() {
+			t.Fatalf("unexpected timer batch %x", batch)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("partial batch was not flushed on the timer")
+	}
+
+	// The timer restarts for the next partial batch
+	if err := b.Accept(ctx, vs[2]); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case batch := <-com.batches:
+		if len(batch) != 1 || batch[0] != vs[2].ID() {
+			t.Fatalf("unexpected timer batch %x", batch)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second partial batch was not flushed on the timer")
+	}
+}
+
+// flakyBatchCommitter fails its first CommitBatch call
+type flakyBatchCommitter struct {
+	recordingBatchCommitter
+	failed bool
+}
+
+func (c *flakyBatchCommitter) CommitBatch(ctx context.Context, vs []dag.Meta) error {
+	c.mu.Lock()
+	fail := !c.failed
+	c.failed = true
+	c.mu.Unlock()
+	if fail {
+		return errors.New("commit failed")
+	}
+	return c.recordingBatchCommitter.CommitBatch(ctx, vs)
+}
+
+func TestBatcherTimerRetriesFailedFlush(t *testing.T) {
+	ctx := context.Background()
+	com := &flakyBatchCommitter{recordingBatchCommitter: recordingBatchCommitter{batches: make(chan []dag.VertexID, 10)}}
+	b := NewBatcher(BatchConfig{MaxBatch: 100, MaxDelay: 20 * time.Millisecond}, com)
+
+	vs := chainDAG(2)
+	for _, v := range vs {
+		if err := b.Accept(ctx, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first timer flush fails; the timer is re-armed and the retry
+	// commits the batch without another Accept
+	select {
+	case batch := <-com.batches:
+		if len(batch) != 2 || batch[0] != vs[0].ID() || batch[1] != vs[1].ID() {
+			t.Fatalf("unexpected timer batch %x", batch)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed batch was not retried on the timer")
+	}
+
+	// The failure is still reported to the next caller
+	if err := b.Flush(ctx); err == nil {
+		t.Fatal("expected the timer flush failure")
+	}
+	if b.Pending() != 0 {
+		t.Fatalf("expected no pending vertices, got %d", b.Pending())
+	}
+}
+
+func TestBatcherFallsBackToCommit(t *testing.T) {
+	ctx := context.Background()
+	com := &recordingCommitter{failAt: 2}
+	b := NewBatcher(BatchConfig{MaxBatch: 4}, com)
+
+	vs := chainDAG(4)
+	for _, v := range vs[:3] {
+		if err := b.Accept(ctx, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The second vertex fails: it and the third stay pending, ahead of the
+	// fourth
+	if err := b.Accept(ctx, vs[3]); err == nil {
+		t.Fatal("expected the commit failure")
+	}
+	if b.Pending() != 3 {
+		t.Fatalf("expected 3 pending vertices, got %d", b.Pending())
+	}
+	if err := b.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(com.commits) != len(vs) {
+		t.Fatalf("expected %d commits, got %d", len(vs), len(com.commits))
+	}
+	for i, v := range vs {
+		if com.commits[i] != v.ID() {
+			t.Fatalf("position %d: commits out of order", i)
+		}
+	}
+	requireCausal(t, vs, com.commits)
+}
@@ -4,4 +4,7 @@
 // graph and accepts vertices in causal order. It's the controlled detonation
 // that commits the prepared transactions—deliberate, irreversible, beautiful.
 // In the metaphor, it's the stellar flare that lights up space.
+//
+// A Batcher sits between the accept order and the committer, grouping
+// causally consecutive acceptances into batches by size or delay.
 package flare
@@ -4,4 +4,8 @@
 // Reaching β signals local finality for the choice under consideration.
 // This is the constructive-interference analogue in the metaphor: persistence,
 // not amplitude, creates a stable signal.
+//
+// Fast start, off by default, lets an item whose first round is a clear
+// super-majority begin at most halfway to β; it still needs at least half
+// of β in further successful rounds to decide.
 package focus
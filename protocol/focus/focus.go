@@ -35,43 +35,251 @@ func (t *Tracker[ID]) Reset(id ID) {
 	delete(t.counts, id)
 }
 
-// Confidence tracks confidence building for consensus
+// HistoryWindow is the number of most recent rounds ConfidenceHistory keeps
+// per item
+const HistoryWindow = 64
+
+// Confidence tracks confidence building for consensus.
+// Uncontested items decide at threshold (BetaVirtuous); items marked
+// contested decide at the rogue threshold (BetaRogue) instead.
 type Confidence[ID comparable] struct {
 	mu        sync.RWMutex
 	threshold int
+	rogue     int
 	alpha     float64
 	states    map[ID]int
+	contested map[ID]bool
+	history   map[ID][]uint32 // counter after each round, oldest first
+	decided   map[ID]bool     // latched once an item reaches its threshold
+
+	halfLife    time.Duration    // 0 disables decay
+	lastSuccess map[ID]time.Time // time of each item's last successful round
+	now         func() time.Time
+
+	fastQuorum     float64 // 0 disables fast start
+	fastConfidence int
+}
+
+// Explanation is a snapshot of why an item is or is not decided
+type Explanation struct {
+	Confidence int
+	Threshold  int
+	Contested  bool
+	Decided    bool
+	History    []uint32 // counter after each of the last HistoryWindow rounds
 }
 
 func NewConfidence[ID comparable](threshold int, alpha float64) *Confidence[ID] {
+	return NewDualConfidence[ID](threshold, threshold, alpha)
+}
+
+// NewDualConfidence creates a Confidence that finalizes uncontested items
+// after betaVirtuous consecutive successes and contested items after
+// betaRogue. A betaRogue below betaVirtuous is raised to betaVirtuous.
+func NewDualConfidence[ID comparable](betaVirtuous, betaRogue int, alpha float64) *Confidence[ID] {
+	if betaRogue < betaVirtuous {
+		betaRogue = betaVirtuous
+	}
 	return &Confidence[ID]{
-		threshold: threshold,
+		threshold: betaVirtuous,
+		rogue:     betaRogue,
 		alpha:     alpha,
 		states:    make(map[ID]int),
+		contested: make(map[ID]bool),
+		history:   make(map[ID][]uint32),
+		decided:   make(map[ID]bool),
+
+		lastSuccess: make(map[ID]time.Time),
+		now:         time.Now,
+	}
+}
+
+// SetDecayHalfLife makes counters decay while their items go without a
+// successful round: a counter halves for every full half-life since its last
+// success. Items still accumulating within a half-life are unaffected, and a
+// stale item that resurfaces resumes from its decayed counter rather than
+// from near finality. Decided items never decay. Zero disables decay.
+func (c *Confidence[ID]) SetDecayHalfLife(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.halfLife = d
+}
+
+// DecayHalfLife returns the decay half-life (0 = no decay)
+func (c *Confidence[ID]) DecayHalfLife() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.halfLife
+}
+
+// SetFastStart lets an uncontested item whose first round reaches quorum
+// start at confidence instead of 1, so a unanimous item does not wait out
+// a cold start. The start is capped at half the item's threshold, so a
+// fast-started item still needs at least as many further successful
+// rounds as it skipped and one lucky round cannot bring β down to 2. A
+// quorum below alpha is raised to alpha. A quorum of 0 or a confidence of
+// 1 or less disables fast start, the default.
+func (c *Confidence[ID]) SetFastStart(quorum float64, confidence int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if quorum <= 0 || confidence <= 1 {
+		c.fastQuorum, c.fastConfidence = 0, 0
+		return
+	}
+	c.fastQuorum = max(quorum, c.alpha)
+	c.fastConfidence = confidence
+}
+
+// FastStart returns the fast-start quorum and confidence (0, 0 = disabled)
+func (c *Confidence[ID]) FastStart() (quorum float64, confidence int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.fastQuorum, c.fastConfidence
+}
+
+// fastStart returns the counter id starts at after a successful first
+// round with ratio, or 1 when fast start does not apply
+// Must be called with c.mu held
+func (c *Confidence[ID]) fastStart(id ID, ratio float64) int {
+	if c.fastQuorum == 0 || ratio < c.fastQuorum || c.contested[id] {
+		return 1
+	}
+	if _, seen := c.history[id]; seen {
+		return 1
 	}
+	return max(1, min(c.fastConfidence, c.thresholdFor(id)/2))
 }
 
+// confidence returns id's counter after decay. A decided item keeps the
+// counter it decided with.
+// Must be called with c.mu held
+func (c *Confidence[ID]) confidence(id ID, now time.Time) int {
+	state := c.states[id]
+	last, ok := c.lastSuccess[id]
+	if c.halfLife <= 0 || !ok || state == 0 || c.decided[id] {
+		return state
+	}
+	halvings := now.Sub(last) / c.halfLife
+	if halvings >= 63 {
+		return 0
+	}
+	return state >> uint(halvings)
+}
+
+// MarkContested records that ids have a competing conflict. From then on
+// they need the rogue threshold to decide; progress so far is kept.
+func (c *Confidence[ID]) MarkContested(ids ...ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		c.contested[id] = true
+	}
+}
+
+// Contested reports whether id has been marked contested
+func (c *Confidence[ID]) Contested(id ID) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.contested[id]
+}
+
+// Threshold returns the number of consecutive successes id needs to decide
+func (c *Confidence[ID]) Threshold(id ID) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.thresholdFor(id)
+}
+
+func (c *Confidence[ID]) thresholdFor(id ID) int {
+	if c.contested[id] {
+		return c.rogue
+	}
+	return c.threshold
+}
+
+// Update records a round for id with ratio of the sample preferring it.
+// Finality is irreversible: once id reaches its threshold it stays decided,
+// and later rounds, decay and contention leave it unchanged.
 func (c *Confidence[ID]) Update(id ID, ratio float64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.decided[id] {
+		return
+	}
 
-	current := c.states[id]
+	now := c.now()
+	current := c.confidence(id, now)
 	if ratio >= c.alpha {
-		c.states[id] = current + 1
+		c.states[id] = max(current+1, c.fastStart(id, ratio))
+		c.lastSuccess[id] = now
 	} else if ratio <= 1.0-c.alpha {
 		c.states[id] = 0 // Reset on opposite preference
+		delete(c.lastSuccess, id)
 	}
+	if c.states[id] >= c.thresholdFor(id) {
+		c.decided[id] = true
+	}
+	c.record(id, now)
+}
+
+// record appends id's counter to its history, dropping the oldest round
+// once HistoryWindow rounds are held
+func (c *Confidence[ID]) record(id ID, now time.Time) {
+	h := c.history[id]
+	if len(h) == HistoryWindow {
+		copy(h, h[1:])
+		h = h[:HistoryWindow-1]
+	}
+	c.history[id] = append(h, uint32(c.confidence(id, now)))
+}
+
+// Forget drops all state held for id: its counter, round history, last
+// success, contention and decided latch. Callers that record decisions
+// themselves forget decided items to bound memory; a forgotten item starts
+// over if it is updated again.
+func (c *Confidence[ID]) Forget(id ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.states, id)
+	delete(c.contested, id)
+	delete(c.history, id)
+	delete(c.decided, id)
+	delete(c.lastSuccess, id)
 }
 
 func (c *Confidence[ID]) State(id ID) (int, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	state := c.states[id]
-	decided := state >= c.threshold
+	state := c.confidence(id, c.now())
+	decided := c.decided[id] || state >= c.thresholdFor(id)
 	return state, decided
 }
 
+// ConfidenceHistory returns id's counter value after each of its last
+// HistoryWindow rounds, oldest first. The result is a copy.
+func (c *Confidence[ID]) ConfidenceHistory(id ID) []uint32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]uint32(nil), c.history[id]...)
+}
+
+// Explain returns id's current counter, threshold and round history
+func (c *Confidence[ID]) Explain(id ID) Explanation {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state := c.confidence(id, c.now())
+	threshold := c.thresholdFor(id)
+	return Explanation{
+		Confidence: state,
+		Threshold:  threshold,
+		Contested:  c.contested[id],
+		Decided:    c.decided[id] || state >= threshold,
+		History:    append([]uint32(nil), c.history[id]...),
+	}
+}
+
 // WindowedConfidence tracks confidence with time windows
 type WindowedConfidence[ID comparable] struct {
 	mu         sync.RWMutex
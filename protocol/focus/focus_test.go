@@ -82,6 +82,63 @@ func TestConfidence(t *testing.T) {
 	}
 }
 
+func TestDualConfidenceBetaVirtuousVsRogue(t *testing.T) {
+	const betaVirtuous, betaRogue = 3, 6
+	conf := NewDualConfidence[string](betaVirtuous, betaRogue, 0.8)
+	conf.MarkContested("contested")
+
+	// roundsToDecide updates id until it decides and returns the round count
+	roundsToDecide := func(id string) int {
+		for round := 1; round <= 2*betaRogue; round++ {
+			conf.Update(id, 0.9)
+			if _, decided := conf.State(id); decided {
+				return round
+			}
+		}
+		return -1
+	}
+
+	if got := roundsToDecide("virtuous"); got != betaVirtuous {
+		t.Errorf("uncontested item decided after %d rounds, want %d", got, betaVirtuous)
+	}
+	if got := roundsToDecide("contested"); got != betaRogue {
+		t.Errorf("contested item decided after %d rounds, want %d", got, betaRogue)
+	}
+}
+
+func TestDualConfidenceSwitchesWhenConflictAppears(t *testing.T) {
+	conf := NewDualConfidence[string](2, 4, 0.8)
+
+	conf.Update("item", 0.9)
+	if conf.Contested("item") || conf.Threshold("item") != 2 {
+		t.Fatalf("expected uncontested item at BetaVirtuous, got threshold %d", conf.Threshold("item"))
+	}
+
+	// A conflict appearing mid-way raises the bar before BetaVirtuous is reached
+	conf.MarkContested("item", "rival")
+	conf.Update("item", 0.9)
+	if s, decided := conf.State("item"); s != 2 || decided {
+		t.Fatalf("expected contested item undecided at state 2, got state=%d decided=%v", s, decided)
+	}
+	if conf.Threshold("rival") != 4 {
+		t.Errorf("expected rival threshold 4, got %d", conf.Threshold("rival"))
+	}
+
+	conf.Update("item", 0.9)
+	conf.Update("item", 0.9)
+	if s, decided := conf.State("item"); s != 4 || !decided {
+		t.Errorf("expected decided at BetaRogue, got state=%d decided=%v", s, decided)
+	}
+}
+
+func TestDualConfidenceRogueNotBelowVirtuous(t *testing.T) {
+	conf := NewDualConfidence[string](5, 2, 0.8)
+	conf.MarkContested("item")
+	if got := conf.Threshold("item"); got != 5 {
+		t.Errorf("expected rogue threshold raised to 5, got %d", got)
+	}
+}
+
 func TestWindowedConfidence(t *testing.T) {
 	conf := NewWindowed[string](2, 0.8, 100*time.Millisecond)
 
@@ -232,3 +289,270 @@ func shouldSkip(yes, no, unknown int) bool {
 	}
 	return float64(no)/float64(total) > 0.6
 }
+
+// TestConfidenceHistoryMatchesRounds checks the recorded history against
+// the counter observed after each round of an item that finalizes.
+func TestConfidenceHistoryMatchesRounds(t *testing.T) {
+	conf := NewConfidence[string](4, 0.8)
+
+	var want []uint32
+	for _, ratio := range []float64{0.9, 0.9, 0.5, 0.1, 0.9, 0.85, 0.9, 0.95} {
+		conf.Update("item", ratio)
+		s, _ := conf.State("item")
+		want = append(want, uint32(s))
+	}
+	if _, decided := conf.State("item"); !decided {
+		t.Fatal("expected item to be decided")
+	}
+
+	got := conf.ConfidenceHistory("item")
+	if len(got) != len(want) {
+		t.Fatalf("history has %d rounds, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("round %d: history %v, want %v", i, got, want)
+		}
+	}
+
+	e := conf.Explain("item")
+	if e.Confidence != 4 || e.Threshold != 4 || !e.Decided || len(e.History) != len(want) {
+		t.Errorf("unexpected explanation %+v", e)
+	}
+
+	// The returned history is a copy
+	got[0] = 99
+	if conf.ConfidenceHistory("item")[0] == 99 {
+		t.Error("history aliases internal state")
+	}
+}
+
+func TestConfidenceHistoryBounded(t *testing.T) {
+	conf := NewConfidence[string](1000, 0.8)
+	for i := 0; i < HistoryWindow+10; i++ {
+		conf.Update("item", 0.9)
+	}
+
+	h := conf.ConfidenceHistory("item")
+	if len(h) != HistoryWindow {
+		t.Fatalf("history has %d rounds, want %d", len(h), HistoryWindow)
+	}
+	if h[0] != 11 || h[len(h)-1] != HistoryWindow+10 {
+		t.Errorf("expected rounds 11..%d, got %d..%d", HistoryWindow+10, h[0], h[len(h)-1])
+	}
+}
+
+func TestConfidenceDecayHalvesPerHalfLife(t *testing.T) {
+	clock := time.Unix(0, 0)
+	c := NewConfidence[string](10, 0.8)
+	c.now = func() time.Time { return clock }
+	c.SetDecayHalfLife(time.Minute)
+
+	for i := 0; i < 8; i++ {
+		c.Update("stale", 0.9)
+	}
+	if state, _ := c.State("stale"); state != 8 {
+		t.Fatalf("expected 8 before decay, got %d", state)
+	}
+
+	// Less than a half-life leaves the counter alone
+	clock = clock.Add(59 * time.Second)
+	if state, _ := c.State("stale"); state != 8 {
+		t.Fatalf("expected 8 within the half-life, got %d", state)
+	}
+
+	for _, want := range []int{4, 2, 1, 0} {
+		clock = clock.Add(time.Minute)
+		if state, _ := c.State("stale"); state != want {
+			t.Fatalf("expected %d after another half-life, got %d", want, state)
+		}
+	}
+	if got := c.Explain("stale").Confidence; got != 0 {
+		t.Fatalf("Explain disagrees with State: %d", got)
+	}
+}
+
+func TestConfidenceDecayResurrectedItem(t *testing.T) {
+	clock := time.Unix(0, 0)
+	c := NewConfidence[string](10, 0.8)
+	c.now = func() time.Time { return clock }
+	c.SetDecayHalfLife(time.Minute)
+
+	for i := 0; i < 9; i++ {
+		c.Update("stale", 0.9)
+	}
+
+	// Without decay one fresh vote would finalize; after two idle
+	// half-lives the item resumes from 9/4 = 2 instead
+	clock = clock.Add(2 * time.Minute)
+	c.Update("stale", 0.9)
+	if state, decided := c.State("stale"); state != 3 || decided {
+		t.Fatalf("expected 3 undecided after resurrection, got %d (decided=%v)", state, decided)
+	}
+}
+
+func TestConfidenceDecaySparesActiveItems(t *testing.T) {
+	clock := time.Unix(0, 0)
+	c := NewConfidence[string](10, 0.8)
+	c.now = func() time.Time { return clock }
+	c.SetDecayHalfLife(time.Minute)
+
+	// Successes arriving faster than the half-life accumulate as usual
+	for i := 0; i < 10; i++ {
+		clock = clock.Add(30 * time.Second)
+		c.Update("active", 0.9)
+	}
+	if state, decided := c.State("active"); state != 10 || !decided {
+		t.Fatalf("expected 10 decided, got %d (decided=%v)", state, decided)
+	}
+
+	// Without a half-life nothing decays
+	plain := NewConfidence[string](10, 0.8)
+	plain.now = func() time.Time { return clock }
+	plain.Update("item", 0.9)
+	clock = clock.Add(time.Hour)
+	if state, _ := plain.State("item"); state != 1 {
+		t.Fatalf("expected no decay when disabled, got %d", state)
+	}
+}
+
+// roundsToFinality feeds id ratio each round until it decides
+func roundsToFinality(c *Confidence[string], id string, ratio float64) int {
+	for round := 1; round <= 100; round++ {
+		c.Update(id, ratio)
+		if _, decided := c.State(id); decided {
+			return round
+		}
+	}
+	return -1
+}
+
+func TestConfidenceFastStartUnanimous(t *testing.T) {
+	cold := NewConfidence[string](10, 0.8)
+	if q, n := cold.FastStart(); q != 0 || n != 0 {
+		t.Fatalf("fast start enabled by default: (%v, %d)", q, n)
+	}
+	if got := roundsToFinality(cold, "genesis", 1.0); got != 10 {
+		t.Fatalf("expected 10 rounds without fast start, got %d", got)
+	}
+
+	warm := NewConfidence[string](10, 0.8)
+	warm.SetFastStart(0.95, 4)
+	if got := roundsToFinality(warm, "genesis", 1.0); got != 7 {
+		t.Fatalf("expected 7 rounds with fast start at 4, got %d", got)
+	}
+	if h := warm.ConfidenceHistory("genesis"); h[0] != 4 {
+		t.Fatalf("expected the first round to start at 4, history %v", h)
+	}
+
+	// However large the boost, it stops halfway to beta, so half of beta
+	// in further rounds is still needed
+	capped := NewConfidence[string](10, 0.8)
+	capped.SetFastStart(0.95, 100)
+	if got := roundsToFinality(capped, "genesis", 1.0); got != 6 {
+		t.Fatalf("expected 6 rounds with fast start capped at 5, got %d", got)
+	}
+	if h := capped.ConfidenceHistory("genesis"); h[0] != 5 {
+		t.Fatalf("expected fast start capped at 5, history %v", h)
+	}
+
+	// A small beta leaves nothing to skip
+	small := NewConfidence[string](2, 0.8)
+	small.SetFastStart(0.95, 100)
+	if got := roundsToFinality(small, "genesis", 1.0); got != 2 {
+		t.Fatalf("expected 2 rounds at beta 2, got %d", got)
+	}
+}
+
+func TestConfidenceFastStartSafetyThreshold(t *testing.T) {
+	c := NewDualConfidence[string](10, 20, 0.8)
+	c.SetFastStart(0.95, 7)
+
+	// A first round below the super-majority starts cold
+	c.Update("split", 0.94)
+	if state, _ := c.State("split"); state != 1 {
+		t.Fatalf("expected 1 below the fast-start quorum, got %d", state)
+	}
+
+	// Only an item's first round can fast-start
+	c.Update("later", 0.9)
+	c.Update("later", 1.0)
+	if state, _ := c.State("later"); state != 2 {
+		t.Fatalf("expected 2 when the super-majority is not the first round, got %d", state)
+	}
+
+	// Contested items never fast-start
+	c.MarkContested("rogue")
+	c.Update("rogue", 1.0)
+	if state, _ := c.State("rogue"); state != 1 {
+		t.Fatalf("expected contested item to start at 1, got %d", state)
+	}
+
+	// A quorum below alpha is raised to alpha
+	low := NewConfidence[string](10, 0.8)
+	low.SetFastStart(0.5, 7)
+	if q, _ := low.FastStart(); q != 0.8 {
+		t.Fatalf("expected quorum raised to alpha 0.8, got %v", q)
+	}
+	low.Update("weak", 0.7)
+	if state, _ := low.State("weak"); state != 0 {
+		t.Fatalf("expected no progress below alpha, got %d", state)
+	}
+
+	low.SetFastStart(0, 0)
+	if q, n := low.FastStart(); q != 0 || n != 0 {
+		t.Fatalf("expected fast start disabled, got (%v, %d)", q, n)
+	}
+}
+
+func TestConfidenceDecidedItemDoesNotDecay(t *testing.T) {
+	clock := time.Unix(0, 0)
+	c := NewDualConfidence[string](4, 6, 0.8)
+	c.now = func() time.Time { return clock }
+	c.SetDecayHalfLife(time.Minute)
+
+	for i := 0; i < 4; i++ {
+		c.Update("final", 0.9)
+	}
+	if state, decided := c.State("final"); state != 4 || !decided {
+		t.Fatalf("expected 4 decided, got %d (decided=%v)", state, decided)
+	}
+
+	// Neither idle half-lives, a conflict nor an opposing round undo it
+	clock = clock.Add(10 * time.Minute)
+	c.MarkContested("final")
+	c.Update("final", 0.1)
+	if state, decided := c.State("final"); state != 4 || !decided {
+		t.Fatalf("decided item regressed to %d (decided=%v)", state, decided)
+	}
+	if e := c.Explain("final"); !e.Decided || e.Confidence != 4 {
+		t.Fatalf("Explain reports %+v for a decided item", e)
+	}
+}
+
+func TestConfidenceForget(t *testing.T) {
+	c := NewDualConfidence[string](2, 4, 0.8)
+	c.SetDecayHalfLife(time.Minute)
+	c.MarkContested("item")
+	for i := 0; i < 4; i++ {
+		c.Update("item", 0.9)
+	}
+	if _, decided := c.State("item"); !decided {
+		t.Fatal("item did not decide")
+	}
+
+	c.Forget("item")
+	if len(c.states) != 0 || len(c.history) != 0 || len(c.lastSuccess) != 0 ||
+		len(c.contested) != 0 || len(c.decided) != 0 {
+		t.Fatal("Forget left state behind")
+	}
+
+	// A forgotten item starts over, uncontested
+	c.Update("item", 0.9)
+	if state, decided := c.State("item"); state != 1 || decided {
+		t.Fatalf("forgotten item resumed at %d (decided=%v)", state, decided)
+	}
+	if h := c.ConfidenceHistory("item"); len(h) != 1 {
+		t.Fatalf("forgotten item kept %d rounds of history", len(h))
+	}
+}
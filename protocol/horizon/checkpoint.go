@@ -0,0 +1,107 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package horizon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/luxfi/consensus/core/dag"
+	"github.com/luxfi/ids"
+)
+
+// checkpointDomain separates checkpoint roots from other SHA-256 uses
+const checkpointDomain = "lux/horizon/checkpoint/v1"
+
+var (
+	// ErrEmptyCut is returned by Checkpoint for a cut with no vertices
+	ErrEmptyCut = errors.New("horizon: empty cut")
+
+	// ErrUnknownCutVertex is returned by Checkpoint for a cut vertex the
+	// store does not hold
+	ErrUnknownCutVertex = errors.New("horizon: cut vertex unknown")
+
+	// ErrNotAntichain is returned by Checkpoint for a cut in which one
+	// vertex descends from another
+	ErrNotAntichain = errors.New("horizon: cut is not an antichain")
+)
+
+// CheckpointProof binds a finalized cut of the DAG to a single root hash.
+// The cut is an antichain: every finalized vertex is at or below one of its
+// members, so an external system (such as an L1 bridge) can anchor the DAG's
+// finalized state by the root alone and check membership without the DAG.
+type CheckpointProof struct {
+	// Cut holds the antichain members in ascending ID order
+	Cut []ids.ID
+
+	// Root commits to Cut
+	Root ids.ID
+}
+
+// Checkpoint returns the proof for cut, a finalized antichain of store. The
+// root depends only on the set of cut vertices, so every node checkpointing
+// the same cut derives the same root whatever order it lists them in.
+func Checkpoint(store dag.Store[ids.ID], cut []ids.ID) (CheckpointProof, error) {
+	if len(cut) == 0 {
+		return CheckpointProof{}, ErrEmptyCut
+	}
+
+	sorted := slices.Clone(cut)
+	slices.SortFunc(sorted, ids.ID.Compare)
+	for i, id := range sorted {
+		if i > 0 && sorted[i-1] == id {
+			return CheckpointProof{}, fmt.Errorf("%w: %s listed twice", ErrNotAntichain, id)
+		}
+		if _, ok := store.Get(id); !ok {
+			return CheckpointProof{}, fmt.Errorf("%w: %s", ErrUnknownCutVertex, id)
+		}
+	}
+	if antichain := dag.Antichain(store, sorted); len(antichain) != len(sorted) {
+		return CheckpointProof{}, fmt.Errorf("%w: %d of %d vertices are ordered by another",
+			ErrNotAntichain, len(sorted)-len(antichain), len(sorted))
+	}
+
+	return CheckpointProof{
+		Cut:  sorted,
+		Root: checkpointRoot(sorted),
+	}, nil
+}
+
+// VerifyCheckpoint reports whether proof lists a well-formed cut committing
+// to root. It checks the commitment, not finality: the caller trusts root
+// because it was agreed on, for example through a quorum certificate.
+func VerifyCheckpoint(proof CheckpointProof, root ids.ID) bool {
+	if len(proof.Cut) == 0 || proof.Root != root {
+		return false
+	}
+	for i := 1; i < len(proof.Cut); i++ {
+		if proof.Cut[i-1].Compare(proof.Cut[i]) >= 0 {
+			return false // unsorted or repeated
+		}
+	}
+	return checkpointRoot(proof.Cut) == root
+}
+
+// Contains reports whether id is a member of the proof's cut
+func (p CheckpointProof) Contains(id ids.ID) bool {
+	_, ok := slices.BinarySearchFunc(p.Cut, id, ids.ID.Compare)
+	return ok
+}
+
+// checkpointRoot hashes a sorted cut with its length, so no two cuts share
+// an encoding
+func checkpointRoot(sorted []ids.ID) ids.ID {
+	h := sha256.New()
+	h.Write([]byte(checkpointDomain))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(sorted))))
+	for _, id := range sorted {
+		h.Write(id[:])
+	}
+	var root ids.ID
+	h.Sum(root[:0])
+	return root
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package horizon
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/luxfi/consensus/core/dag"
+	"github.com/luxfi/ids"
+)
+
+// idStore implements dag.Store[ids.ID] for checkpoint tests
+type idStore struct {
+	blocks   map[ids.ID]*idBlock
+	children map[ids.ID][]ids.ID
+}
+
+type idBlock struct {
+	id      ids.ID
+	parents []ids.ID
+	round   uint64
+}
+
+func (b *idBlock) ID() ids.ID        { return b.id }
+func (b *idBlock) Parents() []ids.ID { return b.parents }
+func (b *idBlock) Author() string    { return "test" }
+func (b *idBlock) Round() uint64     { return b.round }
+
+func newIDStore() *idStore {
+	return &idStore{blocks: make(map[ids.ID]*idBlock), children: make(map[ids.ID][]ids.ID)}
+}
+
+func (s *idStore) add(round uint64, parents ...ids.ID) ids.ID {
+	id := ids.GenerateTestID()
+	s.blocks[id] = &idBlock{id: id, parents: parents, round: round}
+	for _, p := range parents {
+		s.children[p] = append(s.children[p], id)
+	}
+	return id
+}
+
+func (s *idStore) Get(v ids.ID) (dag.BlockView[ids.ID], bool) {
+	b, ok := s.blocks[v]
+	return b, ok
+}
+
+func (s *idStore) Children(v ids.ID) []ids.ID { return s.children[v] }
+
+func (s *idStore) Head() []ids.ID {
+	var head []ids.ID
+	for id := range s.blocks {
+		if len(s.children[id]) == 0 {
+			head = append(head, id)
+		}
+	}
+	return head
+}
+
+// newMergeStore builds
+//
+//	  root
+//	 /    \
+//	l1     r1
+//	|      |
+//	l2     r2
+//	 \    /
+//	 merge
+func newMergeStore() (s *idStore, root, l1, r1, l2, r2, merge ids.ID) {
+	s = newIDStore()
+	root = s.add(0)
+	l1 = s.add(1, root)
+	r1 = s.add(1, root)
+	l2 = s.add(2, l1)
+	r2 = s.add(2, r1)
+	merge = s.add(3, l2, r2)
+	return
+}
+
+func TestCheckpoint(t *testing.T) {
+	s, _, l1, _, l2, r2, merge := newMergeStore()
+
+	proof, err := Checkpoint(s, []ids.ID{r2, l2})
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if !VerifyCheckpoint(proof, proof.Root) {
+		t.Fatal("checkpoint does not verify against its own root")
+	}
+	if !proof.Contains(l2) || !proof.Contains(r2) || proof.Contains(merge) {
+		t.Errorf("unexpected cut membership: %v", proof.Cut)
+	}
+
+	// The same cut listed in another order yields the same root
+	again, err := Checkpoint(s, []ids.ID{l2, r2})
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if again.Root != proof.Root || !slices.Equal(again.Cut, proof.Cut) {
+		t.Error("checkpoint root depends on cut order")
+	}
+
+	// A cut mixing heights is fine as long as it is an antichain
+	mixed, err := Checkpoint(s, []ids.ID{l1, r2})
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if mixed.Root == proof.Root {
+		t.Error("different cuts share a root")
+	}
+	if VerifyCheckpoint(mixed, proof.Root) {
+		t.Error("proof verified against another cut's root")
+	}
+
+	single, err := Checkpoint(s, []ids.ID{merge})
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if !VerifyCheckpoint(single, single.Root) {
+		t.Error("single-vertex checkpoint does not verify")
+	}
+}
+
+func TestCheckpointSwappedVertex(t *testing.T) {
+	s, _, l1, _, l2, r2, _ := newMergeStore()
+
+	proof, err := Checkpoint(s, []ids.ID{l2, r2})
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	root := proof.Root
+
+	// Replace a member, keeping the cut sorted so only the commitment fails
+	forged := CheckpointProof{Cut: slices.Clone(proof.Cut), Root: root}
+	i := slices.Index(forged.Cut, l2)
+	forged.Cut[i] = l1
+	slices.SortFunc(forged.Cut, ids.ID.Compare)
+	if VerifyCheckpoint(forged, root) {
+		t.Fatal("proof with a swapped vertex verified")
+	}
+
+	// Reordering the members is rejected too
+	reordered := CheckpointProof{Cut: []ids.ID{proof.Cut[1], proof.Cut[0]}, Root: root}
+	if VerifyCheckpoint(reordered, root) {
+		t.Fatal("proof with unsorted members verified")
+	}
+
+	if VerifyCheckpoint(CheckpointProof{Root: root}, root) {
+		t.Fatal("empty proof verified")
+	}
+}
+
+func TestCheckpointRejectsInvalidCut(t *testing.T) {
+	s, root, l1, _, l2, r2, _ := newMergeStore()
+
+	tests := []struct {
+		name string
+		cut  []ids.ID
+		want error
+	}{
+		{name: "empty", cut: nil, want: ErrEmptyCut},
+		{name: "unknown vertex", cut: []ids.ID{l2, ids.GenerateTestID()}, want: ErrUnknownCutVertex},
+		{name: "ancestor and descendant", cut: []ids.ID{l1, l2, r2}, want: ErrNotAntichain},
+		{name: "root below the cut", cut: []ids.ID{root, r2}, want: ErrNotAntichain},
+		{name: "duplicate", cut: []ids.ID{l2, l2}, want: ErrNotAntichain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Checkpoint(s, tt.cut); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package horizon
+
+import (
+	"cmp"
+	"errors"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/luxfi/consensus/core/dag"
+	"github.com/luxfi/ids"
+)
+
+// ErrUnknownParent is returned by ReachabilityIndex.Add when a parent is
+// neither indexed nor present in the backing store.
+var ErrUnknownParent = errors.New("horizon: parent vertex unknown")
+
+// ReachabilityIndex answers dag.IsReachable queries in O(1). Each vertex
+// carries a bitset of its ancestors (itself included), built as the OR of
+// its parents' bitsets when it is added, so queries never walk the graph.
+// An insertion costs one bitset OR per parent.
+//
+// Bitsets grow with the number of indexed vertices, so the index as a
+// whole is quadratic in it. Prune drops finalized vertices to keep it
+// bounded by the live part of the DAG.
+type ReachabilityIndex[V comparable] struct {
+	mu        sync.RWMutex
+	store     dag.Store[V]
+	index     map[V]int
+	ancestors [][]uint64 // by vertex index
+	pruned    map[V]struct{}
+}
+
+// NewReachabilityIndex creates an index over store, seeded with every
+// vertex reachable from the store's heads through parent edges.
+func NewReachabilityIndex[V comparable](store dag.Store[V]) *ReachabilityIndex[V] {
+	r := &ReachabilityIndex[V]{
+		store:  store,
+		index:  make(map[V]int),
+		pruned: make(map[V]struct{}),
+	}
+	for _, head := range store.Head() {
+		_ = r.indexFromStore(head)
+	}
+	return r
+}
+
+// Add indexes v with the given parents. Parents not yet indexed are pulled
+// from the backing store first, except pruned ones; adding an indexed or
+// pruned vertex is a no-op.
+func (r *ReachabilityIndex[V]) Add(v V, parents []V) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.known(v) {
+		return nil
+	}
+	for _, p := range parents {
+		if err := r.indexFromStore(p); err != nil {
+			return err
+		}
+	}
+	r.insert(v, parents)
+	return nil
+}
+
+// Reachable reports whether to descends from from (or equals it), agreeing
+// with dag.IsReachable over the indexed graph. A pruned vertex is no longer
+// indexed, so a query naming one (other than from == to) reports false;
+// finalized history is answered by dag.IsReachable over the store.
+func (r *ReachabilityIndex[V]) Reachable(from, to V) bool {
+	if from == to {
+		return true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fi, ok := r.index[from]
+	if !ok {
+		return false
+	}
+	ti, ok := r.index[to]
+	if !ok {
+		return false
+	}
+	return hasBit(r.ancestors[ti], fi)
+}
+
+// Len returns the number of indexed vertices
+func (r *ReachabilityIndex[V]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.index)
+}
+
+// Prune drops finalized and all of its indexed ancestors, which are
+// finalized with it, and compacts the remaining bitsets so their memory is
+// released. Pruned vertices are never re-indexed from the store, and as
+// parents of later vertices they are skipped. Prune reports how many
+// vertices it dropped; pruning an unindexed vertex drops none.
+//
+// Compaction rewrites every remaining bitset, so call Prune once per
+// finalized cut rather than per vertex.
+func (r *ReachabilityIndex[V]) Prune(finalized V) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fi, ok := r.index[finalized]
+	if !ok {
+		return 0
+	}
+	drop := r.ancestors[fi]
+
+	// Survivors keep their relative order, so parents still precede
+	// children and bitsets only need their bits renumbered
+	old := make([]V, len(r.ancestors))
+	for v, i := range r.index {
+		old[i] = v
+	}
+	remap := make([]int, len(old))
+	kept := 0
+	for i := range old {
+		if hasBit(drop, i) {
+			remap[i] = -1
+			continue
+		}
+		remap[i] = kept
+		kept++
+	}
+
+	ancestors := make([][]uint64, 0, kept)
+	for i, v := range old {
+		if remap[i] < 0 {
+			delete(r.index, v)
+			r.pruned[v] = struct{}{}
+			continue
+		}
+		set := make([]uint64, remap[i]/64+1)
+		for w, word := range r.ancestors[i] {
+			for word != 0 {
+				b := w*64 + bits.TrailingZeros64(word)
+				word &= word - 1
+				if j := remap[b]; j >= 0 {
+					set[j/64] |= 1 << (uint(j) % 64)
+				}
+			}
+		}
+		r.index[v] = remap[i]
+		ancestors = append(ancestors, set)
+	}
+	r.ancestors = ancestors
+	return len(old) - kept
+}
+
+// hasBit reports whether bit i is set in set
+func hasBit(set []uint64, i int) bool {
+	word := i / 64
+	return word < len(set) && set[word]&(1<<(uint(i)%64)) != 0
+}
+
+// known reports whether v is indexed or pruned. Caller holds r.mu.
+func (r *ReachabilityIndex[V]) known(v V) bool {
+	if _, ok := r.index[v]; ok {
+		return true
+	}
+	_, ok := r.pruned[v]
+	return ok
+}
+
+// insert assigns v the next index and its ancestor bitset. All parents must
+// already be indexed. Caller holds r.mu.
+func (r *ReachabilityIndex[V]) insert(v V, parents []V) {
+	i := len(r.ancestors)
+	bits := make([]uint64, i/64+1)
+	for _, p := range parents {
+		pi, ok := r.index[p]
+		if !ok {
+			continue
+		}
+		for w, word := range r.ancestors[pi] {
+			bits[w] |= word
+		}
+	}
+	bits[i/64] |= 1 << (uint(i) % 64)
+
+	r.index[v] = i
+	r.ancestors = append(r.ancestors, bits)
+}
+
+// indexFromStore indexes v and its unindexed ancestors from the store,
+// parents before children. Caller holds r.mu (or has exclusive access).
+func (r *ReachabilityIndex[V]) indexFromStore(v V) error {
+	if r.known(v) {
+		return nil
+	}
+
+	type frame struct {
+		v       V
+		parents []V
+		next    int
+	}
+	onStack := make(map[V]bool)
+	var stack []frame
+
+	push := func(v V) error {
+		blk, ok := r.store.Get(v)
+		if !ok {
+			return ErrUnknownParent
+		}
+		onStack[v] = true
+		stack = append(stack, frame{v: v, parents: blk.Parents()})
+		return nil
+	}
+	if err := push(v); err != nil {
+		return err
+	}
+
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next < len(top.parents) {
+			p := top.parents[top.next]
+			top.next++
+			if r.known(p) || onStack[p] {
+				continue
+			}
+			if err := push(p); err != nil {
+				return err
+			}
+			continue
+		}
+		r.insert(top.v, top.parents)
+		delete(onStack, top.v)
+		stack = stack[:len(stack)-1]
+	}
+	return nil
+}
+
+// ReachableInRange returns every vertex reachable from one of roots, the
+// roots included, whose height lies in [minHeight, maxHeight]. A vertex's
+// height is its Round. Reachability agrees with dag.IsReachable: the walk
+// follows child edges through vertices outside the band too, so a vertex
+// in the band beneath an out-of-band one is still found. Vertices the store
+// cannot return have no height and are left out. The result is ordered by
+// height, then ID.
+func ReachableInRange(store dag.Store[ids.ID], roots []ids.ID, minHeight, maxHeight uint64) []ids.ID {
+	if minHeight > maxHeight {
+		return nil
+	}
+
+	type entry struct {
+		id     ids.ID
+		height uint64
+	}
+	var (
+		found   []entry
+		visited = make(map[ids.ID]bool, len(roots))
+		queue   []ids.ID
+	)
+	for _, root := range roots {
+		if !visited[root] {
+			visited[root] = true
+			queue = append(queue, root)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		if blk, ok := store.Get(v); ok {
+			if h := blk.Round(); h >= minHeight && h <= maxHeight {
+				found = append(found, entry{id: v, height: h})
+			}
+		}
+		for _, child := range store.Children(v) {
+			if !visited[child] {
+				visited[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	slices.SortFunc(found, func(a, b entry) int {
+		if c := cmp.Compare(a.height, b.height); c != 0 {
+			return c
+		}
+		return a.id.Compare(b.id)
+	})
+	result := make([]ids.ID, len(found))
+	for i, e := range found {
+		result[i] = e.id
+	}
+	return result
+}
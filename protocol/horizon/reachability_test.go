@@ -0,0 +1,364 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package horizon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/luxfi/consensus/core/dag"
+	"github.com/luxfi/ids"
+)
+
+// reachStore is a dag.Store[int] with indexed parent and child edges
+type reachStore struct {
+	blocks   map[int]*reachBlock
+	children map[int][]int
+}
+
+type reachBlock struct {
+	id      int
+	parents []int
+}
+
+func (b *reachBlock) ID() int        { return b.id }
+func (b *reachBlock) Parents() []int { return b.parents }
+func (b *reachBlock) Author() string { return "test" }
+func (b *reachBlock) Round() uint64  { return uint64(b.id) }
+func newReachStore() *reachStore {
+	return &reachStore{blocks: map[int]*reachBlock{}, children: map[int][]int{}}
+}
+func (s *reachStore) Children(v int) []int { return s.children[v] }
+
+func (s *reachStore) add(v int, parents ...int) {
+	s.blocks[v] = &reachBlock{id: v, parents: parents}
+	for _, p := range parents {
+		s.children[p] = append(s.children[p], v)
+	}
+}
+
+func (s *reachStore) Get(v int) (dag.BlockView[int], bool) {
+	b, ok := s.blocks[v]
+	return b, ok
+}
+
+func (s *reachStore) Head() []int {
+	var head []int
+	for v := range s.blocks {
+		if len(s.children[v]) == 0 {
+			head = append(head, v)
+		}
+	}
+	return head
+}
+
+func TestReachabilityIndex(t *testing.T) {
+	// 0 -> 1 -> 3, 0 -> 2 -> 3, 4 isolated
+	s := newReachStore()
+	s.add(0)
+	s.add(1, 0)
+	s.add(2, 0)
+	s.add(3, 1, 2)
+	s.add(4)
+	r := NewReachabilityIndex[int](s)
+
+	tests := []struct {
+		from, to int
+		want     bool
+	}{
+		{0, 3, true},
+		{1, 3, true},
+		{3, 0, false},
+		{1, 2, false},
+		{4, 3, false},
+		{2, 2, true},
+		{9, 9, true}, // unknown but equal, as dag.IsReachable
+		{9, 3, false},
+	}
+	for _, tt := range tests {
+		if got := r.Reachable(tt.from, tt.to); got != tt.want {
+			t.Errorf("Reachable(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	// Incremental insertion
+	s.add(5, 3, 4)
+	if err := r.Add(5, []int{3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Reachable(0, 5) || !r.Reachable(4, 5) || r.Reachable(5, 0) {
+		t.Error("incremental vertex 5 has wrong ancestry")
+	}
+	if err := r.Add(6, []int{42}); !errors.Is(err, ErrUnknownParent) {
+		t.Errorf("expected ErrUnknownParent, got %v", err)
+	}
+}
+
+func TestReachabilityIndexPrune(t *testing.T) {
+	// 0 -> 1 -> 2 -> 4, 1 -> 3 -> 4, 0 -> 5 (a side branch), 4 -> 6
+	s := newReachStore()
+	s.add(0)
+	s.add(1, 0)
+	s.add(2, 1)
+	s.add(3, 1)
+	s.add(4, 2, 3)
+	s.add(5, 0)
+	s.add(6, 4)
+	r := NewReachabilityIndex[int](s)
+	if r.Len() != 7 {
+		t.Fatalf("indexed %d vertices, want 7", r.Len())
+	}
+
+	// Finalizing 2 drops it with its ancestors 0 and 1
+	if n := r.Prune(2); n != 3 {
+		t.Fatalf("Prune(2) dropped %d vertices, want 3", n)
+	}
+	if n := r.Prune(2); n != 0 {
+		t.Fatalf("pruning again dropped %d vertices", n)
+	}
+	if r.Len() != 4 {
+		t.Fatalf("indexed %d vertices after prune, want 4", r.Len())
+	}
+
+	// Live vertices keep their reachability after compaction
+	for _, tt := range []struct {
+		from, to int
+		want     bool
+	}{
+		{3, 4, true},
+		{3, 6, true},
+		{4, 6, true},
+		{6, 3, false},
+		{5, 6, false},
+		{1, 4, false}, // pruned
+		{2, 2, true},
+	} {
+		if got := r.Reachable(tt.from, tt.to); got != tt.want {
+			t.Errorf("Reachable(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	// A child of a pruned vertex is indexed without re-indexing the
+	// finalized history from the store
+	s.add(7, 2, 6)
+	if err := r.Add(7, []int{2, 6}); err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 5 || !r.Reachable(3, 7) || r.Reachable(2, 7) {
+		t.Fatalf("vertex 7 indexed wrongly: %d vertices", r.Len())
+	}
+}
+
+// buildFuzzDAG derives a DAG from data: byte i picks the parents of vertex i
+// among the (up to 8) preceding vertices as a bitmask
+func buildFuzzDAG(data []byte) (*reachStore, [][]int) {
+	if len(data) > 200 {
+		data = data[:200]
+	}
+	s := newReachStore()
+	parents := make([][]int, len(data))
+	for i, b := range data {
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 && i-bit-1 >= 0 {
+				parents[i] = append(parents[i], i-bit-1)
+			}
+		}
+	}
+	return s, parents
+}
+
+func FuzzReachabilityIndex(f *testing.F) {
+	f.Add([]byte{0, 1, 1, 3, 0, 5, 0xff, 2})
+	f.Add([]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	f.Add([]byte{0x81, 0x42, 0x24, 0x18})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s, parents := buildFuzzDAG(data)
+		n := len(parents)
+
+		// Seed the index with the first half, insert the rest incrementally
+		half := n / 2
+		for v := 0; v < half; v++ {
+			s.add(v, parents[v]...)
+		}
+		r := NewReachabilityIndex[int](s)
+		for v := half; v < n; v++ {
+			s.add(v, parents[v]...)
+			if err := r.Add(v, parents[v]); err != nil {
+				t.Fatalf("Add(%d): %v", v, err)
+			}
+		}
+		if r.Len() != n {
+			t.Fatalf("indexed %d vertices, want %d", r.Len(), n)
+		}
+
+		for from := 0; from < n; from++ {
+			for to := 0; to < n; to++ {
+				want := dag.IsReachable[int](s, from, to)
+				if got := r.Reachable(from, to); got != want {
+					t.Fatalf("Reachable(%d, %d) = %v, IsReachable = %v", from, to, got, want)
+				}
+			}
+		}
+
+		// Pruning the middle vertex and its ancestors leaves the rest intact
+		if n == 0 {
+			return
+		}
+		r.Prune(half)
+		for from := 0; from < n; from++ {
+			for to := 0; to < n; to++ {
+				want := from == to
+				if !want && !dag.IsReachable[int](s, from, half) && !dag.IsReachable[int](s, to, half) {
+					want = dag.IsReachable[int](s, from, to)
+				}
+				if got := r.Reachable(from, to); got != want {
+					t.Fatalf("after Prune(%d): Reachable(%d, %d) = %v, want %v", half, from, to, got, want)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkReachabilityDeepChain(b *testing.B) {
+	for _, depth := range []int{1_000, 10_000} {
+		s := newReachStore()
+		s.add(0)
+		for v := 1; v < depth; v++ {
+			s.add(v, v-1)
+		}
+		r := NewReachabilityIndex[int](s)
+
+		b.Run(fmt.Sprintf("naive-%d", depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dag.IsReachable[int](s, 0, depth-1)
+			}
+		})
+		b.Run(fmt.Sprintf("index-%d", depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				r.Reachable(0, depth-1)
+			}
+		})
+		b.Run(fmt.Sprintf("add-%d", depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				v := depth + i
+				s.add(v, v-1)
+				_ = r.Add(v, []int{v - 1})
+			}
+		})
+	}
+}
+
+// newLayeredStore builds layers 0..depth-1 of width vertices each. Vertex j
+// of a layer has parents j and j+1 (mod width) of the layer below, except
+// for a detached vertex at height depth/2 with no parents.
+func newLayeredStore(depth, width int) (s *idStore, layers [][]ids.ID, detached ids.ID) {
+	s = newIDStore()
+	layers = make([][]ids.ID, depth)
+	for h := 0; h < depth; h++ {
+		for j := 0; j < width; j++ {
+			var parents []ids.ID
+			if h > 0 {
+				parents = []ids.ID{layers[h-1][j], layers[h-1][(j+1)%width]}
+			}
+			layers[h] = append(layers[h], s.add(uint64(h), parents...))
+		}
+	}
+	detached = s.add(uint64(depth / 2))
+	return s, layers, detached
+}
+
+func TestReachableInRange(t *testing.T) {
+	const depth, width = 7, 4
+	s, layers, detached := newLayeredStore(depth, width)
+	roots := []ids.ID{layers[1][0], layers[2][3]}
+
+	got := ReachableInRange(s, roots, 2, 4)
+	if len(got) == 0 {
+		t.Fatal("no vertices in range")
+	}
+
+	// Agrees with pairwise dag.IsReachable restricted to the band
+	var want []ids.ID
+	for id, blk := range s.blocks {
+		if blk.round < 2 || blk.round > 4 {
+			continue
+		}
+		for _, root := range roots {
+			if dag.IsReachable[ids.ID](s, root, id) {
+				want = append(want, id)
+				break
+			}
+		}
+	}
+	if !slices.Equal(sortedIDs(got), sortedIDs(want)) {
+		t.Fatalf("ReachableInRange = %v, want %v", got, want)
+	}
+
+	// Both ends of the band are respected: the layer-1 root and the
+	// reachable layers 5 and 6 are excluded, layers 2 and 4 are included
+	in := make(map[ids.ID]bool)
+	for _, id := range got {
+		in[id] = true
+	}
+	if in[layers[1][0]] {
+		t.Error("root below minHeight included")
+	}
+	for _, id := range append(layers[5], layers[6]...) {
+		if !dag.IsReachable[ids.ID](s, roots[0], id) {
+			t.Fatalf("layer %d vertex not structurally reachable", s.blocks[id].round)
+		}
+		if in[id] {
+			t.Errorf("vertex at height %d above maxHeight included", s.blocks[id].round)
+		}
+	}
+	if !in[layers[2][3]] || !in[layers[4][0]] {
+		t.Error("vertex at a band edge excluded")
+	}
+	if in[detached] {
+		t.Error("unreachable vertex in the band included")
+	}
+
+	// Ordered by height, then ID
+	for i := 1; i < len(got); i++ {
+		a, b := s.blocks[got[i-1]], s.blocks[got[i]]
+		if a.round > b.round || (a.round == b.round && a.id.Compare(b.id) >= 0) {
+			t.Fatalf("result out of order at %d", i)
+		}
+	}
+}
+
+func TestReachableInRangeEdges(t *testing.T) {
+	s, layers, _ := newLayeredStore(4, 3)
+
+	if got := ReachableInRange(s, layers[0], 3, 2); got != nil {
+		t.Errorf("inverted band returned %v", got)
+	}
+	if got := ReachableInRange(s, nil, 0, 3); len(got) != 0 {
+		t.Errorf("no roots returned %v", got)
+	}
+
+	// A single-height band holds just that layer; the whole DAG is
+	// reachable from layer 0
+	if got := ReachableInRange(s, layers[0], 2, 2); !slices.Equal(sortedIDs(got), sortedIDs(layers[2])) {
+		t.Errorf("band [2, 2] = %v, want layer 2", got)
+	}
+	if got := ReachableInRange(s, layers[0], 0, 3); len(got) != 4*3 {
+		t.Errorf("full band returned %d vertices, want %d", len(got), 4*3)
+	}
+
+	// A root missing from the store contributes nothing
+	if got := ReachableInRange(s, []ids.ID{ids.GenerateTestID()}, 0, 3); len(got) != 0 {
+		t.Errorf("unknown root returned %v", got)
+	}
+}
+
+// sortedIDs returns a sorted copy of vs
+func sortedIDs(vs []ids.ID) []ids.ID {
+	out := slices.Clone(vs)
+	slices.SortFunc(out, ids.ID.Compare)
+	return out
+}
@@ -18,6 +18,7 @@
 //   - DAG structure: vertices can have multiple parents
 //   - Parallel finality: multiple vertices can be finalized concurrently
 //   - Frontier tracking: maintains the DAG tips (unfinalized vertices)
+//   - Parent selection: a ProposalStrategy picks which tips a new vertex references
 //   - Causal ordering: ensures consistent total ordering of finalized vertices
 //
 // Usage:
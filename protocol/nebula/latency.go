@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nebula
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/luxfi/consensus/protocol/field"
+)
+
+// DefaultLatencyWindow is the number of finalizations LatencyStats covers
+// when Config.LatencyWindow is unset
+const DefaultLatencyWindow = 1024
+
+// DefaultLatencyPending is the number of undecided vertices stamped at once
+// when Config.LatencyPending is unset
+const DefaultLatencyPending = 4096
+
+// LatencyStats summarizes first-seen to finalized durations over the most
+// recent finalized vertices
+type LatencyStats struct {
+	Count int // finalizations in the window
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// latencyWindow is a fixed-size ring of the most recent latencies. Recording
+// never allocates; LatencyStats sorts into a scratch buffer sized up front.
+type latencyWindow struct {
+	ring    []time.Duration
+	next    int
+	full    bool
+	scratch []time.Duration
+}
+
+func newLatencyWindow(size int) latencyWindow {
+	if size <= 0 {
+		size = DefaultLatencyWindow
+	}
+	return latencyWindow{
+		ring:    make([]time.Duration, size),
+		scratch: make([]time.Duration, size),
+	}
+}
+
+func (w *latencyWindow) record(d time.Duration) {
+	w.ring[w.next] = d
+	w.next++
+	if w.next == len(w.ring) {
+		w.next = 0
+		w.full = true
+	}
+}
+
+func (w *latencyWindow) stats() LatencyStats {
+	n := w.next
+	if w.full {
+		n = len(w.ring)
+	}
+	if n == 0 {
+		return LatencyStats{}
+	}
+	sorted := w.scratch[:n]
+	copy(sorted, w.ring[:n])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return LatencyStats{
+		Count: n,
+		P50:   percentile(sorted, 0.50),
+		P95:   percentile(sorted, 0.95),
+		P99:   percentile(sorted, 0.99),
+		Max:   sorted[n-1],
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// stamp is when a vertex was first seen and its slot in the stamp order
+type stamp struct {
+	at   time.Time
+	slot int
+}
+
+// latencyTracker stamps vertices when first seen and records the elapsed
+// time when they are finalized. Vertices that never finalize, because they
+// lost a conflict, timed out or failed to commit, are not reported to it,
+// so the stamps are capped: the order ring remembers which vertex holds
+// each slot, and stamping a vertex when every slot is taken evicts the
+// oldest stamp. Both are sized up front.
+type latencyTracker[V VID] struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	firstSeen map[V]stamp // undecided vertices only
+	order     []V         // vertex stamped in each slot, oldest at next once full
+	next      int
+	window    latencyWindow
+}
+
+func newLatencyTracker[V VID](size, pending int) *latencyTracker[V] {
+	if pending <= 0 {
+		pending = DefaultLatencyPending
+	}
+	return &latencyTracker[V]{
+		now:       time.Now,
+		firstSeen: make(map[V]stamp, pending),
+		order:     make([]V, 0, pending),
+		window:    newLatencyWindow(size),
+	}
+}
+
+// seen stamps v unless it already has a stamp, evicting the oldest stamp
+// if every slot is taken
+func (t *latencyTracker[V]) seen(v V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.firstSeen[v]; ok {
+		return
+	}
+	slot := t.next
+	if len(t.order) < cap(t.order) {
+		t.order = append(t.order, v)
+	} else {
+		// The slot's vertex may have finalized, or been stamped again in a
+		// newer slot, since it was stamped here
+		if old, ok := t.firstSeen[t.order[slot]]; ok && old.slot == slot {
+			delete(t.firstSeen, t.order[slot])
+		}
+		t.order[slot] = v
+	}
+	t.next = (slot + 1) % cap(t.order)
+	t.firstSeen[v] = stamp{at: t.now(), slot: slot}
+}
+
+// finalized records latencies for the stamped vertices in ordered. A vertex
+// committed again is not stamped anymore and is skipped.
+func (t *latencyTracker[V]) finalized(ordered []V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	for _, v := range ordered {
+		if s, ok := t.firstSeen[v]; ok {
+			t.window.record(now.Sub(s.at))
+			delete(t.firstSeen, v)
+		}
+	}
+}
+
+func (t *latencyTracker[V]) stats() LatencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.window.stats()
+}
+
+// latencyCommitter records finalization latencies for every successfully
+// committed prefix before handing it back to the caller
+type latencyCommitter[V VID] struct {
+	next    field.Committer[V]
+	latency *latencyTracker[V]
+}
+
+func (c latencyCommitter[V]) Commit(ctx context.Context, ordered []V) error {
+	if err := c.next.Commit(ctx, ordered); err != nil {
+		return err
+	}
+	c.latency.finalized(ordered)
+	return nil
+}
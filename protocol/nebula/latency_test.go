@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nebula
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/field"
+	"github.com/luxfi/consensus/protocol/wave"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyWindowPercentiles(t *testing.T) {
+	require := require.New(t)
+
+	w := newLatencyWindow(100)
+	require.Equal(LatencyStats{}, w.stats())
+
+	for i := 100; i >= 1; i-- {
+		w.record(time.Duration(i) * time.Millisecond)
+	}
+	require.Equal(LatencyStats{
+		Count: 100,
+		P50:   50 * time.Millisecond,
+		P95:   95 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}, w.stats())
+
+	// The window slides: older samples are overwritten
+	for i := 0; i < 100; i++ {
+		w.record(time.Second)
+	}
+	stats := w.stats()
+	require.Equal(100, stats.Count)
+	require.Equal(time.Second, stats.P50)
+	require.Equal(time.Second, stats.Max)
+}
+
+// TestLatencyTrackerSyntheticFinalizations stamps vertices on a virtual
+// clock and finalizes them with latencies spread uniformly over 1-1000ms.
+func TestLatencyTrackerSyntheticFinalizations(t *testing.T) {
+	require := require.New(t)
+
+	const n = 1000
+	clock := time.Unix(0, 0)
+	tr := newLatencyTracker[int](n, 0)
+	tr.now = func() time.Time { return clock }
+
+	for v := 0; v < n; v++ {
+		tr.seen(v)
+	}
+	for v := 0; v < n; v++ {
+		clock = time.Unix(0, 0).Add(time.Duration((v*7919)%n+1) * time.Millisecond)
+		tr.finalized([]int{v})
+	}
+	// A repeated commit is not counted twice
+	tr.finalized([]int{0})
+
+	stats := tr.stats()
+	require.Equal(n, stats.Count)
+	require.InDelta(500*time.Millisecond, stats.P50, float64(10*time.Millisecond))
+	require.InDelta(950*time.Millisecond, stats.P95, float64(10*time.Millisecond))
+	require.InDelta(990*time.Millisecond, stats.P99, float64(10*time.Millisecond))
+	require.Equal(1000*time.Millisecond, stats.Max)
+	require.Empty(tr.firstSeen)
+}
+
+func TestLatencyTrackerNoAllocs(t *testing.T) {
+	tr := newLatencyTracker[int](64, 8)
+	tr.seen(1)
+	tr.finalized([]int{1})
+
+	ordered := []int{1}
+	allocs := testing.AllocsPerRun(100, func() {
+		tr.seen(1)
+		tr.finalized(ordered)
+	})
+	require.Zero(t, allocs)
+
+	// Vertices that never finalize evict each other without allocating
+	v := 100
+	allocs = testing.AllocsPerRun(100, func() {
+		tr.seen(v)
+		v++
+	})
+	require.Zero(t, allocs)
+}
+
+func TestLatencyTrackerCapsPending(t *testing.T) {
+	require := require.New(t)
+
+	const pending = 4
+	clock := time.Unix(0, 0)
+	tr := newLatencyTracker[int](16, pending)
+	tr.now = func() time.Time { return clock }
+
+	// Vertices that lose a conflict or time out are never finalized; only
+	// the newest stamps are kept
+	for v := 0; v < 10; v++ {
+		tr.seen(v)
+	}
+	require.Len(tr.firstSeen, pending)
+	for v := 6; v < 10; v++ {
+		require.Contains(tr.firstSeen, v)
+	}
+
+	// A finalized vertex frees its stamp, and an evicted one is not counted
+	clock = clock.Add(time.Millisecond)
+	tr.finalized([]int{9, 0})
+	require.Equal(1, tr.stats().Count)
+
+	// A vertex stamped again in a newer slot keeps that stamp when its old
+	// slot is reused
+	tr.finalized([]int{8})
+	tr.seen(8)
+	tr.seen(10)
+	tr.seen(11)
+	require.Len(tr.firstSeen, 3)
+	require.Contains(tr.firstSeen, 8)
+	require.Contains(tr.firstSeen, 11)
+}
+
+type rootVertex string
+
+func (v rootVertex) ID() string         { return string(v) }
+func (rootVertex) Parents() []string    { return nil }
+func (rootVertex) Author() types.NodeID { return types.NodeID{} }
+func (rootVertex) Round() uint64        { return 0 }
+
+// rootStore holds parentless vertices, so each is committed once decided
+type rootStore struct{ heads []string }
+
+func (s *rootStore) Head() []string { return s.heads }
+func (s *rootStore) Get(v string) (field.BlockView[string], bool) {
+	return rootVertex(v), true
+}
+func (s *rootStore) Children(string) []string { return nil }
+
+func TestNebulaLatencyStats(t *testing.T) {
+	require := require.New(t)
+
+	const k, beta = 4, 3
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+	}
+
+	n := NewNebula[string](Config{PollSize: k, Alpha: 0.75, Beta: beta, RoundTO: time.Second, LatencyWindow: 8},
+		cut, &splitTransport{k: k, quorum: true}, &rootStore{heads: []string{"a", "b"}}, nopProposer{}, nopCommitter{})
+
+	clock := time.Unix(0, 0)
+	n.latency.now = func() time.Time { return clock }
+
+	ctx := context.Background()
+	n.OnObserve(ctx, "a")
+	clock = clock.Add(40 * time.Millisecond)
+	for round := 0; round < beta+2; round++ {
+		require.NoError(n.Tick(ctx))
+		clock = clock.Add(10 * time.Millisecond)
+	}
+	require.True(n.IsFinalized("a"))
+	require.True(n.IsFinalized("b"))
+
+	// "a" was observed 40ms before "b" reached the frontier; both are
+	// decided in the same round and later commits are not double counted
+	stats := n.LatencyStats()
+	require.Equal(2, stats.Count)
+	require.Equal(60*time.Millisecond, stats.Max)
+	require.Equal(20*time.Millisecond, stats.P50)
+}
+
+var _ wave.Transport[string] = (*splitTransport)(nil)
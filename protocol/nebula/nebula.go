@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/luxfi/consensus/config"
 	"github.com/luxfi/consensus/protocol/field"
 	"github.com/luxfi/consensus/protocol/prism"
 	"github.com/luxfi/consensus/protocol/wave"
@@ -16,6 +17,8 @@ type VID interface{ comparable }
 type Nebula[V VID] struct {
 	fieldEngine *field.Driver[V]
 	config      Config
+	latency     *latencyTracker[V]
+	parents     *parentSelector[V]
 }
 
 // Config holds configuration for Nebula consensus mode
@@ -25,20 +28,109 @@ type Config struct {
 	Beta       uint32        // confidence threshold
 	RoundTO    time.Duration // round timeout
 	GenesisSet []byte        // genesis vertex set
+
+	// RoundTOBackoff, when set, grows the round timeout while rounds fail
+	// to reach quorum; nil keeps RoundTO fixed.
+	RoundTOBackoff *wave.RoundTOBackoff
+
+	// ConcurrentRepolls is how many fresh committees are polled in parallel
+	// after a round fails to reach quorum (0 = no re-polling)
+	ConcurrentRepolls int
+
+	// ConcurrentPolls caps the polls in flight across all items
+	// (0 = no limit)
+	ConcurrentPolls int
+
+	// MinRoundInterval is the minimum gap between polls of the same item
+	// (0 = no limit)
+	MinRoundInterval time.Duration
+
+	// MaxItemProcessingTime bounds how long an item may stay undecided
+	// before it is timed out (0 = no limit)
+	MaxItemProcessingTime time.Duration
+
+	// MaxOutstandingItems caps how many items may be undecided at once;
+	// new items beyond it are not polled until some decide (0 = no limit)
+	MaxOutstandingItems int
+
+	// LatencyWindow is how many recent finalizations LatencyStats covers
+	// (0 = DefaultLatencyWindow)
+	LatencyWindow int
+
+	// LatencyPending caps how many undecided vertices are timed at once;
+	// beyond it the oldest is no longer timed (0 = DefaultLatencyPending)
+	LatencyPending int
+
+	// ProposalStrategy selects a new vertex's parents from the frontier
+	// (default AllTips)
+	ProposalStrategy ProposalStrategy
+
+	// ProposalTips is k for KOldestTips and RandomTips
+	// (0 = DefaultProposalTips)
+	ProposalTips int
+
+	// ProposalSeed seeds RandomTips
+	ProposalSeed uint64
+
+	// CommitRetries is how many times a failed commit is retried before
+	// it is reported on CommitErrors (0 = no retries)
+	CommitRetries int
+
+	// CommitBackoff is the wait before the first commit retry, doubling
+	// for each one after (0 = field.DefaultCommitBackoff)
+	CommitBackoff time.Duration
+
+	// Clock times round timeouts, pacing, processing deadlines, commit
+	// retries and finalization latency (nil = wave.SystemClock); tests
+	// pass a wave.ManualClock
+	Clock wave.Clock
+}
+
+// ConfigFor returns a Config with the sampling, polling and admission
+// settings of the parameters
+func ConfigFor(p config.Parameters) Config {
+	return Config{
+		PollSize:              p.K,
+		Alpha:                 p.Alpha,
+		Beta:                  p.Beta,
+		RoundTO:               p.RoundTO,
+		ConcurrentRepolls:     p.ConcurrentRepolls,
+		ConcurrentPolls:       p.ConcurrentPolls,
+		MinRoundInterval:      p.MinRoundInterval,
+		MaxItemProcessingTime: p.MaxItemProcessingTime,
+		MaxOutstandingItems:   p.MaxOutstandingItems,
+	}
 }
 
 // NewNebula creates a new Nebula instance with Field engine
 func NewNebula[V VID](cfg Config, cut prism.Cut[V], tx wave.Transport[V], store field.Store[V], prop field.Proposer[V], com field.Committer[V]) *Nebula[V] {
 	fieldConfig := field.Config{
-		PollSize: cfg.PollSize,
-		Alpha:    cfg.Alpha,
-		Beta:     cfg.Beta,
-		RoundTO:  cfg.RoundTO,
+		PollSize:              cfg.PollSize,
+		Alpha:                 cfg.Alpha,
+		Beta:                  cfg.Beta,
+		RoundTO:               cfg.RoundTO,
+		RoundTOBackoff:        cfg.RoundTOBackoff,
+		ConcurrentRepolls:     cfg.ConcurrentRepolls,
+		ConcurrentPolls:       cfg.ConcurrentPolls,
+		MinRoundInterval:      cfg.MinRoundInterval,
+		MaxItemProcessingTime: cfg.MaxItemProcessingTime,
+		MaxOutstandingItems:   cfg.MaxOutstandingItems,
+		CommitRetries:         cfg.CommitRetries,
+		CommitBackoff:         cfg.CommitBackoff,
+		Clock:                 cfg.Clock,
+	}
+
+	latency := newLatencyTracker[V](cfg.LatencyWindow, cfg.LatencyPending)
+	if cfg.Clock != nil {
+		latency.now = cfg.Clock.Now
 	}
+	com = latencyCommitter[V]{next: com, latency: latency}
 
 	return &Nebula[V]{
 		fieldEngine: field.NewDriver(fieldConfig, cut, tx, store, prop, com),
 		config:      cfg,
+		latency:     latency,
+		parents:     newParentSelector(cfg, store),
 	}
 }
 
@@ -54,16 +146,26 @@ func (n *Nebula[V]) Stop(ctx context.Context) error {
 
 // ProposeVertex proposes a new vertex to the DAG
 func (n *Nebula[V]) ProposeVertex(ctx context.Context, parents []V) (V, error) {
-	return n.fieldEngine.Propose(ctx, parents)
+	v, err := n.fieldEngine.Propose(ctx, parents)
+	if err == nil {
+		n.latency.seen(v)
+	}
+	return v, err
 }
 
 // Tick performs one consensus round for DAG progression
 func (n *Nebula[V]) Tick(ctx context.Context) error {
+	for _, v := range n.fieldEngine.GetFrontier() {
+		if !n.fieldEngine.IsFinalized(v) {
+			n.latency.seen(v)
+		}
+	}
 	return n.fieldEngine.Tick(ctx)
 }
 
 // OnObserve should be called when observing new vertices from the network
 func (n *Nebula[V]) OnObserve(ctx context.Context, vertex V) {
+	n.latency.seen(vertex)
 	n.fieldEngine.OnObserve(ctx, vertex)
 }
 
@@ -81,3 +183,28 @@ func (n *Nebula[V]) IsFinalized(vertex V) bool {
 func (n *Nebula[V]) GetCommittedVertices() []V {
 	return n.fieldEngine.GetCommittedVertices()
 }
+
+// RoundTimeout returns the timeout the next round will wait for votes
+func (n *Nebula[V]) RoundTimeout() time.Duration {
+	return n.fieldEngine.RoundTimeout()
+}
+
+// Timeouts delivers vertices that exceeded MaxItemProcessingTime undecided
+func (n *Nebula[V]) Timeouts() <-chan V {
+	return n.fieldEngine.Timeouts()
+}
+
+// CommitErrors delivers committed prefixes the Committer still rejected
+// after Config.CommitRetries retries. A rejected prefix is not added to
+// GetCommittedVertices, and nothing finalized after it is committed until
+// a later Tick commits it.
+func (n *Nebula[V]) CommitErrors() <-chan *field.CommitError[V] {
+	return n.fieldEngine.CommitErrors()
+}
+
+// LatencyStats returns percentiles of the time vertices took from first
+// seen (proposed, observed or on the frontier) to finalized, over the last
+// Config.LatencyWindow finalizations
+func (n *Nebula[V]) LatencyStats() LatencyStats {
+	return n.latency.stats()
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nebula
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/field"
+	"github.com/luxfi/consensus/protocol/prism"
+	"github.com/luxfi/consensus/protocol/wave"
+	"github.com/stretchr/testify/require"
+)
+
+type testCut struct{ peers []types.NodeID }
+
+func (c *testCut) Sample(k int) []types.NodeID {
+	if k > len(c.peers) {
+		k = len(c.peers)
+	}
+	return c.peers[:k]
+}
+
+func (c *testCut) Luminance() prism.Luminance {
+	return prism.Luminance{ActivePeers: len(c.peers), TotalPeers: len(c.peers)}
+}
+
+// splitTransport answers every poll with k votes, split evenly unless
+// quorum is set, in which case all votes prefer the vertex.
+type splitTransport struct {
+	k      int
+	quorum bool
+}
+
+func (s *splitTransport) RequestVotes(_ context.Context, peers []types.NodeID, item string) <-chan wave.Photon[string] {
+	ch := make(chan wave.Photon[string], s.k)
+	for i := 0; i < s.k; i++ {
+		ch <- wave.Photon[string]{Item: item, Prefer: s.quorum || i%2 == 0, Sender: peers[i%len(peers)]}
+	}
+	close(ch)
+	return ch
+}
+
+func (s *splitTransport) MakeLocalPhoton(item string, prefer bool) wave.Photon[string] {
+	return wave.Photon[string]{Item: item, Prefer: prefer}
+}
+
+type headStore struct{ heads []string }
+
+func (s *headStore) Head() []string                             { return s.heads }
+func (s *headStore) Get(string) (field.BlockView[string], bool) { return nil, false }
+func (s *headStore) Children(string) []string                   { return nil }
+
+type nopProposer struct{}
+
+func (nopProposer) Propose(context.Context, []string) (string, error) { return "", nil }
+
+type nopCommitter struct{}
+
+func (nopCommitter) Commit(context.Context, []string) error { return nil }
+
+func TestNebulaRoundTimeoutBackoff(t *testing.T) {
+	require := require.New(t)
+
+	const k = 4
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+	}
+	tx := &splitTransport{k: k}
+
+	n := NewNebula[string](Config{
+		PollSize: k,
+		Alpha:    0.8,
+		Beta:     100,
+		RoundTO:  time.Second,
+		RoundTOBackoff: &wave.RoundTOBackoff{
+			Base:       5 * time.Millisecond,
+			Max:        60 * time.Millisecond,
+			Multiplier: 3,
+		},
+	}, cut, tx, &headStore{heads: []string{"a", "b"}}, nopProposer{}, nopCommitter{})
+
+	ctx := context.Background()
+	require.Equal(5*time.Millisecond, n.RoundTimeout())
+
+	expected := []time.Duration{
+		15 * time.Millisecond,
+		45 * time.Millisecond,
+		60 * time.Millisecond,
+	}
+	for _, want := range expected {
+		require.NoError(n.Tick(ctx))
+		require.Equal(want, n.RoundTimeout())
+	}
+
+	tx.quorum = true
+	require.NoError(n.Tick(ctx))
+	require.Equal(5*time.Millisecond, n.RoundTimeout())
+}
+
+func TestNebulaCustomVoteTransport(t *testing.T) {
+	require := require.New(t)
+
+	const k, beta = 4, 2
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+	}
+	// Every sampled peer prefers vertex "a" and rejects "b"
+	stub := wave.VoteTransportFunc[string](func(_ context.Context, peers []types.NodeID, item string) <-chan wave.Photon[string] {
+		ch := make(chan wave.Photon[string], len(peers))
+		for _, peer := range peers {
+			ch <- wave.Photon[string]{Item: item, Prefer: item == "a", Sender: peer}
+		}
+		close(ch)
+		return ch
+	})
+
+	n := NewNebula[string](Config{PollSize: k, Alpha: 0.75, Beta: beta, RoundTO: time.Second},
+		cut, wave.NewTransport[string](stub, types.NodeID{0xff}), &headStore{heads: []string{"a", "b"}}, nopProposer{}, nopCommitter{})
+
+	ctx := context.Background()
+	for round := 0; round < beta; round++ {
+		require.False(n.IsFinalized("a"))
+		require.NoError(n.Tick(ctx))
+	}
+	require.True(n.IsFinalized("a"))
+	require.False(n.IsFinalized("b"))
+}
+
+// quietTransport samples peers that never answer while silent is set, and
+// otherwise answers with k votes for the vertex
+type quietTransport struct {
+	k      int
+	silent bool
+}
+
+func (q *quietTransport) RequestVotes(_ context.Context, peers []types.NodeID, item string) <-chan wave.Photon[string] {
+	if q.silent {
+		return make(chan wave.Photon[string])
+	}
+	ch := make(chan wave.Photon[string], q.k)
+	for i := 0; i < q.k; i++ {
+		ch <- wave.Photon[string]{Item: item, Prefer: true, Sender: peers[i%len(peers)]}
+	}
+	close(ch)
+	return ch
+}
+
+func (q *quietTransport) MakeLocalPhoton(item string, prefer bool) wave.Photon[string] {
+	return wave.Photon[string]{Item: item, Prefer: prefer}
+}
+
+func TestNebulaManualClock(t *testing.T) {
+	require := require.New(t)
+
+	const k = 4
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+	}
+	tx := &quietTransport{k: k, silent: true}
+	clock := wave.NewManualClock(time.Unix(0, 0))
+
+	n := NewNebula[string](Config{
+		PollSize: k,
+		Alpha:    0.75,
+		Beta:     1,
+		RoundTO:  time.Second,
+		RoundTOBackoff: &wave.RoundTOBackoff{
+			Base:       5 * time.Millisecond,
+			Max:        60 * time.Millisecond,
+			Multiplier: 3,
+		},
+		Clock: clock,
+	}, cut, tx, &rootStore{heads: []string{"a"}}, nopProposer{}, nopCommitter{})
+
+	ctx := context.Background()
+	n.OnObserve(ctx, "a")
+
+	// Each silent round ends exactly at its timeout, which then triples
+	for _, roundTO := range []time.Duration{5 * time.Millisecond, 15 * time.Millisecond, 45 * time.Millisecond} {
+		require.Equal(roundTO, n.RoundTimeout())
+
+		done := make(chan error, 1)
+		go func() { done <- n.Tick(ctx) }()
+		clock.BlockUntil(1)
+		clock.Advance(roundTO - time.Nanosecond)
+		require.Equal(1, clock.Waiters())
+		clock.Advance(time.Nanosecond)
+		require.NoError(<-done)
+	}
+	require.Equal(60*time.Millisecond, n.RoundTimeout())
+
+	// A round reaching quorum finalizes "a" and resets the timeout; its
+	// latency is the 65ms of virtual time the silent rounds took
+	tx.silent = false
+	require.NoError(n.Tick(ctx))
+	require.True(n.IsFinalized("a"))
+	require.Equal(5*time.Millisecond, n.RoundTimeout())
+
+	stats := n.LatencyStats()
+	require.Equal(1, stats.Count)
+	require.Equal(65*time.Millisecond, stats.Max)
+}
+
+// flakyCommitter rejects the first fails commits, then accepts
+type flakyCommitter struct {
+	fails    int
+	attempts int
+	at       []time.Time
+}
+
+func (c *flakyCommitter) Commit(context.Context, []string) error {
+	c.attempts++
+	c.at = append(c.at, time.Now())
+	if c.attempts <= c.fails {
+		return errors.New("execution layer rejected commit")
+	}
+	return nil
+}
+
+func TestNebulaCommitRetry(t *testing.T) {
+	require := require.New(t)
+
+	const k = 4
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+	}
+	store := &rootStore{heads: []string{"a"}}
+	com := &flakyCommitter{fails: 4}
+
+	n := NewNebula[string](Config{
+		PollSize:      k,
+		Alpha:         0.75,
+		Beta:          1,
+		RoundTO:       time.Second,
+		CommitRetries: 2,
+		CommitBackoff: time.Millisecond,
+	}, cut, &splitTransport{k: k, quorum: true}, store, nopProposer{}, com)
+	ctx := context.Background()
+
+	// "a" finalizes but every attempt this tick fails: it is reported and
+	// not committed
+	err := n.Tick(ctx)
+	var commitErr *field.CommitError[string]
+	require.ErrorAs(err, &commitErr)
+	require.Equal([]string{"a"}, commitErr.Vertices)
+	require.Equal(3, commitErr.Attempts)
+	require.Equal(commitErr, <-n.CommitErrors())
+	require.True(n.IsFinalized("a"))
+	require.Empty(n.GetCommittedVertices())
+
+	// Retries back off: 1ms, then 2ms
+	require.GreaterOrEqual(com.at[1].Sub(com.at[0]), time.Millisecond)
+	require.GreaterOrEqual(com.at[2].Sub(com.at[1]), 2*time.Millisecond)
+
+	// "b" finalizes behind it; neither is committed while "a" still fails
+	store.heads = []string{"b"}
+	com.fails = 8
+	require.Error(n.Tick(ctx))
+	require.Equal([]string{"a"}, (<-n.CommitErrors()).Vertices)
+	require.True(n.IsFinalized("b"))
+	require.Empty(n.GetCommittedVertices())
+
+	// Once the committer recovers "a" is committed first, then "b"
+	com.fails = com.attempts + 1
+	require.NoError(n.Tick(ctx))
+	require.Equal([]string{"a", "b"}, n.GetCommittedVertices())
+
+	// Committed vertices are not committed again
+	attempts := com.attempts
+	require.NoError(n.Tick(ctx))
+	require.Equal(attempts, com.attempts)
+	require.Empty(n.CommitErrors())
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nebula
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"sync"
+
+	"github.com/luxfi/consensus/protocol/field"
+)
+
+// ProposalStrategy selects which frontier tips a new vertex references as
+// parents, trading DAG width against per-vertex fan-in
+type ProposalStrategy int
+
+const (
+	// AllTips references every tip, collapsing the frontier as fast as
+	// possible at the cost of unbounded fan-in
+	AllTips ProposalStrategy = iota
+
+	// KOldestTips references the k tips with the lowest round, so stale
+	// tips are picked up first and fan-in never exceeds k
+	KOldestTips
+
+	// RandomTips references k tips drawn from a generator seeded with
+	// Config.ProposalSeed, so a replay picks the same parents
+	RandomTips
+)
+
+// DefaultProposalTips is k when Config.ProposalTips is unset
+const DefaultProposalTips = 2
+
+func (s ProposalStrategy) String() string {
+	switch s {
+	case AllTips:
+		return "all-tips"
+	case KOldestTips:
+		return "k-oldest-tips"
+	case RandomTips:
+		return "random-tips"
+	default:
+		return "unknown"
+	}
+}
+
+// parentSelector applies the configured ProposalStrategy to a frontier
+type parentSelector[V VID] struct {
+	strategy ProposalStrategy
+	k        int
+	store    field.Store[V]
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func newParentSelector[V VID](cfg Config, store field.Store[V]) *parentSelector[V] {
+	k := cfg.ProposalTips
+	if k <= 0 {
+		k = DefaultProposalTips
+	}
+	return &parentSelector[V]{
+		strategy: cfg.ProposalStrategy,
+		k:        k,
+		store:    store,
+		rng:      rand.New(rand.NewPCG(cfg.ProposalSeed, 0)),
+	}
+}
+
+// selectParents returns the parents for a vertex built on frontier. The
+// frontier is not modified.
+func (s *parentSelector[V]) selectParents(frontier []V) []V {
+	if s.strategy == AllTips || len(frontier) <= s.k {
+		return append([]V(nil), frontier...)
+	}
+
+	switch s.strategy {
+	case KOldestTips:
+		tips := append([]V(nil), frontier...)
+		sort.SliceStable(tips, func(i, j int) bool {
+			return s.round(tips[i]) < s.round(tips[j])
+		})
+		return tips[:s.k]
+
+	case RandomTips:
+		s.mu.Lock()
+		picks := s.rng.Perm(len(frontier))[:s.k]
+		s.mu.Unlock()
+		// Keep frontier order so the subset, not the draw order, decides
+		// the parent list
+		sort.Ints(picks)
+		tips := make([]V, s.k)
+		for i, p := range picks {
+			tips[i] = frontier[p]
+		}
+		return tips
+
+	default:
+		return append([]V(nil), frontier...)
+	}
+}
+
+// round is v's round, or the highest round for a vertex the store does not
+// know, so known tips are preferred
+func (s *parentSelector[V]) round(v V) uint64 {
+	if b, ok := s.store.Get(v); ok {
+		return b.Round()
+	}
+	return math.MaxUint64
+}
+
+// SelectParents returns the tips of frontier a new vertex would reference
+// under the configured ProposalStrategy
+func (n *Nebula[V]) SelectParents(frontier []V) []V {
+	return n.parents.selectParents(frontier)
+}
+
+// ProposeFromFrontier proposes a new vertex whose parents are chosen from
+// the current frontier by the configured ProposalStrategy
+func (n *Nebula[V]) ProposeFromFrontier(ctx context.Context) (V, error) {
+	return n.ProposeVertex(ctx, n.SelectParents(n.GetFrontier()))
+}
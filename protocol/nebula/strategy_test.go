@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nebula
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/field"
+	"github.com/stretchr/testify/require"
+)
+
+type roundVertex struct {
+	id    string
+	round uint64
+}
+
+func (v roundVertex) ID() string         { return v.id }
+func (roundVertex) Parents() []string    { return nil }
+func (roundVertex) Author() types.NodeID { return types.NodeID{} }
+func (v roundVertex) Round() uint64      { return v.round }
+
+// roundStore is a frontier of tips with known rounds
+type roundStore struct {
+	heads  []string
+	rounds map[string]uint64
+}
+
+func (s *roundStore) Head() []string { return s.heads }
+func (s *roundStore) Get(v string) (field.BlockView[string], bool) {
+	r, ok := s.rounds[v]
+	return roundVertex{id: v, round: r}, ok
+}
+func (s *roundStore) Children(string) []string { return nil }
+
+// recordingProposer remembers the parents of the last proposal
+type recordingProposer struct{ parents []string }
+
+func (p *recordingProposer) Propose(_ context.Context, parents []string) (string, error) {
+	p.parents = parents
+	return "new", nil
+}
+
+// testFrontier has six tips whose rounds do not follow frontier order
+func testFrontier() *roundStore {
+	return &roundStore{
+		heads:  []string{"a", "b", "c", "d", "e", "f"},
+		rounds: map[string]uint64{"a": 5, "b": 2, "c": 9, "d": 1, "e": 2, "f": 7},
+	}
+}
+
+func newStrategyNebula(cfg Config, store field.Store[string], prop field.Proposer[string]) *Nebula[string] {
+	cfg.PollSize, cfg.Alpha, cfg.Beta, cfg.RoundTO = 1, 1, 1, time.Second
+	cut := &testCut{peers: []types.NodeID{{1}}}
+	return NewNebula[string](cfg, cut, &splitTransport{k: 1}, store, prop, nopCommitter{})
+}
+
+func TestProposalStrategyAllTips(t *testing.T) {
+	require := require.New(t)
+
+	store := testFrontier()
+	prop := &recordingProposer{}
+	n := newStrategyNebula(Config{}, store, prop)
+
+	_, err := n.ProposeFromFrontier(context.Background())
+	require.NoError(err)
+	require.Equal(store.heads, prop.parents)
+}
+
+func TestProposalStrategyKOldestTips(t *testing.T) {
+	require := require.New(t)
+
+	store := testFrontier()
+	prop := &recordingProposer{}
+	n := newStrategyNebula(Config{ProposalStrategy: KOldestTips, ProposalTips: 3}, store, prop)
+
+	// Rounds 1, 2, 2: ties keep frontier order
+	_, err := n.ProposeFromFrontier(context.Background())
+	require.NoError(err)
+	require.Equal([]string{"d", "b", "e"}, prop.parents)
+	require.Equal([]string{"a", "b", "c", "d", "e", "f"}, store.heads)
+
+	// Unknown tips are referenced last
+	require.Equal([]string{"d", "b"}, newStrategyNebula(Config{ProposalStrategy: KOldestTips}, store, nil).
+		SelectParents([]string{"x", "b", "d"}))
+
+	// Fan-in never exceeds k, and a narrow frontier is referenced whole
+	for k := 1; k <= 8; k++ {
+		n := newStrategyNebula(Config{ProposalStrategy: KOldestTips, ProposalTips: k}, store, nil)
+		for width := 0; width <= len(store.heads); width++ {
+			parents := n.SelectParents(store.heads[:width])
+			require.LessOrEqual(len(parents), k)
+			require.Equal(min(k, width), len(parents))
+		}
+	}
+}
+
+func TestProposalStrategyRandomTips(t *testing.T) {
+	require := require.New(t)
+
+	store := testFrontier()
+	cfg := Config{ProposalStrategy: RandomTips, ProposalTips: 3, ProposalSeed: 42}
+	a := newStrategyNebula(cfg, store, nil)
+	b := newStrategyNebula(cfg, store, nil)
+
+	// The same seed yields the same sequence of subsets
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		parents := a.SelectParents(store.heads)
+		require.Equal(parents, b.SelectParents(store.heads))
+		require.Len(parents, 3)
+
+		// Distinct tips, in frontier order
+		last := -1
+		for _, p := range parents {
+			idx := slices.Index(store.heads, p)
+			require.Greater(idx, last)
+			last = idx
+			seen[p] = true
+		}
+	}
+	require.Len(seen, len(store.heads), "every tip is eventually referenced")
+
+	other := newStrategyNebula(Config{ProposalStrategy: RandomTips, ProposalTips: 3, ProposalSeed: 7}, store, nil)
+	differs := false
+	for i := 0; i < 20 && !differs; i++ {
+		differs = !slices.Equal(other.SelectParents(store.heads), a.SelectParents(store.heads))
+	}
+	require.True(differs, "different seeds pick different subsets")
+}
@@ -17,6 +17,8 @@
 //   - Sequential finality: one block finalized at a time
 //   - Height tracking: maintains current blockchain height
 //   - Preference tracking: manages preferred block selection
+//   - Proposer selection: ProposerSelector picks each height's proposer by stake,
+//     and other nodes verify the proposer's claim
 //
 // Usage:
 //
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/luxfi/consensus/config"
 	"github.com/luxfi/consensus/protocol/prism"
 	"github.com/luxfi/consensus/protocol/ray"
 	"github.com/luxfi/consensus/protocol/wave"
@@ -22,15 +23,66 @@ type Config struct {
 	Beta        uint32        // confidence threshold
 	RoundTO     time.Duration // round timeout
 	GenesisHash [32]byte      // genesis block hash
+
+	// RoundTOBackoff, when set, grows the round timeout while rounds fail
+	// to reach quorum; nil keeps RoundTO fixed.
+	RoundTOBackoff *wave.RoundTOBackoff
+
+	// ConcurrentRepolls is how many fresh committees are polled in parallel
+	// after a round fails to reach quorum (0 = no re-polling)
+	ConcurrentRepolls int
+
+	// ConcurrentPolls caps the polls in flight across all items
+	// (0 = no limit)
+	ConcurrentPolls int
+
+	// MinRoundInterval is the minimum gap between polls of the same item
+	// (0 = no limit)
+	MinRoundInterval time.Duration
+
+	// MaxItemProcessingTime bounds how long an item may stay undecided
+	// before it is timed out (0 = no limit)
+	MaxItemProcessingTime time.Duration
+
+	// MaxOutstandingItems caps how many items may be undecided at once;
+	// new items beyond it are not polled until some decide (0 = no limit)
+	MaxOutstandingItems int
+
+	// Clock times round timeouts, pacing and processing deadlines
+	// (nil = wave.SystemClock); tests pass a wave.ManualClock
+	Clock wave.Clock
+}
+
+// ConfigFor returns a Config with the sampling, polling and admission
+// settings of the parameters
+func ConfigFor(p config.Parameters) Config {
+	return Config{
+		SampleSize:            p.K,
+		Alpha:                 p.Alpha,
+		Beta:                  p.Beta,
+		RoundTO:               p.RoundTO,
+		ConcurrentRepolls:     p.ConcurrentRepolls,
+		ConcurrentPolls:       p.ConcurrentPolls,
+		MinRoundInterval:      p.MinRoundInterval,
+		MaxItemProcessingTime: p.MaxItemProcessingTime,
+		MaxOutstandingItems:   p.MaxOutstandingItems,
+	}
 }
 
 // NewNova creates a new Nova instance with Ray engine
 func NewNova[T comparable](cfg Config, cut prism.Cut[T], tx wave.Transport[T], source ray.Source[T], sink ray.Sink[T]) *Nova[T] {
 	rayConfig := ray.Config{
-		PollSize: cfg.SampleSize,
-		Alpha:    cfg.Alpha,
-		Beta:     cfg.Beta,
-		RoundTO:  cfg.RoundTO,
+		PollSize:              cfg.SampleSize,
+		Alpha:                 cfg.Alpha,
+		Beta:                  cfg.Beta,
+		RoundTO:               cfg.RoundTO,
+		RoundTOBackoff:        cfg.RoundTOBackoff,
+		ConcurrentRepolls:     cfg.ConcurrentRepolls,
+		ConcurrentPolls:       cfg.ConcurrentPolls,
+		MinRoundInterval:      cfg.MinRoundInterval,
+		MaxItemProcessingTime: cfg.MaxItemProcessingTime,
+		MaxOutstandingItems:   cfg.MaxOutstandingItems,
+		Clock:                 cfg.Clock,
 	}
 
 	return &Nova[T]{
@@ -73,3 +125,13 @@ func (n *Nova[T]) IsFinalized(block T) bool {
 func (n *Nova[T]) Height() uint64 {
 	return n.rayEngine.Height()
 }
+
+// RoundTimeout returns the timeout the next round will wait for votes
+func (n *Nova[T]) RoundTimeout() time.Duration {
+	return n.rayEngine.RoundTimeout()
+}
+
+// Timeouts delivers items that exceeded MaxItemProcessingTime undecided
+func (n *Nova[T]) Timeouts() <-chan T {
+	return n.rayEngine.Timeouts()
+}
@@ -0,0 +1,256 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nova
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/prism"
+	"github.com/luxfi/consensus/protocol/wave"
+	"github.com/stretchr/testify/require"
+)
+
+type testCut struct{ peers []types.NodeID }
+
+func (c *testCut) Sample(k int) []types.NodeID {
+	if k > len(c.peers) {
+		k = len(c.peers)
+	}
+	return c.peers[:k]
+}
+
+func (c *testCut) Luminance() prism.Luminance {
+	return prism.Luminance{ActivePeers: len(c.peers), TotalPeers: len(c.peers)}
+}
+
+// splitTransport answers every poll with k votes, split evenly unless
+// quorum is set, in which case all votes prefer the item.
+type splitTransport struct {
+	k      int
+	quorum bool
+}
+
+func (s *splitTransport) RequestVotes(_ context.Context, peers []types.NodeID, item string) <-chan wave.Photon[string] {
+	ch := make(chan wave.Photon[string], s.k)
+	for i := 0; i < s.k; i++ {
+		ch <- wave.Photon[string]{Item: item, Prefer: s.quorum || i%2 == 0, Sender: peers[i%len(peers)]}
+	}
+	close(ch)
+	return ch
+}
+
+func (s *splitTransport) MakeLocalPhoton(item string, prefer bool) wave.Photon[string] {
+	return wave.Photon[string]{Item: item, Prefer: prefer}
+}
+
+type pendingSource struct{ items []string }
+
+func (p *pendingSource) NextPending(context.Context, int) []string { return p.items }
+
+type nopSink struct{}
+
+func (nopSink) Decide(context.Context, []string, types.Decision) error { return nil }
+
+func TestNovaRoundTimeoutBackoff(t *testing.T) {
+	require := require.New(t)
+
+	const k = 4
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+	}
+	tx := &splitTransport{k: k}
+
+	n := NewNova[string](Config{
+		SampleSize: k,
+		Alpha:      0.8,
+		Beta:       100,
+		RoundTO:    time.Second,
+		RoundTOBackoff: &wave.RoundTOBackoff{
+			Base:       10 * time.Millisecond,
+			Max:        80 * time.Millisecond,
+			Multiplier: 2,
+		},
+	}, cut, tx, &pendingSource{items: []string{"block"}}, nopSink{})
+
+	ctx := context.Background()
+	require.Equal(10*time.Millisecond, n.RoundTimeout())
+
+	expected := []time.Duration{
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		80 * time.Millisecond,
+		80 * time.Millisecond,
+	}
+	for _, want := range expected {
+		require.NoError(n.Tick(ctx))
+		require.Equal(want, n.RoundTimeout())
+	}
+
+	tx.quorum = true
+	require.NoError(n.Tick(ctx))
+	require.Equal(10*time.Millisecond, n.RoundTimeout())
+
+	tx.quorum = false
+	require.NoError(n.Tick(ctx))
+	require.Equal(20*time.Millisecond, n.RoundTimeout())
+}
+
+func TestNovaRoundTimeoutFixedByDefault(t *testing.T) {
+	require := require.New(t)
+
+	cut := &testCut{peers: []types.NodeID{{1}, {2}}}
+	n := NewNova[string](Config{
+		SampleSize: 2,
+		Alpha:      0.8,
+		Beta:       100,
+		RoundTO:    250 * time.Millisecond,
+	}, cut, &splitTransport{k: 2}, &pendingSource{items: []string{"block"}}, nopSink{})
+
+	for i := 0; i < 3; i++ {
+		require.NoError(n.Tick(context.Background()))
+		require.Equal(250*time.Millisecond, n.RoundTimeout())
+	}
+}
+
+// peerVotes is a VoteTransport answering each poll with one vote per
+// sampled peer, as a real network transport would
+type peerVotes struct {
+	prefer map[types.NodeID]bool
+	polls  int
+}
+
+func (p *peerVotes) RequestVotes(ctx context.Context, peers []types.NodeID, item string) <-chan wave.Photon[string] {
+	p.polls++
+	ch := make(chan wave.Photon[string], len(peers))
+	go func() {
+		defer close(ch)
+		for _, peer := range peers {
+			select {
+			case ch <- wave.Photon[string]{Item: item, Prefer: p.prefer[peer], Sender: peer, Timestamp: time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+type recordingSink struct{ decided []string }
+
+func (r *recordingSink) Decide(_ context.Context, items []string, d types.Decision) error {
+	if d == types.DecideAccept {
+		r.decided = append(r.decided, items...)
+	}
+	return nil
+}
+
+func TestNovaCustomVoteTransport(t *testing.T) {
+	require := require.New(t)
+
+	const k, beta = 5, 3
+	cut := &testCut{peers: make([]types.NodeID, k)}
+	vt := &peerVotes{prefer: make(map[types.NodeID]bool)}
+	for i := range cut.peers {
+		cut.peers[i] = types.NodeID{byte(i + 1)}
+		vt.prefer[cut.peers[i]] = i != 0 // one dissenter, 4/5 = α
+	}
+	sink := &recordingSink{}
+
+	n := NewNova[string](Config{SampleSize: k, Alpha: 0.8, Beta: beta, RoundTO: time.Second},
+		cut, wave.NewTransport[string](vt, types.NodeID{0xff}), &pendingSource{items: []string{"block"}}, sink)
+
+	ctx := context.Background()
+	for round := 1; round <= beta; round++ {
+		require.False(n.IsFinalized("block"), "finalized before round %d", round)
+		require.NoError(n.Tick(ctx))
+	}
+	require.True(n.IsFinalized("block"))
+	require.Equal([]string{"block"}, sink.decided)
+	require.Equal(beta, vt.polls)
+}
+
+// silentTransport samples peers that never answer, so every round runs to
+// its timeout
+type silentTransport struct{}
+
+func (silentTransport) RequestVotes(context.Context, []types.NodeID, string) <-chan wave.Photon[string] {
+	return make(chan wave.Photon[string])
+}
+
+func (silentTransport) MakeLocalPhoton(item string, prefer bool) wave.Photon[string] {
+	return wave.Photon[string]{Item: item, Prefer: prefer}
+}
+
+// tickOnClock runs one Tick whose single poll waits on clock, and checks
+// the round ends exactly when roundTO of virtual time has passed
+func tickOnClock(t *testing.T, n *Nova[string], clock *wave.ManualClock, roundTO time.Duration) {
+	require := require.New(t)
+
+	done := make(chan error, 1)
+	go func() { done <- n.Tick(context.Background()) }()
+
+	clock.BlockUntil(1)
+	clock.Advance(roundTO - time.Nanosecond)
+	require.Equal(1, clock.Waiters(), "round ended before its %v timeout", roundTO)
+	clock.Advance(time.Nanosecond)
+	require.NoError(<-done)
+	require.Zero(clock.Waiters())
+}
+
+func TestNovaManualClock(t *testing.T) {
+	require := require.New(t)
+
+	clock := wave.NewManualClock(time.Unix(0, 0))
+	n := NewNova[string](Config{
+		SampleSize: 3,
+		Alpha:      0.8,
+		Beta:       100,
+		RoundTO:    time.Second,
+		RoundTOBackoff: &wave.RoundTOBackoff{
+			Base:       10 * time.Millisecond,
+			Max:        40 * time.Millisecond,
+			Multiplier: 2,
+		},
+		MaxItemProcessingTime: 100 * time.Millisecond,
+		Clock:                 clock,
+	}, &testCut{peers: []types.NodeID{{1}, {2}, {3}}}, silentTransport{}, &pendingSource{items: []string{"block"}}, nopSink{})
+
+	// Rounds of 10, 20, 40 and 40ms: the backoff doubles up to its cap
+	for _, roundTO := range []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		40 * time.Millisecond,
+	} {
+		require.Equal(roundTO, n.RoundTimeout())
+		tickOnClock(t, n, clock, roundTO)
+	}
+
+	// 110ms have passed since the first round: the next tick times the
+	// block out without polling
+	require.NoError(n.Tick(context.Background()))
+	require.Equal("block", <-n.Timeouts())
+	require.Zero(clock.Waiters())
+}
+
+func TestNovaConfigFor(t *testing.T) {
+	require := require.New(t)
+
+	p := config.DefaultParams()
+	p.ConcurrentPolls = 8
+	p.ConcurrentRepolls = 2
+	p.MaxOutstandingItems = 16
+	cfg := ConfigFor(p)
+	require.Equal(p.K, cfg.SampleSize)
+	require.Equal(p.Alpha, cfg.Alpha)
+	require.Equal(p.Beta, cfg.Beta)
+	require.Equal(8, cfg.ConcurrentPolls)
+	require.Equal(2, cfg.ConcurrentRepolls)
+	require.Equal(16, cfg.MaxOutstandingItems)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nova
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/prism"
+)
+
+// proposerDomain separates proposer proofs from other SHA-256 uses
+const proposerDomain = "lux/nova/proposer/v1"
+
+var (
+	// ErrNoProposers is returned when no validator has positive stake
+	ErrNoProposers = errors.New("nova: no validators with positive stake")
+
+	// ErrStakeOverflow is returned when the total stake overflows uint64
+	ErrStakeOverflow = errors.New("nova: total stake overflows")
+
+	// ErrDuplicateValidator is returned when a validator ID is listed more
+	// than once
+	ErrDuplicateValidator = errors.New("nova: duplicate validator")
+
+	// ErrBeaconUnavailable is returned when the beacon has no randomness
+	// for a height yet
+	ErrBeaconUnavailable = errors.New("nova: beacon unavailable for height")
+
+	// ErrInvalidProposerProof is returned when a claim's proof is not the
+	// draw for its height
+	ErrInvalidProposerProof = errors.New("nova: proposer proof does not match height")
+
+	// ErrWrongProposer is returned when a claim names a proposer its proof
+	// does not select
+	ErrWrongProposer = errors.New("nova: proposer not selected by proof")
+)
+
+// ProposerClaim asserts that Proposer proposes the block at Height. Proof
+// is the draw that selected it.
+type ProposerClaim struct {
+	Height   uint64
+	Proposer types.NodeID
+	Proof    [sha256.Size]byte
+}
+
+// Beacon returns the randomness for height, or false if it is not fixed
+// yet. It must become known only once height's predecessor is final, such
+// as the ID of the finalized block at height-1, so the proposer schedule
+// cannot be read more than one height ahead and the previous proposer,
+// having committed its block before the beacon is known, cannot grind it.
+type Beacon func(height uint64) ([32]byte, bool)
+
+// ProposerSelector picks each height's block proposer with probability
+// proportional to stake. The draw for a height is SHA-256 of the beacon
+// for that height and the height itself, so every node holding the same
+// validator set and finalized history derives the same proposer, while no
+// one can compute it before the beacon is fixed. A node that receives a
+// block checks the proposer's claim with Verify.
+type ProposerSelector struct {
+	beacon     Beacon
+	validators []prism.Validator // positive stake, sorted by ID
+	total      uint64
+}
+
+// NewProposerSelector creates a selector over validators drawing from
+// beacon. Zero-stake validators are never selected, a validator listed
+// twice is rejected, and the order of validators does not affect the
+// result.
+func NewProposerSelector(beacon Beacon, validators []prism.Validator) (*ProposerSelector, error) {
+	s := &ProposerSelector{beacon: beacon}
+	seen := make(map[types.NodeID]struct{}, len(validators))
+	for _, v := range validators {
+		if _, dup := seen[v.ID]; dup {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateValidator, v.ID)
+		}
+		seen[v.ID] = struct{}{}
+		if v.Weight == 0 {
+			continue
+		}
+		if v.Weight > math.MaxUint64-s.total {
+			return nil, ErrStakeOverflow
+		}
+		s.total += v.Weight
+		s.validators = append(s.validators, v)
+	}
+	if len(s.validators) == 0 {
+		return nil, ErrNoProposers
+	}
+	slices.SortFunc(s.validators, func(a, b prism.Validator) int {
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	return s, nil
+}
+
+// Select returns the claim for the proposer of height. It fails with
+// ErrBeaconUnavailable until the beacon for height is fixed.
+func (s *ProposerSelector) Select(height uint64) (ProposerClaim, error) {
+	proof, err := s.proof(height)
+	if err != nil {
+		return ProposerClaim{}, err
+	}
+	return ProposerClaim{
+		Height:   height,
+		Proposer: s.proposer(proof),
+		Proof:    proof,
+	}, nil
+}
+
+// Verify checks that claim's proof is the draw for its height and that the
+// draw selects its proposer
+func (s *ProposerSelector) Verify(claim ProposerClaim) error {
+	proof, err := s.proof(claim.Height)
+	if err != nil {
+		return err
+	}
+	if proof != claim.Proof {
+		return fmt.Errorf("%w: height %d", ErrInvalidProposerProof, claim.Height)
+	}
+	if want := s.proposer(proof); want != claim.Proposer {
+		return fmt.Errorf("%w: height %d selects %s, not %s", ErrWrongProposer, claim.Height, want, claim.Proposer)
+	}
+	return nil
+}
+
+// proof returns the draw for height
+func (s *ProposerSelector) proof(height uint64) ([sha256.Size]byte, error) {
+	beacon, ok := s.beacon(height)
+	if !ok {
+		return [sha256.Size]byte{}, fmt.Errorf("%w: %d", ErrBeaconUnavailable, height)
+	}
+	buf := make([]byte, 0, len(proposerDomain)+len(beacon)+8)
+	buf = append(buf, proposerDomain...)
+	buf = append(buf, beacon[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, height)
+	return sha256.Sum256(buf), nil
+}
+
+// proposer maps proof to a stake position in [0, total) and returns the
+// validator whose cumulative stake range holds it. Positions are drawn by
+// rejection sampling so no validator gains from modulo bias.
+func (s *ProposerSelector) proposer(proof [sha256.Size]byte) types.NodeID {
+	limit := (math.MaxUint64 / s.total) * s.total
+	var target uint64
+	for counter := uint64(0); ; counter++ {
+		sum := sha256.Sum256(binary.BigEndian.AppendUint64(proof[:], counter))
+		if v := binary.BigEndian.Uint64(sum[:8]); v < limit {
+			target = v % s.total
+			break
+		}
+	}
+
+	for _, v := range s.validators {
+		if target < v.Weight {
+			return v.ID
+		}
+		target -= v.Weight
+	}
+	// Unreachable: target < total
+	return s.validators[len(s.validators)-1].ID
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nova
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/prism"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidators(stakes ...uint64) []prism.Validator {
+	validators := make([]prism.Validator, len(stakes))
+	for i, stake := range stakes {
+		validators[i] = prism.Validator{ID: types.NodeID{byte(i + 1)}, Weight: stake}
+	}
+	return validators
+}
+
+// testBeacon stands in for the finalized block IDs of a chain: a distinct
+// value per height, varying with seed
+func testBeacon(seed byte) Beacon {
+	return func(height uint64) ([32]byte, bool) {
+		var b [32]byte
+		b[0] = seed
+		binary.BigEndian.PutUint64(b[1:], height)
+		return b, true
+	}
+}
+
+// mustSelect returns s's claim for height
+func mustSelect(t *testing.T, s *ProposerSelector, height uint64) ProposerClaim {
+	t.Helper()
+	claim, err := s.Select(height)
+	require.NoError(t, err)
+	return claim
+}
+
+func TestProposerSelectionTracksStake(t *testing.T) {
+	require := require.New(t)
+
+	validators := testValidators(10, 20, 30, 40, 0)
+	s, err := NewProposerSelector(testBeacon(7), validators)
+	require.NoError(err)
+
+	const heights = 20000
+	counts := make(map[types.NodeID]int)
+	for h := uint64(0); h < heights; h++ {
+		counts[mustSelect(t, s, h).Proposer]++
+	}
+
+	for _, v := range validators {
+		want := float64(v.Weight) / 100
+		got := float64(counts[v.ID]) / heights
+		require.InDelta(want, got, 0.015, "validator %s with stake %d", v.ID, v.Weight)
+	}
+	require.Zero(counts[validators[4].ID], "zero-stake validator selected")
+}
+
+func TestProposerSelectionDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	validators := testValidators(5, 1, 9, 3)
+	a, err := NewProposerSelector(testBeacon(1), validators)
+	require.NoError(err)
+
+	// Every node derives the same proposers, whatever order it lists
+	// validators in
+	reversed := slices.Clone(validators)
+	slices.Reverse(reversed)
+	b, err := NewProposerSelector(testBeacon(1), reversed)
+	require.NoError(err)
+
+	other, err := NewProposerSelector(testBeacon(2), validators)
+	require.NoError(err)
+
+	differs := false
+	for h := uint64(0); h < 100; h++ {
+		require.Equal(mustSelect(t, a, h), mustSelect(t, b, h))
+		require.NoError(b.Verify(mustSelect(t, a, h)))
+		if mustSelect(t, a, h).Proposer != mustSelect(t, other, h).Proposer {
+			differs = true
+		}
+	}
+	require.True(differs, "the beacon does not affect selection")
+}
+
+func TestProposerClaimForgeryRejected(t *testing.T) {
+	require := require.New(t)
+
+	validators := testValidators(50, 30, 20)
+	s, err := NewProposerSelector(testBeacon(3), validators)
+	require.NoError(err)
+
+	claim := mustSelect(t, s, 42)
+	require.NoError(s.Verify(claim))
+
+	// Another validator claims the height with the real proof
+	for _, v := range validators {
+		if v.ID == claim.Proposer {
+			continue
+		}
+		forged := claim
+		forged.Proposer = v.ID
+		require.ErrorIs(s.Verify(forged), ErrWrongProposer)
+	}
+
+	// A proof made up to select the forger
+	forged := claim
+	forged.Proof[0] ^= 1
+	require.ErrorIs(s.Verify(forged), ErrInvalidProposerProof)
+
+	// A valid claim replayed at another height
+	replayed := claim
+	replayed.Height = 43
+	require.ErrorIs(s.Verify(replayed), ErrInvalidProposerProof)
+}
+
+func TestProposerSelectorErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewProposerSelector(testBeacon(0), nil)
+	require.ErrorIs(err, ErrNoProposers)
+
+	_, err = NewProposerSelector(testBeacon(0), testValidators(0, 0))
+	require.ErrorIs(err, ErrNoProposers)
+
+	_, err = NewProposerSelector(testBeacon(0), testValidators(math.MaxUint64, 1))
+	require.ErrorIs(err, ErrStakeOverflow)
+
+	// A validator listed twice would have its stake counted twice
+	validators := testValidators(5, 5)
+	validators = append(validators, validators[0])
+	_, err = NewProposerSelector(testBeacon(0), validators)
+	require.ErrorIs(err, ErrDuplicateValidator)
+	validators[2].Weight = 0
+	_, err = NewProposerSelector(testBeacon(0), validators)
+	require.ErrorIs(err, ErrDuplicateValidator)
+}
+
+func TestProposerSelectorWaitsForBeacon(t *testing.T) {
+	require := require.New(t)
+
+	// The beacon for a height is fixed once its predecessor finalizes
+	finalized := uint64(10)
+	beacon := testBeacon(4)
+	s, err := NewProposerSelector(func(height uint64) ([32]byte, bool) {
+		if height == 0 || height-1 > finalized {
+			return [32]byte{}, false
+		}
+		return beacon(height)
+	}, testValidators(1, 2, 3))
+	require.NoError(err)
+
+	claim := mustSelect(t, s, 11)
+	require.NoError(s.Verify(claim))
+
+	// The schedule past the next height cannot be computed or verified yet
+	_, err = s.Select(12)
+	require.ErrorIs(err, ErrBeaconUnavailable)
+	claim.Height = 12
+	require.ErrorIs(s.Verify(claim), ErrBeaconUnavailable)
+}
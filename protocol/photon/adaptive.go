@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/log"
+)
+
+// LiveCountFunc reports how many validators are currently live
+type LiveCountFunc func() int
+
+// AdaptiveEmitter samples committees of min(K, live validator count) so a
+// fixed K never exceeds the network actually available. Committees are drawn
+// deterministically from the seed: the same (seed, liveCount) pair always
+// yields the same sequence of committees.
+type AdaptiveEmitter struct {
+	nodes     []types.NodeID
+	options   EmitterOptions
+	seed      []byte
+	liveCount LiveCountFunc
+	log       log.Logger
+
+	mu         sync.Mutex
+	round      uint64
+	lastK      int
+	lastLiveCt int
+}
+
+// NewAdaptiveEmitter creates an emitter over nodes that clamps options.K to
+// the count reported by liveCount. seed is usually SeedBytes of a DeriveSeed
+// result. A nil logger disables clamp warnings.
+func NewAdaptiveEmitter(nodes []types.NodeID, options EmitterOptions, seed []byte, liveCount LiveCountFunc, logger log.Logger) *AdaptiveEmitter {
+	if logger == nil {
+		logger = log.Noop()
+	}
+	return &AdaptiveEmitter{
+		nodes:     nodes,
+		options:   options,
+		seed:      append([]byte(nil), seed...),
+		liveCount: liveCount,
+		log:       logger,
+		lastK:     options.K,
+	}
+}
+
+// EffectiveK returns the committee size the next round samples:
+// min(configured K, live count), raised to options.MinK and capped at the
+// known nodes. The floor keeps a shrinking network from driving the
+// committee, and with it wave's α threshold, toward 0; a round that
+// samples offline validators fails instead of finalizing on too few
+// votes.
+func (e *AdaptiveEmitter) EffectiveK() int {
+	k, _ := e.effectiveK()
+	return k
+}
+
+func (e *AdaptiveEmitter) effectiveK() (k, live int) {
+	live = len(e.nodes)
+	if e.liveCount != nil {
+		live = e.liveCount()
+	}
+	if live < 0 {
+		live = 0
+	}
+	k = max(min(e.options.K, live), e.options.MinK, 1)
+	k = min(k, len(e.nodes))
+
+	// Warn once per change rather than on every round
+	e.mu.Lock()
+	changed := k != e.lastK || live != e.lastLiveCt
+	e.lastK, e.lastLiveCt = k, live
+	e.mu.Unlock()
+	if changed && k < e.options.K {
+		e.log.Warn("photon: clamping committee size to live validators",
+			log.Int("configuredK", e.options.K),
+			log.Int("liveCount", live),
+			log.Int("effectiveK", k))
+	}
+	return k, live
+}
+
+// Sample returns the committee for round. The result depends only on the
+// seed, the current live count and round.
+func (e *AdaptiveEmitter) Sample(round uint64) []types.NodeID {
+	k, live := e.effectiveK()
+	return e.sample(round, k, live)
+}
+
+// Emit samples the committee for the next round
+func (e *AdaptiveEmitter) Emit(msg interface{}) ([]types.NodeID, error) {
+	e.mu.Lock()
+	round := e.round
+	e.round++
+	e.mu.Unlock()
+	return e.Sample(round), nil
+}
+
+// EmitTo emits a message to specific nodes
+func (e *AdaptiveEmitter) EmitTo(nodes []types.NodeID, msg interface{}) error {
+	return nil
+}
+
+// sample runs a partial Fisher-Yates shuffle driven by a SHA-256 stream
+// keyed on (seed, live, round)
+func (e *AdaptiveEmitter) sample(round uint64, k, live int) []types.NodeID {
+	n := len(e.nodes)
+	shuffled := make([]types.NodeID, n)
+	copy(shuffled, e.nodes)
+
+	stream := newSeedStream(e.seed, uint64(live), round)
+	for i := 0; i < k; i++ {
+		j := i + stream.intn(n-i)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled[:k]
+}
+
+// seedStream is a deterministic counter-mode SHA-256 generator
+type seedStream struct {
+	prefix  []byte
+	counter uint64
+}
+
+func newSeedStream(seed []byte, live, round uint64) *seedStream {
+	prefix := make([]byte, 0, len(seed)+16)
+	prefix = append(prefix, seed...)
+	prefix = binary.BigEndian.AppendUint64(prefix, live)
+	prefix = binary.BigEndian.AppendUint64(prefix, round)
+	return &seedStream{prefix: prefix}
+}
+
+func (s *seedStream) uint64() uint64 {
+	buf := make([]byte, 0, len(s.prefix)+8)
+	buf = append(buf, s.prefix...)
+	buf = binary.BigEndian.AppendUint64(buf, s.counter)
+	s.counter++
+	sum := sha256.Sum256(buf)
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// intn returns a uniform integer in [0, max) using the same rejection
+// sampling as cryptoRandInt to avoid modulo bias
+func (s *seedStream) intn(max int) int {
+	if max <= 0 {
+		return 0
+	}
+	limit := (^uint64(0) / uint64(max)) * uint64(max)
+	for {
+		if v := s.uint64(); v < limit {
+			return int(v % uint64(max))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/consensus/core/types"
+)
+
+func testNodes(n int) []types.NodeID {
+	nodes := make([]types.NodeID, n)
+	for i := range nodes {
+		nodes[i] = types.NodeID{byte(i + 1)}
+	}
+	return nodes
+}
+
+func TestAdaptiveEmitterClampsK(t *testing.T) {
+	tests := []struct {
+		name      string
+		liveCount int
+		wantK     int
+	}{
+		{name: "live below K", liveCount: 5, wantK: 5},
+		{name: "live equal to K", liveCount: 8, wantK: 8},
+		{name: "live above K", liveCount: 12, wantK: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			nodes := testNodes(16)
+			e := NewAdaptiveEmitter(nodes, EmitterOptions{K: 8}, []byte("seed"), func() int { return tt.liveCount }, nil)
+			require.Equal(tt.wantK, e.EffectiveK())
+
+			committee, err := e.Emit(nil)
+			require.NoError(err)
+			require.Len(committee, tt.wantK)
+
+			seen := make(map[types.NodeID]bool)
+			for _, id := range committee {
+				require.False(seen[id], "duplicate committee member")
+				require.Contains(nodes, id)
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestAdaptiveEmitterClampsToKnownNodes(t *testing.T) {
+	e := NewAdaptiveEmitter(testNodes(3), EmitterOptions{K: 8}, nil, func() int { return 10 }, nil)
+	require.Equal(t, 3, e.EffectiveK())
+	require.Len(t, e.Sample(0), 3)
+}
+
+func TestAdaptiveEmitterFloorsK(t *testing.T) {
+	require := require.New(t)
+
+	live := 2
+	nodes := testNodes(16)
+	e := NewAdaptiveEmitter(nodes, EmitterOptions{K: 8, MinK: 5}, []byte("seed"), func() int { return live }, nil)
+	require.Equal(5, e.EffectiveK())
+	require.Len(e.Sample(0), 5)
+
+	// Without a floor the committee still never shrinks to nothing
+	live = 0
+	e = NewAdaptiveEmitter(nodes, EmitterOptions{K: 8}, []byte("seed"), func() int { return live }, nil)
+	require.Equal(1, e.EffectiveK())
+
+	// The floor cannot exceed the known nodes
+	e = NewAdaptiveEmitter(testNodes(3), EmitterOptions{K: 8, MinK: 5}, nil, func() int { return live }, nil)
+	require.Equal(3, e.EffectiveK())
+}
+
+func TestAdaptiveEmitterReproducible(t *testing.T) {
+	require := require.New(t)
+
+	nodes := testNodes(32)
+	live := 10
+	liveCount := func() int { return live }
+	a := NewAdaptiveEmitter(nodes, EmitterOptions{K: 20}, []byte("seed"), liveCount, nil)
+	b := NewAdaptiveEmitter(nodes, EmitterOptions{K: 20}, []byte("seed"), liveCount, nil)
+
+	// Same (seed, liveCount) yields the same committee sequence
+	for round := 0; round < 5; round++ {
+		ca, _ := a.Emit(nil)
+		cb, _ := b.Emit(nil)
+		require.Equal(ca, cb)
+	}
+	require.Equal(a.Sample(7), b.Sample(7))
+
+	// A different seed or live count changes the draw
+	c := NewAdaptiveEmitter(nodes, EmitterOptions{K: 20}, []byte("other"), liveCount, nil)
+	require.NotEqual(a.Sample(0), c.Sample(0))
+
+	before := a.Sample(0)
+	live = 11
+	require.NotEqual(before, a.Sample(0))
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/luxfi/consensus/core/types"
+)
+
+// auditLabel separates committee proofs from other HMAC-SHA256 uses
+const auditLabel = "lux/photon/committee-proof/v1"
+
+var (
+	// ErrProofMismatch is returned when a committee proof is not the PRF
+	// output for its seed and round
+	ErrProofMismatch = errors.New("photon: committee proof does not match seed and round")
+
+	// ErrCommitteeMismatch is returned when a committee is not the one its
+	// proof selects
+	ErrCommitteeMismatch = errors.New("photon: committee does not match proof")
+)
+
+// AuditRecord is one sampled committee and the proof that selected it
+type AuditRecord struct {
+	Seed      []byte
+	Round     uint64
+	Committee []types.NodeID
+	Proof     []byte
+}
+
+// AuditSink stores the records of an AuditingEmitter
+type AuditSink interface {
+	Record(AuditRecord) error
+}
+
+// MemoryAuditSink is an AuditSink that keeps records in memory
+type MemoryAuditSink struct {
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+// Record appends r
+func (s *MemoryAuditSink) Record(r AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, r)
+	return nil
+}
+
+// Records returns the records in the order they were stored
+func (s *MemoryAuditSink) Records() []AuditRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.records)
+}
+
+// AuditingEmitter samples the committee of each round from a PRF of its
+// seed and round and records every committee to a sink. The PRF output is
+// the committee's proof: anyone holding the validator set can recompute the
+// committee from it with VerifyCommitteeProof, so a node cannot later deny
+// which committee it sampled or claim a different one.
+type AuditingEmitter struct {
+	nodes   []types.NodeID
+	options EmitterOptions
+	seed    []byte
+	sink    AuditSink
+
+	mu    sync.Mutex
+	round uint64
+}
+
+// NewAuditingEmitter creates an emitter that samples options.K of nodes per
+// round under seed and records each committee to sink. seed is usually
+// SeedBytes of a DeriveSeed result.
+func NewAuditingEmitter(nodes []types.NodeID, options EmitterOptions, seed []byte, sink AuditSink) *AuditingEmitter {
+	return &AuditingEmitter{
+		nodes:   slices.Clone(nodes),
+		options: options,
+		seed:    slices.Clone(seed),
+		sink:    sink,
+	}
+}
+
+// Emit samples and records the committee for the next round
+func (e *AuditingEmitter) Emit(msg interface{}) ([]types.NodeID, error) {
+	e.mu.Lock()
+	round := e.round
+	e.round++
+	e.mu.Unlock()
+
+	committee, proof := e.ProveCommittee(e.seed, round)
+	record := AuditRecord{
+		Seed:      slices.Clone(e.seed),
+		Round:     round,
+		Committee: slices.Clone(committee),
+		Proof:     proof,
+	}
+	if err := e.sink.Record(record); err != nil {
+		return nil, fmt.Errorf("photon: recording committee for round %d: %w", round, err)
+	}
+	return committee, nil
+}
+
+// EmitTo emits a message to specific nodes
+func (e *AuditingEmitter) EmitTo(nodes []types.NodeID, msg interface{}) error {
+	return nil
+}
+
+// ProveCommittee returns the committee sampled for (seed, round) and its
+// proof, the PRF output the committee is drawn from
+func (e *AuditingEmitter) ProveCommittee(seed []byte, round uint64) (committee []types.NodeID, proof []byte) {
+	proof = committeeProof(seed, round)
+	return proofCommittee(e.nodes, e.options.K, proof), proof
+}
+
+// VerifyCommitteeProof checks that proof is the PRF output for (seed,
+// round) and that committee is exactly the committee of k it selects from
+// nodes. The order nodes are listed in does not matter.
+func VerifyCommitteeProof(nodes []types.NodeID, k int, seed []byte, round uint64, committee []types.NodeID, proof []byte) error {
+	if !hmac.Equal(proof, committeeProof(seed, round)) {
+		return fmt.Errorf("%w: round %d", ErrProofMismatch, round)
+	}
+	if !slices.Equal(committee, proofCommittee(nodes, k, proof)) {
+		return fmt.Errorf("%w: round %d", ErrCommitteeMismatch, round)
+	}
+	return nil
+}
+
+// committeeProof returns HMAC-SHA256 keyed by seed over the round
+func committeeProof(seed []byte, round uint64) []byte {
+	mac := hmac.New(sha256.New, seed)
+	mac.Write([]byte(auditLabel))
+	mac.Write(binary.BigEndian.AppendUint64(nil, round))
+	return mac.Sum(nil)
+}
+
+// proofCommittee samples k of nodes with SampleCommittee keyed on proof,
+// so the committee depends on the set of nodes, not the order a caller
+// lists them in
+func proofCommittee(nodes []types.NodeID, k int, proof []byte) []types.NodeID {
+	return SampleCommittee(proof, nodes, k)
+}
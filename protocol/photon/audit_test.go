@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/consensus/core/types"
+)
+
+func TestAuditingEmitterProofRecomputesCommittee(t *testing.T) {
+	require := require.New(t)
+
+	nodes := testNodes(32)
+	seed := SeedBytes(DeriveSeed([]byte("lux/test"), 10, 3))
+	sink := &MemoryAuditSink{}
+	e := NewAuditingEmitter(nodes, EmitterOptions{K: 8}, seed, sink)
+
+	var emitted [][]types.NodeID
+	for i := 0; i < 4; i++ {
+		committee, err := e.Emit(nil)
+		require.NoError(err)
+		require.Len(committee, 8)
+		emitted = append(emitted, committee)
+	}
+
+	records := sink.Records()
+	require.Len(records, 4)
+	for i, r := range records {
+		require.Equal(seed, r.Seed)
+		require.Equal(uint64(i), r.Round)
+		require.Equal(emitted[i], r.Committee)
+
+		// Anyone holding the validator set recomputes the exact committee
+		require.NoError(VerifyCommitteeProof(nodes, 8, r.Seed, r.Round, r.Committee, r.Proof))
+
+		committee, proof := e.ProveCommittee(r.Seed, r.Round)
+		require.Equal(r.Committee, committee)
+		require.Equal(r.Proof, proof)
+
+		// including one that lists the validators in another order
+		reversed := slices.Clone(nodes)
+		slices.Reverse(reversed)
+		require.NoError(VerifyCommitteeProof(reversed, 8, r.Seed, r.Round, r.Committee, r.Proof))
+	}
+	require.NotEqual(records[0].Committee, records[1].Committee)
+}
+
+func TestAuditingEmitterRejectsTampering(t *testing.T) {
+	require := require.New(t)
+
+	nodes := testNodes(32)
+	seed := []byte("round-seed")
+	e := NewAuditingEmitter(nodes, EmitterOptions{K: 8}, seed, &MemoryAuditSink{})
+	committee, proof := e.ProveCommittee(seed, 5)
+	require.NoError(VerifyCommitteeProof(nodes, 8, seed, 5, committee, proof))
+
+	// A member swapped for a node outside the committee
+	swapped := slices.Clone(committee)
+	for _, id := range nodes {
+		if !slices.Contains(committee, id) {
+			swapped[0] = id
+			break
+		}
+	}
+	err := VerifyCommitteeProof(nodes, 8, seed, 5, swapped, proof)
+	require.ErrorIs(err, ErrCommitteeMismatch)
+
+	// Reordered, truncated and extended committees
+	reordered := slices.Clone(committee)
+	reordered[0], reordered[1] = reordered[1], reordered[0]
+	require.ErrorIs(VerifyCommitteeProof(nodes, 8, seed, 5, reordered, proof), ErrCommitteeMismatch)
+	require.ErrorIs(VerifyCommitteeProof(nodes, 8, seed, 5, committee[:7], proof), ErrCommitteeMismatch)
+	require.ErrorIs(VerifyCommitteeProof(nodes, 8, seed, 5, append(slices.Clone(committee), swapped[0]), proof), ErrCommitteeMismatch)
+
+	// The proof of one round does not stand for another
+	require.ErrorIs(VerifyCommitteeProof(nodes, 8, seed, 6, committee, proof), ErrProofMismatch)
+	require.ErrorIs(VerifyCommitteeProof(nodes, 8, []byte("other-seed"), 5, committee, proof), ErrProofMismatch)
+
+	tampered := slices.Clone(proof)
+	tampered[0] ^= 1
+	require.ErrorIs(VerifyCommitteeProof(nodes, 8, seed, 5, committee, tampered), ErrProofMismatch)
+}
+
+type failingSink struct{}
+
+func (failingSink) Record(AuditRecord) error { return errSinkFull }
+
+var errSinkFull = errors.New("sink full")
+
+func TestAuditingEmitterSinkError(t *testing.T) {
+	require := require.New(t)
+
+	e := NewAuditingEmitter(testNodes(8), EmitterOptions{K: 4}, []byte("seed"), failingSink{})
+	_, err := e.Emit(nil)
+	require.ErrorIs(err, errSinkFull)
+}
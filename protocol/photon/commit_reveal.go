@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/prism"
+)
+
+// commitDomain separates committee commitments from other SHA-256 uses
+const commitDomain = "lux/photon/committee-commit/v1"
+
+var (
+	// ErrNoCommitment is returned when a committee is revealed or checked
+	// without a prior commitment
+	ErrNoCommitment = errors.New("photon: no committee commitment")
+
+	// ErrCommitmentMismatch is returned when a committee does not hash to
+	// its commitment
+	ErrCommitmentMismatch = errors.New("photon: committee does not match commitment")
+
+	// ErrEmptyCommittee is returned by Commit when the emitter has no
+	// nodes or a committee size below 1
+	ErrEmptyCommittee = errors.New("photon: empty committee")
+)
+
+// Commitment binds a round seed to a committee without revealing it
+type Commitment [sha256.Size]byte
+
+// sortedNodes returns a copy of nodes in canonical byte order
+func sortedNodes(nodes []types.NodeID) []types.NodeID {
+	sorted := slices.Clone(nodes)
+	slices.SortFunc(sorted, func(a, b types.NodeID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return sorted
+}
+
+// SampleCommittee returns the committee of min(k, len(nodes)) nodes that
+// seed selects, by a partial Fisher-Yates shuffle of the canonically
+// ordered nodes driven by a SHA-256 stream keyed on seed. The result
+// depends only on seed, k and the set of nodes, so every node holding the
+// validator set recomputes the same committee from a revealed seed.
+func SampleCommittee(seed []byte, nodes []types.NodeID, k int) []types.NodeID {
+	shuffled := sortedNodes(nodes)
+	n := len(shuffled)
+	k = max(min(k, n), 0)
+
+	stream := newSeedStream(seed, uint64(n), uint64(k))
+	for i := 0; i < k; i++ {
+		j := i + stream.intn(n-i)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled[:k]
+}
+
+// CommitCommittee returns the commitment to committee for seed. Member
+// order does not affect the result.
+func CommitCommittee(seed []byte, committee []types.NodeID) Commitment {
+	sorted := sortedNodes(committee)
+
+	h := sha256.New()
+	h.Write([]byte(commitDomain))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(seed))))
+	h.Write(seed)
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(sorted))))
+	for _, id := range sorted {
+		h.Write(id[:])
+	}
+	var c Commitment
+	h.Sum(c[:0])
+	return c
+}
+
+// Verify returns ErrCommitmentMismatch unless committee is the committee c
+// commits to for seed
+func (c Commitment) Verify(seed []byte, committee []types.NodeID) error {
+	if CommitCommittee(seed, committee) != c {
+		return fmt.Errorf("%w: %d members for seed %x", ErrCommitmentMismatch, len(committee), seed)
+	}
+	return nil
+}
+
+// CommitRevealEmitter delays the committee of round r behind a hash. At the
+// end of round r-1 Commit draws the committee as SampleCommittee of the
+// round's seed and publishes only its Commitment; when round r opens Reveal
+// discloses it. A voter that sees the commitment early learns nothing it
+// can act on, and any committee other than the revealed seed's sample is
+// rejected.
+//
+// The most recently revealed round is the open round: Emit and Sample
+// return its committee and VerifyCommittee checks against its commitment.
+// Passing the emitter to wave both as the cut and through
+// wave.WithCommitteeVerifier polls exactly the revealed seed's sample.
+type CommitRevealEmitter struct {
+	base  Emitter
+	nodes []types.NodeID
+	k     int
+
+	mu      sync.Mutex
+	pending map[string]pendingCommittee
+	open    *pendingCommittee
+}
+
+type pendingCommittee struct {
+	seed       []byte
+	commitment Commitment
+	committee  []types.NodeID
+}
+
+// NewCommitRevealEmitter samples committees of k of nodes behind a
+// commit-reveal delay. Messages sent with EmitTo go through base.
+func NewCommitRevealEmitter(base Emitter, nodes []types.NodeID, k int) *CommitRevealEmitter {
+	return &CommitRevealEmitter{
+		base:    base,
+		nodes:   slices.Clone(nodes),
+		k:       k,
+		pending: make(map[string]pendingCommittee),
+	}
+}
+
+// Commit draws the committee for seed and returns its commitment.
+// Committing the same seed again returns the same commitment.
+func (e *CommitRevealEmitter) Commit(seed []byte) (Commitment, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if p, ok := e.pending[string(seed)]; ok {
+		return p.commitment, nil
+	}
+	committee := SampleCommittee(seed, e.nodes, e.k)
+	if len(committee) == 0 {
+		return Commitment{}, fmt.Errorf("%w: %d nodes, k=%d", ErrEmptyCommittee, len(e.nodes), e.k)
+	}
+	p := pendingCommittee{
+		seed:       slices.Clone(seed),
+		commitment: CommitCommittee(seed, committee),
+		committee:  slices.Clone(committee),
+	}
+	e.pending[string(seed)] = p
+	return p.commitment, nil
+}
+
+// Reveal opens the round committed to by seed and returns its committee
+// after checking it against the commitment
+func (e *CommitRevealEmitter) Reveal(seed []byte) ([]types.NodeID, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p, ok := e.pending[string(seed)]
+	if !ok {
+		return nil, fmt.Errorf("%w: seed %x", ErrNoCommitment, seed)
+	}
+	if err := p.commitment.Verify(seed, p.committee); err != nil {
+		return nil, err
+	}
+	delete(e.pending, string(seed))
+	e.open = &p
+	return slices.Clone(p.committee), nil
+}
+
+// VerifyCommittee checks that committee is the open round's committee,
+// the sample of its revealed seed
+func (e *CommitRevealEmitter) VerifyCommittee(committee []types.NodeID) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.open == nil {
+		return ErrNoCommitment
+	}
+	return e.open.commitment.Verify(e.open.seed, committee)
+}
+
+// Emit returns the open round's committee
+func (e *CommitRevealEmitter) Emit(msg interface{}) ([]types.NodeID, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.open == nil {
+		return nil, ErrNoCommitment
+	}
+	return slices.Clone(e.open.committee), nil
+}
+
+// Sample implements prism.Cut. It returns the open round's committee
+// whatever k is, so a poll of it passes VerifyCommittee, and nil before
+// any round is revealed.
+func (e *CommitRevealEmitter) Sample(int) []types.NodeID {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.open == nil {
+		return nil
+	}
+	return slices.Clone(e.open.committee)
+}
+
+// Luminance implements prism.Cut over the open round's committee
+func (e *CommitRevealEmitter) Luminance() prism.Luminance {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	active := 0
+	if e.open != nil {
+		active = len(e.open.committee)
+	}
+	return prism.Luminance{ActivePeers: active, TotalPeers: len(e.nodes), Lx: float64(active)}
+}
+
+// EmitTo emits a message to specific nodes through the base emitter
+func (e *CommitRevealEmitter) EmitTo(nodes []types.NodeID, msg interface{}) error {
+	return e.base.EmitTo(nodes, msg)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/consensus/core/types"
+)
+
+func TestCommitRevealCycle(t *testing.T) {
+	require := require.New(t)
+	e := NewCommitRevealEmitter(NewUniformEmitter(testNodes(20), EmitterOptions{K: 5, Fanout: 5}), testNodes(20), 5)
+
+	_, err := e.Emit(nil)
+	require.ErrorIs(err, ErrNoCommitment)
+
+	// Round r-1 closes with a commitment to round r
+	seed := []byte("round-7")
+	c, err := e.Commit(seed)
+	require.NoError(err)
+	again, err := e.Commit(seed)
+	require.NoError(err)
+	require.Equal(c, again, "re-committing must not redraw the committee")
+
+	_, err = e.Reveal([]byte("round-8"))
+	require.ErrorIs(err, ErrNoCommitment)
+
+	// Round r opens
+	committee, err := e.Reveal(seed)
+	require.NoError(err)
+	require.Len(committee, 5)
+	require.NoError(c.Verify(seed, committee))
+	require.NoError(e.VerifyCommittee(committee))
+
+	// Order does not matter
+	reversed := slices.Clone(committee)
+	slices.Reverse(reversed)
+	require.NoError(e.VerifyCommittee(reversed))
+
+	emitted, err := e.Emit(nil)
+	require.NoError(err)
+	require.Equal(committee, emitted)
+	require.Equal(committee, e.Sample(5))
+
+	// A commitment is revealed once
+	_, err = e.Reveal(seed)
+	require.ErrorIs(err, ErrNoCommitment)
+}
+
+func TestCommitRevealRejectsMismatch(t *testing.T) {
+	require := require.New(t)
+	e := NewCommitRevealEmitter(NewUniformEmitter(testNodes(20), EmitterOptions{K: 5, Fanout: 5}), testNodes(20), 5)
+
+	seed := []byte("round-1")
+	c, err := e.Commit(seed)
+	require.NoError(err)
+	committee, err := e.Reveal(seed)
+	require.NoError(err)
+
+	// Swap one member for a node outside the committee
+	swapped := slices.Clone(committee)
+	for _, n := range testNodes(20) {
+		if !slices.Contains(committee, n) {
+			swapped[0] = n
+			break
+		}
+	}
+	require.ErrorIs(c.Verify(seed, swapped), ErrCommitmentMismatch)
+	require.ErrorIs(e.VerifyCommittee(swapped), ErrCommitmentMismatch)
+	require.ErrorIs(e.VerifyCommittee(committee[:4]), ErrCommitmentMismatch)
+
+	// The same committee under another seed is a different commitment
+	require.ErrorIs(c.Verify([]byte("round-2"), committee), ErrCommitmentMismatch)
+	require.NotEqual(c, CommitCommittee(nil, []types.NodeID{}))
+}
+
+func TestCommitRevealSamplesSeed(t *testing.T) {
+	require := require.New(t)
+	nodes := testNodes(20)
+
+	// The committee is the seed's sample, whatever order nodes are held in
+	seed := []byte("round-3")
+	want := SampleCommittee(seed, nodes, 5)
+	require.Len(want, 5)
+	reversed := slices.Clone(nodes)
+	slices.Reverse(reversed)
+	require.Equal(want, SampleCommittee(seed, reversed, 5))
+	require.NotEqual(want, SampleCommittee([]byte("round-4"), nodes, 5))
+	require.Len(SampleCommittee(seed, nodes, 50), 20)
+
+	e := NewCommitRevealEmitter(NewUniformEmitter(reversed, DefaultEmitterOptions()), reversed, 5)
+	require.Nil(e.Sample(5))
+	c, err := e.Commit(seed)
+	require.NoError(err)
+	require.Equal(CommitCommittee(seed, want), c)
+	committee, err := e.Reveal(seed)
+	require.NoError(err)
+	require.Equal(want, committee)
+	require.Equal(5, e.Luminance().ActivePeers)
+
+	empty := NewCommitRevealEmitter(NewUniformEmitter(nil, DefaultEmitterOptions()), nil, 5)
+	_, err = empty.Commit(seed)
+	require.ErrorIs(err, ErrEmptyCommittee)
+}
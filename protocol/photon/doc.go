@@ -5,5 +5,8 @@
 // No physics is implied or required.
 //
 // Typical use: engines pass a seed/phase to produce a stable committee for
-// a round; wave applies thresholds over the observed tallies.
+// a round; wave applies thresholds over the observed tallies. Seeds should
+// come from DeriveSeed, which maps a (domain, height, round) triple to the
+// same seed on every node; engines still passing ad-hoc integer seeds should
+// migrate to it.
 package photon
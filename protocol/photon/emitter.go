@@ -30,6 +30,10 @@ type EmitterOptions struct {
 	K       int // Committee size
 	Fanout  int // Number of nodes to emit to
 	Timeout int // Timeout in milliseconds
+
+	// MinK is the smallest committee an AdaptiveEmitter shrinks K to; set
+	// it to at least α (0 = 1)
+	MinK int
 }
 
 // UniformEmitter implements uniform random emission
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// seedLabel separates derived sampling seeds from other HMAC-SHA256 uses
+const seedLabel = "lux/photon/seed/v1"
+
+// DeriveSeed returns the sampling seed for (height, round) under domain,
+// computed as HMAC-SHA256 keyed by domain. Every node given the same inputs
+// derives the same seed, and distinct (domain, height, round) triples
+// collide only with the probability of a 64-bit PRF output collision, unlike
+// ad-hoc arithmetic such as round*10+attempt.
+//
+// Engines should derive every committee seed with DeriveSeed, using a
+// domain that names the chain and the purpose of the sample, and pass it to
+// emitters with SeedBytes.
+func DeriveSeed(domain []byte, height uint64, round uint64) uint64 {
+	mac := hmac.New(sha256.New, domain)
+	mac.Write([]byte(seedLabel))
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], height)
+	binary.BigEndian.PutUint64(buf[8:], round)
+	mac.Write(buf[:])
+	return binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+}
+
+// SeedBytes encodes seed for the emitters that take byte seeds, such as
+// NewAdaptiveEmitter and CommitRevealEmitter.Commit
+func SeedBytes(seed uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seed)
+}
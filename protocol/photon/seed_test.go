@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package photon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveSeedNoCollisions(t *testing.T) {
+	require := require.New(t)
+
+	// Includes the (height, round) pairs an r*10+attempt scheme would merge
+	seen := make(map[uint64][3]uint64)
+	for d, domain := range [][]byte{[]byte("lux/test/a"), []byte("lux/test/b")} {
+		for height := uint64(0); height < 500; height++ {
+			for round := uint64(0); round < 100; round++ {
+				seed := DeriveSeed(domain, height, round)
+				prev, dup := seen[seed]
+				require.False(dup, "seed %x for (%d, %d, %d) collides with %v", seed, d, height, round, prev)
+				seen[seed] = [3]uint64{uint64(d), height, round}
+			}
+		}
+	}
+}
+
+func TestDeriveSeedReproducible(t *testing.T) {
+	require := require.New(t)
+
+	// Fixed vectors: every node must derive these exact seeds
+	tests := []struct {
+		domain string
+		height uint64
+		round  uint64
+		want   uint64
+	}{
+		{domain: "lux/test", height: 0, round: 0, want: 0x90f4b5c35e1f028c},
+		{domain: "lux/test", height: 42, round: 7, want: 0xde0761c9abf41461},
+		{domain: "", height: 1, round: 1, want: 0xcac86c3b6a289c53},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 3; i++ {
+			require.Equal(tt.want, DeriveSeed([]byte(tt.domain), tt.height, tt.round))
+		}
+	}
+
+	// Height and round are not interchangeable
+	require.NotEqual(DeriveSeed(nil, 1, 2), DeriveSeed(nil, 2, 1))
+}
+
+func TestDerivedSeedDrivesEmitter(t *testing.T) {
+	require := require.New(t)
+
+	nodes := testNodes(32)
+	seed := SeedBytes(DeriveSeed([]byte("lux/test"), 10, 3))
+	a := NewAdaptiveEmitter(nodes, EmitterOptions{K: 8}, seed, nil, nil)
+	b := NewAdaptiveEmitter(nodes, EmitterOptions{K: 8}, SeedBytes(DeriveSeed([]byte("lux/test"), 10, 3)), nil, nil)
+	require.Equal(a.Sample(0), b.Sample(0))
+
+	other := NewAdaptiveEmitter(nodes, EmitterOptions{K: 8}, SeedBytes(DeriveSeed([]byte("lux/test"), 10, 4)), nil, nil)
+	require.NotEqual(a.Sample(0), other.Sample(0))
+}
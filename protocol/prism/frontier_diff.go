@@ -0,0 +1,51 @@
+// Copyright (C) 2020-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prism
+
+// FrontierDiff walks back from a peer's frontier and returns every vertex the
+// local side does not hold. The result is ordered parents-before-children so
+// it can be inserted as-is without violating causality.
+//
+// parents reports a vertex's parent IDs in the peer's DAG; has reports
+// whether the local DAG already holds a vertex. The walk stops at vertices the
+// local side already has, so only the missing suffix of the DAG is visited.
+// Given the same frontier order the result is deterministic.
+func FrontierDiff[V comparable](peerFrontier []V, parents func(V) []V, has func(V) bool) []V {
+	var (
+		missing []V
+		visited = make(map[V]bool)
+	)
+
+	type frame struct {
+		id       V
+		expanded bool
+	}
+	for _, tip := range peerFrontier {
+		stack := []frame{{id: tip}}
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if top.expanded {
+				missing = append(missing, top.id)
+				continue
+			}
+			if visited[top.id] || has(top.id) {
+				continue
+			}
+			visited[top.id] = true
+
+			// Emit after all parents: push the post-order marker first,
+			// then parents in reverse so they pop in declared order.
+			stack = append(stack, frame{id: top.id, expanded: true})
+			ps := parents(top.id)
+			for i := len(ps) - 1; i >= 0; i-- {
+				if !visited[ps[i]] {
+					stack = append(stack, frame{id: ps[i]})
+				}
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,27 @@
+package prism
+
+import "testing"
+
+func TestFrontierDiffParentsFirst(t *testing.T) {
+	// 1 <- 2 <- 4, 1 <- 3 <- 4; local already holds 1.
+	parents := map[int][]int{1: nil, 2: {1}, 3: {1}, 4: {2, 3}}
+	has := func(v int) bool { return v == 1 }
+
+	got := FrontierDiff([]int{4}, func(v int) []int { return parents[v] }, has)
+	want := []int{2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestFrontierDiffNothingMissing(t *testing.T) {
+	has := func(int) bool { return true }
+	if got := FrontierDiff([]int{1, 2}, func(int) []int { return nil }, has); len(got) != 0 {
+		t.Fatalf("expected no missing vertices, got %v", got)
+	}
+}
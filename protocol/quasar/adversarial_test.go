@@ -393,7 +393,7 @@ func TestEngine_SubmitNilBlock(t *testing.T) {
 	require.NoError(t, engine.Start(ctx))
 	defer engine.Stop()
 
-	err = engine.Submit(nil)
+	err = engine.Submit(context.Background(), nil)
 	require.Error(t, err, "submitting nil block must error")
 }
 
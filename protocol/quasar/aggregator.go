@@ -0,0 +1,194 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// aggregator.go — cross-round signature share aggregation.
+//
+// A round that ends short of the threshold does not waste the shares it
+// gathered: the Aggregator keeps every verified QuasarSig share per block
+// hash, whatever round it arrived in, and aggregates as soon as the shares
+// from all rounds together reach the threshold. Each validator counts once
+// per block, so a validator re-signing in a later round adds nothing.
+package quasar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrDuplicateShare is returned for a share from a validator already
+	// counted for the block, in this round or an earlier one
+	ErrDuplicateShare = errors.New("quasar: validator share already counted")
+
+	// ErrShareAfterFinality is returned for a share that arrives after the
+	// block's certificate was aggregated
+	ErrShareAfterFinality = errors.New("quasar: block already finalized")
+
+	// ErrInvalidShare is returned for a share that does not verify against
+	// the block hash
+	ErrInvalidShare = errors.New("quasar: invalid signature share")
+)
+
+// Aggregator accumulates signature shares for blocks across rounds and
+// aggregates each block's certificate once, when its threshold is met
+type Aggregator struct {
+	signer    *Signer
+	threshold int
+
+	mu        sync.Mutex
+	pending   map[[32]byte]*shareSet
+	finalized map[[32]byte]*AggregatedSignature
+	forgotten map[[32]byte]struct{} // finalized blocks whose certificates were dropped
+}
+
+// shareSet is one block's shares and the round each arrived in
+type shareSet struct {
+	shares map[string]*QuasarSig
+	rounds map[string]uint64
+}
+
+// NewAggregator returns an aggregator that verifies shares with signer and
+// fires once threshold distinct validators have signed a block
+func NewAggregator(signer *Signer, threshold int) (*Aggregator, error) {
+	if signer == nil {
+		return nil, errors.New("quasar: aggregator needs a signer")
+	}
+	if threshold < 1 {
+		return nil, fmt.Errorf("quasar: aggregator threshold %d < 1", threshold)
+	}
+	return &Aggregator{
+		signer:    signer,
+		threshold: threshold,
+		pending:   make(map[[32]byte]*shareSet),
+		finalized: make(map[[32]byte]*AggregatedSignature),
+		forgotten: make(map[[32]byte]struct{}),
+	}, nil
+}
+
+// AddShare records sig, signed over blockHash in round. It returns the
+// aggregated certificate when this share brings the block to the
+// threshold, and nil while the block is still short of it. Shares are
+// verified without holding the aggregator's lock, so shares for different
+// blocks, or from different validators, verify concurrently.
+func (a *Aggregator) AddShare(blockHash [32]byte, round uint64, sig *QuasarSig) (*AggregatedSignature, error) {
+	if sig == nil || sig.ValidatorID == "" {
+		return nil, ErrInvalidShare
+	}
+
+	// Check before verifying so replays cost nothing
+	a.mu.Lock()
+	err := a.checkShareLocked(blockHash, sig.ValidatorID)
+	a.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.signer.VerifyQuasarSig(blockHash[:], sig) {
+		return nil, fmt.Errorf("%w from %s", ErrInvalidShare, sig.ValidatorID)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The block may have finalized, or the validator's share been counted,
+	// while this one was verifying
+	if err := a.checkShareLocked(blockHash, sig.ValidatorID); err != nil {
+		return nil, err
+	}
+	set := a.pending[blockHash]
+	if set == nil {
+		set = &shareSet{
+			shares: make(map[string]*QuasarSig),
+			rounds: make(map[string]uint64),
+		}
+		a.pending[blockHash] = set
+	}
+	set.shares[sig.ValidatorID] = sig
+	set.rounds[sig.ValidatorID] = round
+
+	if len(set.shares) < a.threshold {
+		return nil, nil
+	}
+
+	cert, err := a.signer.AggregateSignatures(blockHash[:], set.sortedShares())
+	if err != nil {
+		return nil, err
+	}
+	delete(a.pending, blockHash)
+	a.finalized[blockHash] = cert
+	return cert, nil
+}
+
+// checkShareLocked fails if blockHash is finalized or validator's share is
+// already counted for it. Caller holds a.mu.
+func (a *Aggregator) checkShareLocked(blockHash [32]byte, validator string) error {
+	if _, ok := a.finalized[blockHash]; ok {
+		return ErrShareAfterFinality
+	}
+	if _, ok := a.forgotten[blockHash]; ok {
+		return ErrShareAfterFinality
+	}
+	if set := a.pending[blockHash]; set != nil {
+		if first, ok := set.rounds[validator]; ok {
+			return fmt.Errorf("%w: %s signed in round %d", ErrDuplicateShare, validator, first)
+		}
+	}
+	return nil
+}
+
+// sortedShares returns the shares ordered by validator ID, so the same
+// share set always aggregates the same way
+func (s *shareSet) sortedShares() []*QuasarSig {
+	ids := make([]string, 0, len(s.shares))
+	for id := range s.shares {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]*QuasarSig, len(ids))
+	for i, id := range ids {
+		out[i] = s.shares[id]
+	}
+	return out
+}
+
+// Shares returns how many distinct validators have signed blockHash and,
+// for each round, how many of them first signed in it
+func (a *Aggregator) Shares(blockHash [32]byte) (int, map[uint64]int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	set := a.pending[blockHash]
+	if set == nil {
+		return 0, nil
+	}
+	perRound := make(map[uint64]int)
+	for _, r := range set.rounds {
+		perRound[r]++
+	}
+	return len(set.shares), perRound
+}
+
+// Certificate returns blockHash's aggregated certificate, if it has one
+func (a *Aggregator) Certificate(blockHash [32]byte) (*AggregatedSignature, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cert, ok := a.finalized[blockHash]
+	return cert, ok
+}
+
+// Forget drops the shares and certificate held for blockHash. Callers
+// forget blocks once their certificates are persisted to bound memory. A
+// finalized block's hash is still remembered, so late shares for it keep
+// failing with ErrShareAfterFinality instead of aggregating a second
+// certificate.
+func (a *Aggregator) Forget(blockHash [32]byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pending, blockHash)
+	if _, ok := a.finalized[blockHash]; ok {
+		delete(a.finalized, blockHash)
+		a.forgotten[blockHash] = struct{}{}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func newTestAggregator(t *testing.T, threshold int, validators ...string) (*Aggregator, *Signer) {
+	t.Helper()
+	s, err := NewSigner(threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range validators {
+		if err := s.AddValidator(id, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, err := NewAggregator(s, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, s
+}
+
+func signShare(t *testing.T, s *Signer, id string, blockHash [32]byte) *QuasarSig {
+	t.Helper()
+	sig, err := s.SignMessage(id, blockHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestAggregatorCombinesRounds(t *testing.T) {
+	a, s := newTestAggregator(t, 3, "v1", "v2", "v3", "v4")
+	block := [32]byte{1}
+
+	// Round 1 gathers two of three shares and times out
+	for _, id := range []string{"v1", "v2"} {
+		cert, err := a.AddShare(block, 1, signShare(t, s, id, block))
+		if err != nil {
+			t.Fatalf("round 1 share from %s: %v", id, err)
+		}
+		if cert != nil {
+			t.Fatal("certificate fired below the threshold")
+		}
+	}
+
+	// Round 2 alone has one share, but together the rounds reach three
+	cert, err := a.AddShare(block, 2, signShare(t, s, "v3", block))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert == nil {
+		t.Fatal("expected a certificate from round 1 and round 2 shares")
+	}
+	if cert.SignerCount != 3 {
+		t.Fatalf("expected 3 signers, got %d", cert.SignerCount)
+	}
+	if !s.VerifyAggregatedSignature(block[:], cert) {
+		t.Fatal("aggregated certificate does not verify")
+	}
+	if got, ok := a.Certificate(block); !ok || got != cert {
+		t.Fatal("certificate not recorded")
+	}
+
+	// A share after finality is refused, not re-aggregated
+	if _, err := a.AddShare(block, 3, signShare(t, s, "v4", block)); !errors.Is(err, ErrShareAfterFinality) {
+		t.Fatalf("expected ErrShareAfterFinality, got %v", err)
+	}
+}
+
+func TestAggregatorRejectsCountedValidator(t *testing.T) {
+	a, s := newTestAggregator(t, 2, "v1", "v2")
+	block := [32]byte{2}
+
+	if _, err := a.AddShare(block, 1, signShare(t, s, "v1", block)); err != nil {
+		t.Fatal(err)
+	}
+	// v1 re-signs in round 2: still one validator
+	if _, err := a.AddShare(block, 2, signShare(t, s, "v1", block)); !errors.Is(err, ErrDuplicateShare) {
+		t.Fatalf("expected ErrDuplicateShare, got %v", err)
+	}
+	count, perRound := a.Shares(block)
+	if count != 1 || perRound[1] != 1 || perRound[2] != 0 {
+		t.Fatalf("expected one round 1 share, got %d %v", count, perRound)
+	}
+
+	// A share over another block does not verify
+	other := [32]byte{3}
+	if _, err := a.AddShare(block, 2, signShare(t, s, "v2", other)); !errors.Is(err, ErrInvalidShare) {
+		t.Fatalf("expected ErrInvalidShare, got %v", err)
+	}
+	if count, _ := a.Shares(block); count != 1 {
+		t.Fatalf("invalid share was counted: %d shares", count)
+	}
+
+	cert, err := a.AddShare(block, 2, signShare(t, s, "v2", block))
+	if err != nil || cert == nil {
+		t.Fatalf("expected a certificate, got %v, %v", cert, err)
+	}
+}
+
+func TestAggregatorConcurrentShares(t *testing.T) {
+	const validators = 8
+	ids := make([]string, validators)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("v%d", i)
+	}
+	a, s := newTestAggregator(t, validators/2, ids...)
+	block := [32]byte{4}
+	sigs := make([]*QuasarSig, validators)
+	for i, id := range ids {
+		sigs[i] = signShare(t, s, id, block)
+	}
+
+	// Every validator submits its share twice at once. Each share counts
+	// once and exactly one certificate fires.
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		certs int
+		added int
+	)
+	for i := 0; i < 2*validators; i++ {
+		wg.Add(1)
+		go func(sig *QuasarSig) {
+			defer wg.Done()
+			cert, err := a.AddShare(block, 1, sig)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				added++
+				if cert != nil {
+					certs++
+				}
+			case !errors.Is(err, ErrDuplicateShare) && !errors.Is(err, ErrShareAfterFinality):
+				t.Errorf("share from %s: %v", sig.ValidatorID, err)
+			}
+		}(sigs[i%validators])
+	}
+	wg.Wait()
+	if certs != 1 {
+		t.Fatalf("expected one certificate, got %d", certs)
+	}
+	if added != validators/2 {
+		t.Fatalf("expected %d shares counted, got %d", validators/2, added)
+	}
+}
+
+func TestAggregatorForgetKeepsFinality(t *testing.T) {
+	a, s := newTestAggregator(t, 1, "v1", "v2")
+	block := [32]byte{5}
+
+	if cert, err := a.AddShare(block, 1, signShare(t, s, "v1", block)); err != nil || cert == nil {
+		t.Fatalf("expected a certificate, got %v, %v", cert, err)
+	}
+	a.Forget(block)
+	if _, ok := a.Certificate(block); ok {
+		t.Fatal("certificate kept after Forget")
+	}
+
+	// A late share does not re-open the forgotten block
+	if _, err := a.AddShare(block, 2, signShare(t, s, "v2", block)); !errors.Is(err, ErrShareAfterFinality) {
+		t.Fatalf("expected ErrShareAfterFinality, got %v", err)
+	}
+
+	// Forgetting a block that never finalized lets it start over
+	pending := [32]byte{6}
+	a2, s2 := newTestAggregator(t, 2, "v1", "v2")
+	if _, err := a2.AddShare(pending, 1, signShare(t, s2, "v1", pending)); err != nil {
+		t.Fatal(err)
+	}
+	a2.Forget(pending)
+	if _, err := a2.AddShare(pending, 2, signShare(t, s2, "v1", pending)); err != nil {
+		t.Fatalf("share for a forgotten pending block: %v", err)
+	}
+}
@@ -325,10 +325,17 @@ const customProposalID = "QUASAR_PROPOSAL_ID_V1"
 
 // EstablishHorizon creates a new event horizon for BLS finality
 func (q *BLS) EstablishHorizon(ctx context.Context, checkpoint VertexID, validators []string) (*dag.EventHorizon[VertexID], error) {
+	// Heights continue from the latest horizon, which may have been
+	// restored from a snapshot
+	height := uint64(1)
+	if latest := q.GetLatestHorizon(); latest != nil {
+		height = latest.Height + 1
+	}
+
 	// Compute new event horizon using Corona + BLS signatures
 	horizon := dag.EventHorizon[VertexID]{
 		Checkpoint: checkpoint,
-		Height:     uint64(len(q.horizons)) + 1,
+		Height:     height,
 		Validators: validators,
 		Signature:  q.createHorizonSignature(checkpoint, validators),
 	}
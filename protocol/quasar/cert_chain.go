@@ -0,0 +1,104 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// cert_chain.go — verification that a run of finalized blocks forms an
+// unbroken certificate chain, so a light client holding a trusted
+// checkpoint can follow finality forward without replaying consensus.
+package quasar
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/consensus/config"
+)
+
+var (
+	// ErrCertChainEmpty is returned for a chain with no blocks
+	ErrCertChainEmpty = errors.New("quasar: empty certificate chain")
+
+	// ErrCertChainInvalidCert marks a block whose cert is missing or does
+	// not verify under the chain's policy over the block's signed message
+	ErrCertChainInvalidCert = errors.New("quasar: invalid certificate in chain")
+
+	// ErrCertChainNoKeys marks a block whose cert epoch has no known
+	// verification keys
+	ErrCertChainNoKeys = errors.New("quasar: no verification keys for certificate epoch")
+
+	// ErrCertChainGap marks a block that skips heights or epochs after
+	// its predecessor
+	ErrCertChainGap = errors.New("quasar: gap in certificate chain")
+
+	// ErrCertChainOrder marks a block at or below its predecessor's
+	// height, or in an earlier epoch
+	ErrCertChainOrder = errors.New("quasar: certificate chain out of order")
+
+	// ErrCertChainLink marks a block on a different chain than its
+	// predecessor
+	ErrCertChainLink = errors.New("quasar: broken certificate chain link")
+)
+
+// CertChainError locates where a certificate chain breaks. It wraps one
+// of the ErrCertChain errors, so match it with errors.Is.
+type CertChainError struct {
+	Index  int    // position of the offending block in the chain
+	Height uint64 // height of the offending block
+	Err    error
+}
+
+func (e *CertChainError) Error() string {
+	return fmt.Sprintf("%v at index %d (height %d)", e.Err, e.Index, e.Height)
+}
+
+func (e *CertChainError) Unwrap() error {
+	return e.Err
+}
+
+// VerifyCertificateChain checks that blocks, in ascending height order,
+// form an unbroken chain of finality certificates. Each block's cert must
+// verify under cp (VerifyUnderPolicy) over the block's signed message,
+// with the keys returned by keys for the cert's epoch, so a cert is bound
+// to its block and cannot be forged or moved to another block. Each block
+// must extend the previous one on the same chain at the next height, in
+// the same or the next epoch. The first block is taken as the anchor,
+// typically a trusted checkpoint. On failure it returns a *CertChainError
+// naming the first block that breaks the chain.
+func VerifyCertificateChain(blocks []*Block, cp config.CertPolicy, keys func(epoch uint64) (CertKeys, bool)) error {
+	if len(blocks) == 0 {
+		return ErrCertChainEmpty
+	}
+
+	for i, b := range blocks {
+		if b == nil {
+			return &CertChainError{Index: i, Err: ErrCertChainInvalidCert}
+		}
+		if b.Cert == nil {
+			return &CertChainError{Index: i, Height: b.Height, Err: ErrCertChainInvalidCert}
+		}
+		epochKeys, ok := keys(b.Cert.Epoch)
+		if !ok {
+			return &CertChainError{Index: i, Height: b.Height, Err: ErrCertChainNoKeys}
+		}
+		if !b.Cert.VerifyUnderPolicy(buildBlockMessage(b), cp, epochKeys) {
+			return &CertChainError{Index: i, Height: b.Height, Err: ErrCertChainInvalidCert}
+		}
+		if i == 0 {
+			continue
+		}
+
+		prev := blocks[i-1]
+		var err error
+		switch {
+		case b.ChainID != prev.ChainID:
+			err = ErrCertChainLink
+		case b.Height <= prev.Height || b.Cert.Epoch < prev.Cert.Epoch:
+			err = ErrCertChainOrder
+		case b.Height != prev.Height+1 || b.Cert.Epoch > prev.Cert.Epoch+1:
+			err = ErrCertChainGap
+		}
+		if err != nil {
+			return &CertChainError{Index: i, Height: b.Height, Err: err}
+		}
+	}
+	return nil
+}
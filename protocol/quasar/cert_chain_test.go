@@ -0,0 +1,179 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/crypto/bls"
+)
+
+// chainPolicy is the BLS-only policy the test chains are certified under
+var chainPolicy = config.CertPolicy{
+	Mode:      config.CertModeOff,
+	Variant:   config.CertVariantHybrid,
+	TimeoutMs: 10_000,
+	Fallback:  config.CertModeOff,
+}
+
+// chainSigner certifies test blocks with one BLS key for every epoch
+type chainSigner struct {
+	t  *testing.T
+	sk *bls.SecretKey
+}
+
+func newChainSigner(t *testing.T) chainSigner {
+	t.Helper()
+	if err := chainPolicy.Validate(); err != nil {
+		t.Fatalf("chainPolicy invalid: %v", err)
+	}
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		t.Fatalf("bls.NewSecretKey: %v", err)
+	}
+	return chainSigner{t: t, sk: sk}
+}
+
+// keys returns the signer's key for every epoch
+func (s chainSigner) keys(uint64) (CertKeys, bool) {
+	return CertKeys{BLS: s.sk.PublicKey()}, true
+}
+
+// sign certifies b over its signed message in epoch
+func (s chainSigner) sign(b *Block, epoch uint64) {
+	s.t.Helper()
+	sig, err := s.sk.Sign(buildBlockMessage(b))
+	if err != nil {
+		s.t.Fatalf("bls.Sign: %v", err)
+	}
+	b.Cert = &QuasarCert{BLS: bls.SignatureToBytes(sig), Epoch: epoch}
+}
+
+// chain returns certified blocks at heights from..from+n-1 on one chain,
+// with epochs advancing every two blocks
+func (s chainSigner) chain(from uint64, n int) []*Block {
+	blocks := make([]*Block, n)
+	for i := range blocks {
+		height := from + uint64(i)
+		blocks[i] = &Block{
+			ID:      [32]byte{byte(height)},
+			ChainID: [32]byte{0xC},
+			Height:  height,
+		}
+		s.sign(blocks[i], height/2)
+	}
+	return blocks
+}
+
+// requireChainBreak checks err is a *CertChainError wrapping want at index
+func requireChainBreak(t *testing.T, err, want error, index int) {
+	t.Helper()
+	var chainErr *CertChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("error = %v, want *CertChainError", err)
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+	if chainErr.Index != index {
+		t.Fatalf("break at index %d, want %d", chainErr.Index, index)
+	}
+}
+
+func TestVerifyCertificateChainValid(t *testing.T) {
+	s := newChainSigner(t)
+	if err := VerifyCertificateChain(s.chain(100, 6), chainPolicy, s.keys); err != nil {
+		t.Fatalf("valid chain: %v", err)
+	}
+	if err := VerifyCertificateChain(s.chain(7, 1), chainPolicy, s.keys); err != nil {
+		t.Fatalf("checkpoint alone: %v", err)
+	}
+	if err := VerifyCertificateChain(nil, chainPolicy, s.keys); !errors.Is(err, ErrCertChainEmpty) {
+		t.Fatalf("empty chain: %v, want %v", err, ErrCertChainEmpty)
+	}
+}
+
+func TestVerifyCertificateChainGap(t *testing.T) {
+	s := newChainSigner(t)
+	blocks := s.chain(100, 5)
+	blocks = append(blocks[:2], blocks[3:]...) // drop height 102
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainGap, 2)
+
+	// Consecutive heights that skip an epoch are a gap too
+	blocks = s.chain(100, 4)
+	blocks[3].Cert.Epoch = blocks[2].Cert.Epoch + 2
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainGap, 3)
+}
+
+func TestVerifyCertificateChainOutOfOrder(t *testing.T) {
+	s := newChainSigner(t)
+
+	// Swapping a pair breaks the chain at the first of the two, which
+	// jumps ahead of its predecessor
+	blocks := s.chain(100, 5)
+	blocks[2], blocks[3] = blocks[3], blocks[2]
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainGap, 2)
+
+	// A block at or below its predecessor's height
+	blocks = append(s.chain(100, 4), s.chain(101, 1)...)
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainOrder, 4)
+
+	// An epoch going backwards is out of order even at the next height
+	blocks = s.chain(100, 4)
+	blocks[3].Cert.Epoch = blocks[2].Cert.Epoch - 1
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainOrder, 3)
+}
+
+func TestVerifyCertificateChainInvalidCert(t *testing.T) {
+	s := newChainSigner(t)
+	blocks := s.chain(100, 4)
+	blocks[3].Cert = nil
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainInvalidCert, 3)
+
+	// Structurally complete placeholder legs carry no signature
+	blocks = s.chain(100, 4)
+	blocks[1].Cert = &QuasarCert{BLS: []byte{1}, Corona: []byte{2}, MLDSARollup: []byte{3}, Epoch: blocks[1].Cert.Epoch}
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainInvalidCert, 1)
+
+	// A cert signed by a key the verifier does not trust is forged
+	forger := newChainSigner(t)
+	blocks = s.chain(100, 4)
+	forger.sign(blocks[2], blocks[2].Cert.Epoch)
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainInvalidCert, 2)
+
+	// A genuine cert moved onto another block does not verify there
+	blocks = s.chain(100, 4)
+	blocks[2].Cert = blocks[1].Cert
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainInvalidCert, 2)
+
+	// Nor does a block whose content was changed after certification
+	blocks = s.chain(100, 4)
+	blocks[1].ID[31] ^= 1
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainInvalidCert, 1)
+
+	// A policy requiring a PQ leg rejects the BLS-only certs
+	strict := config.CertPolicy{Mode: config.CertModeFast, Variant: config.CertVariantHybrid, TimeoutMs: 10_000}
+	requireChainBreak(t, VerifyCertificateChain(s.chain(100, 2), strict, s.keys), ErrCertChainInvalidCert, 0)
+}
+
+func TestVerifyCertificateChainNoKeys(t *testing.T) {
+	s := newChainSigner(t)
+	untilEpoch51 := func(epoch uint64) (CertKeys, bool) {
+		if epoch > 51 {
+			return CertKeys{}, false
+		}
+		return s.keys(epoch)
+	}
+	requireChainBreak(t, VerifyCertificateChain(s.chain(100, 6), chainPolicy, untilEpoch51), ErrCertChainNoKeys, 4)
+}
+
+func TestVerifyCertificateChainLink(t *testing.T) {
+	s := newChainSigner(t)
+	blocks := s.chain(100, 4)
+	blocks[2].ChainID = [32]byte{0xD}
+	s.sign(blocks[2], blocks[2].Cert.Epoch)
+	requireChainBreak(t, VerifyCertificateChain(blocks, chainPolicy, s.keys), ErrCertChainLink, 2)
+}
@@ -0,0 +1,396 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// cert_log.go — append-only log of issued certificates, replayable for
+// later verification and compactable down to the latest cert per chain.
+//
+// Each record is framed as
+//
+//	len:4 || crc32c:4 || payload:len
+//	payload = version:1 || chain_id:32 || block_id:32 || height:8 || cert
+//
+// where cert is QuasarCert.MarshalBinary. A record is written with a single
+// Write call, so a crash leaves at most a truncated final record.
+// NewCertificateLog detects it and cuts it off before appending resumes.
+package quasar
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sync"
+)
+
+const (
+	certLogVersion = 1
+
+	// certLogHeaderSize is the len + crc32c frame header
+	certLogHeaderSize = 4 + 4
+
+	// certLogFixedPayload is the payload before the cert bytes
+	certLogFixedPayload = 1 + 32 + 32 + 8
+
+	// MaxCertLogRecord bounds a record's payload so a corrupt length
+	// cannot force a huge allocation on replay
+	MaxCertLogRecord = 16 << 20
+)
+
+var certLogCRC = crc32.MakeTable(crc32.Castagnoli)
+
+var (
+	// ErrCertLogCorrupt is returned when a complete record fails its
+	// checksum or cannot be decoded
+	ErrCertLogCorrupt = errors.New("quasar: certificate log corrupt")
+
+	// ErrCertLogNoSync is returned when SyncWrites is requested but the
+	// backend has no Sync method
+	ErrCertLogNoSync = errors.New("quasar: certificate log backend cannot sync")
+
+	// ErrCertLogNoCert is returned when appending an entry without a cert
+	ErrCertLogNoCert = errors.New("quasar: certificate log entry has no cert")
+
+	// ErrCertLogNoTruncate is returned when a log ends in a torn record but
+	// the backend has no Truncate method to cut it
+	ErrCertLogNoTruncate = errors.New("quasar: certificate log backend cannot truncate")
+)
+
+// CertLogEntry is one issued certificate and the block it finalized
+type CertLogEntry struct {
+	ChainID [32]byte
+	BlockID [32]byte
+	Height  uint64
+	Cert    *QuasarCert
+}
+
+// CertLogEntryFor returns the log entry for a finalized block
+func CertLogEntryFor(block *Block) CertLogEntry {
+	return CertLogEntry{
+		ChainID: block.ChainID,
+		BlockID: block.ID,
+		Height:  block.Height,
+		Cert:    block.Cert,
+	}
+}
+
+// CertLogBackend is the storage behind a CertificateLog. Writes append to
+// the log; NewReader returns a reader positioned at its start. To recover
+// from a torn final record the backend must also implement
+// interface{ Truncate(size int64) error }, as *os.File does; a file should
+// be opened with O_APPEND so writes land at the new end.
+type CertLogBackend interface {
+	io.Writer
+	NewReader() (io.Reader, error)
+}
+
+// CertLogConfig configures a CertificateLog
+type CertLogConfig struct {
+	// SyncWrites calls the backend's Sync method after every append, so an
+	// Append that returns nil is durable. The backend must implement
+	// interface{ Sync() error }, as *os.File does.
+	SyncWrites bool
+}
+
+// CertificateLog is an append-only, length-prefixed log of certificates
+type CertificateLog struct {
+	mu      sync.Mutex
+	backend CertLogBackend
+	cfg     CertLogConfig
+}
+
+// NewCertificateLog returns a log appending to backend. It replays the
+// existing log first: a torn final record left by a crash is truncated, and
+// a corrupt record fails with ErrCertLogCorrupt.
+func NewCertificateLog(backend CertLogBackend, cfg CertLogConfig) (*CertificateLog, error) {
+	if err := checkCertLogSync(backend, cfg); err != nil {
+		return nil, err
+	}
+	l := &CertificateLog{backend: backend, cfg: cfg}
+	if err := l.recover(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// recover cuts a torn final record so the next append follows the last
+// intact one
+func (l *CertificateLog) recover() error {
+	r, err := l.backend.NewReader()
+	if err != nil {
+		return err
+	}
+	it := NewCertLogIterator(r)
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	if !it.Truncated() {
+		return nil
+	}
+
+	t, ok := l.backend.(interface{ Truncate(size int64) error })
+	if !ok {
+		return fmt.Errorf("%w: torn record at offset %d", ErrCertLogNoTruncate, it.Offset())
+	}
+	if err := t.Truncate(it.Offset()); err != nil {
+		return fmt.Errorf("quasar: certificate log truncate: %w", err)
+	}
+	if s, ok := l.backend.(io.Seeker); ok {
+		if _, err := s.Seek(it.Offset(), io.SeekStart); err != nil {
+			return fmt.Errorf("quasar: certificate log seek: %w", err)
+		}
+	}
+	if l.cfg.SyncWrites {
+		if err := l.backend.(interface{ Sync() error }).Sync(); err != nil {
+			return fmt.Errorf("quasar: certificate log sync: %w", err)
+		}
+	}
+	return nil
+}
+
+func checkCertLogSync(backend CertLogBackend, cfg CertLogConfig) error {
+	if !cfg.SyncWrites {
+		return nil
+	}
+	if _, ok := backend.(interface{ Sync() error }); !ok {
+		return ErrCertLogNoSync
+	}
+	return nil
+}
+
+// Append writes entry as one record and, with SyncWrites, syncs it before
+// returning
+func (l *CertificateLog) Append(entry CertLogEntry) error {
+	record, err := encodeCertLogRecord(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.write(record)
+}
+
+// write appends an encoded record
+// Must be called with l.mu held
+func (l *CertificateLog) write(record []byte) error {
+	if _, err := l.backend.Write(record); err != nil {
+		return fmt.Errorf("quasar: certificate log write: %w", err)
+	}
+	if l.cfg.SyncWrites {
+		if err := l.backend.(interface{ Sync() error }).Sync(); err != nil {
+			return fmt.Errorf("quasar: certificate log sync: %w", err)
+		}
+	}
+	return nil
+}
+
+// Replay returns an iterator over the log from its start
+func (l *CertificateLog) Replay() (*CertLogIterator, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, err := l.backend.NewReader()
+	if err != nil {
+		return nil, err
+	}
+	return NewCertLogIterator(r), nil
+}
+
+// Compact rewrites the log into dst, dropping every certificate below
+// beforeHeight except the latest one of its chain, and switches the log to
+// append to dst. Records keep their order. It returns the number of
+// records dropped. On error the log keeps appending to its old backend.
+func (l *CertificateLog) Compact(beforeHeight uint64, dst CertLogBackend) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := checkCertLogSync(dst, l.cfg); err != nil {
+		return 0, err
+	}
+
+	// First pass: the latest height per chain
+	latest, err := l.scan(func(e *CertLogEntry, _ []byte) error { return nil })
+	if err != nil {
+		return 0, err
+	}
+
+	// Second pass: copy the records that survive
+	next := &CertificateLog{backend: dst, cfg: l.cfg}
+	dropped := 0
+	if _, err := l.scan(func(e *CertLogEntry, record []byte) error {
+		if e.Height < beforeHeight && e.Height < latest[e.ChainID] {
+			dropped++
+			return nil
+		}
+		return next.write(record)
+	}); err != nil {
+		return 0, err
+	}
+
+	l.backend = dst
+	return dropped, nil
+}
+
+// scan replays the current backend, calling fn with each entry and its
+// encoded record, and returns the latest height seen per chain
+// Must be called with l.mu held
+func (l *CertificateLog) scan(fn func(e *CertLogEntry, record []byte) error) (map[[32]byte]uint64, error) {
+	r, err := l.backend.NewReader()
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[[32]byte]uint64)
+	it := NewCertLogIterator(r)
+	for it.Next() {
+		e := it.Entry()
+		if h, ok := latest[e.ChainID]; !ok || e.Height > h {
+			latest[e.ChainID] = e.Height
+		}
+		if err := fn(e, it.record); err != nil {
+			return nil, err
+		}
+	}
+	return latest, it.Err()
+}
+
+// CertLogIterator replays a certificate log. Use it like bufio.Scanner:
+//
+//	for it.Next() { use(it.Entry()) }
+//	if err := it.Err(); err != nil { ... }
+//
+// A truncated final record ends the iteration without an error; Truncated
+// reports it and Offset gives the length of the intact prefix, which is
+// where NewCertificateLog cuts the torn tail.
+type CertLogIterator struct {
+	r         io.Reader
+	entry     *CertLogEntry
+	record    []byte
+	offset    int64
+	truncated bool
+	err       error
+}
+
+// NewCertLogIterator returns an iterator reading records from r
+func NewCertLogIterator(r io.Reader) *CertLogIterator {
+	return &CertLogIterator{r: r}
+}
+
+// Next advances to the next record, reporting whether there is one
+func (it *CertLogIterator) Next() bool {
+	if it.err != nil || it.truncated {
+		return false
+	}
+
+	var header [certLogHeaderSize]byte
+	if n, err := io.ReadFull(it.r, header[:]); err != nil {
+		return it.stop(n, err)
+	}
+	size := binary.BigEndian.Uint32(header[:4])
+	if size < certLogFixedPayload+minCertSize || size > MaxCertLogRecord {
+		it.err = fmt.Errorf("%w: record at offset %d has length %d", ErrCertLogCorrupt, it.offset, size)
+		return false
+	}
+
+	record := make([]byte, certLogHeaderSize+int(size))
+	copy(record, header[:])
+	if _, err := io.ReadFull(it.r, record[certLogHeaderSize:]); err != nil {
+		return it.stop(certLogHeaderSize, err)
+	}
+	payload := record[certLogHeaderSize:]
+	if crc32.Checksum(payload, certLogCRC) != binary.BigEndian.Uint32(header[4:]) {
+		it.err = fmt.Errorf("%w: checksum mismatch at offset %d", ErrCertLogCorrupt, it.offset)
+		return false
+	}
+
+	entry, err := decodeCertLogPayload(payload)
+	if err != nil {
+		it.err = fmt.Errorf("%w: record at offset %d: %v", ErrCertLogCorrupt, it.offset, err)
+		return false
+	}
+	it.entry = entry
+	it.record = record
+	it.offset += int64(len(record))
+	return true
+}
+
+// stop ends the iteration after a short read of n bytes
+func (it *CertLogIterator) stop(n int, err error) bool {
+	switch {
+	case err == io.EOF && n == 0:
+		// Clean end of log
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		it.truncated = true
+	default:
+		it.err = err
+	}
+	it.entry, it.record = nil, nil
+	return false
+}
+
+// Entry returns the current record's entry
+func (it *CertLogIterator) Entry() *CertLogEntry { return it.entry }
+
+// Err returns the error that ended the iteration, if any
+func (it *CertLogIterator) Err() error { return it.err }
+
+// Truncated reports whether the log ended in a partial record
+func (it *CertLogIterator) Truncated() bool { return it.truncated }
+
+// Offset returns the length of the log's intact prefix read so far
+func (it *CertLogIterator) Offset() int64 { return it.offset }
+
+func encodeCertLogRecord(entry CertLogEntry) ([]byte, error) {
+	if entry.Cert == nil {
+		return nil, ErrCertLogNoCert
+	}
+	cert, err := entry.Cert.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	size := certLogFixedPayload + len(cert)
+	if size > MaxCertLogRecord {
+		return nil, fmt.Errorf("quasar: certificate log record too large (%d bytes)", size)
+	}
+
+	buf := make([]byte, certLogHeaderSize, certLogHeaderSize+size)
+	buf = append(buf, certLogVersion)
+	buf = append(buf, entry.ChainID[:]...)
+	buf = append(buf, entry.BlockID[:]...)
+	buf = appendU64(buf, entry.Height)
+	buf = append(buf, cert...)
+
+	payload := buf[certLogHeaderSize:]
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.Checksum(payload, certLogCRC))
+	return buf, nil
+}
+
+func decodeCertLogPayload(payload []byte) (*CertLogEntry, error) {
+	if len(payload) < certLogFixedPayload {
+		return nil, ErrCertCorrupt
+	}
+	if payload[0] != certLogVersion {
+		return nil, fmt.Errorf("unsupported version %d", payload[0])
+	}
+	r := &qcReader{buf: payload[1:]}
+
+	entry := &CertLogEntry{Cert: &QuasarCert{}}
+	if err := r.read32(&entry.ChainID); err != nil {
+		return nil, err
+	}
+	if err := r.read32(&entry.BlockID); err != nil {
+		return nil, err
+	}
+	height, err := r.u64()
+	if err != nil {
+		return nil, err
+	}
+	entry.Height = height
+	if err := entry.Cert.UnmarshalBinary(r.buf); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,316 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// memCertLog is an in-memory CertLogBackend
+type memCertLog struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (m *memCertLog) NewReader() (io.Reader, error) {
+	return bytes.NewReader(m.Bytes()), nil
+}
+
+func (m *memCertLog) Sync() error {
+	m.syncs++
+	return nil
+}
+
+// noSyncLog is a backend without a Sync method
+type noSyncLog struct{ bytes.Buffer }
+
+func (n *noSyncLog) NewReader() (io.Reader, error) {
+	return bytes.NewReader(n.Bytes()), nil
+}
+
+func testLogEntry(chain byte, height uint64) CertLogEntry {
+	return CertLogEntry{
+		ChainID: [32]byte{chain},
+		BlockID: [32]byte{chain, byte(height)},
+		Height:  height,
+		Cert: &QuasarCert{
+			BLS:         bytes.Repeat([]byte{byte(height)}, 48),
+			Pulsar:      []byte{chain, byte(height), 0xAA},
+			MLDSARollup: []byte("rollup"),
+			Epoch:       height / 10,
+			Finality:    time.Unix(int64(height), 0),
+			Validators:  4,
+		},
+	}
+}
+
+func replayAll(t *testing.T, it *CertLogIterator) []CertLogEntry {
+	t.Helper()
+	var out []CertLogEntry
+	for it.Next() {
+		out = append(out, *it.Entry())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	return out
+}
+
+func requireEntries(t *testing.T, got, want []CertLogEntry) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ChainID != want[i].ChainID || got[i].BlockID != want[i].BlockID || got[i].Height != want[i].Height {
+			t.Fatalf("entry %d: header mismatch", i)
+		}
+		w := *want[i].Cert
+		w.Finality = w.Finality.UTC()
+		g := *got[i].Cert
+		g.Finality = g.Finality.UTC()
+		if !reflect.DeepEqual(g, w) {
+			t.Fatalf("entry %d: cert mismatch\n got %+v\nwant %+v", i, g, w)
+		}
+	}
+}
+
+func TestCertificateLogRoundTrip(t *testing.T) {
+	backend := &memCertLog{}
+	log, err := NewCertificateLog(backend, CertLogConfig{SyncWrites: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []CertLogEntry
+	for h := uint64(1); h <= 5; h++ {
+		e := testLogEntry(byte(h%2), h)
+		if err := log.Append(e); err != nil {
+			t.Fatalf("append %d: %v", h, err)
+		}
+		want = append(want, e)
+	}
+	if backend.syncs != 5 {
+		t.Fatalf("expected a sync per append, got %d", backend.syncs)
+	}
+
+	it, err := log.Replay()
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireEntries(t, replayAll(t, it), want)
+	if it.Truncated() || it.Offset() != int64(backend.Len()) {
+		t.Fatalf("expected a clean end at %d, got offset %d (truncated=%v)", backend.Len(), it.Offset(), it.Truncated())
+	}
+
+	// A finalized block's cert is logged as-is
+	block := &Block{ID: [32]byte{9}, ChainID: [32]byte{1}, Height: 6, Cert: testLogEntry(1, 6).Cert}
+	if err := log.Append(CertLogEntryFor(block)); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Append(CertLogEntry{Height: 7}); !errors.Is(err, ErrCertLogNoCert) {
+		t.Fatalf("expected ErrCertLogNoCert, got %v", err)
+	}
+}
+
+func TestCertificateLogSyncRequiresBackendSupport(t *testing.T) {
+	if _, err := NewCertificateLog(&noSyncLog{}, CertLogConfig{SyncWrites: true}); !errors.Is(err, ErrCertLogNoSync) {
+		t.Fatalf("expected ErrCertLogNoSync, got %v", err)
+	}
+	if _, err := NewCertificateLog(&noSyncLog{}, CertLogConfig{}); err != nil {
+		t.Fatalf("unsynced log: %v", err)
+	}
+}
+
+func TestCertificateLogCompact(t *testing.T) {
+	log, err := NewCertificateLog(&memCertLog{}, CertLogConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Chain 1 certifies heights 1-6, chain 2 only heights 1-2
+	var entries []CertLogEntry
+	for h := uint64(1); h <= 6; h++ {
+		entries = append(entries, testLogEntry(1, h))
+		if h <= 2 {
+			entries = append(entries, testLogEntry(2, h))
+		}
+	}
+	for _, e := range entries {
+		if err := log.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compacted := &memCertLog{}
+	dropped, err := log.Compact(4, compacted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Chain 1 keeps heights 4-6; chain 2 keeps its latest cert at height 2
+	// even though it is below the cutoff
+	want := []CertLogEntry{testLogEntry(2, 2), testLogEntry(1, 4), testLogEntry(1, 5), testLogEntry(1, 6)}
+	if dropped != len(entries)-len(want) {
+		t.Fatalf("expected %d dropped, got %d", len(entries)-len(want), dropped)
+	}
+	it, err := log.Replay()
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireEntries(t, replayAll(t, it), want)
+
+	// The log now appends to the compacted backend
+	if err := log.Append(testLogEntry(2, 3)); err != nil {
+		t.Fatal(err)
+	}
+	requireEntries(t, replayAll(t, NewCertLogIterator(bytes.NewReader(compacted.Bytes()))), append(want, testLogEntry(2, 3)))
+}
+
+func TestCertificateLogTruncatedTail(t *testing.T) {
+	backend := &memCertLog{}
+	log, err := NewCertificateLog(backend, CertLogConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CertLogEntry{testLogEntry(1, 1), testLogEntry(1, 2)}
+	for _, e := range want {
+		if err := log.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	intact := backend.Len()
+	if err := log.Append(testLogEntry(1, 3)); err != nil {
+		t.Fatal(err)
+	}
+	full := backend.Len()
+
+	// A crash tears the final record at any point, including its header
+	for _, cut := range []int{intact + 3, intact + certLogHeaderSize, full - 1} {
+		torn := bytes.NewReader(backend.Bytes()[:cut])
+		it := NewCertLogIterator(torn)
+		requireEntries(t, replayAll(t, it), want)
+		if !it.Truncated() {
+			t.Fatalf("cut at %d: expected a truncated tail", cut)
+		}
+		if it.Offset() != int64(intact) {
+			t.Fatalf("cut at %d: expected intact prefix %d, got %d", cut, intact, it.Offset())
+		}
+	}
+
+	// Without a Truncate method the torn tail cannot be cut
+	backend.Truncate(intact + 5)
+	if _, err := NewCertificateLog(backend, CertLogConfig{}); !errors.Is(err, ErrCertLogNoTruncate) {
+		t.Fatalf("expected ErrCertLogNoTruncate, got %v", err)
+	}
+}
+
+// fileCertLog is a CertLogBackend over a file opened with O_APPEND
+type fileCertLog struct {
+	*os.File
+}
+
+func openFileCertLog(t *testing.T, path string) *fileCertLog {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return &fileCertLog{f}
+}
+
+func (f *fileCertLog) NewReader() (io.Reader, error) {
+	data, err := os.ReadFile(f.Name())
+	return bytes.NewReader(data), err
+}
+
+func TestCertificateLogRecoversTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "certs.log")
+	log, err := NewCertificateLog(openFileCertLog(t, path), CertLogConfig{SyncWrites: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CertLogEntry{testLogEntry(1, 1), testLogEntry(1, 2)}
+	for _, e := range want {
+		if err := log.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intact := info.Size()
+
+	// A crash mid-append leaves part of the next record behind
+	record, err := encodeCertLogRecord(testLogEntry(1, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write(record[:len(record)/2]); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// Reopening cuts the torn record and appends resume after the intact
+	// prefix
+	log, err = NewCertificateLog(openFileCertLog(t, path), CertLogConfig{SyncWrites: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err = os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != intact {
+		t.Fatalf("expected the log cut to %d bytes, got %d", intact, info.Size())
+	}
+	if err := log.Append(testLogEntry(1, 3)); err != nil {
+		t.Fatal(err)
+	}
+	it, err := log.Replay()
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireEntries(t, replayAll(t, it), append(want, testLogEntry(1, 3)))
+	if it.Truncated() {
+		t.Fatal("recovered log still truncated")
+	}
+}
+
+func TestCertificateLogCorruptRecord(t *testing.T) {
+	backend := &memCertLog{}
+	log, err := NewCertificateLog(backend, CertLogConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for h := uint64(1); h <= 2; h++ {
+		if err := log.Append(testLogEntry(1, h)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Flip a payload byte in the first record: a complete record with a
+	// bad checksum is corruption, not a torn write
+	data := append([]byte(nil), backend.Bytes()...)
+	data[certLogHeaderSize+10] ^= 0xFF
+	it := NewCertLogIterator(bytes.NewReader(data))
+	if it.Next() {
+		t.Fatal("expected no entries from a corrupt log")
+	}
+	if !errors.Is(it.Err(), ErrCertLogCorrupt) {
+		t.Fatalf("expected ErrCertLogCorrupt, got %v", it.Err())
+	}
+}
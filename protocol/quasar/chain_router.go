@@ -0,0 +1,179 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// chain_router.go — routing of submitted blocks to per-chain buffers by
+// ChainID. Names are display metadata only: two subnets may share a name
+// and still get separate buffers.
+package quasar
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownChain is returned when a block names a ChainID that is not
+// registered and the router does not auto-register
+var ErrUnknownChain = errors.New("quasar: unknown chain")
+
+// DefaultChainBuffer is the number of blocks buffered per chain when
+// ChainRouterConfig.BufferSize is unset
+const DefaultChainBuffer = 100
+
+// ChainRouterConfig configures a ChainRouter
+type ChainRouterConfig struct {
+	// AutoRegister registers the chain of a block with an unknown ChainID
+	// on submission; otherwise the block is refused with ErrUnknownChain
+	AutoRegister bool
+
+	// BufferSize bounds each chain's buffer; when it is full the oldest
+	// block is dropped (0 = DefaultChainBuffer)
+	BufferSize int
+}
+
+// NameChainID returns the ChainID used for a chain registered by name
+// only, and for a block submitted without a ChainID
+func NameChainID(name string) [32]byte {
+	return sha256.Sum256([]byte(name))
+}
+
+// ChainHandle is a registered chain and its block buffer
+type ChainHandle struct {
+	id     [32]byte
+	name   string
+	blocks chan *ChainBlock
+}
+
+// ChainID returns the chain's ID
+func (h *ChainHandle) ChainID() [32]byte { return h.id }
+
+// Name returns the display name the chain was registered with
+func (h *ChainHandle) Name() string { return h.name }
+
+// Buffered returns how many blocks are waiting to be processed
+func (h *ChainHandle) Buffered() int { return len(h.blocks) }
+
+// push buffers block, dropping the oldest buffered block when full
+func (h *ChainHandle) push(block *ChainBlock) {
+	for {
+		select {
+		case h.blocks <- block:
+			return
+		default:
+			select {
+			case <-h.blocks:
+			default:
+			}
+		}
+	}
+}
+
+// ChainRouter maps ChainIDs to chain buffers
+type ChainRouter struct {
+	mu     sync.RWMutex
+	cfg    ChainRouterConfig
+	chains map[[32]byte]*ChainHandle
+
+	// onRegister is called, outside mu, for each newly registered chain
+	onRegister func(*ChainHandle)
+}
+
+// NewChainRouter returns an empty router
+func NewChainRouter(cfg ChainRouterConfig) *ChainRouter {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultChainBuffer
+	}
+	return &ChainRouter{
+		cfg:    cfg,
+		chains: make(map[[32]byte]*ChainHandle),
+	}
+}
+
+// Register returns the handle for chain id, registering it under name if
+// it is new. Registering a known ID again returns the existing handle and
+// keeps its original name.
+func (r *ChainRouter) Register(id [32]byte, name string) *ChainHandle {
+	r.mu.Lock()
+	if h, ok := r.chains[id]; ok {
+		r.mu.Unlock()
+		return h
+	}
+	h := &ChainHandle{
+		id:     id,
+		name:   name,
+		blocks: make(chan *ChainBlock, r.cfg.BufferSize),
+	}
+	r.chains[id] = h
+	onRegister := r.onRegister
+	r.mu.Unlock()
+
+	if onRegister != nil {
+		onRegister(h)
+	}
+	return h
+}
+
+// Lookup returns the handle for chain id
+func (r *ChainRouter) Lookup(id [32]byte) (*ChainHandle, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.chains[id]
+	return h, ok
+}
+
+// Route returns the handle for block's chain. A block without a ChainID is
+// routed by NameChainID of its ChainName. An unknown chain is registered
+// under the block's ChainName when AutoRegister is set, and is otherwise
+// an ErrUnknownChain.
+func (r *ChainRouter) Route(block *ChainBlock) (*ChainHandle, error) {
+	id := block.ChainID
+	if id == ([32]byte{}) {
+		id = NameChainID(block.ChainName)
+	}
+	if h, ok := r.Lookup(id); ok {
+		return h, nil
+	}
+
+	r.mu.RLock()
+	auto := r.cfg.AutoRegister
+	r.mu.RUnlock()
+	if !auto {
+		return nil, fmt.Errorf("%w: %x (%s)", ErrUnknownChain, id[:], block.ChainName)
+	}
+	return r.Register(id, block.ChainName), nil
+}
+
+// Submit routes block and buffers it on its chain
+func (r *ChainRouter) Submit(block *ChainBlock) error {
+	h, err := r.Route(block)
+	if err != nil {
+		return err
+	}
+	h.push(block)
+	return nil
+}
+
+// SetAutoRegister sets whether Route registers unknown chains
+func (r *ChainRouter) SetAutoRegister(auto bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cfg.AutoRegister = auto
+}
+
+// Chains returns the registered chains ordered by ChainID
+func (r *ChainRouter) Chains() []*ChainHandle {
+	r.mu.RLock()
+	out := make([]*ChainHandle, 0, len(r.chains))
+	for _, h := range r.chains {
+		out = append(out, h)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].id[:], out[j].id[:]) < 0
+	})
+	return out
+}
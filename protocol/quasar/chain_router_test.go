@@ -0,0 +1,132 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func subnetBlock(chainID byte, name string, id byte) *ChainBlock {
+	return &ChainBlock{
+		ChainID:   [32]byte{chainID},
+		ChainName: name,
+		ID:        [32]byte{id},
+		Height:    uint64(id),
+		Timestamp: time.Now(),
+	}
+}
+
+// drain returns the blocks buffered on h
+func drain(h *ChainHandle) []*ChainBlock {
+	var out []*ChainBlock
+	for {
+		select {
+		case b := <-h.blocks:
+			out = append(out, b)
+		default:
+			return out
+		}
+	}
+}
+
+func TestQuasarSubmitBlockRoutesByChainID(t *testing.T) {
+	q, err := NewTestQuasar(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Not started, so submitted blocks stay buffered
+
+	// Two subnets share a display name; a third is auto-registered
+	a := q.RegisterChainID([32]byte{0xA}, "Subnet")
+	b := q.RegisterChainID([32]byte{0xB}, "Subnet")
+	for _, block := range []*ChainBlock{
+		subnetBlock(0xA, "Subnet", 1),
+		subnetBlock(0xB, "Subnet", 2),
+		subnetBlock(0xA, "Subnet", 3),
+		subnetBlock(0xC, "Oracle", 4),
+	} {
+		if err := q.SubmitBlock(block); err != nil {
+			t.Fatalf("submit %x: %v", block.ID[:1], err)
+		}
+	}
+
+	c, ok := q.ChainRouter().Lookup([32]byte{0xC})
+	if !ok || c.Name() != "Oracle" {
+		t.Fatal("unknown chain not auto-registered under its name")
+	}
+	want := map[*ChainHandle][]byte{a: {1, 3}, b: {2}, c: {4}}
+	for h, ids := range want {
+		got := drain(h)
+		if len(got) != len(ids) {
+			t.Fatalf("chain %x buffered %d blocks, want %d", h.id[:1], len(got), len(ids))
+		}
+		for i, block := range got {
+			if block.ChainID != h.ChainID() || block.ID[0] != ids[i] {
+				t.Fatalf("chain %x got block %x of chain %x", h.id[:1], block.ID[:1], block.ChainID[:1])
+			}
+		}
+	}
+
+	// Registering a known ID again keeps its handle and name
+	if again := q.RegisterChainID([32]byte{0xA}, "Renamed"); again != a || again.Name() != "Subnet" {
+		t.Fatal("re-registration replaced the chain")
+	}
+}
+
+func TestChainRouterUnknownChain(t *testing.T) {
+	r := NewChainRouter(ChainRouterConfig{})
+	r.Register([32]byte{1}, "Known")
+
+	if err := r.Submit(subnetBlock(1, "Known", 1)); err != nil {
+		t.Fatalf("registered chain: %v", err)
+	}
+	if err := r.Submit(subnetBlock(2, "Known", 1)); !errors.Is(err, ErrUnknownChain) {
+		t.Fatalf("unknown ID with a known name: %v, want %v", err, ErrUnknownChain)
+	}
+	if len(r.Chains()) != 1 {
+		t.Fatal("refused block registered a chain")
+	}
+
+	r.SetAutoRegister(true)
+	if err := r.Submit(subnetBlock(2, "Known", 1)); err != nil {
+		t.Fatalf("auto-register: %v", err)
+	}
+	if chains := r.Chains(); len(chains) != 2 || chains[1].ChainID() != ([32]byte{2}) {
+		t.Fatal("chain not auto-registered")
+	}
+}
+
+func TestChainRouterLegacyNames(t *testing.T) {
+	q, err := NewTestQuasar(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.ChainRouter().SetAutoRegister(false)
+
+	// A block without a ChainID routes to the chain registered by its name
+	block := subnetBlock(0, "P-Chain", 1)
+	if err := q.SubmitBlock(block); err != nil {
+		t.Fatalf("legacy block: %v", err)
+	}
+	h, ok := q.ChainRouter().Lookup(NameChainID("P-Chain"))
+	if !ok || h.Buffered() != 1 {
+		t.Fatal("legacy block not routed by name")
+	}
+}
+
+func TestChainRouterDropsOldest(t *testing.T) {
+	r := NewChainRouter(ChainRouterConfig{BufferSize: 2})
+	h := r.Register([32]byte{1}, "Full")
+	for id := byte(1); id <= 3; id++ {
+		if err := r.Submit(subnetBlock(1, "Full", id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := drain(h)
+	if len(got) != 2 || got[0].ID[0] != 2 || got[1].ID[0] != 3 {
+		t.Fatal("full buffer did not drop its oldest block")
+	}
+}
@@ -6,10 +6,12 @@ package quasar
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
 	"github.com/luxfi/crypto/banderwagon"
 	"github.com/luxfi/crypto/bls"
 	"github.com/luxfi/crypto/threshold"
@@ -380,7 +382,7 @@ func TestEngine_Submit_NilBlock(t *testing.T) {
 		t.Fatalf("NewEngine failed: %v", err)
 	}
 
-	err = engine.Submit(nil)
+	err = engine.Submit(context.Background(), nil)
 	if err == nil {
 		t.Error("expected error for nil block")
 	}
@@ -406,7 +408,7 @@ func TestEngine_Submit_ValidBlock(t *testing.T) {
 		Data:      []byte("test data"),
 	}
 
-	err = engine.Submit(block)
+	err = engine.Submit(context.Background(), block)
 	if err != nil {
 		t.Errorf("Submit failed: %v", err)
 	}
@@ -428,7 +430,7 @@ func TestEngine_Submit_BufferFull(t *testing.T) {
 			Height:    uint64(i),
 			Timestamp: time.Now(),
 		}
-		err := engine.Submit(block)
+		err := engine.Submit(context.Background(), block)
 		if i >= 1000 && err == nil {
 			// After 1000, should get buffer full error
 			continue // May still succeed if under limit
@@ -437,12 +439,48 @@ func TestEngine_Submit_BufferFull(t *testing.T) {
 
 	// One more should fail
 	block := &Block{ID: [32]byte{0xFF}, Height: 9999, Timestamp: time.Now()}
-	err = engine.Submit(block)
+	err = engine.Submit(context.Background(), block)
 	if err == nil {
 		t.Error("expected buffer full error")
 	}
 }
 
+func TestEngine_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("already started", func(t *testing.T) {
+		e, err := NewTestEngine(Config{QThreshold: 1, QuasarTimeout: 30})
+		if err != nil {
+			t.Fatalf("NewTestEngine failed: %v", err)
+		}
+		if err := e.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		defer e.Stop()
+		if err := e.Start(ctx); !errors.Is(err, engine.ErrAlreadyStarted) {
+			t.Fatalf("second Start: got %v, want ErrAlreadyStarted", err)
+		}
+	})
+
+	t.Run("buffer full", func(t *testing.T) {
+		// Not started, so nothing drains the queue
+		e, err := NewTestEngine(Config{QThreshold: 1, QuasarTimeout: 30})
+		if err != nil {
+			t.Fatalf("NewTestEngine failed: %v", err)
+		}
+		for i := 0; ; i++ {
+			err := e.Submit(context.Background(), &Block{ID: [32]byte{byte(i), byte(i >> 8)}, Height: uint64(i), Timestamp: time.Now()})
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, engine.ErrBufferFull) {
+				t.Fatalf("Submit %d: got %v, want ErrBufferFull", i, err)
+			}
+			break
+		}
+	})
+}
+
 func TestEngine_Finalized(t *testing.T) {
 	cfg := Config{QThreshold: 1, QuasarTimeout: 30}
 	engine, err := NewTestEngine(cfg)
@@ -482,7 +520,7 @@ func TestEngine_IsFinalized(t *testing.T) {
 		Timestamp: time.Now(),
 		Data:      []byte("test"),
 	}
-	_ = engine.Submit(block)
+	_ = engine.Submit(context.Background(), block)
 
 	// Wait for processing
 	time.Sleep(100 * time.Millisecond)
@@ -515,7 +553,7 @@ func TestEngine_Stats(t *testing.T) {
 			Timestamp: time.Now(),
 			Data:      []byte("test"),
 		}
-		_ = engine.Submit(block)
+		_ = engine.Submit(context.Background(), block)
 	}
 
 	time.Sleep(100 * time.Millisecond)
@@ -1524,7 +1562,7 @@ func TestQuasar_ProcessChain_ContextDone(t *testing.T) {
 	}
 
 	// Register a chain
-	_ = q.RegisterChain("Test-Chain")
+	h := q.RegisterChainID(NameChainID("Test-Chain"), "Test-Chain")
 
 	// Create cancelled context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1534,7 +1572,7 @@ func TestQuasar_ProcessChain_ContextDone(t *testing.T) {
 	// Start it in goroutine and let it exit
 	done := make(chan struct{})
 	go func() {
-		q.processChain(ctx, "Test-Chain")
+		q.processChain(ctx, h)
 		close(done)
 	}()
 
@@ -1604,7 +1642,7 @@ func TestEngine_ProcessBlock_NilCert(t *testing.T) {
 		Timestamp: time.Now(),
 	}
 
-	_ = engine.Submit(block)
+	_ = engine.Submit(context.Background(), block)
 
 	// Wait for processing
 	time.Sleep(50 * time.Millisecond)
@@ -1634,7 +1672,7 @@ func TestEngine_FinalizedChannel_BufferFull(t *testing.T) {
 			Height:    uint64(i),
 			Timestamp: time.Now(),
 		}
-		_ = engine.Submit(block)
+		_ = engine.Submit(context.Background(), block)
 	}
 
 	// Wait for processing
@@ -1799,11 +1837,8 @@ func TestQuasar_SubmitBlock_BufferNotExists(t *testing.T) {
 		t.Fatalf("NewQuasar failed: %v", err)
 	}
 
-	// Manually corrupt internal state - remove chain buffer but keep it registered
-	q.mu.Lock()
-	q.registeredChains["Ghost-Chain"] = true
-	// Don't create a buffer for it
-	q.mu.Unlock()
+	// Unknown chains are refused once auto-registration is off
+	q.ChainRouter().SetAutoRegister(false)
 
 	block := &Block{
 		ChainID:   [32]byte{1},
@@ -1814,8 +1849,8 @@ func TestQuasar_SubmitBlock_BufferNotExists(t *testing.T) {
 	}
 
 	err = q.SubmitBlock(block)
-	if err == nil {
-		t.Error("expected error for missing buffer")
+	if !errors.Is(err, ErrUnknownChain) {
+		t.Errorf("expected ErrUnknownChain, got %v", err)
 	}
 }
 
@@ -2057,7 +2092,7 @@ func TestEngine_ProcessBlock_FinalizedChannelDrop(t *testing.T) {
 			Height:    uint64(i),
 			Timestamp: time.Now(),
 		}
-		_ = engine.Submit(block)
+		_ = engine.Submit(context.Background(), block)
 	}
 
 	// Wait for processing - some blocks should be dropped from finalized channel
@@ -2495,3 +2530,43 @@ func TestSigner_ThresholdMode_AddValidatorThreshold(t *testing.T) {
 		t.Error("expected non-nil share from v3")
 	}
 }
+
+func TestEngine_HealthCheck(t *testing.T) {
+	engine, err := NewEngine(DefaultConfig)
+	if err != nil {
+		t.Fatalf("NewEngine failed: %v", err)
+	}
+	ctx := context.Background()
+
+	health := func() map[string]interface{} {
+		t.Helper()
+		h, err := engine.HealthCheck(ctx)
+		if err != nil {
+			t.Fatalf("HealthCheck failed: %v", err)
+		}
+		return h.(map[string]interface{})
+	}
+
+	if h := health(); h["healthy"].(bool) || h["running"].(bool) {
+		t.Fatalf("engine healthy before Start: %v", h)
+	}
+	if err := engine.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	h := health()
+	if !h["healthy"].(bool) {
+		t.Fatalf("engine unhealthy after Start: %v", h)
+	}
+	if h["keys_initialized"].(bool) {
+		t.Fatal("expected no signing keys on a fresh engine")
+	}
+	if h["last_finalized_height"].(uint64) != 0 {
+		t.Fatalf("expected last finalized height 0, got %v", h["last_finalized_height"])
+	}
+	if err := engine.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if health()["healthy"].(bool) {
+		t.Fatal("engine healthy after Stop")
+	}
+}
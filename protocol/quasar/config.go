@@ -1,10 +1,29 @@
 package quasar
 
+import "github.com/luxfi/consensus/config"
+
 // Config represents quasar protocol configuration
 type Config struct {
 	QThreshold    int
 	QuasarTimeout int
+
+	// OptimalProcessing is the target number of blocks in flight. Submit
+	// waits while that many are being processed (0 = unthrottled).
+	OptimalProcessing int
+
+	// SigningMode selects the certificate signature legs; see
+	// SigningModeFor to derive it from config.Parameters.
+	SigningMode SigningMode
 }
 
 // DefaultConfig for quasar protocol
 var DefaultConfig = Config{QThreshold: 3, QuasarTimeout: 30}
+
+// ConfigFor returns DefaultConfig with the ingestion throttle and signing
+// mode taken from the parameters.
+func ConfigFor(p config.Parameters) Config {
+	cfg := DefaultConfig
+	cfg.OptimalProcessing = p.OptimalProcessing
+	cfg.SigningMode = SigningModeFor(p)
+	return cfg
+}
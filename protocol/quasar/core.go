@@ -22,8 +22,9 @@ import (
 type Quasar struct {
 	mu sync.RWMutex
 
-	// Dynamic chain registration - automatically includes new chains
-	chainBuffers map[string]chan *ChainBlock // chainName -> buffer
+	// Dynamic chain registration - routes submitted blocks by ChainID
+	// (see chain_router.go)
+	router *ChainRouter
 
 	// Standard chain buffers (P/X/C)
 	pChainBlocks chan *ChainBlock
@@ -46,9 +47,6 @@ type Quasar struct {
 	processedBlocks uint64
 	quantumProofs   uint64
 
-	// Chain registry - track all registered chains
-	registeredChains map[string]bool // chainName -> active
-
 	// Context for starting chain processors
 	ctx context.Context
 
@@ -99,17 +97,17 @@ func NewQuasar(threshold int) (*Quasar, error) {
 	}
 
 	core := &Quasar{
-		pChainBlocks:     make(chan *ChainBlock, 100),
-		xChainBlocks:     make(chan *ChainBlock, 100),
-		cChainBlocks:     make(chan *ChainBlock, 100),
-		chainBuffers:     make(map[string]chan *ChainBlock),
-		signer:           s,
-		epochManager:     NewEpochManager(threshold, 3), // Keep 3 epochs in history
-		pendingBlocks:    make(map[string]*QuantumBlock),
-		finalizedBlocks:  make(map[string]*QuantumBlock),
-		quantumHeight:    0,
-		registeredChains: make(map[string]bool),
-	}
+		pChainBlocks:    make(chan *ChainBlock, 100),
+		xChainBlocks:    make(chan *ChainBlock, 100),
+		cChainBlocks:    make(chan *ChainBlock, 100),
+		router:          NewChainRouter(ChainRouterConfig{AutoRegister: true}),
+		signer:          s,
+		epochManager:    NewEpochManager(threshold, 3), // Keep 3 epochs in history
+		pendingBlocks:   make(map[string]*QuantumBlock),
+		finalizedBlocks: make(map[string]*QuantumBlock),
+		quantumHeight:   0,
+	}
+	core.router.onRegister = core.startChain
 
 	// Auto-register primary chains (errors ignored as these are guaranteed to succeed on init)
 	_ = core.RegisterChain("P-Chain")
@@ -131,17 +129,17 @@ func NewTestQuasar(threshold int) (*Quasar, error) {
 	}
 
 	core := &Quasar{
-		pChainBlocks:     make(chan *ChainBlock, 100),
-		xChainBlocks:     make(chan *ChainBlock, 100),
-		cChainBlocks:     make(chan *ChainBlock, 100),
-		chainBuffers:     make(map[string]chan *ChainBlock),
-		signer:           s,
-		epochManager:     NewEpochManager(threshold, 3),
-		pendingBlocks:    make(map[string]*QuantumBlock),
-		finalizedBlocks:  make(map[string]*QuantumBlock),
-		quantumHeight:    0,
-		registeredChains: make(map[string]bool),
-	}
+		pChainBlocks:    make(chan *ChainBlock, 100),
+		xChainBlocks:    make(chan *ChainBlock, 100),
+		cChainBlocks:    make(chan *ChainBlock, 100),
+		router:          NewChainRouter(ChainRouterConfig{AutoRegister: true}),
+		signer:          s,
+		epochManager:    NewEpochManager(threshold, 3),
+		pendingBlocks:   make(map[string]*QuantumBlock),
+		finalizedBlocks: make(map[string]*QuantumBlock),
+		quantumHeight:   0,
+	}
+	core.router.onRegister = core.startChain
 
 	_ = core.RegisterChain("P-Chain")
 	_ = core.RegisterChain("X-Chain")
@@ -350,7 +348,7 @@ func (q *Quasar) addVoteLocked(quantumHash string, validatorID string, sig *Quas
 	qBlock.ValidatorSigs[validatorID] = sig
 
 	// Check threshold
-	if len(qBlock.ValidatorSigs) >= q.signer.threshold {
+	if len(qBlock.ValidatorSigs) >= q.signer.Quorum() {
 		// Move from pending to finalized
 		delete(q.pendingBlocks, quantumHash)
 		q.finalizedBlocks[quantumHash] = qBlock
@@ -456,12 +454,17 @@ func (q *Quasar) finalizeQuantumEpoch() {
 
 // computeQuantumHash creates a quantum-resistant hash
 func (q *Quasar) computeQuantumHash(block *Block) string {
+	return quantumHash(block.ChainName, block.ID, block.Height, block.Timestamp)
+}
+
+// quantumHash is the hash validators sign for a source block
+func quantumHash(chainName string, id [32]byte, height uint64, timestamp time.Time) string {
 	// Combine block data with quantum parameters
 	data := fmt.Sprintf("%s:%x:%d:%d",
-		block.ChainName,
-		block.ID[:],
-		block.Height,
-		block.Timestamp.Unix())
+		chainName,
+		id[:],
+		height,
+		timestamp.Unix())
 
 	// SHA-256 provides 128-bit quantum security (Grover's sqrt speedup on 256-bit)
 	hash := sha256.Sum256([]byte(data))
@@ -524,104 +527,74 @@ func (q *Quasar) VerifyQuantumFinalityWithContext(ctx context.Context, blockHash
 }
 
 // RegisterChain dynamically registers a new chain for automatic quantum security
-// All new chains are automatically protected by the event horizon
+// All new chains are automatically protected by the event horizon.
+// A chain registered by name alone gets NameChainID(chainName) as its ID.
 func (q *Quasar) RegisterChain(chainName string) error {
-	q.mu.Lock()
+	q.RegisterChainID(NameChainID(chainName), chainName)
+	return nil
+}
 
-	if q.registeredChains[chainName] {
-		q.mu.Unlock()
-		return nil // Already registered
-	}
+// RegisterChainID registers chain id under the display name, returning its
+// handle. Registering a known ID again returns the existing handle.
+func (q *Quasar) RegisterChainID(id [32]byte, name string) *ChainHandle {
+	return q.router.Register(id, name)
+}
 
-	// Create buffer for this chain
-	q.chainBuffers[chainName] = make(chan *ChainBlock, 100)
-	q.registeredChains[chainName] = true
+// ChainRouter returns the router that maps submitted blocks to chains
+func (q *Quasar) ChainRouter() *ChainRouter {
+	return q.router
+}
 
-	// Get context for starting processor
+// startChain starts the processor for a newly registered chain if Start
+// was called
+func (q *Quasar) startChain(h *ChainHandle) {
+	q.mu.RLock()
 	ctx := q.ctx
-	q.mu.Unlock()
+	q.mu.RUnlock()
 
-	// Start processor for this chain if we have a context (i.e., Start was called)
 	if ctx != nil {
-		go q.processChain(ctx, chainName)
+		go q.processChain(ctx, h)
 	}
 
-	fmt.Printf("[QUASAR] Chain '%s' pulled into event horizon - quantum security active\n", chainName)
-	return nil
+	fmt.Printf("[QUASAR] Chain '%s' (%x) pulled into event horizon - quantum security active\n", h.Name(), h.id[:4])
 }
 
 // SubmitBlock is the universal RPC endpoint for ANY chain/contract to add blocks
-// External systems (bridge, contracts) use this to enter the event horizon
+// External systems (bridge, contracts) use this to enter the event horizon.
+// Blocks are routed by ChainID; an unknown chain is auto-registered unless
+// the router's AutoRegister is off, in which case ErrUnknownChain is returned.
 func (q *Quasar) SubmitBlock(block *ChainBlock) error {
-	q.mu.RLock()
-	// Auto-register chain if not yet registered
-	if !q.registeredChains[block.ChainName] {
-		q.mu.RUnlock()
-		if err := q.RegisterChain(block.ChainName); err != nil {
-			return fmt.Errorf("failed to auto-register chain %s: %w", block.ChainName, err)
-		}
-		q.mu.RLock()
-	}
-
-	buffer, exists := q.chainBuffers[block.ChainName]
-	q.mu.RUnlock()
-
-	if !exists {
-		return fmt.Errorf("chain %s not registered", block.ChainName)
-	}
-
-	select {
-	case buffer <- block:
-		// Block accepted into event horizon
-		return nil
-	default:
-		// Buffer full, drop oldest and insert new
-		<-buffer
-		buffer <- block
-		return nil
-	}
+	return q.router.Submit(block)
 }
 
 // ProcessDynamicChains starts processors for all dynamically registered chains
 // This runs alongside the legacy P/X/C chain processors
 func (q *Quasar) ProcessDynamicChains(ctx context.Context) {
-	q.mu.RLock()
-	chains := make([]string, 0, len(q.chainBuffers))
-	for chain := range q.chainBuffers {
-		chains = append(chains, chain)
-	}
-	q.mu.RUnlock()
-
 	// Start a processor for each registered chain
-	for _, chainName := range chains {
-		go q.processChain(ctx, chainName)
+	for _, h := range q.router.Chains() {
+		go q.processChain(ctx, h)
 	}
 }
 
 // processChain handles blocks from any dynamically registered chain
-func (q *Quasar) processChain(ctx context.Context, chainName string) {
-	q.mu.RLock()
-	buffer := q.chainBuffers[chainName]
-	q.mu.RUnlock()
-
+func (q *Quasar) processChain(ctx context.Context, h *ChainHandle) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case block := <-buffer:
+		case block := <-h.blocks:
 			q.processBlock(block)
 		}
 	}
 }
 
-// GetRegisteredChains returns all chains currently in the event horizon
+// GetRegisteredChains returns the names of all chains currently in the
+// event horizon, ordered by ChainID. Names need not be unique.
 func (q *Quasar) GetRegisteredChains() []string {
-	q.mu.RLock()
-	defer q.mu.RUnlock()
-
-	chains := make([]string, 0, len(q.registeredChains))
-	for chain := range q.registeredChains {
-		chains = append(chains, chain)
+	handles := q.router.Chains()
+	chains := make([]string, 0, len(handles))
+	for _, h := range handles {
+		chains = append(chains, h.Name())
 	}
 	return chains
 }
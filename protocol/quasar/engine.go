@@ -10,9 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/consensus/engine"
 )
 
 // quasarEngine implements the Engine interface.
@@ -26,6 +28,13 @@ type quasarEngine struct {
 	incoming  chan *Block
 	finalized chan *Block
 
+	// Ingestion throttle: one slot per block in flight, capped at
+	// cfg.OptimalProcessing (nil when unthrottled). stopped is closed by
+	// Stop and replaced by the next Start.
+	slots      chan struct{}
+	stopped    chan struct{}
+	processing atomic.Int64
+
 	// State
 	finalizedBlocks map[string]*Block // hash -> block
 	height          uint64
@@ -52,6 +61,10 @@ var (
 	// and the engine would emit a cert the network believes is triple
 	// when it's only single-layer.
 	ErrPartialTripleCert = errors.New("Certifier: refusing to emit partial cert under triple-mode profile")
+
+	// ErrEngineStopped is returned by Submit when the engine is stopped,
+	// including while the block is waiting for a processing slot.
+	ErrEngineStopped = errors.New("quasar engine stopped")
 )
 
 // NewEngine creates a new Quasar consensus engine.
@@ -67,14 +80,7 @@ func NewEngine(cfg Config) (Engine, error) {
 		return nil, fmt.Errorf("failed to create certifier: %w", err)
 	}
 
-	bufSize := 1000
-	return &quasarEngine{
-		cfg:             cfg,
-		incoming:        make(chan *Block, bufSize),
-		finalized:       make(chan *Block, bufSize),
-		finalizedBlocks: make(map[string]*Block),
-		certifier:       certifier,
-	}, nil
+	return newQuasarEngine(cfg, certifier), nil
 }
 
 // NewTestEngine creates a Quasar engine with threshold=1 for single-node testing.
@@ -90,25 +96,25 @@ func NewTestEngine(cfg Config) (Engine, error) {
 		return nil, fmt.Errorf("failed to create certifier: %w", err)
 	}
 
-	bufSize := 1000
-	return &quasarEngine{
-		cfg:             cfg,
-		incoming:        make(chan *Block, bufSize),
-		finalized:       make(chan *Block, bufSize),
-		finalizedBlocks: make(map[string]*Block),
-		certifier:       certifier,
-	}, nil
+	return newQuasarEngine(cfg, certifier), nil
 }
 
-// Start begins the consensus engine.
+// Start begins the consensus engine. Starting a running engine fails with
+// engine.ErrAlreadyStarted; a stopped engine can be started again.
 func (q *quasarEngine) Start(ctx context.Context) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	if q.ctx != nil && q.ctx.Err() == nil {
+		return engine.ErrAlreadyStarted
+	}
+	if isClosed(q.stopped) {
+		q.stopped = make(chan struct{})
+	}
 	q.ctx, q.cancel = context.WithCancel(ctx)
 	q.startTime = time.Now()
 
-	go q.processLoop()
+	go q.processLoop(q.ctx)
 	return nil
 }
 
@@ -120,20 +126,71 @@ func (q *quasarEngine) Stop() error {
 	if q.cancel != nil {
 		q.cancel()
 	}
+	if !isClosed(q.stopped) {
+		close(q.stopped)
+	}
 	return nil
 }
 
-// Submit adds a block to the consensus pipeline.
-func (q *quasarEngine) Submit(block *Block) error {
+// isClosed reports whether ch has been closed.
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Submit adds a block to the consensus pipeline. When OptimalProcessing is
+// set, Submit waits while that many blocks are in flight so ingestion is
+// paced to the engine's optimal operating point; the wait ends with
+// ErrEngineStopped if the engine stops, or ctx's error if ctx is done first.
+// A full queue fails with engine.ErrBufferFull.
+func (q *quasarEngine) Submit(ctx context.Context, block *Block) error {
 	if block == nil {
 		return fmt.Errorf("nil block")
 	}
 
+	q.mu.RLock()
+	stopped := q.stopped
+	q.mu.RUnlock()
+	if isClosed(stopped) {
+		return ErrEngineStopped
+	}
+
+	if q.slots != nil {
+		select {
+		case q.slots <- struct{}{}:
+		case <-stopped:
+			return ErrEngineStopped
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// Count the block before it is queued so the processing loop can never
+	// decrement it first
+	q.processing.Add(1)
 	select {
 	case q.incoming <- block:
 		return nil
 	default:
-		return fmt.Errorf("buffer full")
+		q.processing.Add(-1)
+		q.release()
+		return fmt.Errorf("%w: %d blocks queued", engine.ErrBufferFull, cap(q.incoming))
+	}
+}
+
+// Processing returns the number of submitted blocks not yet processed.
+func (q *quasarEngine) Processing() int {
+	return int(q.processing.Load())
+}
+
+// release frees the processing slot held by one block.
+func (q *quasarEngine) release() {
+	if q.slots != nil {
+		<-q.slots
 	}
 }
 
@@ -162,23 +219,67 @@ func (q *quasarEngine) Stats() Stats {
 		ProcessedBlocks: q.processed,
 		FinalizedBlocks: uint64(len(q.finalizedBlocks)),
 		PendingBlocks:   len(q.incoming),
+		Processing:      q.Processing(),
 		Validators:      q.certifier.validatorCount(),
 		Uptime:          time.Since(q.startTime),
 	}
 }
 
-// processLoop is the main consensus loop.
-func (q *quasarEngine) processLoop() {
+// HealthCheck reports the engine's health. The engine is healthy while it
+// is started and, under a profile demanding triple-mode certificates, has
+// signing keys attached; without them it could never finalize a block.
+func (q *quasarEngine) HealthCheck(ctx context.Context) (interface{}, error) {
+	q.mu.RLock()
+	running := q.ctx != nil && q.ctx.Err() == nil
+	height := q.height
+	q.mu.RUnlock()
+
+	q.certifier.mu.RLock()
+	keysInitialized := q.certifier.signer != nil
+	demandsTriple := q.certifier.demandsTriple()
+	q.certifier.mu.RUnlock()
+
+	return map[string]interface{}{
+		"healthy":               running && (keysInitialized || !demandsTriple),
+		"running":               running,
+		"keys_initialized":      keysInitialized,
+		"last_finalized_height": height,
+		"validators":            q.certifier.validatorCount(),
+	}, nil
+}
+
+// processLoop is the main consensus loop; it runs until ctx, the context of
+// the Start that launched it, is cancelled.
+func (q *quasarEngine) processLoop(ctx context.Context) {
 	for {
 		select {
-		case <-q.ctx.Done():
+		case <-ctx.Done():
 			return
 		case block := <-q.incoming:
 			q.processBlock(block)
+			q.processing.Add(-1)
+			q.release()
 		}
 	}
 }
 
+func newQuasarEngine(cfg Config, certifier *Certifier) *quasarEngine {
+	bufSize := 1000
+	q := &quasarEngine{
+		cfg:             cfg,
+		incoming:        make(chan *Block, bufSize),
+		finalized:       make(chan *Block, bufSize),
+		stopped:         make(chan struct{}),
+		finalizedBlocks: make(map[string]*Block),
+		certifier:       certifier,
+	}
+	certifier.SetSigningMode(cfg.SigningMode)
+	if cfg.OptimalProcessing > 0 {
+		q.slots = make(chan struct{}, cfg.OptimalProcessing)
+	}
+	return q
+}
+
 // processBlock processes a single block through consensus.
 func (q *quasarEngine) processBlock(block *Block) {
 	q.mu.Lock()
@@ -199,11 +300,18 @@ func (q *quasarEngine) processBlock(block *Block) {
 	// generateCert path forgets to refuse silently. Belt and braces.
 	q.certifier.mu.RLock()
 	demandsTriple := q.certifier.demandsTriple()
+	mode := q.certifier.mode
 	q.certifier.mu.RUnlock()
 	if demandsTriple && !cert.Verify(nil) {
 		return
 	}
 
+	// In RingOnlyMode a cert carrying any BLS bytes is mixed and is
+	// rejected, as is one whose Corona leg does not verify.
+	if mode == RingOnlyMode && q.certifier.verifyRingOnlyCert(block, cert) != nil {
+		return
+	}
+
 	// Finalize block
 	block.Cert = cert
 	block.Hash = computeHash(block)
@@ -265,6 +373,14 @@ type Certifier struct {
 	// SHA-256 placeholder or single-layer cert under such a profile —
 	// the engine's caller MUST then treat the round as unfinalised.
 	profile *config.ChainSecurityProfile
+
+	// mode selects the signature legs generateCert produces. In
+	// RingOnlyMode certs carry only the Corona leg (see ring_only.go).
+	mode SigningMode
+
+	// thresholdPolicy decides what a validator set change that breaks
+	// [SafetyFloor(n), n] does to threshold (see threshold_policy.go).
+	thresholdPolicy ThresholdPolicy
 }
 
 func newCertifier(threshold int) (*Certifier, error) {
@@ -275,12 +391,15 @@ func newCertifier(threshold int) (*Certifier, error) {
 }
 
 // AttachSigner wires a real BLS+Corona+ML-DSA signer into the certifier.
-// After this call, generateCert produces real cryptographic certificates.
+// After this call, generateCert produces real cryptographic certificates,
+// and the signer aggregates and verifies against the certifier's effective
+// Threshold, following every validator set change.
 func (h *Certifier) AttachSigner(ctx context.Context, s *signer) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.signer = s
 	h.signerCtx = ctx
+	h.syncSignerLocked()
 }
 
 // SetProfile binds a ChainSecurityProfile to this certifier. When the
@@ -295,6 +414,21 @@ func (h *Certifier) SetProfile(profile *config.ChainSecurityProfile) {
 	h.profile = profile
 }
 
+// SetSigningMode selects the signature legs generateCert produces.
+// RingOnlyMode skips BLS entirely; DualMode (default) keeps the BLS path.
+func (h *Certifier) SetSigningMode(mode SigningMode) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.mode = mode
+}
+
+// SigningMode returns the certifier's signing mode.
+func (h *Certifier) SigningMode() SigningMode {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.mode
+}
+
 // demandsTriple reports whether the certifier's profile requires every
 // cert to be triple-mode (P+Q+Z). Strict-PQ and FIPS demand it; other
 // profiles do not. Caller MUST hold h.mu.RLock.
@@ -322,8 +456,19 @@ func (h *Certifier) generateCert(block *Block) *QuasarCert {
 	ctx := h.signerCtx
 	validatorCount := len(h.validators)
 	demandsTriple := h.demandsTriple()
+	mode := h.mode
 	h.mu.RUnlock()
 
+	// RingOnlyMode never touches BLS keys and has no placeholder: the
+	// cert is a Corona aggregate or nothing. A ring-only cert cannot
+	// satisfy a triple-mode profile.
+	if mode == RingOnlyMode {
+		if signer == nil || demandsTriple {
+			return nil
+		}
+		return h.ringOnlyCert(ctx, signer, block, validatorCount)
+	}
+
 	if signer != nil {
 		cert := h.realCert(ctx, signer, block, validatorCount)
 		if cert != nil {
@@ -462,16 +607,41 @@ func buildPRFKey(block *Block) []byte {
 	return h[:]
 }
 
-// AddValidator adds a validator to the consensus.
-func (h *Certifier) AddValidator(id string, weight int) {
+// AddValidator adds a validator to the consensus. Under ThresholdReject
+// it returns ErrUnsafeQuorumChange if the threshold would fall below the
+// safety floor of the grown set; under ThresholdAdjust the effective
+// threshold is raised instead.
+func (h *Certifier) AddValidator(id string, weight int) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+
+	n := len(h.validators)
+	if _, ok := h.validators[id]; !ok {
+		n++
+	}
+	if err := h.checkQuorumChange(n); err != nil {
+		return err
+	}
 	h.validators[id] = weight
+	h.syncSignerLocked()
+	return nil
 }
 
-// RemoveValidator removes a validator from the consensus.
-func (h *Certifier) RemoveValidator(id string) {
+// RemoveValidator removes a validator from the consensus. Under
+// ThresholdReject it returns ErrUnsafeQuorumChange if the threshold would
+// exceed the shrunk set; under ThresholdAdjust the effective threshold is
+// lowered instead.
+func (h *Certifier) RemoveValidator(id string) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+
+	if _, ok := h.validators[id]; !ok {
+		return nil
+	}
+	if err := h.checkQuorumChange(len(h.validators) - 1); err != nil {
+		return err
+	}
 	delete(h.validators, id)
+	h.syncSignerLocked()
+	return nil
 }
@@ -0,0 +1,143 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// horizon_snapshot.go — persist and reload the BLS engine's event horizons
+// so a restarted node resumes from its last horizon instead of recomputing
+// canonical order from genesis.
+//
+// The decoder is strict: it is bounds-checked, rejects trailing bytes and
+// unknown versions, and reuses qcReader (quorum_cert.go) for framing.
+package quasar
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/consensus/core/dag"
+)
+
+const (
+	horizonSnapshotMagic   = "QHZN"
+	horizonSnapshotVersion = 1
+)
+
+var (
+	// ErrHorizonSnapshotCorrupt is returned when a snapshot is truncated,
+	// malformed or has trailing bytes.
+	ErrHorizonSnapshotCorrupt = errors.New("quasar: horizon snapshot corrupt")
+
+	// ErrHorizonSnapshotHeights is returned when snapshot heights are not
+	// strictly increasing.
+	ErrHorizonSnapshotHeights = errors.New("quasar: horizon snapshot heights not monotonic")
+
+	// ErrHorizonSnapshotStale is returned when the snapshot's latest height
+	// is below the engine's current latest horizon.
+	ErrHorizonSnapshotStale = errors.New("quasar: horizon snapshot older than current state")
+)
+
+// SnapshotHorizons serializes the ordered horizon list. Layout:
+//
+//	magic:4 "QHZN" || version:1
+//	count:4
+//	[ per horizon ]
+//	  checkpoint:32 || height:8
+//	  validators:4 [ len:4 validator:N ]...
+//	  sig_len:4 sig:N
+func (q *BLS) SnapshotHorizons() ([]byte, error) {
+	buf := make([]byte, 0, len(horizonSnapshotMagic)+1+4+len(q.horizons)*(32+8+4+4+2*kmacMACOutLen))
+	buf = append(buf, horizonSnapshotMagic...)
+	buf = append(buf, horizonSnapshotVersion)
+	buf = appendU32(buf, uint32(len(q.horizons)))
+	for _, h := range q.horizons {
+		buf = append(buf, h.Checkpoint[:]...)
+		buf = appendU64(buf, h.Height)
+		buf = appendU32(buf, uint32(len(h.Validators)))
+		for _, v := range h.Validators {
+			buf = appendU32(buf, uint32(len(v)))
+			buf = append(buf, v...)
+		}
+		buf = appendU32(buf, uint32(len(h.Signature)))
+		buf = append(buf, h.Signature...)
+	}
+	return buf, nil
+}
+
+// RestoreHorizons replaces the horizon list with a snapshot produced by
+// SnapshotHorizons. Heights must be strictly increasing, and a snapshot
+// whose latest height is below the current latest horizon is rejected.
+// On error the current horizons are left untouched.
+func (q *BLS) RestoreHorizons(data []byte) error {
+	horizons, err := decodeHorizonSnapshot(data)
+	if err != nil {
+		return err
+	}
+
+	var prev uint64
+	for i, h := range horizons {
+		if h.Height <= prev {
+			return fmt.Errorf("%w: horizon %d at height %d after %d", ErrHorizonSnapshotHeights, i, h.Height, prev)
+		}
+		prev = h.Height
+	}
+
+	if current := q.GetLatestHorizon(); current != nil && prev < current.Height {
+		return fmt.Errorf("%w: snapshot height %d < current %d", ErrHorizonSnapshotStale, prev, current.Height)
+	}
+
+	q.horizons = horizons
+	return nil
+}
+
+func decodeHorizonSnapshot(data []byte) ([]dag.EventHorizon[VertexID], error) {
+	if len(data) < len(horizonSnapshotMagic)+1 || string(data[:len(horizonSnapshotMagic)]) != horizonSnapshotMagic {
+		return nil, ErrHorizonSnapshotCorrupt
+	}
+	if data[len(horizonSnapshotMagic)] != horizonSnapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrHorizonSnapshotCorrupt, data[len(horizonSnapshotMagic)])
+	}
+	r := &qcReader{buf: data[len(horizonSnapshotMagic)+1:]}
+
+	count, err := r.u32()
+	if err != nil {
+		return nil, ErrHorizonSnapshotCorrupt
+	}
+	// Each horizon is at least checkpoint + height + two length prefixes
+	if uint64(count) > uint64(len(r.buf))/(32+8+4+4) {
+		return nil, ErrHorizonSnapshotCorrupt
+	}
+
+	horizons := make([]dag.EventHorizon[VertexID], 0, count)
+	for i := uint32(0); i < count; i++ {
+		var h dag.EventHorizon[VertexID]
+		var checkpoint [32]byte
+		if err := r.read32(&checkpoint); err != nil {
+			return nil, ErrHorizonSnapshotCorrupt
+		}
+		h.Checkpoint = VertexID(checkpoint)
+		if h.Height, err = r.u64(); err != nil {
+			return nil, ErrHorizonSnapshotCorrupt
+		}
+
+		n, err := r.u32()
+		if err != nil || uint64(n) > uint64(len(r.buf))/4 {
+			return nil, ErrHorizonSnapshotCorrupt
+		}
+		h.Validators = make([]string, n)
+		for j := range h.Validators {
+			v, err := r.lenPrefixed()
+			if err != nil {
+				return nil, ErrHorizonSnapshotCorrupt
+			}
+			h.Validators[j] = string(v)
+		}
+
+		if h.Signature, err = r.lenPrefixed(); err != nil {
+			return nil, ErrHorizonSnapshotCorrupt
+		}
+		horizons = append(horizons, h)
+	}
+	if len(r.buf) != 0 {
+		return nil, ErrHorizonSnapshotCorrupt
+	}
+	return horizons, nil
+}
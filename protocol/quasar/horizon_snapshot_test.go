@@ -0,0 +1,118 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/luxfi/consensus/config"
+)
+
+func newSnapshotBLS(t *testing.T, horizons int) *BLS {
+	t.Helper()
+	q := NewBLS(config.DefaultParams(), newMockStore())
+	if err := q.Initialize(context.Background(), []byte("bls-key"), []byte("pq-key")); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < horizons; i++ {
+		validators := []string{"validator-a", "validator-b", string(rune('c' + i))}
+		if _, err := q.EstablishHorizon(context.Background(), VertexID{byte(i + 1)}, validators); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return q
+}
+
+func TestHorizonSnapshotRoundTrip(t *testing.T) {
+	src := newSnapshotBLS(t, 3)
+	data, err := src.SnapshotHorizons()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := newSnapshotBLS(t, 0)
+	if err := dst.RestoreHorizons(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(src.horizons, dst.horizons) {
+		t.Fatalf("restored horizons differ:\n got %+v\nwant %+v", dst.horizons, src.horizons)
+	}
+
+	again, err := dst.SnapshotHorizons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Fatal("snapshot encoding is not deterministic")
+	}
+
+	// New horizons continue from the restored height
+	next, err := dst.EstablishHorizon(context.Background(), VertexID{0xAA}, []string{"validator-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Height != 4 {
+		t.Fatalf("next height = %d, want 4", next.Height)
+	}
+}
+
+func TestHorizonSnapshotRejectsStale(t *testing.T) {
+	stale, err := newSnapshotBLS(t, 2).SnapshotHorizons()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := newSnapshotBLS(t, 3)
+	want := append(q.horizons[:0:0], q.horizons...)
+	if err := q.RestoreHorizons(stale); !errors.Is(err, ErrHorizonSnapshotStale) {
+		t.Fatalf("expected ErrHorizonSnapshotStale, got %v", err)
+	}
+	if !reflect.DeepEqual(want, q.horizons) {
+		t.Fatal("rejected snapshot modified horizons")
+	}
+}
+
+func TestHorizonSnapshotRejectsNonMonotonic(t *testing.T) {
+	src := newSnapshotBLS(t, 3)
+	src.horizons[2].Height = src.horizons[1].Height
+	data, err := src.SnapshotHorizons()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newSnapshotBLS(t, 0).RestoreHorizons(data); !errors.Is(err, ErrHorizonSnapshotHeights) {
+		t.Fatalf("expected ErrHorizonSnapshotHeights, got %v", err)
+	}
+}
+
+func TestHorizonSnapshotRejectsCorrupt(t *testing.T) {
+	data, err := newSnapshotBLS(t, 2).SnapshotHorizons()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	badVersion := append([]byte(nil), data...)
+	badVersion[len(horizonSnapshotMagic)] = 0xFF
+
+	cases := map[string][]byte{
+		"empty":     nil,
+		"bad magic": append([]byte("XXXX"), data[4:]...),
+		"version":   badVersion,
+		"truncated": data[:len(data)-1],
+		"trailing":  append(append([]byte(nil), data...), 0x00),
+	}
+	for name, input := range cases {
+		q := newSnapshotBLS(t, 0)
+		if err := q.RestoreHorizons(input); !errors.Is(err, ErrHorizonSnapshotCorrupt) {
+			t.Errorf("%s: expected ErrHorizonSnapshotCorrupt, got %v", name, err)
+		}
+		if len(q.horizons) != 0 {
+			t.Errorf("%s: corrupt snapshot modified horizons", name)
+		}
+	}
+}
@@ -56,7 +56,7 @@ var (
 // Three independent signing paths:
 //   - BLS12-381: classical threshold signatures (ECDL hardness)
 //   - Corona:    Ring-LWE 2-round threshold signatures (Module-LWE hardness)
-//   - ML-DSA-65: FIPS 204 post-quantum identity signatures (Module-LWE + Module-SIS)
+//   - ML-DSA:    FIPS 204 post-quantum identity signatures at the signer's SecurityLevel (default ML-DSA-65)
 //
 // All three run in parallel via TripleSign. Each can be enabled independently.
 type signer struct {
@@ -74,9 +74,10 @@ type signer struct {
 	coronaSigners  map[string]*coronaThreshold.Signer
 	coronaShares   map[string]*coronaThreshold.KeyShare
 
-	// Post-quantum ML-DSA-65 identity signing (FIPS 204)
+	// Post-quantum ML-DSA identity signing (FIPS 204) at level
 	mldsaKeys    map[string]*mldsa.PrivateKey
 	mldsaPubKeys map[string]*mldsa.PublicKey
+	level        SecurityLevel
 
 	// BLS direct keys (classical signing)
 	blsKeys    map[string]*bls.SecretKey
@@ -85,6 +86,11 @@ type signer struct {
 	// Consensus state
 	validators map[string]*Validator
 	threshold  int // Number of validators needed for consensus
+
+	// quorum, when non-zero, replaces threshold as the number of
+	// signatures needed to aggregate, verify or finalize. An attached
+	// Certifier keeps it at its effective threshold.
+	quorum int
 }
 
 // Validator represents a consensus validator
@@ -92,7 +98,7 @@ type Validator struct {
 	ID          string
 	BLSPubKey   *bls.PublicKey
 	CoronaPub   []byte           // Corona group public key contribution
-	MLDSAPubKey *mldsa.PublicKey // ML-DSA-65 identity key (nil if not configured)
+	MLDSAPubKey *mldsa.PublicKey // ML-DSA identity key at the signer's SecurityLevel (nil if not configured)
 	Weight      uint64
 	Active      bool
 }
@@ -109,6 +115,10 @@ type SignerConfig struct {
 	// Corona threshold (native 2-round protocol)
 	CoronaShares   map[string]*coronaThreshold.KeyShare
 	CoronaGroupKey *coronaThreshold.GroupKey
+
+	// SecurityLevel selects the ML-DSA identity parameter set
+	// (zero = SecurityLevelMLDSA65)
+	SecurityLevel SecurityLevel
 }
 
 // CoronaRound1State holds Round 1 data for all parties in a signing session.
@@ -143,6 +153,9 @@ func newSignerWithDualThreshold(config SignerConfig) (*signer, error) {
 	if config.Threshold < 1 {
 		return nil, errors.New("threshold must be at least 1")
 	}
+	if !config.SecurityLevel.Valid() {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSecurityLevel, uint8(config.SecurityLevel))
+	}
 
 	h := &signer{
 		blsKeys:       make(map[string]*bls.SecretKey),
@@ -154,6 +167,7 @@ func newSignerWithDualThreshold(config SignerConfig) (*signer, error) {
 		mldsaPubKeys:  make(map[string]*mldsa.PublicKey),
 		validators:    make(map[string]*Validator),
 		threshold:     config.Threshold,
+		level:         config.SecurityLevel.resolve(),
 	}
 
 	// Initialize BLS threshold scheme
@@ -383,7 +397,7 @@ func (s *signer) TripleSignRound1(ctx context.Context, validatorID string, messa
 		}()
 	}
 
-	// Path 3: ML-DSA-65 (PQ identity, FIPS 204)
+	// Path 3: ML-DSA (PQ identity, FIPS 204)
 	if hasMLDSA {
 		go func() {
 			defer wg.Done()
@@ -439,8 +453,8 @@ func (s *signer) AddValidator(id string, weight uint64) error {
 	s.blsKeys[id] = blsSK
 	s.blsPubKeys[id] = blsPK
 
-	// ML-DSA-65 key (post-quantum identity, FIPS 204)
-	mldsaSK, err := mldsa.GenerateKey(rand.Reader, mldsa.MLDSA65)
+	// ML-DSA key (post-quantum identity, FIPS 204) at the signer's level
+	mldsaSK, err := mldsa.GenerateKey(rand.Reader, s.level.Mode())
 	if err != nil {
 		return fmt.Errorf("failed to generate ML-DSA key: %w", err)
 	}
@@ -609,8 +623,9 @@ func (s *signer) AggregateSignaturesWithContext(ctx context.Context, message []b
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if len(signatures) < s.threshold {
-		return nil, fmt.Errorf("insufficient signatures: %d < %d", len(signatures), s.threshold)
+	quorum := s.quorumLocked()
+	if len(signatures) < quorum {
+		return nil, fmt.Errorf("insufficient signatures: %d < %d", len(signatures), quorum)
 	}
 
 	// Check for threshold mode
@@ -626,7 +641,7 @@ func (s *signer) AggregateSignaturesWithContext(ctx context.Context, message []b
 			}
 		}
 
-		if len(blsShares) >= s.threshold {
+		if len(blsShares) >= quorum {
 			blsAggSig, err := s.blsAggregator.Aggregate(ctx, message, blsShares, nil)
 			if err != nil {
 				return nil, fmt.Errorf("BLS aggregation failed: %w", err)
@@ -685,7 +700,7 @@ func (s *signer) VerifyAggregatedSignatureWithContext(ctx context.Context, messa
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if aggSig.SignerCount < s.threshold {
+	if aggSig.SignerCount < s.quorumLocked() {
 		return false
 	}
 
@@ -730,7 +745,7 @@ type QuasarSig struct {
 	BLS         []byte // BLS-12-381 aggregate (classical fast-path; empty in pure-PQ)
 	Corona      []byte // Corona (Ring-LWE) threshold signature
 	Pulsar      []byte // Pulsar-M (Module-LWE) threshold signature
-	MLDSA       []byte // Per-validator ML-DSA-65 (FIPS 204 identity attestation)
+	MLDSA       []byte // Per-validator ML-DSA at the signer's SecurityLevel (FIPS 204 identity attestation)
 	ValidatorID string
 	IsThreshold bool
 	SignerIndex int
@@ -779,6 +794,31 @@ func (s *signer) GetThreshold() int {
 	return s.threshold
 }
 
+// Quorum returns the number of signatures needed to aggregate, verify or
+// finalize: the effective threshold of an attached Certifier, else the
+// configured threshold.
+func (s *signer) Quorum() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.quorumLocked()
+}
+
+// quorumLocked returns Quorum. Caller MUST hold s.mu (read or write).
+func (s *signer) quorumLocked() int {
+	if s.quorum > 0 {
+		return s.quorum
+	}
+	return s.threshold
+}
+
+// setQuorum replaces the signature count Quorum reports; 0 restores the
+// configured threshold
+func (s *signer) setQuorum(t int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quorum = t
+}
+
 // IsThresholdMode returns true if BLS threshold signing is enabled.
 func (s *signer) IsThresholdMode() bool {
 	return s.blsScheme != nil
@@ -0,0 +1,192 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/crypto/bls"
+	coronaThreshold "github.com/luxfi/threshold/protocols/corona"
+)
+
+// SigningMode selects which signature legs the certifier produces.
+type SigningMode uint8
+
+const (
+	// DualMode signs every cert with the classical BLS aggregate alongside
+	// the PQ legs. This is the default.
+	DualMode SigningMode = iota
+
+	// RingOnlyMode is the ring-signature-only finality fast path: certs are
+	// formed from the Corona (Ring-LWE) threshold signature alone and the
+	// BLS field is zero-filled to bls.SignatureLen so the wire layout stays
+	// byte-compatible. No BLS key is read or used.
+	RingOnlyMode
+)
+
+var (
+	// ErrMixedCertificate is returned in RingOnlyMode for a certificate or
+	// signature set that carries BLS material next to ring-only material.
+	ErrMixedCertificate = errors.New("quasar: mixed BLS and ring-only certificate")
+
+	// ErrRingOnlyUnverified is returned when a ring-only certificate's
+	// Corona leg is missing or fails verification.
+	ErrRingOnlyUnverified = errors.New("quasar: ring-only certificate failed verification")
+)
+
+// String returns the mode name.
+func (m SigningMode) String() string {
+	switch m {
+	case DualMode:
+		return "dual"
+	case RingOnlyMode:
+		return "ring-only"
+	default:
+		return fmt.Sprintf("SigningMode(%d)", uint8(m))
+	}
+}
+
+// SigningModeFor returns the signing mode selected by the parameters.
+func SigningModeFor(p config.Parameters) SigningMode {
+	if p.RingOnly {
+		return RingOnlyMode
+	}
+	return DualMode
+}
+
+// isZeroBLS reports whether b is absent or a zero-filled placeholder.
+func isZeroBLS(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsRingOnly reports whether the cert was formed in RingOnlyMode: a Corona
+// leg and a zero-filled (or absent) BLS field.
+func (c *QuasarCert) IsRingOnly() bool {
+	return c != nil && len(c.Corona) > 0 && isZeroBLS(c.BLS)
+}
+
+// VerifyRingOnly verifies a RingOnlyMode cert against the Corona group key.
+// A cert carrying any non-zero BLS bytes is rejected as mixed.
+func (c *QuasarCert) VerifyRingOnly(message []byte, groupKey *coronaThreshold.GroupKey) error {
+	if c == nil {
+		return ErrRingOnlyUnverified
+	}
+	if !isZeroBLS(c.BLS) {
+		return ErrMixedCertificate
+	}
+	if !c.VerifyWithRealKeys(message, nil, groupKey, nil) {
+		return ErrRingOnlyUnverified
+	}
+	return nil
+}
+
+// CheckRingOnlySigs rejects a signature set in which any share carries BLS
+// bytes. In RingOnlyMode every contribution must be ring-only.
+func CheckRingOnlySigs(sigs []*QuasarSig) error {
+	for i, sig := range sigs {
+		if sig != nil && !isZeroBLS(sig.BLS) {
+			return fmt.Errorf("%w: signature %d carries BLS", ErrMixedCertificate, i)
+		}
+	}
+	return nil
+}
+
+// RingOnlySign runs the Corona 2-round protocol over the first threshold
+// locally held shares (by share index) and returns the aggregate signature.
+// Only Corona state is read; the BLS signers and keys are never touched.
+func (s *signer) RingOnlySign(ctx context.Context, message []byte, sessionID int, prfKey []byte) (*coronaThreshold.Signature, error) {
+	s.mu.RLock()
+	shares := make([]*coronaThreshold.KeyShare, 0, len(s.coronaShares))
+	for _, share := range s.coronaShares {
+		shares = append(shares, share)
+	}
+	t := s.threshold
+	s.mu.RUnlock()
+
+	if len(shares) < t {
+		return nil, fmt.Errorf("ring-only signing needs %d Corona shares, have %d", t, len(shares))
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].Index < shares[j].Index })
+	shares = shares[:t]
+
+	signers := make([]*coronaThreshold.Signer, t)
+	signerIDs := make([]int, t)
+	for i, share := range shares {
+		signers[i] = coronaThreshold.NewSigner(share)
+		signerIDs[i] = share.Index
+	}
+
+	round1 := make(map[int]*coronaThreshold.Round1Data, t)
+	for i, sg := range signers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r1, err := sg.Round1(sessionID, prfKey, signerIDs)
+		if err != nil {
+			return nil, fmt.Errorf("corona round 1: %w", err)
+		}
+		round1[signerIDs[i]] = r1
+	}
+
+	round2 := make(map[int]*coronaThreshold.Round2Data, t)
+	for i, sg := range signers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r2, err := sg.Round2(sessionID, string(message), prfKey, signerIDs, round1)
+		if err != nil {
+			return nil, fmt.Errorf("corona round 2: %w", err)
+		}
+		round2[signerIDs[i]] = r2
+	}
+
+	return signers[0].Finalize(round2)
+}
+
+// ringOnlyCert produces a RingOnlyMode cert for block: a Corona aggregate
+// and a zero-filled BLS field. Returns nil if the signer cannot complete a
+// Corona signature.
+func (h *Certifier) ringOnlyCert(ctx context.Context, s *signer, block *Block, validatorCount int) *QuasarCert {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sig, err := s.RingOnlySign(ctx, buildBlockMessage(block), int(block.Height), buildPRFKey(block))
+	if err != nil {
+		return nil
+	}
+
+	return &QuasarCert{
+		BLS:        make([]byte, bls.SignatureLen),
+		Corona:     EncodeCoronaSig(sig),
+		Epoch:      block.Height,
+		Finality:   time.Now(),
+		Validators: validatorCount,
+	}
+}
+
+// verifyRingOnlyCert checks a cert produced for block in RingOnlyMode
+// against the attached signer's Corona group key.
+func (h *Certifier) verifyRingOnlyCert(block *Block, cert *QuasarCert) error {
+	h.mu.RLock()
+	s := h.signer
+	h.mu.RUnlock()
+	if s == nil {
+		return ErrRingOnlyUnverified
+	}
+	s.mu.RLock()
+	groupKey := s.coronaGroupKey
+	s.mu.RUnlock()
+	return cert.VerifyRingOnly(buildBlockMessage(block), groupKey)
+}
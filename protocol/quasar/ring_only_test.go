@@ -0,0 +1,170 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/crypto/threshold"
+)
+
+// spyBLSSigner wraps a BLS threshold signer and counts every use.
+type spyBLSSigner struct {
+	threshold.Signer
+	calls *atomic.Int32
+}
+
+func (s spyBLSSigner) Index() int {
+	s.calls.Add(1)
+	return s.Signer.Index()
+}
+
+func (s spyBLSSigner) PublicShare() []byte {
+	s.calls.Add(1)
+	return s.Signer.PublicShare()
+}
+
+func (s spyBLSSigner) NonceGen(ctx context.Context) (threshold.NonceCommitment, threshold.NonceState, error) {
+	s.calls.Add(1)
+	return s.Signer.NonceGen(ctx)
+}
+
+func (s spyBLSSigner) SignShare(ctx context.Context, message []byte, signers []int, nonce threshold.NonceState) (threshold.SignatureShare, error) {
+	s.calls.Add(1)
+	return s.Signer.SignShare(ctx, message, signers, nonce)
+}
+
+func (s spyBLSSigner) KeyShare() threshold.KeyShare {
+	s.calls.Add(1)
+	return s.Signer.KeyShare()
+}
+
+// newRingOnlySigner returns a dual-threshold signer whose BLS signers are
+// spies and whose direct BLS keys are removed.
+func newRingOnlySigner(t *testing.T) (*signer, *atomic.Int32) {
+	t.Helper()
+	cfg, err := GenerateDualKeys(2, 3)
+	if err != nil {
+		t.Fatalf("GenerateDualKeys: %v", err)
+	}
+	s, err := newSignerWithDualThreshold(*cfg)
+	if err != nil {
+		t.Fatalf("newSignerWithDualThreshold: %v", err)
+	}
+	calls := new(atomic.Int32)
+	for id, sg := range s.blsSigners {
+		s.blsSigners[id] = spyBLSSigner{Signer: sg, calls: calls}
+	}
+	s.blsKeys = nil
+	return s, calls
+}
+
+func TestRingOnlyCertSkipsBLS(t *testing.T) {
+	s, blsCalls := newRingOnlySigner(t)
+
+	c, err := newCertifier(2)
+	if err != nil {
+		t.Fatalf("newCertifier: %v", err)
+	}
+	c.SetSigningMode(RingOnlyMode)
+	c.AddValidator("v0", 1)
+	c.AttachSigner(context.Background(), s)
+
+	block := &Block{ID: [32]byte{1}, ChainID: [32]byte{2}, Height: 7, Timestamp: time.Now()}
+	cert := c.generateCert(block)
+	if cert == nil {
+		t.Fatal("ring-only certifier produced no cert")
+	}
+	if n := blsCalls.Load(); n != 0 {
+		t.Fatalf("BLS signers used %d times in RingOnlyMode", n)
+	}
+	if !bytes.Equal(cert.BLS, make([]byte, bls.SignatureLen)) {
+		t.Fatalf("BLS field = %x, want %d zero bytes", cert.BLS, bls.SignatureLen)
+	}
+	if !cert.IsRingOnly() || cert.HasClassicalFastPath() {
+		t.Fatalf("cert not recognised as ring-only")
+	}
+
+	// Verification succeeds with the classical half absent, including
+	// after a wire round trip.
+	raw, err := cert.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	var decoded QuasarCert
+	if err := decoded.UnmarshalBinary(raw); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	msg := buildBlockMessage(block)
+	if err := decoded.VerifyRingOnly(msg, s.coronaGroupKey); err != nil {
+		t.Fatalf("VerifyRingOnly: %v", err)
+	}
+	if !decoded.VerifyWithRealKeys(msg, nil, s.coronaGroupKey, nil) {
+		t.Fatal("VerifyWithRealKeys rejected ring-only cert without a BLS key")
+	}
+	if err := c.verifyRingOnlyCert(block, &decoded); err != nil {
+		t.Fatalf("verifyRingOnlyCert: %v", err)
+	}
+}
+
+func TestRingOnlyRejectsMixed(t *testing.T) {
+	s, _ := newRingOnlySigner(t)
+
+	c, err := newCertifier(2)
+	if err != nil {
+		t.Fatalf("newCertifier: %v", err)
+	}
+	c.SetSigningMode(RingOnlyMode)
+	c.AttachSigner(context.Background(), s)
+
+	block := &Block{ID: [32]byte{3}, ChainID: [32]byte{4}, Height: 8, Timestamp: time.Now()}
+	cert := c.generateCert(block)
+	if cert == nil {
+		t.Fatal("ring-only certifier produced no cert")
+	}
+	cert.BLS[0] = 1
+	if err := c.verifyRingOnlyCert(block, cert); !errors.Is(err, ErrMixedCertificate) {
+		t.Fatalf("mixed cert: got %v, want ErrMixedCertificate", err)
+	}
+
+	sigs := []*QuasarSig{{Corona: []byte{1}}, {BLS: []byte{9}, Corona: []byte{1}}}
+	if err := CheckRingOnlySigs(sigs); !errors.Is(err, ErrMixedCertificate) {
+		t.Fatalf("mixed sigs: got %v, want ErrMixedCertificate", err)
+	}
+	if err := CheckRingOnlySigs(sigs[:1]); err != nil {
+		t.Fatalf("ring-only sigs: %v", err)
+	}
+}
+
+func TestRingOnlyNoPlaceholder(t *testing.T) {
+	c, err := newCertifier(1)
+	if err != nil {
+		t.Fatalf("newCertifier: %v", err)
+	}
+	c.SetSigningMode(RingOnlyMode)
+	c.AddValidator("v1", 1)
+
+	block := &Block{ID: [32]byte{5}, Height: 9, Timestamp: time.Now()}
+	if cert := c.generateCert(block); cert != nil {
+		t.Fatalf("ring-only certifier without a signer emitted %+v", cert)
+	}
+}
+
+func TestSigningModeFor(t *testing.T) {
+	p := config.DefaultParams()
+	if got := SigningModeFor(p); got != DualMode {
+		t.Fatalf("default params: got %v, want %v", got, DualMode)
+	}
+	p.RingOnly = true
+	if got := SigningModeFor(p); got != RingOnlyMode {
+		t.Fatalf("RingOnly params: got %v, want %v", got, RingOnlyMode)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/mldsa"
+)
+
+// SecurityLevel selects the FIPS 204 ML-DSA parameter set a signer uses
+// for per-validator identity keys. The values are the NIST security
+// categories. The zero value resolves to SecurityLevelMLDSA65, the
+// production identity default.
+type SecurityLevel uint8
+
+const (
+	// SecurityLevelMLDSA44 is NIST category 2: smallest keys and
+	// signatures, fastest signing
+	SecurityLevelMLDSA44 SecurityLevel = 2
+
+	// SecurityLevelMLDSA65 is NIST category 3 (default)
+	SecurityLevelMLDSA65 SecurityLevel = 3
+
+	// SecurityLevelMLDSA87 is NIST category 5: largest keys and signatures
+	SecurityLevelMLDSA87 SecurityLevel = 5
+)
+
+var (
+	// ErrUnknownSecurityLevel is returned for a SecurityLevel outside the
+	// three ML-DSA parameter sets
+	ErrUnknownSecurityLevel = errors.New("quasar: unknown ML-DSA security level")
+
+	// ErrSecurityLevelFixed is returned when changing the level of a signer
+	// that already holds ML-DSA keys
+	ErrSecurityLevelFixed = errors.New("quasar: security level fixed once ML-DSA keys exist")
+)
+
+// resolve maps the zero value to the default level
+func (l SecurityLevel) resolve() SecurityLevel {
+	if l == 0 {
+		return SecurityLevelMLDSA65
+	}
+	return l
+}
+
+// Valid reports whether l names an ML-DSA parameter set
+func (l SecurityLevel) Valid() bool {
+	switch l.resolve() {
+	case SecurityLevelMLDSA44, SecurityLevelMLDSA65, SecurityLevelMLDSA87:
+		return true
+	default:
+		return false
+	}
+}
+
+// Mode returns the ML-DSA parameter set for l
+func (l SecurityLevel) Mode() mldsa.Mode {
+	switch l.resolve() {
+	case SecurityLevelMLDSA44:
+		return mldsa.MLDSA44
+	case SecurityLevelMLDSA87:
+		return mldsa.MLDSA87
+	default:
+		return mldsa.MLDSA65
+	}
+}
+
+// QuorumScheme returns the quorum-certificate scheme for l, so identity
+// signatures produced at l verify as quorum signer records of that scheme
+func (l SecurityLevel) QuorumScheme() QuorumSchemeID {
+	switch l.resolve() {
+	case SecurityLevelMLDSA44:
+		return QuorumSchemeMLDSA44
+	case SecurityLevelMLDSA87:
+		return QuorumSchemeMLDSA87
+	default:
+		return QuorumSchemeMLDSA65
+	}
+}
+
+// SignatureSize returns the ML-DSA signature length at l in bytes
+func (l SecurityLevel) SignatureSize() int {
+	return mldsa.GetSignatureSize(l.Mode())
+}
+
+// String returns the parameter set name
+func (l SecurityLevel) String() string {
+	if !l.Valid() {
+		return fmt.Sprintf("security-level(%d)", uint8(l))
+	}
+	return l.QuorumScheme().String()
+}
+
+// VerifyIdentity verifies an ML-DSA identity signature against a public key
+// encoded at level l. A key or signature from another level fails.
+func (l SecurityLevel) VerifyIdentity(pubKey, message, sig []byte) bool {
+	if !l.Valid() {
+		return false
+	}
+	return mldsaVerifier(l.Mode())(pubKey, message, nil, sig)
+}
+
+// SetSecurityLevel selects the ML-DSA parameter set for identity keys
+// generated by AddValidator. It must be called before any ML-DSA key exists.
+func (s *signer) SetSecurityLevel(level SecurityLevel) error {
+	if !level.Valid() {
+		return fmt.Errorf("%w: %d", ErrUnknownSecurityLevel, uint8(level))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.mldsaKeys) > 0 && level.resolve() != s.level.resolve() {
+		return fmt.Errorf("%w: %s", ErrSecurityLevelFixed, s.level.resolve())
+	}
+	s.level = level.resolve()
+	return nil
+}
+
+// SecurityLevel returns the ML-DSA parameter set of the signer's identity keys
+func (s *signer) SecurityLevel() SecurityLevel {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.level.resolve()
+}
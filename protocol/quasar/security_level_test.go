@@ -0,0 +1,97 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"testing"
+)
+
+var securityLevels = []SecurityLevel{SecurityLevelMLDSA44, SecurityLevelMLDSA65, SecurityLevelMLDSA87}
+
+// identityAt returns a signer at level holding one validator, and that
+// validator's identity signature over msg
+func identityAt(t *testing.T, level SecurityLevel, msg []byte) (*signer, []byte, []byte) {
+	t.Helper()
+	s, err := newSignerWithDualThreshold(SignerConfig{Threshold: 1, TotalParties: 1, SecurityLevel: level})
+	if err != nil {
+		t.Fatalf("%s: new signer: %v", level, err)
+	}
+	if err := s.AddValidator("v0", 1); err != nil {
+		t.Fatalf("%s: add validator: %v", level, err)
+	}
+	sig, err := s.SignMessage("v0", msg)
+	if err != nil {
+		t.Fatalf("%s: sign: %v", level, err)
+	}
+	if !s.VerifyQuasarSig(msg, sig) {
+		t.Fatalf("%s: signature does not verify at its own level", level)
+	}
+	return s, s.validators["v0"].MLDSAPubKey.Bytes(), append([]byte(nil), sig.MLDSA...)
+}
+
+func TestSecurityLevelMatrix(t *testing.T) {
+	msg := []byte("security level")
+
+	pubKeys := make(map[SecurityLevel][]byte)
+	sigs := make(map[SecurityLevel][]byte)
+	prevSize := 0
+	for _, level := range securityLevels {
+		s, pk, sig := identityAt(t, level, msg)
+		if got := s.SecurityLevel(); got != level {
+			t.Fatalf("expected signer at %s, got %s", level, got)
+		}
+		if len(sig) != level.SignatureSize() {
+			t.Fatalf("%s: expected %d-byte signature, got %d", level, level.SignatureSize(), len(sig))
+		}
+		if len(sig) <= prevSize {
+			t.Fatalf("%s: signature size %d does not grow past %d", level, len(sig), prevSize)
+		}
+		prevSize = len(sig)
+		pubKeys[level], sigs[level] = pk, sig
+	}
+
+	for _, verifyAt := range securityLevels {
+		for _, signedAt := range securityLevels {
+			ok := verifyAt.VerifyIdentity(pubKeys[signedAt], msg, sigs[signedAt])
+			if want := verifyAt == signedAt; ok != want {
+				t.Errorf("%s signature verified at %s: got %v, want %v", signedAt, verifyAt, ok, want)
+			}
+		}
+	}
+}
+
+func TestSecurityLevelDefault(t *testing.T) {
+	s, err := newSigner(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.SecurityLevel(); got != SecurityLevelMLDSA65 {
+		t.Fatalf("expected default %s, got %s", SecurityLevelMLDSA65, got)
+	}
+	if got := SecurityLevel(0).QuorumScheme(); got != QuorumSchemeMLDSA65 {
+		t.Fatalf("expected zero level to map to %s, got %s", QuorumSchemeMLDSA65, got)
+	}
+
+	if err := s.SetSecurityLevel(4); !errors.Is(err, ErrUnknownSecurityLevel) {
+		t.Fatalf("expected ErrUnknownSecurityLevel, got %v", err)
+	}
+	if _, err := newSignerWithDualThreshold(SignerConfig{Threshold: 1, SecurityLevel: 1}); !errors.Is(err, ErrUnknownSecurityLevel) {
+		t.Fatalf("expected ErrUnknownSecurityLevel from config, got %v", err)
+	}
+
+	// The level is fixed once identity keys exist
+	if err := s.SetSecurityLevel(SecurityLevelMLDSA44); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddValidator("v0", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSecurityLevel(SecurityLevelMLDSA87); !errors.Is(err, ErrSecurityLevelFixed) {
+		t.Fatalf("expected ErrSecurityLevelFixed, got %v", err)
+	}
+	if err := s.SetSecurityLevel(SecurityLevelMLDSA44); err != nil {
+		t.Fatalf("re-setting the current level: %v", err)
+	}
+}
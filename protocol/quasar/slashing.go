@@ -0,0 +1,201 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// slashing.go — equivocation evidence for slashing.
+//
+// A validator equivocates when it signs two different blocks of the same
+// chain at the same height. The evidence carries both block headers and
+// both signatures, so anyone holding the validator's public keys can
+// recompute the signed hashes and check the signatures without trusting
+// the node that detected it.
+package quasar
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/luxfi/crypto/bls"
+)
+
+// SignedVote is a validator's signature over one source block's quantum
+// hash, with the header fields the hash commits to
+type SignedVote struct {
+	ChainName string
+	BlockID   [32]byte
+	Height    uint64
+	Timestamp time.Time
+	Sig       *QuasarSig
+}
+
+// QuantumHash returns the hash the vote's signature covers
+func (v SignedVote) QuantumHash() string {
+	return quantumHash(v.ChainName, v.BlockID, v.Height, v.Timestamp)
+}
+
+// SlashingEvidence proves that ValidatorID signed two conflicting blocks:
+// different blocks of ChainName at Height. First and Second are ordered by
+// block ID, so the same equivocation always yields the same evidence.
+type SlashingEvidence struct {
+	ValidatorID string
+	ChainName   string
+	Height      uint64
+	First       SignedVote
+	Second      SignedVote
+}
+
+// DetectSlashable scans the signatures accumulated for blockHash, pending
+// or finalized, against those for every other block of the same chain and
+// height, and returns evidence for each validator that signed both. It
+// returns nil for an unknown block or when no validator equivocated.
+func (q *Quasar) DetectSlashable(blockHash string) []SlashingEvidence {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	target, ok := q.pendingBlocks[blockHash]
+	if !ok {
+		if target, ok = q.finalizedBlocks[blockHash]; !ok {
+			return nil
+		}
+	}
+
+	var out []SlashingEvidence
+	scan := func(other *QuantumBlock) {
+		if other.QuantumHash == blockHash {
+			return
+		}
+		for _, a := range target.SourceBlocks {
+			for _, b := range other.SourceBlocks {
+				if a.ChainName != b.ChainName || a.Height != b.Height || a.ID == b.ID {
+					continue
+				}
+				for id, sigA := range target.ValidatorSigs {
+					if sigB, ok := other.ValidatorSigs[id]; ok {
+						out = append(out, newSlashingEvidence(id, a, sigA, b, sigB))
+					}
+				}
+			}
+		}
+	}
+	for _, other := range q.pendingBlocks {
+		scan(other)
+	}
+	for _, other := range q.finalizedBlocks {
+		scan(other)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ValidatorID != out[j].ValidatorID {
+			return out[i].ValidatorID < out[j].ValidatorID
+		}
+		return conflictingID(out[i], blockHash) < conflictingID(out[j], blockHash)
+	})
+	return out
+}
+
+// conflictingID returns the quantum hash of the evidence's vote that is
+// not for blockHash
+func conflictingID(ev SlashingEvidence, blockHash string) string {
+	if h := ev.First.QuantumHash(); h != blockHash {
+		return h
+	}
+	return ev.Second.QuantumHash()
+}
+
+func newSlashingEvidence(validatorID string, a *Block, sigA *QuasarSig, b *Block, sigB *QuasarSig) SlashingEvidence {
+	first, second := signedVote(a, sigA), signedVote(b, sigB)
+	if bytes.Compare(first.BlockID[:], second.BlockID[:]) > 0 {
+		first, second = second, first
+	}
+	return SlashingEvidence{
+		ValidatorID: validatorID,
+		ChainName:   a.ChainName,
+		Height:      a.Height,
+		First:       first,
+		Second:      second,
+	}
+}
+
+// signedVote copies sig, which may come from the signature pool, into a
+// vote for block
+func signedVote(block *Block, sig *QuasarSig) SignedVote {
+	cp := *sig
+	cp.BLS = bytes.Clone(sig.BLS)
+	cp.Corona = bytes.Clone(sig.Corona)
+	cp.Pulsar = bytes.Clone(sig.Pulsar)
+	cp.MLDSA = bytes.Clone(sig.MLDSA)
+	return SignedVote{
+		ChainName: block.ChainName,
+		BlockID:   block.ID,
+		Height:    block.Height,
+		Timestamp: block.Timestamp,
+		Sig:       &cp,
+	}
+}
+
+// Validators returns the public keys of the current validator set, keyed
+// by validator ID, for checking evidence with VerifySlashingEvidence
+func (q *Quasar) Validators() map[string]*Validator {
+	q.signer.mu.RLock()
+	defer q.signer.mu.RUnlock()
+
+	out := make(map[string]*Validator, len(q.signer.validators))
+	for id, v := range q.signer.validators {
+		cp := *v
+		out[id] = &cp
+	}
+	return out
+}
+
+// VerifySlashingEvidence reports whether ev proves that its validator
+// signed two different blocks of the same chain at the same height. Each
+// signature must verify under the validator's own keys in validators: a
+// non-threshold BLS signature under BLSPubKey and an ML-DSA signature
+// under MLDSAPubKey. A vote carrying only a threshold BLS share is not
+// attributable to one validator and does not count.
+func VerifySlashingEvidence(ev *SlashingEvidence, validators map[string]*Validator) bool {
+	if ev == nil {
+		return false
+	}
+	val, ok := validators[ev.ValidatorID]
+	if !ok || val == nil {
+		return false
+	}
+	for _, v := range []SignedVote{ev.First, ev.Second} {
+		if v.ChainName != ev.ChainName || v.Height != ev.Height {
+			return false
+		}
+	}
+	if ev.First.BlockID == ev.Second.BlockID {
+		return false
+	}
+	return verifyVote(ev.ValidatorID, ev.First, val) && verifyVote(ev.ValidatorID, ev.Second, val)
+}
+
+// verifyVote checks that v carries a signature by val, and that every
+// attributable signature it carries verifies
+func verifyVote(validatorID string, v SignedVote, val *Validator) bool {
+	if v.Sig == nil || v.Sig.ValidatorID != validatorID {
+		return false
+	}
+	msg := []byte(v.QuantumHash())
+
+	attributed := false
+	if len(v.Sig.MLDSA) > 0 {
+		if val.MLDSAPubKey == nil || !val.MLDSAPubKey.Verify(msg, v.Sig.MLDSA, nil) {
+			return false
+		}
+		attributed = true
+	}
+	if !v.Sig.IsThreshold {
+		if val.BLSPubKey == nil {
+			return false
+		}
+		blsSig, err := bls.SignatureFromBytes(v.Sig.BLS)
+		if err != nil || !bls.Verify(val.BLSPubKey, blsSig, msg) {
+			return false
+		}
+		attributed = true
+	}
+	return attributed
+}
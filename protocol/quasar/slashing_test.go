@@ -0,0 +1,149 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"testing"
+	"time"
+)
+
+// newSlashingQuasar returns a Quasar with validator1..validator4 that
+// finalizes a block at three votes. Every processed block is self-signed
+// by validator1.
+func newSlashingQuasar(t *testing.T) *Quasar {
+	t.Helper()
+	q, err := NewQuasar(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.InitializeValidators([]string{"validator1", "validator2", "validator3", "validator4"}); err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func slashingBlock(chain string, id byte, height uint64) *Block {
+	return &Block{
+		ChainName: chain,
+		ID:        [32]byte{id},
+		Height:    height,
+		Timestamp: time.Unix(1_700_000_000, 0),
+	}
+}
+
+// vote submits a block and has each validator sign it
+func vote(t *testing.T, q *Quasar, block *Block, validators ...string) string {
+	t.Helper()
+	hash := q.computeQuantumHash(block)
+	q.processBlock(block)
+	for _, id := range validators {
+		sig, err := q.SignMessage(id, []byte(hash))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q.ReceiveVote(hash, id, sig) {
+			t.Fatalf("vote from %s for %x rejected", id, block.ID[:1])
+		}
+	}
+	return hash
+}
+
+// cloneVote deep-copies v so a test can tamper with it
+func cloneVote(v SignedVote) SignedVote {
+	return signedVote(&Block{ChainName: v.ChainName, ID: v.BlockID, Height: v.Height, Timestamp: v.Timestamp}, v.Sig)
+}
+
+func TestDetectSlashableEquivocation(t *testing.T) {
+	q := newSlashingQuasar(t)
+
+	// validator2 signs both blocks at height 10; validator3 signs one
+	a := vote(t, q, slashingBlock("P-Chain", 0xA, 10), "validator2", "validator3")
+	b := vote(t, q, slashingBlock("P-Chain", 0xB, 10), "validator2")
+
+	for _, hash := range []string{a, b} {
+		evidence := q.DetectSlashable(hash)
+		if len(evidence) != 2 {
+			t.Fatalf("got %d pieces of evidence, want 2", len(evidence))
+		}
+		for i, want := range []string{"validator1", "validator2"} {
+			ev := evidence[i]
+			if ev.ValidatorID != want || ev.ChainName != "P-Chain" || ev.Height != 10 {
+				t.Fatalf("evidence %d = %s on %s at %d", i, ev.ValidatorID, ev.ChainName, ev.Height)
+			}
+			if ev.First.BlockID != [32]byte{0xA} || ev.Second.BlockID != [32]byte{0xB} {
+				t.Fatal("evidence votes not ordered by block ID")
+			}
+			if !VerifySlashingEvidence(&ev, q.Validators()) {
+				t.Fatalf("evidence against %s does not verify", want)
+			}
+		}
+	}
+}
+
+func TestVerifySlashingEvidenceRejectsForgery(t *testing.T) {
+	q := newSlashingQuasar(t)
+	vote(t, q, slashingBlock("X-Chain", 1, 7), "validator2")
+	hash := vote(t, q, slashingBlock("X-Chain", 2, 7), "validator2")
+
+	evidence := q.DetectSlashable(hash)
+	if len(evidence) != 2 {
+		t.Fatalf("got %d pieces of evidence, want 2", len(evidence))
+	}
+	validators := q.Validators()
+
+	tests := map[string]func(ev *SlashingEvidence){
+		"blamed on another validator": func(ev *SlashingEvidence) {
+			ev.ValidatorID = "validator3"
+			ev.First.Sig.ValidatorID = "validator3"
+			ev.Second.Sig.ValidatorID = "validator3"
+		},
+		"different heights": func(ev *SlashingEvidence) { ev.Second.Height = 8 },
+		"same block twice":  func(ev *SlashingEvidence) { ev.Second = ev.First },
+		"altered header":    func(ev *SlashingEvidence) { ev.Second.BlockID = [32]byte{3} },
+		"altered signature": func(ev *SlashingEvidence) { ev.First.Sig.BLS[0] ^= 1 },
+		"missing signature": func(ev *SlashingEvidence) { ev.Second.Sig = nil },
+	}
+	for name, tamper := range tests {
+		ev := evidence[1]
+		ev.First, ev.Second = cloneVote(ev.First), cloneVote(ev.Second)
+		tamper(&ev)
+		if VerifySlashingEvidence(&ev, validators) {
+			t.Errorf("%s: forged evidence verified", name)
+		}
+	}
+
+	// Evidence checked against another network's keys does not verify
+	other := newSlashingQuasar(t)
+	if VerifySlashingEvidence(&evidence[1], other.Validators()) {
+		t.Error("evidence verified under unrelated validator keys")
+	}
+	if VerifySlashingEvidence(nil, validators) {
+		t.Error("nil evidence verified")
+	}
+}
+
+func TestDetectSlashableHonest(t *testing.T) {
+	q := newSlashingQuasar(t)
+
+	// Every validator signs one block per chain and height
+	hashes := []string{
+		vote(t, q, slashingBlock("C-Chain", 1, 20), "validator2", "validator3"),
+		vote(t, q, slashingBlock("C-Chain", 2, 21), "validator2"),
+		vote(t, q, slashingBlock("X-Chain", 3, 20), "validator2"),
+	}
+	// The same block resubmitted is not a conflict
+	resubmitted := slashingBlock("C-Chain", 2, 21)
+	resubmitted.Timestamp = resubmitted.Timestamp.Add(time.Minute)
+	hashes = append(hashes, vote(t, q, resubmitted, "validator3"))
+
+	for _, hash := range hashes {
+		if evidence := q.DetectSlashable(hash); len(evidence) != 0 {
+			t.Fatalf("honest validators produced %d pieces of evidence, first against %s",
+				len(evidence), evidence[0].ValidatorID)
+		}
+	}
+	if q.DetectSlashable("unknown") != nil {
+		t.Fatal("evidence for an unknown block")
+	}
+}
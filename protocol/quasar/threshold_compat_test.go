@@ -317,7 +317,7 @@ func TestQuasarEngineBasic(t *testing.T) {
 		Data:      []byte("test data"),
 	}
 
-	err = engine.Submit(block)
+	err = engine.Submit(context.Background(), block)
 	require.NoError(err)
 
 	// Wait for finalization
@@ -364,7 +364,7 @@ func TestQuasarEngineMultipleBlocks(t *testing.T) {
 			Height:    uint64(i + 1),
 			Timestamp: time.Now(),
 		}
-		err = engine.Submit(block)
+		err = engine.Submit(context.Background(), block)
 		require.NoError(err)
 	}
 
@@ -616,7 +616,7 @@ func TestQuasarThroughput(t *testing.T) {
 			Height:    uint64(i + 1),
 			Timestamp: time.Now(),
 		}
-		engine.Submit(block)
+		engine.Submit(context.Background(), block)
 	}
 
 	// Drain finalized channel
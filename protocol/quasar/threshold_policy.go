@@ -0,0 +1,125 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsafeQuorumChange is returned under ThresholdReject when a validator
+// set change or threshold update would leave the threshold outside
+// [SafetyFloor(n), n].
+var ErrUnsafeQuorumChange = errors.New("quasar: quorum change leaves threshold outside [safety floor, validator count]")
+
+// ThresholdPolicy selects what the Certifier does when a validator set
+// change would move its threshold outside [SafetyFloor(n), n].
+type ThresholdPolicy uint8
+
+const (
+	// ThresholdAdjust keeps the configured threshold and clamps the
+	// effective threshold into [SafetyFloor(n), n] on every change. The
+	// effective value is a pure function of the configured threshold and
+	// n, so every node computes the same one. This is the default.
+	ThresholdAdjust ThresholdPolicy = iota
+
+	// ThresholdReject refuses any change that would leave the configured
+	// threshold outside [SafetyFloor(n), n]. Adds are allowed while the set
+	// is still smaller than the threshold, so the set can be bootstrapped;
+	// raise the threshold with SetThreshold before growing past its floor.
+	ThresholdReject
+)
+
+// SafetyFloor is the smallest threshold of n validators that tolerates
+// f = (n-1)/3 Byzantine faults: 2n/3+1, so two quorums always overlap in an
+// honest validator.
+func SafetyFloor(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return 2*n/3 + 1
+}
+
+// clampThreshold returns t clamped into [SafetyFloor(n), n]
+func clampThreshold(t, n int) int {
+	if n <= 0 {
+		return t
+	}
+	if floor := SafetyFloor(n); t < floor {
+		t = floor
+	}
+	if t > n {
+		t = n
+	}
+	return t
+}
+
+// SetThresholdPolicy selects how validator set changes treat the threshold
+func (h *Certifier) SetThresholdPolicy(p ThresholdPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.thresholdPolicy = p
+	h.syncSignerLocked()
+}
+
+// SetThreshold changes the configured threshold. Under ThresholdReject it
+// must lie within [SafetyFloor(n), n] for the current set.
+func (h *Certifier) SetThreshold(t int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if t < 1 {
+		return fmt.Errorf("%w: threshold %d < 1", ErrUnsafeQuorumChange, t)
+	}
+	n := len(h.validators)
+	if h.thresholdPolicy == ThresholdReject && n > 0 && clampThreshold(t, n) != t {
+		return fmt.Errorf("%w: threshold %d with %d validators (floor %d)", ErrUnsafeQuorumChange, t, n, SafetyFloor(n))
+	}
+	h.threshold = t
+	h.syncSignerLocked()
+	return nil
+}
+
+// Threshold returns the effective threshold for the current validator set.
+// Under ThresholdAdjust this is the configured threshold clamped into
+// [SafetyFloor(n), n]; under ThresholdReject it is the configured value.
+func (h *Certifier) Threshold() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.thresholdLocked()
+}
+
+// thresholdLocked returns Threshold
+// Must be called with h.mu held
+func (h *Certifier) thresholdLocked() int {
+	if h.thresholdPolicy == ThresholdReject {
+		return h.threshold
+	}
+	return clampThreshold(h.threshold, len(h.validators))
+}
+
+// syncSignerLocked makes the attached signer require the effective
+// threshold when it aggregates and verifies signatures
+// Must be called with h.mu held
+func (h *Certifier) syncSignerLocked() {
+	if h.signer != nil {
+		h.signer.setQuorum(h.thresholdLocked())
+	}
+}
+
+// checkQuorumChange validates moving to n validators under the policy
+// Must be called with h.mu held
+func (h *Certifier) checkQuorumChange(n int) error {
+	if h.thresholdPolicy != ThresholdReject {
+		return nil
+	}
+	t := h.threshold
+	switch {
+	case n < len(h.validators) && t > n:
+		return fmt.Errorf("%w: threshold %d exceeds %d validators", ErrUnsafeQuorumChange, t, n)
+	case n > len(h.validators) && n >= t && t < SafetyFloor(n):
+		return fmt.Errorf("%w: threshold %d below safety floor %d of %d validators", ErrUnsafeQuorumChange, t, SafetyFloor(n), n)
+	}
+	return nil
+}
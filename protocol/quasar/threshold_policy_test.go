@@ -0,0 +1,162 @@
+// Copyright (C) 2025-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quasar
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSafetyFloor(t *testing.T) {
+	for n, want := range map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 7: 5, 10: 7, 100: 67} {
+		if got := SafetyFloor(n); got != want {
+			t.Errorf("SafetyFloor(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+// addValidators adds v0..v(n-1), failing the test on error
+func addValidators(t *testing.T, hc *Certifier, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := hc.AddValidator(fmt.Sprintf("v%d", i), 1); err != nil {
+			t.Fatalf("add v%d: %v", i, err)
+		}
+	}
+}
+
+func TestQuorumChangeRejectRemoveBelowThreshold(t *testing.T) {
+	hc, _ := newCertifier(3)
+	hc.SetThresholdPolicy(ThresholdReject)
+	addValidators(t, hc, 4)
+
+	if err := hc.RemoveValidator("v0"); err != nil {
+		t.Fatalf("remove to n=3 with threshold 3: %v", err)
+	}
+	if err := hc.RemoveValidator("v1"); !errors.Is(err, ErrUnsafeQuorumChange) {
+		t.Fatalf("expected ErrUnsafeQuorumChange removing below threshold, got %v", err)
+	}
+	if got := hc.validatorCount(); got != 3 {
+		t.Fatalf("rejected removal changed the set: %d validators", got)
+	}
+	if got := hc.Threshold(); got != 3 {
+		t.Fatalf("expected threshold 3, got %d", got)
+	}
+
+	// Removing an unknown validator is not a quorum change
+	if err := hc.RemoveValidator("missing"); err != nil {
+		t.Fatalf("remove unknown validator: %v", err)
+	}
+}
+
+func TestQuorumChangeRejectAddBelowFloor(t *testing.T) {
+	hc, _ := newCertifier(3)
+	hc.SetThresholdPolicy(ThresholdReject)
+
+	// Bootstrap up to and past the threshold while it is still safe
+	addValidators(t, hc, 4)
+
+	// A fifth validator raises the floor to 4
+	if err := hc.AddValidator("v4", 1); !errors.Is(err, ErrUnsafeQuorumChange) {
+		t.Fatalf("expected ErrUnsafeQuorumChange adding past the floor, got %v", err)
+	}
+	if err := hc.SetThreshold(2); !errors.Is(err, ErrUnsafeQuorumChange) {
+		t.Fatalf("expected ErrUnsafeQuorumChange lowering threshold below floor, got %v", err)
+	}
+	if err := hc.SetThreshold(4); err != nil {
+		t.Fatalf("raise threshold: %v", err)
+	}
+	if err := hc.AddValidator("v4", 1); err != nil {
+		t.Fatalf("add after raising threshold: %v", err)
+	}
+
+	// Re-adding an existing validator only updates its weight
+	if err := hc.AddValidator("v4", 2); err != nil {
+		t.Fatalf("re-add existing validator: %v", err)
+	}
+}
+
+func TestQuorumChangeAdjust(t *testing.T) {
+	hc, _ := newCertifier(3)
+	addValidators(t, hc, 4)
+	if got := hc.Threshold(); got != 3 {
+		t.Fatalf("n=4: expected threshold 3, got %d", got)
+	}
+
+	// Removals below the configured threshold lower it to n
+	for _, tc := range []struct {
+		remove string
+		want   int
+	}{
+		{"v0", 3},
+		{"v1", 2},
+		{"v2", 1},
+	} {
+		if err := hc.RemoveValidator(tc.remove); err != nil {
+			t.Fatalf("remove %s: %v", tc.remove, err)
+		}
+		if got := hc.Threshold(); got != tc.want {
+			t.Fatalf("after removing %s: expected threshold %d, got %d", tc.remove, tc.want, got)
+		}
+	}
+
+	// Growth raises it to the safety floor, and the configured value
+	// returns once the set is large enough again
+	addValidators(t, hc, 10)
+	if got, want := hc.Threshold(), SafetyFloor(10); got != want {
+		t.Fatalf("n=10: expected threshold %d, got %d", want, got)
+	}
+}
+
+func TestAdjustedThresholdReachesSigner(t *testing.T) {
+	s, err := newSigner(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("adjusted-threshold")
+	var sigs []*QuasarSig
+	for i := 0; i < 4; i++ {
+		id := fmt.Sprintf("v%d", i)
+		if err := s.AddValidator(id, 1); err != nil {
+			t.Fatal(err)
+		}
+		sig, err := s.SignMessage(id, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	// Four validators clamp the configured threshold of 1 up to 3, and the
+	// attached signer enforces it
+	hc, _ := newCertifier(1)
+	hc.AttachSigner(nil, s)
+	addValidators(t, hc, 4)
+	if got := s.Quorum(); got != 3 {
+		t.Fatalf("signer quorum = %d, want 3", got)
+	}
+	if _, err := s.AggregateSignatures(msg, sigs[:2]); err == nil {
+		t.Fatal("aggregated 2 signatures below the adjusted threshold")
+	}
+	agg, err := s.AggregateSignatures(msg, sigs[:3])
+	if err != nil {
+		t.Fatalf("aggregate at the adjusted threshold: %v", err)
+	}
+	if !s.VerifyAggregatedSignature(msg, agg) {
+		t.Fatal("aggregate at the adjusted threshold did not verify")
+	}
+	agg.SignerCount = 2
+	if s.VerifyAggregatedSignature(msg, agg) {
+		t.Fatal("verified an aggregate claiming fewer signers than the threshold")
+	}
+
+	// Raising the configured threshold above the set clamps it to n
+	if err := hc.SetThreshold(9); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Quorum(); got != 4 {
+		t.Fatalf("signer quorum = %d, want 4", got)
+	}
+}
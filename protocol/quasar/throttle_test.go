@@ -0,0 +1,208 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+
+package quasar
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+)
+
+func TestSubmitWaitsAtOptimalProcessing(t *testing.T) {
+	eng, err := NewTestEngine(Config{QThreshold: 1, OptimalProcessing: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := eng.(*quasarEngine)
+
+	// Not started: nothing drains, so the fifth Submit must wait
+	for i := 0; i < 4; i++ {
+		if err := q.Submit(context.Background(), &Block{ID: [32]byte{byte(i)}, Height: uint64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := q.Processing(); got != 4 {
+		t.Fatalf("processing = %d, want 4", got)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- q.Submit(context.Background(), &Block{ID: [32]byte{4}, Height: 4}) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Submit returned %v above OptimalProcessing", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := q.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Submit still throttled after the engine drained")
+	}
+}
+
+func TestSubmitPacedUnderHighIngest(t *testing.T) {
+	const (
+		optimal   = 4
+		producers = 8
+		perProd   = 50
+	)
+	eng, err := NewTestEngine(Config{QThreshold: 1, OptimalProcessing: optimal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := eng.(*quasarEngine)
+	if err := q.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Stop()
+
+	var peak atomic.Int64
+	stop := make(chan struct{})
+	sampled := make(chan struct{})
+	go func() {
+		defer close(sampled)
+		for {
+			if n := int64(q.Stats().Processing); n > peak.Load() {
+				peak.Store(n)
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProd; i++ {
+				if err := q.Submit(context.Background(), &Block{ID: [32]byte{byte(p), byte(i)}, Height: uint64(p*perProd + i)}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for q.Processing() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	<-sampled
+
+	if got := q.Processing(); got != 0 {
+		t.Fatalf("processing = %d after drain, want 0", got)
+	}
+	if got := q.Stats().ProcessedBlocks; got != producers*perProd {
+		t.Fatalf("processed %d blocks, want %d", got, producers*perProd)
+	}
+	if p := peak.Load(); p > optimal {
+		t.Fatalf("processing peaked at %d, above OptimalProcessing %d", p, optimal)
+	}
+}
+
+func TestSubmitUnblocksOnStop(t *testing.T) {
+	eng, err := NewTestEngine(Config{QThreshold: 1, OptimalProcessing: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := eng.(*quasarEngine)
+	if err := q.Submit(context.Background(), &Block{ID: [32]byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- q.Submit(context.Background(), &Block{ID: [32]byte{2}}) }()
+	if err := q.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; !errors.Is(err, ErrEngineStopped) {
+		t.Fatalf("expected ErrEngineStopped, got %v", err)
+	}
+}
+
+func TestSubmitHonorsContext(t *testing.T) {
+	eng, err := NewTestEngine(Config{QThreshold: 1, OptimalProcessing: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := eng.(*quasarEngine)
+	if err := q.Submit(context.Background(), &Block{ID: [32]byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.Submit(ctx, &Block{ID: [32]byte{2}}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if got := q.Processing(); got != 1 {
+		t.Fatalf("processing = %d after cancelled Submit, want 1", got)
+	}
+}
+
+func TestEngineRestartsAfterStop(t *testing.T) {
+	eng, err := NewTestEngine(Config{QThreshold: 1, OptimalProcessing: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := eng.(*quasarEngine)
+	ctx := context.Background()
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Submit(ctx, &Block{ID: [32]byte{1}, Height: 1}); !errors.Is(err, ErrEngineStopped) {
+		t.Fatalf("Submit on stopped engine: %v, want ErrEngineStopped", err)
+	}
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	defer q.Stop()
+	if err := q.Submit(ctx, &Block{ID: [32]byte{2}, Height: 2}); err != nil {
+		t.Fatalf("Submit after restart: %v", err)
+	}
+	select {
+	case b := <-q.Finalized():
+		if b.Height != 2 {
+			t.Fatalf("finalized height %d, want 2", b.Height)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("restarted engine did not process the block")
+	}
+}
+
+func TestConfigFor(t *testing.T) {
+	cfg := ConfigFor(config.Parameters{OptimalProcessing: 8, RingOnly: true})
+	if cfg.OptimalProcessing != 8 {
+		t.Fatalf("OptimalProcessing = %d, want 8", cfg.OptimalProcessing)
+	}
+	if cfg.SigningMode != RingOnlyMode {
+		t.Fatalf("SigningMode = %v, want %v", cfg.SigningMode, RingOnlyMode)
+	}
+	if cfg.QThreshold != DefaultConfig.QThreshold {
+		t.Fatalf("QThreshold = %d, want default %d", cfg.QThreshold, DefaultConfig.QThreshold)
+	}
+}
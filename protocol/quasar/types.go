@@ -94,12 +94,13 @@ func (c *QuasarCert) IsDoubleLattice() bool {
 
 // HasClassicalFastPath reports whether the cert carries BLS bytes — the
 // optional classical aggregate that rides alongside the PQ surface on
-// chains that opt into the hybrid posture. Empty in pure-PQ mode.
+// chains that opt into the hybrid posture. Empty in pure-PQ mode and
+// zero-filled in RingOnlyMode.
 func (c *QuasarCert) HasClassicalFastPath() bool {
 	if c == nil {
 		return false
 	}
-	return len(c.BLS) > 0
+	return !isZeroBLS(c.BLS)
 }
 
 // HasIdentityRollup reports whether the cert carries the
@@ -516,8 +517,9 @@ type Engine interface {
 	// Stop gracefully shuts down the consensus engine
 	Stop() error
 
-	// Submit adds a block to the consensus pipeline
-	Submit(block *Block) error
+	// Submit adds a block to the consensus pipeline, waiting for a
+	// processing slot until ctx is done when the engine is throttled
+	Submit(ctx context.Context, block *Block) error
 
 	// Finalized returns a channel of finalized blocks
 	Finalized() <-chan *Block
@@ -527,6 +529,10 @@ type Engine interface {
 
 	// Stats returns consensus metrics
 	Stats() Stats
+
+	// HealthCheck reports whether the engine is running, whether signing
+	// keys are attached, and the last finalized height
+	HealthCheck(ctx context.Context) (interface{}, error)
 }
 
 // Stats contains consensus metrics.
@@ -535,6 +541,7 @@ type Stats struct {
 	ProcessedBlocks uint64        // Total blocks processed
 	FinalizedBlocks uint64        // Total blocks finalized
 	PendingBlocks   int           // Blocks awaiting finality
+	Processing      int           // Blocks submitted but not yet processed
 	Validators      int           // Active validator count
 	Uptime          time.Duration // Time since start
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/luxfi/consensus/config"
 	"github.com/luxfi/consensus/core/types"
 	"github.com/luxfi/consensus/protocol/prism"
 	"github.com/luxfi/consensus/protocol/wave"
@@ -34,10 +35,38 @@ type Config struct {
 	Beta     uint32
 	RoundTO  time.Duration
 	MaxBatch int
+
+	// RoundTOBackoff, when set, grows the round timeout while rounds fail
+	// to reach quorum; nil keeps RoundTO fixed.
+	RoundTOBackoff *wave.RoundTOBackoff
+
+	// ConcurrentRepolls is how many fresh committees are polled in parallel
+	// after a round fails to reach quorum (0 = no re-polling)
+	ConcurrentRepolls int
+
+	// ConcurrentPolls caps the polls in flight across all items
+	// (0 = no limit)
+	ConcurrentPolls int
+
+	// MinRoundInterval is the minimum gap between polls of the same item
+	// (0 = no limit)
+	MinRoundInterval time.Duration
+
+	// MaxItemProcessingTime bounds how long an item may stay undecided
+	// before it is timed out (0 = no limit)
+	MaxItemProcessingTime time.Duration
+
+	// MaxOutstandingItems caps how many items may be undecided at once;
+	// new items beyond it are not polled until some decide (0 = no limit)
+	MaxOutstandingItems int
+
+	// Clock times rounds and deadlines (nil = wave.SystemClock)
+	Clock wave.Clock
 }
 
 type Driver[T ID] struct {
 	wv            *wave.Wave[T]
+	timer         *wave.RoundTimer
 	cut           prism.Cut[T]
 	tx            Transport[T]
 	src           Source[T]
@@ -48,6 +77,22 @@ type Driver[T ID] struct {
 	hasPreference bool
 }
 
+// ConfigFor returns a Config with the sampling, polling and admission
+// settings of the parameters
+func ConfigFor(p config.Parameters) Config {
+	return Config{
+		PollSize:              p.K,
+		Alpha:                 p.Alpha,
+		Beta:                  p.Beta,
+		RoundTO:               p.RoundTO,
+		ConcurrentRepolls:     p.ConcurrentRepolls,
+		ConcurrentPolls:       p.ConcurrentPolls,
+		MinRoundInterval:      p.MinRoundInterval,
+		MaxItemProcessingTime: p.MaxItemProcessingTime,
+		MaxOutstandingItems:   p.MaxOutstandingItems,
+	}
+}
+
 func NewDriver[T ID](cfg Config, cut prism.Cut[T], tx Transport[T], src Source[T], out Sink[T]) *Driver[T] {
 	if cfg.PollSize == 0 {
 		cfg.PollSize = 20
@@ -65,10 +110,22 @@ func NewDriver[T ID](cfg Config, cut prism.Cut[T], tx Transport[T], src Source[T
 		cfg.MaxBatch = 64
 	}
 
-	wvVal, _ := wave.New[T](wave.Config{K: cfg.PollSize, Alpha: cfg.Alpha, Beta: cfg.Beta, RoundTO: cfg.RoundTO}, cut, tx)
+	wvVal, _ := wave.New[T](wave.Config{
+		K:                     cfg.PollSize,
+		Alpha:                 cfg.Alpha,
+		Beta:                  cfg.Beta,
+		RoundTO:               cfg.RoundTO,
+		ConcurrentRepolls:     cfg.ConcurrentRepolls,
+		ConcurrentPolls:       cfg.ConcurrentPolls,
+		MinRoundInterval:      cfg.MinRoundInterval,
+		MaxItemProcessingTime: cfg.MaxItemProcessingTime,
+		MaxOutstandingItems:   cfg.MaxOutstandingItems,
+		Clock:                 cfg.Clock,
+	}, cut, tx)
 	return &Driver[T]{
-		wv:  &wvVal,
-		cut: cut, tx: tx, src: src, out: out, cfg: cfg,
+		wv:    &wvVal,
+		timer: wave.NewRoundTimer(cfg.RoundTO, cfg.RoundTOBackoff),
+		cut:   cut, tx: tx, src: src, out: out, cfg: cfg,
 		height:        0,
 		hasPreference: false,
 	}
@@ -83,14 +140,21 @@ func (d *Driver[T]) Tick(ctx context.Context) error {
 	}
 
 	var decided []T
+	quorum := false
+	roundTO := d.timer.Timeout()
 	for _, it := range items {
-		d.wv.Tick(ctx, it)
+		if d.wv.TickTimeout(ctx, it, roundTO) {
+			quorum = true
+		}
 		if st, ok := d.wv.State(it); ok && st.Decided {
 			if st.Result == types.DecideAccept {
 				decided = append(decided, it)
 			}
 		}
 	}
+	if ctx.Err() == nil {
+		d.timer.Observe(quorum)
+	}
 	if len(decided) > 0 {
 		if len(decided) > 0 {
 			d.preference = decided[0] // Update preference to latest decided
@@ -135,6 +199,16 @@ func (d *Driver[T]) IsFinalized(item T) bool {
 	return false
 }
 
+// RoundTimeout returns the timeout the next round will wait for votes
+func (d *Driver[T]) RoundTimeout() time.Duration {
+	return d.timer.Timeout()
+}
+
+// Timeouts delivers items that exceeded MaxItemProcessingTime undecided
+func (d *Driver[T]) Timeouts() <-chan T {
+	return d.wv.Timeouts()
+}
+
 // Height returns the current consensus height (for linear chains)
 func (d *Driver[T]) Height() uint64 {
 	return d.height
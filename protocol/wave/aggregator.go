@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import "github.com/luxfi/consensus/core/types"
+
+// Tally is the raw result of one poll handed to an Aggregator
+type Tally struct {
+	Yes   int // votes preferring the item
+	No    int // votes against the item
+	K     int // sample size of the round
+	Phase uint64
+
+	// Threshold is the round's vote threshold: the FPC threshold for Phase
+	// when FPC is enabled, K*Alpha otherwise
+	Threshold int
+}
+
+// Aggregator turns a poll tally into the round's (preferOK, confOK) pair.
+// confOK reports whether the committee reached quorum; if it did, preferOK
+// is the direction it reached it in (true = for the item). When confOK is
+// false the round resets the item's confidence and preferOK is ignored.
+// Aggregate must be deterministic and safe for concurrent use.
+type Aggregator interface {
+	Aggregate(t Tally) (preferOK, confOK bool)
+}
+
+// AggregatorFunc adapts a function to Aggregator
+type AggregatorFunc func(t Tally) (preferOK, confOK bool)
+
+// Aggregate calls f
+func (f AggregatorFunc) Aggregate(t Tally) (preferOK, confOK bool) {
+	return f(t)
+}
+
+// ThresholdAggregator is the default Aggregator: a side reaches quorum when
+// its votes reach the round threshold, yes taking precedence.
+type ThresholdAggregator struct{}
+
+// Aggregate compares each side's votes against t.Threshold
+func (ThresholdAggregator) Aggregate(t Tally) (preferOK, confOK bool) {
+	switch {
+	case t.Yes >= t.Threshold:
+		return true, true
+	case t.No >= t.Threshold:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// Option configures a Wave
+type Option func(*options)
+
+type options struct {
+	aggregator Aggregator
+	verifier   CommitteeVerifier
+	alpha      *AlphaController
+	selector   ThresholdSelector
+}
+
+// WithAggregator replaces the default ThresholdAggregator
+func WithAggregator(agg Aggregator) Option {
+	return func(o *options) {
+		o.aggregator = agg
+	}
+}
+
+// CommitteeVerifier checks a sampled committee before wave polls it, e.g.
+// against a photon.CommitRevealEmitter commitment
+type CommitteeVerifier interface {
+	VerifyCommittee(committee []types.NodeID) error
+}
+
+// WithCommitteeVerifier makes every poll verify its committee first. A
+// committee that fails verification is not polled and its round does not
+// count.
+func WithCommitteeVerifier(v CommitteeVerifier) Option {
+	return func(o *options) {
+		o.verifier = v
+	}
+}
+
+// aggregate runs the configured aggregator over one poll
+func (w *Wave[T]) aggregate(yesVotes, totalVotes, threshold int, phase uint64) (preferOK, confOK bool) {
+	return w.agg.Aggregate(Tally{
+		Yes:       yesVotes,
+		No:        totalVotes - yesVotes,
+		K:         w.k(),
+		Phase:     phase,
+		Threshold: threshold,
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// trimmedCount ignores the two most extreme voters, one from each side,
+// before comparing against the threshold
+var trimmedCount = AggregatorFunc(func(t Tally) (preferOK, confOK bool) {
+	if t.Yes > 0 && t.No > 0 {
+		t.Yes--
+		t.No--
+	} else if t.Yes > 1 {
+		t.Yes -= 2
+	} else if t.No > 1 {
+		t.No -= 2
+	} else {
+		return false, false
+	}
+	return ThresholdAggregator{}.Aggregate(t)
+})
+
+func newAggregatorWave(t *testing.T, opts ...Option) *Wave[string] {
+	cfg := Config{K: 10, Alpha: 0.7, Beta: 2, RoundTO: time.Second}
+	w, err := New[string](cfg, newMockCut[string](10), newMockTransport[string](), opts...)
+	require.NoError(t, err)
+	return &w
+}
+
+func TestThresholdAggregator(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		yes, no      int
+		prefer, conf bool
+	}{
+		{yes: 7, no: 3, prefer: true, conf: true},
+		{yes: 3, no: 7, prefer: false, conf: true},
+		{yes: 6, no: 4, prefer: false, conf: false},
+		{yes: 0, no: 0, prefer: false, conf: false},
+	} {
+		prefer, conf := ThresholdAggregator{}.Aggregate(Tally{Yes: tc.yes, No: tc.no, K: 10, Threshold: 7})
+		require.Equal(tc.prefer, prefer, "%d/%d", tc.yes, tc.no)
+		require.Equal(tc.conf, conf, "%d/%d", tc.yes, tc.no)
+	}
+}
+
+func TestDefaultAggregatorUnchanged(t *testing.T) {
+	require := require.New(t)
+
+	// The default and an explicit ThresholdAggregator decide identically
+	polls := []PollResult{{Yes: 7, Total: 10}, {Yes: 6, Total: 10}, {Yes: 8, Total: 10}, {Yes: 9, Total: 10}}
+	def := newAggregatorWave(t)
+	explicit := newAggregatorWave(t, WithAggregator(ThresholdAggregator{}))
+	for _, p := range polls {
+		require.Equal(def.RecordPoll("a", p.Yes, p.Total), explicit.RecordPoll("a", p.Yes, p.Total))
+	}
+	s1, _ := def.State("a")
+	s2, _ := explicit.State("a")
+	require.Equal(*s1, *s2)
+	require.True(s1.Decided)
+}
+
+func TestTrimmedCountAggregator(t *testing.T) {
+	require := require.New(t)
+
+	def := newAggregatorWave(t)
+	trimmed := newAggregatorWave(t, WithAggregator(trimmedCount))
+
+	// 7 of 10 clears the threshold of 7 only if the outliers are counted
+	for i := 0; i < 2; i++ {
+		def.RecordPoll("a", 7, 10)
+		trimmed.RecordPoll("a", 7, 10)
+	}
+	s, _ := def.State("a")
+	require.True(s.Decided)
+	require.Equal(uint32(2), s.Count)
+
+	s, _ = trimmed.State("a")
+	require.False(s.Decided)
+	require.Zero(s.Count)
+
+	// A wider margin survives the trim
+	require.False(trimmed.RecordPoll("b", 9, 10))
+	require.True(trimmed.RecordPoll("b", 9, 10))
+	require.True(trimmed.Preference("b"))
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ErrInvalidAlphaBounds is returned by NewAlphaController for bounds
+// outside (0.5, 1] or with Min above Max
+var ErrInvalidAlphaBounds = errors.New("wave: invalid alpha bounds")
+
+// Defaults for AlphaControllerConfig
+const (
+	DefaultAlphaWindow     = 20
+	DefaultAlphaStep       = 0.01
+	DefaultAlphaRaiseAbove = 0.95
+	DefaultAlphaLowerBelow = 0.8
+)
+
+// alphaScale is the fixed-point resolution of the controller. Alpha and
+// rates are kept in thousandths so every platform computes the same value
+// from the same history.
+const alphaScale = 1000
+
+// AlphaControllerConfig bounds and paces an AlphaController
+type AlphaControllerConfig struct {
+	Initial float64 // starting alpha, clamped to [Min, Max] (0 = Max)
+	Min     float64 // lowest alpha, must exceed 0.5
+	Max     float64 // highest alpha, at most 1
+
+	// Window is how many round outcomes make up one adjustment
+	// (0 = DefaultAlphaWindow)
+	Window int
+
+	// Step is how far alpha moves per adjustment (0 = DefaultAlphaStep)
+	Step float64
+
+	// RaiseAbove is the window success rate at or above which alpha rises
+	// (0 = DefaultAlphaRaiseAbove); LowerBelow is the rate below which it
+	// falls (0 = DefaultAlphaLowerBelow)
+	RaiseAbove float64
+	LowerBelow float64
+}
+
+// AlphaController adjusts wave's alpha from the success rate of recent
+// rounds: a window in which nearly every round reached quorum raises alpha
+// toward Max, one in which many failed lowers it toward Min to keep the
+// network live. Alpha is a pure function of the sequence of outcomes
+// observed, so nodes must feed it the same shared outcomes (e.g. those
+// recorded with each finalized block) rather than their local rounds.
+type AlphaController struct {
+	min, max, step int // thousandths
+	raise, lower   int // thousandths
+	window         int
+
+	mu                sync.Mutex
+	alpha             int // thousandths
+	rounds, successes int // in the current window
+}
+
+// NewAlphaController returns a controller starting at cfg.Initial
+func NewAlphaController(cfg AlphaControllerConfig) (*AlphaController, error) {
+	if cfg.Min <= 0.5 || cfg.Max > 1 || cfg.Min > cfg.Max {
+		return nil, fmt.Errorf("%w: [%v, %v]", ErrInvalidAlphaBounds, cfg.Min, cfg.Max)
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultAlphaWindow
+	}
+	if cfg.Step <= 0 {
+		cfg.Step = DefaultAlphaStep
+	}
+	if cfg.RaiseAbove <= 0 {
+		cfg.RaiseAbove = DefaultAlphaRaiseAbove
+	}
+	if cfg.LowerBelow <= 0 {
+		cfg.LowerBelow = DefaultAlphaLowerBelow
+	}
+	if cfg.Initial == 0 {
+		cfg.Initial = cfg.Max
+	}
+
+	c := &AlphaController{
+		min:    fixed(cfg.Min),
+		max:    fixed(cfg.Max),
+		step:   max(fixed(cfg.Step), 1),
+		raise:  fixed(cfg.RaiseAbove),
+		lower:  fixed(cfg.LowerBelow),
+		window: cfg.Window,
+	}
+	c.alpha = min(max(fixed(cfg.Initial), c.min), c.max)
+	return c, nil
+}
+
+// fixed converts x to thousandths
+func fixed(x float64) int {
+	return int(math.Round(x * alphaScale))
+}
+
+// Observe records whether one round reached quorum. Every Window outcomes
+// alpha is raised or lowered by Step according to the window's success
+// rate, staying within [Min, Max].
+func (c *AlphaController) Observe(success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rounds++
+	if success {
+		c.successes++
+	}
+	if c.rounds < c.window {
+		return
+	}
+
+	// Compare successes/window with the rates in integer arithmetic
+	rate := c.successes * alphaScale
+	switch {
+	case rate >= c.raise*c.window:
+		c.alpha = min(c.alpha+c.step, c.max)
+	case rate < c.lower*c.window:
+		c.alpha = max(c.alpha-c.step, c.min)
+	}
+	c.rounds, c.successes = 0, 0
+}
+
+// Alpha returns the current alpha
+func (c *AlphaController) Alpha() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return float64(c.alpha) / alphaScale
+}
+
+// WithAlphaController makes wave take its threshold ratio from c instead of
+// Config.Alpha. It has no effect when FPC is enabled.
+func WithAlphaController(c *AlphaController) Option {
+	return func(o *options) {
+		o.alpha = c
+	}
+}
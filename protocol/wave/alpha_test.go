@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAlphaController(t *testing.T) *AlphaController {
+	c, err := NewAlphaController(AlphaControllerConfig{
+		Initial: 0.7,
+		Min:     0.6,
+		Max:     0.8,
+		Window:  10,
+		Step:    0.02,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+// observeRate feeds windows rounds of which the first successes out of
+// each ten succeed
+func observeRate(c *AlphaController, windows, successes int) {
+	for w := 0; w < windows; w++ {
+		for i := 0; i < 10; i++ {
+			c.Observe(i < successes)
+		}
+	}
+}
+
+func TestAlphaControllerBounds(t *testing.T) {
+	for _, cfg := range []AlphaControllerConfig{
+		{Min: 0.5, Max: 0.8},
+		{Min: 0.7, Max: 1.1},
+		{Min: 0.8, Max: 0.7},
+	} {
+		_, err := NewAlphaController(cfg)
+		require.ErrorIs(t, err, ErrInvalidAlphaBounds, "%+v", cfg)
+	}
+}
+
+func TestAlphaControllerTracksSuccessRate(t *testing.T) {
+	require := require.New(t)
+	c := newTestAlphaController(t)
+
+	// A partial window changes nothing
+	for i := 0; i < 9; i++ {
+		c.Observe(true)
+	}
+	require.Equal(0.7, c.Alpha())
+	c.Observe(true)
+	require.Equal(0.72, c.Alpha())
+
+	// Steady success raises alpha to Max and holds it there
+	observeRate(c, 20, 10)
+	require.Equal(0.8, c.Alpha())
+
+	// A rate between the marks leaves alpha alone
+	observeRate(c, 5, 9)
+	require.Equal(0.8, c.Alpha())
+
+	// Churn lowers alpha step by step down to Min
+	observeRate(c, 1, 5)
+	require.Equal(0.78, c.Alpha())
+	observeRate(c, 20, 2)
+	require.Equal(0.6, c.Alpha())
+
+	// and recovery raises it again
+	observeRate(c, 3, 10)
+	require.Equal(0.66, c.Alpha())
+}
+
+func TestAlphaControllerDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	a := newTestAlphaController(t)
+	b := newTestAlphaController(t)
+	rng := rand.New(rand.NewSource(42))
+
+	// A history drifting between healthy and churning phases
+	for round := 0; round < 5000; round++ {
+		p := 0.95
+		if (round/500)%2 == 1 {
+			p = 0.5
+		}
+		success := rng.Float64() < p
+		a.Observe(success)
+		b.Observe(success)
+
+		alpha := a.Alpha()
+		require.Equal(alpha, b.Alpha(), "round %d", round)
+		require.GreaterOrEqual(alpha, 0.6)
+		require.LessOrEqual(alpha, 0.8)
+	}
+}
+
+func TestWaveUsesAlphaController(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestAlphaController(t)
+	w := newAggregatorWave(t, WithAlphaController(c))
+	require.Equal(7, w.threshold(0))
+
+	// Alpha 0.6 on K=10 makes 6 votes a quorum
+	observeRate(c, 5, 0)
+	require.Equal(6, w.threshold(0))
+	require.False(w.RecordPoll("a", 5, 10))
+	require.False(w.RecordPoll("a", 6, 10))
+	require.True(w.RecordPoll("a", 6, 10))
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"math"
+	"time"
+)
+
+// RoundTOBackoff grows the round timeout geometrically while consecutive
+// rounds fail to reach quorum, e.g. during a network partition.
+type RoundTOBackoff struct {
+	Base       time.Duration // timeout after a successful round (default: RoundTO)
+	Max        time.Duration // upper bound on the timeout (0 = uncapped)
+	Multiplier float64       // growth per failed round (default: 2)
+}
+
+// RoundTimer tracks the effective round timeout. Without a backoff it always
+// returns the fixed timeout.
+type RoundTimer struct {
+	fixed   time.Duration
+	backoff *RoundTOBackoff
+	current time.Duration
+}
+
+// NewRoundTimer creates a timer for the fixed round timeout roundTO, growing
+// per backoff when it is non-nil.
+func NewRoundTimer(roundTO time.Duration, backoff *RoundTOBackoff) *RoundTimer {
+	t := &RoundTimer{fixed: roundTO}
+	if backoff != nil {
+		b := *backoff
+		if b.Base <= 0 {
+			b.Base = roundTO
+		}
+		if b.Multiplier <= 1 {
+			b.Multiplier = 2
+		}
+		if b.Max > 0 && b.Max < b.Base {
+			b.Max = b.Base
+		}
+		t.backoff = &b
+		t.current = b.Base
+	}
+	return t
+}
+
+// Timeout returns the timeout to use for the next round.
+func (t *RoundTimer) Timeout() time.Duration {
+	if t.backoff == nil {
+		return t.fixed
+	}
+	return t.current
+}
+
+// Observe records the outcome of a round: a round without quorum grows the
+// timeout, the first round with quorum resets it to Base.
+func (t *RoundTimer) Observe(quorum bool) {
+	if t.backoff == nil {
+		return
+	}
+	if quorum {
+		t.current = t.backoff.Base
+		return
+	}
+	if next := float64(t.current) * t.backoff.Multiplier; next < math.MaxInt64 {
+		t.current = time.Duration(next)
+	} else {
+		t.current = math.MaxInt64
+	}
+	if t.backoff.Max > 0 && t.current > t.backoff.Max {
+		t.current = t.backoff.Max
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTimerFixedWithoutBackoff(t *testing.T) {
+	require := require.New(t)
+
+	timer := NewRoundTimer(100*time.Millisecond, nil)
+	for i := 0; i < 5; i++ {
+		timer.Observe(false)
+		require.Equal(100*time.Millisecond, timer.Timeout())
+	}
+}
+
+func TestRoundTimerBackoffCurve(t *testing.T) {
+	require := require.New(t)
+
+	timer := NewRoundTimer(100*time.Millisecond, &RoundTOBackoff{
+		Base:       10 * time.Millisecond,
+		Max:        50 * time.Millisecond,
+		Multiplier: 2,
+	})
+	require.Equal(10*time.Millisecond, timer.Timeout())
+
+	expected := []time.Duration{
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+	}
+	for _, want := range expected {
+		timer.Observe(false)
+		require.Equal(want, timer.Timeout())
+	}
+
+	timer.Observe(true)
+	require.Equal(10*time.Millisecond, timer.Timeout())
+	timer.Observe(false)
+	require.Equal(20*time.Millisecond, timer.Timeout())
+}
+
+func TestRoundTimerBackoffDefaults(t *testing.T) {
+	require := require.New(t)
+
+	timer := NewRoundTimer(100*time.Millisecond, &RoundTOBackoff{})
+	require.Equal(100*time.Millisecond, timer.Timeout())
+
+	timer.Observe(false)
+	require.Equal(200*time.Millisecond, timer.Timeout())
+	timer.Observe(false)
+	require.Equal(400*time.Millisecond, timer.Timeout())
+}
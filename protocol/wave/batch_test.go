@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordWave(t testing.TB) *Wave[string] {
+	cfg := Config{K: 20, Alpha: 0.8, Beta: 15, RoundTO: time.Second}
+	w, err := New[string](cfg, newMockCut[string](20), newMockTransport[string]())
+	require.NoError(t, err)
+	return &w
+}
+
+func randomPolls(rng *rand.Rand, n int) []PollResult {
+	polls := make([]PollResult, n)
+	for i := range polls {
+		total := rng.Intn(21) // includes empty polls
+		yes := 0
+		if total > 0 {
+			// Bias towards yes so some items decide accept
+			yes = total - rng.Intn(total/3+1)
+		}
+		polls[i] = PollResult{Yes: yes, Total: total}
+	}
+	return polls
+}
+
+// TestRecordPollBatchEquivalence tests that a batch leaves the same state as
+// recording each poll in order
+func TestRecordPollBatchEquivalence(t *testing.T) {
+	require := require.New(t)
+
+	rng := rand.New(rand.NewSource(1))
+	items := []string{"a", "b", "c", "d"}
+	single := newRecordWave(t)
+	batched := newRecordWave(t)
+
+	for round := 0; round < 50; round++ {
+		item := items[rng.Intn(len(items))]
+		polls := randomPolls(rng, 1+rng.Intn(8))
+
+		acceptedSingle := false
+		for _, p := range polls {
+			if single.RecordPoll(item, p.Yes, p.Total) {
+				acceptedSingle = true
+			}
+		}
+		require.Equal(acceptedSingle, batched.RecordPollBatch(item, polls))
+	}
+
+	require.Equal(single.phase, batched.phase)
+	for _, item := range items {
+		s1, ok1 := single.State(item)
+		s2, ok2 := batched.State(item)
+		require.Equal(ok1, ok2)
+		if ok1 {
+			require.Equal(*s1, *s2, "item %s", item)
+		}
+		require.Equal(single.Preference(item), batched.Preference(item))
+	}
+}
+
+// TestRecordPollBatchAccepts tests the batch reports the accept it causes once
+func TestRecordPollBatchAccepts(t *testing.T) {
+	require := require.New(t)
+
+	w := newRecordWave(t)
+	polls := make([]PollResult, w.cfg.Beta)
+	for i := range polls {
+		polls[i] = PollResult{Yes: 20, Total: 20}
+	}
+
+	require.False(w.RecordPollBatch("tx", polls[:len(polls)-1]))
+	require.True(w.RecordPollBatch("tx", polls[len(polls)-1:]))
+	require.False(w.RecordPollBatch("tx", polls), "already decided")
+	require.False(w.RecordPollBatch("tx", nil))
+}
+
+func BenchmarkRecordPoll(b *testing.B) {
+	polls := randomPolls(rand.New(rand.NewSource(1)), 64)
+
+	b.Run("per-poll", func(b *testing.B) {
+		w := newRecordWave(b)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, p := range polls {
+				w.RecordPoll("item", p.Yes, p.Total)
+			}
+		}
+	})
+	b.Run("batched", func(b *testing.B) {
+		w := newRecordWave(b)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			w.RecordPollBatch("item", polls)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for round timeouts, pacing and processing
+// deadlines. SystemClock is the default; ManualClock lets tests step time.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks on C until stopped
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the Clock backed by package time
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
+
+func (SystemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (SystemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct{ t *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.t.C }
+func (t systemTicker) Stop()               { t.t.Stop() }
+
+// ManualClock is a Clock whose time only moves on Advance. Timers and
+// tickers fire during Advance once their deadline is reached; like the
+// time package, a tick is dropped if the previous one was not received.
+type ManualClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*manualWaiter
+}
+
+// manualWaiter is a pending After timer or ticker of a ManualClock
+type manualWaiter struct {
+	at     time.Time
+	period time.Duration // 0 for a one-shot timer
+	c      chan time.Time
+	clock  *ManualClock
+}
+
+// NewManualClock returns a manual clock reading start
+func NewManualClock(start time.Time) *ManualClock {
+	c := &ManualClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the clock's time once it has advanced
+// by d. It fires immediately for d <= 0.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.addLocked(&manualWaiter{at: c.now.Add(d), c: ch, clock: c})
+	return ch
+}
+
+// NewTicker returns a ticker firing every d of advanced time. It panics
+// for d <= 0, as time.NewTicker does.
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("wave: non-positive interval for ManualClock.NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &manualWaiter{at: c.now.Add(d), period: d, c: make(chan time.Time, 1), clock: c}
+	c.addLocked(w)
+	return w
+}
+
+// Advance moves the clock forward by d, firing every timer and ticker due
+// by the new time in deadline order
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	end := c.now.Add(d)
+	for len(c.waiters) > 0 && !c.waiters[0].at.After(end) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.at
+		select {
+		case w.c <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			c.addLocked(w)
+		}
+	}
+	c.now = end
+	c.cond.Broadcast()
+}
+
+// Waiters returns how many timers and tickers are pending
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n timers and tickers are pending, so a
+// test can advance time only once the code under test is waiting on it
+func (c *ManualClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// addLocked inserts w keeping waiters ordered by deadline, after any
+// waiter with the same deadline
+// Must be called with c.mu held
+func (c *ManualClock) addLocked(w *manualWaiter) {
+	i := sort.Search(len(c.waiters), func(i int) bool { return c.waiters[i].at.After(w.at) })
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[i+1:], c.waiters[i:])
+	c.waiters[i] = w
+	c.cond.Broadcast()
+}
+
+func (w *manualWaiter) C() <-chan time.Time { return w.c }
+
+func (w *manualWaiter) Stop() {
+	c := w.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func fired(c <-chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestManualClockAfter(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(100, 0)
+	clock := NewManualClock(start)
+	late := clock.After(20 * time.Millisecond)
+	early := clock.After(10 * time.Millisecond)
+	require.Equal(2, clock.Waiters())
+
+	clock.Advance(10*time.Millisecond - time.Nanosecond)
+	require.False(fired(early))
+
+	clock.Advance(time.Nanosecond)
+	require.Equal(start.Add(10*time.Millisecond), <-early)
+	require.False(fired(late))
+	require.Equal(1, clock.Waiters())
+
+	clock.Advance(time.Hour)
+	require.Equal(start.Add(20*time.Millisecond), <-late)
+	require.Equal(start.Add(time.Hour+10*time.Millisecond), clock.Now())
+	require.Zero(clock.Waiters())
+
+	require.True(fired(clock.After(0)))
+}
+
+func TestManualClockTicker(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second)
+	require.Equal(time.Unix(1, 0), <-ticker.C())
+
+	// Ticks the receiver missed are dropped, as with time.Ticker
+	clock.Advance(3 * time.Second)
+	require.Equal(time.Unix(2, 0), <-ticker.C())
+	require.False(fired(ticker.C()))
+
+	ticker.Stop()
+	clock.Advance(time.Minute)
+	require.False(fired(ticker.C()))
+	require.Zero(clock.Waiters())
+}
+
+func TestWaveRoundTimeoutUsesClock(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	silent := VoteTransportFunc[string](func(context.Context, []types.NodeID, string) <-chan Photon[string] {
+		return make(chan Photon[string])
+	})
+	w, err := New[string](Config{K: 3, Alpha: 0.8, Beta: 2, RoundTO: time.Second, Clock: clock},
+		newMockCut[string](3), NewTransport[string](silent, types.NodeID{}))
+	require.NoError(err)
+
+	done := make(chan bool)
+	go func() { done <- w.TickTimeout(context.Background(), "x", 50*time.Millisecond) }()
+
+	clock.BlockUntil(1)
+	clock.Advance(50*time.Millisecond - time.Nanosecond)
+	require.Equal(1, clock.Waiters())
+	clock.Advance(time.Nanosecond)
+	require.False(<-done)
+}
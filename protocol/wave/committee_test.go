@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/photon"
+	"github.com/luxfi/consensus/protocol/prism"
+)
+
+// newCommittedWave opens a committed round over six validators and returns
+// a wave verifying its committee, sampling peers from cut or, if cut is
+// nil, from the emitter itself
+func newCommittedWave(t *testing.T, cut func([]types.NodeID) prism.Cut[string]) (*Wave[string], *mockTransport[string]) {
+	require := require.New(t)
+
+	validators := newMockCut[string](6).peers
+	e := photon.NewCommitRevealEmitter(photon.NewUniformEmitter(validators, photon.DefaultEmitterOptions()), validators, 3)
+	seed := []byte("round-1")
+	_, err := e.Commit(seed)
+	require.NoError(err)
+	_, err = e.Reveal(seed)
+	require.NoError(err)
+
+	var c prism.Cut[string] = e
+	if cut != nil {
+		c = cut(validators)
+	}
+	tx := newMockTransport[string]()
+	cfg := Config{K: 3, Alpha: 0.6, Beta: 1, RoundTO: time.Second}
+	w, err := New[string](cfg, c, tx, WithCommitteeVerifier(e))
+	require.NoError(err)
+	return &w, tx
+}
+
+func TestCommitteeVerifierCountsCommittedCommittee(t *testing.T) {
+	require := require.New(t)
+
+	// The emitter samples exactly the revealed seed's committee
+	w, tx := newCommittedWave(t, nil)
+	for i := 0; i < 3; i++ {
+		tx.AddVote("a", true)
+	}
+
+	require.True(w.TickTimeout(context.Background(), "a", time.Second))
+	state, _ := w.State("a")
+	require.True(state.Decided)
+}
+
+func TestCommitteeVerifierRejectsUncommittedCommittee(t *testing.T) {
+	require := require.New(t)
+
+	// A cut of its own samples a committee other than the seed's
+	w, tx := newCommittedWave(t, func(validators []types.NodeID) prism.Cut[string] {
+		return &mockCut[string]{peers: validators}
+	})
+	for i := 0; i < 3; i++ {
+		tx.AddVote("a", true)
+	}
+
+	require.False(w.TickTimeout(context.Background(), "a", time.Second))
+	state, _ := w.State("a")
+	require.False(state.Decided)
+	require.Zero(state.Count)
+}
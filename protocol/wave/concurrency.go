@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// pollLimiter bounds how many polls are in flight at once. A nil slots
+// channel admits every poll but still counts it.
+type pollLimiter struct {
+	slots    chan struct{}
+	reject   bool
+	inFlight atomic.Int64
+}
+
+// newPollLimiter returns a limiter admitting up to n polls at once
+// (n <= 0 = unbounded). When reject is set a poll past the limit is refused
+// instead of waiting for a slot.
+func newPollLimiter(n int, reject bool) *pollLimiter {
+	l := &pollLimiter{reject: reject}
+	if n > 0 {
+		l.slots = make(chan struct{}, n)
+	}
+	return l
+}
+
+// acquire takes a slot, waiting for one unless the limiter rejects. It
+// returns false if no slot was taken because the limiter is full and
+// rejects, or ctx was cancelled while waiting.
+func (l *pollLimiter) acquire(ctx context.Context) bool {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			if l.reject {
+				return false
+			}
+			select {
+			case l.slots <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+		}
+	}
+	l.inFlight.Add(1)
+	return true
+}
+
+// release frees a slot taken by acquire
+func (l *pollLimiter) release() {
+	l.inFlight.Add(-1)
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// PollsInFlight returns how many first-round polls are awaiting votes
+func (w *Wave[T]) PollsInFlight() int {
+	return int(w.polls.inFlight.Load())
+}
+
+// RepollsInFlight returns how many re-polls are awaiting votes
+func (w *Wave[T]) RepollsInFlight() int {
+	return int(w.repolls.inFlight.Load())
+}
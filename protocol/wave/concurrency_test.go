@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedTransport holds every poll until release lets it answer. The first
+// poll of each item splits when split is set; re-polls answer unanimously.
+type gatedTransport struct {
+	k     int
+	split bool
+
+	mu       sync.Mutex
+	requests int
+	seen     map[string]bool
+	release  chan struct{}
+}
+
+func newGatedTransport(k int, split bool) *gatedTransport {
+	return &gatedTransport{k: k, split: split, seen: make(map[string]bool), release: make(chan struct{}, 64)}
+}
+
+func (g *gatedTransport) RequestVotes(ctx context.Context, peers []types.NodeID, item string) <-chan Photon[string] {
+	g.mu.Lock()
+	g.requests++
+	first := !g.seen[item]
+	g.seen[item] = true
+	g.mu.Unlock()
+
+	ch := make(chan Photon[string], g.k)
+	if first && g.split {
+		for i := 0; i < g.k; i++ {
+			ch <- Photon[string]{Item: item, Prefer: i%2 == 0, Sender: peers[i]}
+		}
+		return ch
+	}
+	go func() {
+		select {
+		case <-g.release:
+		case <-ctx.Done():
+			return
+		}
+		for i := 0; i < g.k; i++ {
+			ch <- Photon[string]{Item: item, Prefer: true, Sender: peers[i]}
+		}
+	}()
+	return ch
+}
+
+func (g *gatedTransport) MakeLocalPhoton(item string, prefer bool) Photon[string] {
+	return Photon[string]{Item: item, Prefer: prefer}
+}
+
+func (g *gatedTransport) Requests() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.requests
+}
+
+// tickAll ticks n items concurrently and returns a channel closed once every
+// tick has returned
+func tickAll(w *Wave[string], n int) <-chan struct{} {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.TickTimeout(context.Background(), fmt.Sprintf("tx%d", i), time.Minute)
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+func TestConcurrentPollsCapped(t *testing.T) {
+	require := require.New(t)
+
+	tx := newGatedTransport(4, false)
+	w, err := New[string](Config{
+		K:               4,
+		Alpha:           0.75,
+		Beta:            1,
+		ConcurrentPolls: 2,
+	}, &rotatingCut{}, tx)
+	require.NoError(err)
+
+	done := tickAll(&w, 5)
+
+	// Two polls go out; the other three wait for a slot
+	require.Eventually(func() bool { return tx.Requests() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(2, tx.Requests())
+	require.Equal(2, w.PollsInFlight())
+
+	// Completing a poll admits a queued one
+	tx.release <- struct{}{}
+	require.Eventually(func() bool { return tx.Requests() == 3 }, time.Second, time.Millisecond)
+	require.Equal(2, w.PollsInFlight())
+
+	for i := 0; i < 4; i++ {
+		tx.release <- struct{}{}
+	}
+	<-done
+	require.Equal(5, tx.Requests())
+	require.Zero(w.PollsInFlight())
+}
+
+func TestConcurrentPollsReject(t *testing.T) {
+	require := require.New(t)
+
+	tx := newGatedTransport(4, false)
+	w, err := New[string](Config{
+		K:                 4,
+		Alpha:             0.75,
+		Beta:              1,
+		ConcurrentPolls:   1,
+		RejectExcessPolls: true,
+	}, &rotatingCut{}, tx)
+	require.NoError(err)
+
+	held := tickAll(&w, 1)
+	require.Eventually(func() bool { return w.PollsInFlight() == 1 }, time.Second, time.Millisecond)
+
+	// A poll past the limit is refused without reaching the transport
+	require.False(w.TickTimeout(context.Background(), "other", time.Minute))
+	require.Equal(1, tx.Requests())
+
+	// Once the held poll completes the next one is admitted
+	tx.release <- struct{}{}
+	<-held
+	tx.release <- struct{}{}
+	require.True(w.TickTimeout(context.Background(), "other", time.Minute))
+	require.Equal(2, tx.Requests())
+}
+
+func TestConcurrentRepollsCapped(t *testing.T) {
+	require := require.New(t)
+
+	// Every first poll splits, so each of the three items re-polls two
+	// fresh committees; only two re-polls may be in flight across them
+	tx := newGatedTransport(4, true)
+	w, err := New[string](Config{
+		K:                 4,
+		Alpha:             0.75,
+		Beta:              1,
+		ConcurrentRepolls: 2,
+	}, &rotatingCut{}, tx)
+	require.NoError(err)
+
+	done := tickAll(&w, 3)
+	require.Eventually(func() bool { return tx.Requests() == 3+2 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(3+2, tx.Requests())
+	require.Equal(2, w.RepollsInFlight())
+	require.Zero(w.PollsInFlight())
+
+	// An item's first re-poll to answer stands in for the round and
+	// cancels its sibling, admitting the queued items' re-polls
+	for i := 0; i < 3*2; i++ {
+		tx.release <- struct{}{}
+	}
+	<-done
+	require.LessOrEqual(tx.Requests(), 3+3*2)
+	require.Eventually(func() bool { return w.RepollsInFlight() == 0 }, time.Second, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		state, ok := w.State(fmt.Sprintf("tx%d", i))
+		require.True(ok)
+		require.True(state.Decided)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/luxfi/consensus/core/types"
+)
+
+// roundKey identifies one poll round of an item. Every poll, including
+// each parallel re-poll, is its own round.
+type roundKey[T comparable] struct {
+	item  T
+	round uint64
+}
+
+// voteDedup remembers which voters have answered each open round, so a
+// vote redelivered by the transport is counted once. A round's voters are
+// forgotten when the round ends, bounding it by the polls in flight.
+type voteDedup[T comparable] struct {
+	next       atomic.Uint64
+	duplicates atomic.Uint64
+
+	mu   sync.Mutex
+	seen map[roundKey[T]]map[types.NodeID]struct{}
+}
+
+func newVoteDedup[T comparable]() *voteDedup[T] {
+	return &voteDedup[T]{seen: make(map[roundKey[T]]map[types.NodeID]struct{})}
+}
+
+// begin opens a new round for item
+func (d *voteDedup[T]) begin(item T) roundKey[T] {
+	key := roundKey[T]{item: item, round: d.next.Add(1)}
+	d.mu.Lock()
+	d.seen[key] = make(map[types.NodeID]struct{})
+	d.mu.Unlock()
+	return key
+}
+
+// first records voter's vote in round and reports whether it is the
+// voter's first in that round. An empty voter cannot be told apart from
+// other unattributed votes, so its votes are always counted.
+func (d *voteDedup[T]) first(key roundKey[T], voter types.NodeID) bool {
+	if voter == (types.NodeID{}) {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	voters := d.seen[key]
+	if _, dup := voters[voter]; dup {
+		d.duplicates.Add(1)
+		return false
+	}
+	voters[voter] = struct{}{}
+	return true
+}
+
+// end closes round, dropping its voters
+func (d *voteDedup[T]) end(key roundKey[T]) {
+	d.mu.Lock()
+	delete(d.seen, key)
+	d.mu.Unlock()
+}
+
+// open returns how many rounds are tracked
+func (d *voteDedup[T]) open() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.seen)
+}
+
+// DuplicateVotes returns how many redelivered votes have been ignored
+func (w *Wave[T]) DuplicateVotes() uint64 {
+	return w.votes.duplicates.Load()
+}
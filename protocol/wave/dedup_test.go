@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// redeliveringTransport answers each poll with one vote per peer, sending
+// every vote copies times. The first yes peers prefer the item.
+type redeliveringTransport struct {
+	yes    int
+	copies int
+}
+
+func (r *redeliveringTransport) RequestVotes(ctx context.Context, peers []types.NodeID, item string) <-chan Photon[string] {
+	ch := make(chan Photon[string], len(peers)*r.copies)
+	for i, peer := range peers {
+		for c := 0; c < r.copies; c++ {
+			ch <- Photon[string]{Item: item, Prefer: i < r.yes, Sender: peer}
+		}
+	}
+	close(ch)
+	return ch
+}
+
+func (r *redeliveringTransport) MakeLocalPhoton(item string, prefer bool) Photon[string] {
+	return Photon[string]{Item: item, Prefer: prefer}
+}
+
+func TestDuplicateVotesCountedOnce(t *testing.T) {
+	require := require.New(t)
+
+	// Two of four voters prefer the item. Counted with their redelivered
+	// copies the first four votes would be yes and reach Alpha.
+	tx := &redeliveringTransport{yes: 2, copies: 3}
+	w, err := New[string](Config{K: 4, Alpha: 0.75, Beta: 1, RoundTO: time.Second}, newMockCut[string](4), tx)
+	require.NoError(err)
+
+	yes, total, ok := w.poll(context.Background(), w.polls, "item", time.Second)
+	require.True(ok)
+	require.Equal(2, yes)
+	require.Equal(4, total)
+	// The poll ends at the fourth voter's first vote, before its copies
+	require.Equal(uint64(3*2), w.DuplicateVotes())
+
+	w.Tick(context.Background(), "item")
+	state, ok := w.State("item")
+	require.True(ok)
+	require.False(state.Decided)
+	require.Zero(w.votes.open())
+}
+
+func TestDuplicateVotesNewRoundCounted(t *testing.T) {
+	require := require.New(t)
+
+	// The same voters answer every round; each round counts them afresh
+	tx := &redeliveringTransport{yes: 4, copies: 2}
+	w, err := New[string](Config{K: 4, Alpha: 0.75, Beta: 2, RoundTO: time.Second}, newMockCut[string](4), tx)
+	require.NoError(err)
+
+	w.Tick(context.Background(), "item")
+	state, _ := w.State("item")
+	require.False(state.Decided)
+	require.Equal(uint32(1), state.Count)
+
+	w.Tick(context.Background(), "item")
+	state, _ = w.State("item")
+	require.True(state.Decided)
+	require.Equal(types.DecideAccept, state.Result)
+	require.Equal(uint64(2*3), w.DuplicateVotes())
+	require.Zero(w.votes.open())
+}
+
+// anonymousTransport answers each poll with one vote per peer but leaves
+// Sender empty. The first yes peers prefer the item.
+type anonymousTransport struct {
+	yes int
+}
+
+func (a *anonymousTransport) RequestVotes(ctx context.Context, peers []types.NodeID, item string) <-chan Photon[string] {
+	ch := make(chan Photon[string], len(peers))
+	for i := range peers {
+		ch <- Photon[string]{Item: item, Prefer: i < a.yes}
+	}
+	close(ch)
+	return ch
+}
+
+func (a *anonymousTransport) MakeLocalPhoton(item string, prefer bool) Photon[string] {
+	return Photon[string]{Item: item, Prefer: prefer}
+}
+
+func TestVotesWithoutSenderCounted(t *testing.T) {
+	require := require.New(t)
+
+	// Votes the transport cannot attribute are not collapsed into one
+	tx := &anonymousTransport{yes: 4}
+	w, err := New[string](Config{K: 4, Alpha: 0.75, Beta: 1, RoundTO: time.Second}, newMockCut[string](4), tx)
+	require.NoError(err)
+
+	yes, total, ok := w.poll(context.Background(), w.polls, "item", time.Second)
+	require.True(ok)
+	require.Equal(4, yes)
+	require.Equal(4, total)
+	require.Zero(w.DuplicateVotes())
+
+	w.Tick(context.Background(), "item")
+	state, ok := w.State("item")
+	require.True(ok)
+	require.True(state.Decided)
+	require.Equal(types.DecideAccept, state.Result)
+}
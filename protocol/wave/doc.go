@@ -5,5 +5,10 @@
 // from [θ_min, θ_max], reproducibly derived from a PRF.
 //
 // Wave doesn't decide anything alone; it transforms a committee's tallies into
-// a boolean (preferOK, confOK) that downstream focus can integrate.
+// a boolean (preferOK, confOK) that downstream focus can integrate. The
+// transformation is an Aggregator; WithAggregator swaps out the default
+// threshold comparison.
+//
+// Round timeouts, pacing and processing deadlines read time from
+// Config.Clock; a ManualClock lets tests step rounds without sleeping.
 package wave
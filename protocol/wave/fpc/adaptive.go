@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fpc
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	// adaptiveStreak is how many consecutive agreeing rounds start narrowing
+	adaptiveStreak = 2
+
+	// adaptiveRate is the fraction of the distance to the target each
+	// further agreeing round closes
+	adaptiveRate = 0.5
+
+	// adaptiveMargin keeps the target this far below the stable quorum so
+	// that rounds at the observed quorum still clear θ
+	adaptiveMargin = 0.05
+
+	// adaptiveMinWidth is the narrowest the θ range gets
+	adaptiveMinWidth = 0.02
+)
+
+// AdaptiveSelector is a Selector whose θ range narrows while the network
+// converges. After consecutive rounds prefer the same side, the range
+// [lo, hi] ⊆ [θ_min, θ_max] closes in on a target just below the weakest
+// quorum those rounds observed, so θ stops landing above the quorum the
+// network actually delivers. A round that flips sides, ties, or falls
+// short of lo re-widens the range to [θ_min, θ_max] to escape a stuck
+// state. θ is still drawn by the PRF within the current range, so nodes
+// that observe the same rounds agree on every threshold. Pass it to
+// wave.WithThresholdSelector to have wave feed it every round's tally.
+type AdaptiveSelector struct {
+	base *Selector
+
+	mu     sync.Mutex
+	lo, hi float64
+	prefer bool    // side the current streak prefers
+	streak int     // consecutive agreeing rounds
+	quorum float64 // weakest winning-side ratio in the streak
+}
+
+// NewAdaptiveSelector creates an adaptive selector over [thetaMin, thetaMax],
+// normalized as NewSelector does. seed must be non-empty.
+func NewAdaptiveSelector(thetaMin, thetaMax float64, seed []byte) (*AdaptiveSelector, error) {
+	base, err := NewSelector(thetaMin, thetaMax, seed)
+	if err != nil {
+		return nil, err
+	}
+	return &AdaptiveSelector{
+		base: base,
+		lo:   base.thetaMin,
+		hi:   base.thetaMax,
+	}, nil
+}
+
+// SelectThreshold picks θ in the current range using the PRF for phase
+// Returns α = ⌈θ·k⌉ for both preference and confidence
+func (s *AdaptiveSelector) SelectThreshold(phase uint64, k int) int {
+	return int(math.Ceil(s.Theta(phase) * float64(k)))
+}
+
+// Theta returns θ for phase under the current range
+func (s *AdaptiveSelector) Theta(phase uint64) float64 {
+	s.mu.Lock()
+	lo, hi := s.lo, s.hi
+	s.mu.Unlock()
+	return lo + s.base.prf(phase)*(hi-lo)
+}
+
+// Observe folds one round's tally into the range. Rounds must be observed
+// in order; the range depends only on the sequence of tallies.
+func (s *AdaptiveSelector) Observe(yesVotes, totalVotes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if totalVotes <= 0 {
+		return
+	}
+	prefer := 2*yesVotes > totalVotes
+	winning := yesVotes
+	if !prefer {
+		winning = totalVotes - yesVotes
+	}
+	ratio := float64(winning) / float64(totalVotes)
+
+	// A tie or a quorum below the range means the network is not
+	// converging on the current range
+	if 2*winning == totalVotes || ratio < s.lo {
+		s.widen()
+		return
+	}
+	// A flip restarts the streak on the new side
+	if s.streak > 0 && prefer != s.prefer {
+		s.widen()
+	}
+	if s.streak == 0 {
+		s.prefer, s.quorum = prefer, ratio
+	}
+	s.streak++
+	s.quorum = math.Min(s.quorum, ratio)
+	if s.streak >= adaptiveStreak {
+		s.narrow()
+	}
+}
+
+// Range returns the current theta range
+func (s *AdaptiveSelector) Range() (min, max float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lo, s.hi
+}
+
+// widen resets the range and streak. Caller holds s.mu.
+func (s *AdaptiveSelector) widen() {
+	s.lo, s.hi = s.base.thetaMin, s.base.thetaMax
+	s.streak = 0
+}
+
+// narrow moves both ends of the range toward the target below the stable
+// quorum, keeping at least adaptiveMinWidth. Caller holds s.mu.
+func (s *AdaptiveSelector) narrow() {
+	lower, upper := s.base.thetaMin, s.base.thetaMax
+	target := math.Max(lower, math.Min(upper, s.quorum-adaptiveMargin))
+
+	lo := s.lo + adaptiveRate*(target-s.lo)
+	hi := s.hi - adaptiveRate*(s.hi-target)
+	if hi-lo < adaptiveMinWidth {
+		lo = math.Max(lower, target-adaptiveMinWidth/2)
+		hi = math.Min(upper, lo+adaptiveMinWidth)
+		lo = math.Max(lower, hi-adaptiveMinWidth)
+	}
+	s.lo, s.hi = lo, hi
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fpc
+
+import (
+	"fmt"
+	"testing"
+)
+
+// thresholdSelector is the selector surface the convergence test drives
+type thresholdSelector interface {
+	SelectThreshold(phase uint64, k int) int
+}
+
+// roundsToDecide polls a network that always returns yes of k votes and
+// counts rounds until beta consecutive rounds clear the threshold
+func roundsToDecide(sel thresholdSelector, yes, k, beta int) int {
+	streak := 0
+	for round := 1; round <= 1000; round++ {
+		if yes >= sel.SelectThreshold(uint64(round), k) {
+			streak++
+		} else {
+			streak = 0
+		}
+		if a, ok := sel.(*AdaptiveSelector); ok {
+			a.Observe(yes, k)
+		}
+		if streak >= beta {
+			return round
+		}
+	}
+	return 1000
+}
+
+func TestAdaptiveSelectorConvergesFaster(t *testing.T) {
+	// A cooperative network delivering a 70% quorum: static θ ∈ [0.5, 0.8]
+	// misses it about a third of the time, resetting confidence
+	const k, yes, beta = 20, 14, 10
+
+	staticTotal, adaptiveTotal := 0, 0
+	for i := 0; i < 20; i++ {
+		seed := []byte(fmt.Sprintf("seed-%d", i))
+		static, err := NewSelector(0.5, 0.8, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		adaptive, err := NewAdaptiveSelector(0.5, 0.8, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := roundsToDecide(static, yes, k, beta)
+		a := roundsToDecide(adaptive, yes, k, beta)
+		if a > beta+adaptiveStreak+1 {
+			t.Errorf("seed %d: adaptive selector needed %d rounds, want at most %d", i, a, beta+adaptiveStreak+1)
+		}
+		staticTotal += s
+		adaptiveTotal += a
+	}
+	if adaptiveTotal >= staticTotal {
+		t.Fatalf("adaptive selector took %d rounds over all seeds, static %d", adaptiveTotal, staticTotal)
+	}
+	t.Logf("rounds to decide: static %d, adaptive %d", staticTotal, adaptiveTotal)
+}
+
+func TestAdaptiveSelectorNarrowsTowardQuorum(t *testing.T) {
+	s, err := NewAdaptiveSelector(0.5, 0.8, []byte("test-seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One round is not a streak
+	s.Observe(15, 20)
+	if lo, hi := s.Range(); lo != 0.5 || hi != 0.8 {
+		t.Fatalf("range narrowed after one round: [%f, %f]", lo, hi)
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Observe(15, 20)
+	}
+	lo, hi := s.Range()
+	if hi-lo > adaptiveMinWidth+1e-9 || lo < 0.5 || hi > 0.75 {
+		t.Fatalf("range [%f, %f] did not close in below the 0.75 quorum", lo, hi)
+	}
+	for phase := uint64(0); phase < 100; phase++ {
+		if th := s.Theta(phase); th < lo || th > hi {
+			t.Fatalf("theta %f outside range [%f, %f]", th, lo, hi)
+		}
+	}
+
+	// A strong "no" streak narrows the same way
+	no, _ := NewAdaptiveSelector(0.5, 0.8, []byte("test-seed"))
+	for i := 0; i < 10; i++ {
+		no.Observe(5, 20)
+	}
+	if nlo, nhi := no.Range(); nlo != lo || nhi != hi {
+		t.Errorf("no-side range [%f, %f] differs from yes-side [%f, %f]", nlo, nhi, lo, hi)
+	}
+}
+
+func TestAdaptiveSelectorRewidens(t *testing.T) {
+	tests := []struct {
+		name       string
+		yes, total int
+	}{
+		{name: "preference flips", yes: 4, total: 20},
+		{name: "tie", yes: 10, total: 20},
+		{name: "quorum below range", yes: 12, total: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewAdaptiveSelector(0.5, 0.8, []byte("test-seed"))
+			for i := 0; i < 5; i++ {
+				s.Observe(16, 20)
+			}
+			if lo, hi := s.Range(); hi-lo >= 0.3 {
+				t.Fatalf("range [%f, %f] did not narrow", lo, hi)
+			}
+
+			s.Observe(tt.yes, tt.total)
+			if lo, hi := s.Range(); lo != 0.5 || hi != 0.8 {
+				t.Fatalf("range [%f, %f] did not re-widen", lo, hi)
+			}
+		})
+	}
+}
+
+func TestAdaptiveSelectorDeterministic(t *testing.T) {
+	a, _ := NewAdaptiveSelector(0.5, 0.8, []byte("test-seed"))
+	b, _ := NewAdaptiveSelector(0.5, 0.8, []byte("test-seed"))
+
+	// Same round history, same thresholds
+	history := [][2]int{{14, 20}, {15, 20}, {13, 20}, {6, 20}, {5, 20}, {4, 20}, {10, 20}, {17, 20}}
+	for phase, r := range history {
+		a.Observe(r[0], r[1])
+		b.Observe(r[0], r[1])
+		if ta, tb := a.SelectThreshold(uint64(phase), 20), b.SelectThreshold(uint64(phase), 20); ta != tb {
+			t.Fatalf("phase %d: thresholds %d and %d differ", phase, ta, tb)
+		}
+	}
+}
+
+func TestNewAdaptiveSelectorRequiresSeed(t *testing.T) {
+	if _, err := NewAdaptiveSelector(0.5, 0.8, nil); err != ErrEmptySeed {
+		t.Fatalf("Expected ErrEmptySeed for nil seed, got %v", err)
+	}
+}
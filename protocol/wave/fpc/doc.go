@@ -3,4 +3,8 @@
 // For round "phase" and committee size k, it picks a θ ∈ [θ_min, θ_max] and
 // returns α = ⌈θ·k⌉ for both preference and confidence. The PRF makes θ stable
 // for a given phase, testable, and deterministic in simulations.
+//
+// AdaptiveSelector narrows the range toward the quorum the network delivers
+// while rounds agree and re-widens it on disagreement; the range depends
+// only on the observed round history.
 package fpc
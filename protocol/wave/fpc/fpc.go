@@ -62,6 +62,12 @@ func (s *Selector) SelectThreshold(phase uint64, k int) int {
 
 // computeTheta uses PRF to deterministically select θ for a given phase
 func (s *Selector) computeTheta(phase uint64) float64 {
+	// Scale to [thetaMin, thetaMax]
+	return s.thetaMin + s.prf(phase)*(s.thetaMax-s.thetaMin)
+}
+
+// prf maps phase to a value in [0,1] derived from the seed
+func (s *Selector) prf(phase uint64) float64 {
 	// Create PRF input: seed || phase
 	h := sha256.New()
 	h.Write(s.seed)
@@ -74,12 +80,7 @@ func (s *Selector) computeTheta(phase uint64) float64 {
 
 	// Convert first 8 bytes of hash to uint64, normalize to [0,1]
 	hashUint := binary.BigEndian.Uint64(hash[:8])
-	normalized := float64(hashUint) / float64(^uint64(0))
-
-	// Scale to [thetaMin, thetaMax]
-	theta := s.thetaMin + normalized*(s.thetaMax-s.thetaMin)
-
-	return theta
+	return float64(hashUint) / float64(^uint64(0))
 }
 
 // Theta returns the raw theta value for a phase (for testing/debugging)
@@ -268,6 +268,36 @@ func TestFPCvsFixedAlpha(t *testing.T) {
 	}
 }
 
+// TestWaveWithAdaptiveSelector checks wave draws thresholds from an
+// adaptive selector and feeds it each round's tally
+func TestWaveWithAdaptiveSelector(t *testing.T) {
+	seed := fpc.DeriveEpochSeed(1, []byte("test-chain"), nil)
+	sel, err := fpc.NewAdaptiveSelector(0.5, 0.8, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither EnableFPC nor FPCSeed is needed with the option
+	cfg := Config{K: 100, Alpha: 0.6, Beta: 20, RoundTO: 100 * time.Millisecond}
+	wave, err := New[ids.ID](cfg, &MockCut{k: cfg.K}, &MockTransport{}, WithThresholdSelector(sel))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if wave.threshold(1) != sel.SelectThreshold(1, cfg.K) {
+		t.Fatal("wave did not take its threshold from the selector")
+	}
+
+	// A converging network narrows the range below its 70% quorum
+	item := ids.GenerateTestID()
+	for i := 0; i < 5; i++ {
+		wave.RecordPoll(item, 70, 100)
+	}
+	lo, hi := sel.Range()
+	if lo <= 0.5 || hi >= 0.8 {
+		t.Fatalf("expected the range to narrow from [0.5, 0.8], got [%f, %f]", lo, hi)
+	}
+}
+
 // Mock implementations for testing
 type MockCut struct {
 	k int
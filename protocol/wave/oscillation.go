@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"github.com/luxfi/consensus/protocol/wave/fpc"
+)
+
+// DefaultOscillationFlips is how many preference flips mark an item as
+// oscillating when Config.OscillationFlips is unset
+const DefaultOscillationFlips = 4
+
+// newEscalation returns the selector for oscillating items' thresholds, or
+// nil when cfg does not escalate them
+func newEscalation(cfg Config) (*fpc.Selector, error) {
+	if !cfg.EscalateOscillating || cfg.EnableFPC {
+		return nil, nil
+	}
+	thetaMin := cfg.ThetaMin
+	if thetaMin == 0 {
+		thetaMin = 0.5
+	}
+	thetaMax := cfg.ThetaMax
+	if thetaMax == 0 {
+		thetaMax = 0.8
+	}
+	return fpc.NewSelector(thetaMin, thetaMax, cfg.FPCSeed)
+}
+
+// oscillationFlips returns the flip count at which an item oscillates
+func (w *Wave[T]) oscillationFlips() uint32 {
+	if w.cfg.OscillationFlips > 0 {
+		return w.cfg.OscillationFlips
+	}
+	return DefaultOscillationFlips
+}
+
+// itemThreshold returns the vote threshold for item in a phase: an
+// escalated oscillating item draws it from the FPC range, any other item
+// uses threshold. Caller holds w.mu.
+func (w *Wave[T]) itemThreshold(item T, phase uint64) int {
+	if w.escalation != nil && w.flips[item] >= w.oscillationFlips() {
+		return w.escalation.SelectThreshold(phase, w.k())
+	}
+	return w.threshold(phase)
+}
+
+// FlipCount returns how many times item's preference has flipped between
+// two quorum-backed sides. It is 0 once item has decided or timed out.
+func (w *Wave[T]) FlipCount(item T) uint32 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.flips[item]
+}
+
+// Oscillating reports whether item is undecided and has flipped at least
+// OscillationFlips times
+func (w *Wave[T]) Oscillating(item T) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.oscillatingLocked(item)
+}
+
+// OscillatingItems returns how many undecided items are oscillating
+func (w *Wave[T]) OscillatingItems() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	n := 0
+	for item := range w.flips {
+		if w.oscillatingLocked(item) {
+			n++
+		}
+	}
+	return n
+}
+
+// oscillatingLocked reports whether item oscillates. Caller holds w.mu.
+func (w *Wave[T]) oscillatingLocked(item T) bool {
+	state, ok := w.states[item]
+	return ok && !state.Decided && !state.TimedOut && w.flips[item] >= w.oscillationFlips()
+}
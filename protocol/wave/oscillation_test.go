@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newOscillationWave(t *testing.T, escalate bool) *Wave[string] {
+	w, err := New[string](Config{
+		K:                   10,
+		Alpha:               0.9,
+		Beta:                5,
+		OscillationFlips:    3,
+		EscalateOscillating: escalate,
+		FPCSeed:             []byte("oscillation"),
+	}, newMockCut[string](10), newMockTransport[string]())
+	require.NoError(t, err)
+	return &w
+}
+
+func TestFlipCountAlternatingMajority(t *testing.T) {
+	require := require.New(t)
+	w := newOscillationWave(t, false)
+
+	// The first quorum sets a preference without flipping it
+	w.RecordPoll("item", 10, 10)
+	require.Zero(w.FlipCount("item"))
+
+	// Each alternating majority flips it once; a poll without quorum or
+	// one confirming the preference does not
+	for flips := uint32(1); flips <= 4; flips++ {
+		yes := 0
+		if flips%2 == 0 {
+			yes = 10
+		}
+		w.RecordPoll("item", yes, 10)
+		w.RecordPoll("item", 5, 10)
+		w.RecordPoll("item", yes, 10)
+		require.Equal(flips, w.FlipCount("item"))
+		require.Equal(flips >= 3, w.Oscillating("item"))
+	}
+	require.Equal(1, w.OscillatingItems())
+	require.Zero(w.FlipCount("other"))
+
+	// A decided item no longer counts as oscillating and its flips are
+	// dropped
+	for i := 0; i < 5; i++ {
+		w.RecordPoll("item", 10, 10)
+	}
+	state, _ := w.State("item")
+	require.True(state.Decided)
+	require.Zero(w.OscillatingItems())
+	require.Zero(w.FlipCount("item"))
+	require.Empty(w.flips)
+}
+
+func TestEscalateOscillatingItem(t *testing.T) {
+	for _, escalate := range []bool{false, true} {
+		w := newOscillationWave(t, escalate)
+		for i := 0; i < 4; i++ {
+			w.RecordPoll("item", 10*(i%2), 10)
+		}
+		require.Equal(t, uint32(3), w.FlipCount("item"))
+
+		// 8 of 10 falls short of Alpha 0.9 but clears every FPC threshold
+		// in [0.5, 0.8], so only an escalated item extends its yes streak
+		w.RecordPoll("item", 8, 10)
+		state, _ := w.State("item")
+		if escalate {
+			require.Equal(t, uint32(2), state.Count)
+		} else {
+			require.Zero(t, state.Count)
+		}
+	}
+
+	// Below the flip threshold the fixed Alpha still applies
+	w := newOscillationWave(t, true)
+	w.RecordPoll("item", 0, 10)
+	w.RecordPoll("item", 10, 10)
+	w.RecordPoll("item", 8, 10)
+	state, _ := w.State("item")
+	require.Zero(t, state.Count)
+}
+
+func TestEscalateOscillatingRequiresSeed(t *testing.T) {
+	_, err := New[string](Config{K: 10, Alpha: 0.9, Beta: 5, EscalateOscillating: true}, newMockCut[string](10), newMockTransport[string]())
+	require.Error(t, err)
+}
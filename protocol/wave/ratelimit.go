@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+)
+
+// ErrRateLimited is returned by a rate-limited VoteHandler for a request
+// from a peer that has exhausted its bucket
+var ErrRateLimited = errors.New("wave: peer rate limited")
+
+// RateLimitConfig configures the per-peer token bucket applied to vote
+// requests from peers and to the photons they send.
+type RateLimitConfig struct {
+	Rate  float64          // Tokens refilled per second, per peer; negative is 0
+	Burst int              // Bucket capacity, per peer; negative is 0
+	Now   func() time.Time // Clock; defaults to time.Now
+}
+
+// tokenBucket is a single peer's allowance.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter is a per-peer token bucket. A peer that exhausts its bucket has
+// further messages dropped until it refills; other peers are unaffected.
+type RateLimiter struct {
+	mu      sync.Mutex
+	cfg     RateLimitConfig
+	buckets map[types.NodeID]*tokenBucket
+	dropped map[types.NodeID]uint64
+}
+
+// NewRateLimiter creates a per-peer rate limiter.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	cfg.Rate = max(cfg.Rate, 0)
+	cfg.Burst = max(cfg.Burst, 0)
+	return &RateLimiter{
+		cfg:     cfg,
+		buckets: make(map[types.NodeID]*tokenBucket),
+		dropped: make(map[types.NodeID]uint64),
+	}
+}
+
+// Allow consumes one token from peer's bucket. It returns false, and counts
+// a drop, if the bucket is empty.
+func (r *RateLimiter) Allow(peer types.NodeID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.cfg.Now()
+	b, ok := r.buckets[peer]
+	if !ok {
+		b = &tokenBucket{tokens: float64(r.cfg.Burst), last: now}
+		r.buckets[peer] = b
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * r.cfg.Rate
+		if b.tokens > float64(r.cfg.Burst) {
+			b.tokens = float64(r.cfg.Burst)
+		}
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		r.dropped[peer]++
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Dropped returns how many messages from peer have been dropped.
+func (r *RateLimiter) Dropped(peer types.NodeID) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped[peer]
+}
+
+// VoteHandler answers a peer's request for this node's vote on item
+type VoteHandler[T comparable] interface {
+	HandleVoteRequest(ctx context.Context, from types.NodeID, item T) (Photon[T], error)
+}
+
+// VoteHandlerFunc adapts a function to VoteHandler
+type VoteHandlerFunc[T comparable] func(ctx context.Context, from types.NodeID, item T) (Photon[T], error)
+
+// HandleVoteRequest calls f
+func (f VoteHandlerFunc[T]) HandleVoteRequest(ctx context.Context, from types.NodeID, item T) (Photon[T], error) {
+	return f(ctx, from, item)
+}
+
+// NewVoteHandler answers vote requests with tx's local photon for w's
+// current preference
+func NewVoteHandler[T comparable](w *Wave[T], tx Transport[T]) VoteHandler[T] {
+	return VoteHandlerFunc[T](func(ctx context.Context, _ types.NodeID, item T) (Photon[T], error) {
+		if err := ctx.Err(); err != nil {
+			return Photon[T]{}, err
+		}
+		return tx.MakeLocalPhoton(item, w.Preference(item)), nil
+	})
+}
+
+// NewRateLimitedHandler wraps h so that vote requests from a peer over its
+// rate are refused with ErrRateLimited before reaching h. This is the
+// receive path a flooding peer hits.
+func NewRateLimitedHandler[T comparable](h VoteHandler[T], limiter *RateLimiter) VoteHandler[T] {
+	return VoteHandlerFunc[T](func(ctx context.Context, from types.NodeID, item T) (Photon[T], error) {
+		if !limiter.Allow(from) {
+			return Photon[T]{}, fmt.Errorf("%w: %s", ErrRateLimited, from)
+		}
+		return h.HandleVoteRequest(ctx, from, item)
+	})
+}
+
+// RateLimitedTransport wraps a Transport and drops photons answering our
+// own polls from peers that exceed their rate. Inbound vote requests are
+// limited by NewRateLimitedHandler.
+type RateLimitedTransport[T comparable] struct {
+	Transport[T]
+	limiter *RateLimiter
+}
+
+// NewRateLimitedTransport wraps tx with limiter on the receive path.
+func NewRateLimitedTransport[T comparable](tx Transport[T], limiter *RateLimiter) *RateLimitedTransport[T] {
+	return &RateLimitedTransport[T]{
+		Transport: tx,
+		limiter:   limiter,
+	}
+}
+
+// Limiter returns the underlying rate limiter.
+func (t *RateLimitedTransport[T]) Limiter() *RateLimiter {
+	return t.limiter
+}
+
+// RequestVotes forwards the request and filters responses through the
+// per-peer rate limiter. If ctx is cancelled the remaining responses are
+// drained, so the wrapped transport never blocks sending them.
+func (t *RateLimitedTransport[T]) RequestVotes(ctx context.Context, peers []types.NodeID, item T) <-chan Photon[T] {
+	in := t.Transport.RequestVotes(ctx, peers, item)
+	out := make(chan Photon[T], len(peers))
+	go func() {
+		defer close(out)
+		for photon := range in {
+			if !t.limiter.Allow(photon.Sender) {
+				continue
+			}
+			select {
+			case out <- photon:
+			case <-ctx.Done():
+				for range in {
+				}
+				return
+			}
+		}
+	}()
+	return out
+}
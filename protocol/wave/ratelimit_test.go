@@ -0,0 +1,141 @@
+package wave
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedTransportDropsFlooder(t *testing.T) {
+	require := require.New(t)
+
+	flooder := types.NodeID{0xAA}
+	honest := types.NodeID{0xBB}
+
+	tx := newMockTransport[string]()
+	for i := 0; i < 10; i++ {
+		tx.votes["item"] = append(tx.votes["item"], Photon[string]{Item: "item", Prefer: true, Sender: flooder})
+	}
+	tx.votes["item"] = append(tx.votes["item"], Photon[string]{Item: "item", Prefer: true, Sender: honest})
+
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 3,
+		Now:   func() time.Time { return now },
+	})
+	rl := NewRateLimitedTransport[string](tx, limiter)
+
+	received := map[types.NodeID]int{}
+	for photon := range rl.RequestVotes(context.Background(), []types.NodeID{flooder, honest}, "item") {
+		received[photon.Sender]++
+	}
+
+	require.Equal(3, received[flooder])
+	require.Equal(1, received[honest])
+	require.Equal(uint64(7), limiter.Dropped(flooder))
+	require.Zero(limiter.Dropped(honest))
+}
+
+func TestRateLimiterRefills(t *testing.T) {
+	require := require.New(t)
+
+	peer := types.NodeID{0x01}
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(RateLimitConfig{
+		Rate:  2,
+		Burst: 2,
+		Now:   func() time.Time { return now },
+	})
+
+	require.True(limiter.Allow(peer))
+	require.True(limiter.Allow(peer))
+	require.False(limiter.Allow(peer))
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(limiter.Allow(peer))
+	require.False(limiter.Allow(peer))
+
+	// Refill is capped at Burst.
+	now = now.Add(time.Hour)
+	require.True(limiter.Allow(peer))
+	require.True(limiter.Allow(peer))
+	require.False(limiter.Allow(peer))
+	require.Equal(uint64(3), limiter.Dropped(peer))
+}
+
+func TestRateLimitedHandlerRefusesFlooder(t *testing.T) {
+	require := require.New(t)
+
+	flooder := types.NodeID{0xAA}
+	honest := types.NodeID{0xBB}
+
+	tx := newMockTransport[string]()
+	w, err := New[string](Config{K: 3, Alpha: 0.6, Beta: 1, RoundTO: time.Second}, newMockCut[string](3), tx)
+	require.NoError(err)
+	limiter := NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 2,
+		Now:   func() time.Time { return time.Unix(0, 0) },
+	})
+	h := NewRateLimitedHandler(NewVoteHandler(&w, tx), limiter)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := h.HandleVoteRequest(ctx, flooder, "item")
+		require.NoError(err)
+	}
+	_, err = h.HandleVoteRequest(ctx, flooder, "item")
+	require.ErrorIs(err, ErrRateLimited)
+	require.Equal(uint64(1), limiter.Dropped(flooder))
+
+	photon, err := h.HandleVoteRequest(ctx, honest, "item")
+	require.NoError(err)
+	require.Equal("item", photon.Item)
+	require.Zero(limiter.Dropped(honest))
+}
+
+func TestRateLimitedTransportDrainsOnCancel(t *testing.T) {
+	sent := make(chan struct{})
+	tx := NewTransport[string](VoteTransportFunc[string](func(ctx context.Context, peers []types.NodeID, item string) <-chan Photon[string] {
+		ch := make(chan Photon[string])
+		go func() {
+			defer close(sent)
+			defer close(ch)
+			for i := 0; i < 5; i++ {
+				ch <- Photon[string]{Item: item, Sender: types.NodeID{byte(i)}}
+			}
+		}()
+		return ch
+	}), types.NodeID{})
+	limiter := NewRateLimiter(RateLimitConfig{Rate: 1, Burst: 10})
+	rl := NewRateLimitedTransport[string](tx, limiter)
+
+	// Nobody reads the responses; cancelling must still release the sender
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = rl.RequestVotes(ctx, []types.NodeID{{1}}, "item")
+	cancel()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("wrapped transport blocked after cancel")
+	}
+}
+
+func TestRateLimiterNegativeConfig(t *testing.T) {
+	require := require.New(t)
+
+	peer := types.NodeID{0x01}
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(RateLimitConfig{
+		Rate:  -5,
+		Burst: -1,
+		Now:   func() time.Time { return now },
+	})
+	require.False(limiter.Allow(peer))
+	now = now.Add(time.Hour)
+	require.False(limiter.Allow(peer))
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/core/types"
+	"github.com/luxfi/consensus/protocol/prism"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingCut hands out a fresh committee on every Sample call
+type rotatingCut struct {
+	mu   sync.Mutex
+	next byte
+}
+
+func (c *rotatingCut) Sample(k int) []types.NodeID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	peers := make([]types.NodeID, k)
+	for i := range peers {
+		c.next++
+		peers[i] = types.NodeID{c.next}
+	}
+	return peers
+}
+
+func (c *rotatingCut) Luminance() prism.Luminance {
+	return prism.Luminance{ActivePeers: 256, TotalPeers: 256}
+}
+
+// repollTransport answers the first poll with a split vote. Re-polls are
+// held until `parallel` of them are in flight, then poll number `winner`
+// (1-based, counting re-polls only) answers unanimously and the rest split.
+type repollTransport struct {
+	k        int
+	parallel int
+	winner   int
+
+	mu       sync.Mutex
+	requests int
+	inFlight chan struct{}
+}
+
+func newRepollTransport(k, parallel, winner int) *repollTransport {
+	return &repollTransport{k: k, parallel: parallel, winner: winner, inFlight: make(chan struct{})}
+}
+
+func (r *repollTransport) RequestVotes(ctx context.Context, peers []types.NodeID, item string) <-chan Photon[string] {
+	r.mu.Lock()
+	n := r.requests
+	r.requests++
+	if n == r.parallel {
+		close(r.inFlight)
+	}
+	r.mu.Unlock()
+
+	ch := make(chan Photon[string], r.k)
+	go func() {
+		if n > 0 {
+			select {
+			case <-r.inFlight:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for i := 0; i < r.k; i++ {
+			ch <- Photon[string]{Item: item, Prefer: n == r.winner || i%2 == 0, Sender: peers[i]}
+		}
+	}()
+	return ch
+}
+
+func (r *repollTransport) MakeLocalPhoton(item string, prefer bool) Photon[string] {
+	return Photon[string]{Item: item, Prefer: prefer}
+}
+
+func (r *repollTransport) Requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+func TestConcurrentRepollsDecideOnFirstQuorum(t *testing.T) {
+	require := require.New(t)
+
+	tx := newRepollTransport(4, 3, 2)
+	w, err := New[string](Config{
+		K:                 4,
+		Alpha:             0.75,
+		Beta:              1,
+		RoundTO:           time.Second,
+		ConcurrentRepolls: 3,
+	}, &rotatingCut{}, tx)
+	require.NoError(err)
+
+	require.True(w.TickTimeout(context.Background(), "tx1", time.Second))
+	require.Equal(4, tx.Requests())
+
+	state, ok := w.State("tx1")
+	require.True(ok)
+	require.True(state.Decided)
+	require.Equal(types.DecideAccept, state.Result)
+}
+
+func TestConcurrentRepollsAllFail(t *testing.T) {
+	require := require.New(t)
+
+	tx := newRepollTransport(4, 3, -1)
+	w, err := New[string](Config{
+		K:                 4,
+		Alpha:             0.75,
+		Beta:              1,
+		RoundTO:           time.Second,
+		ConcurrentRepolls: 3,
+	}, &rotatingCut{}, tx)
+	require.NoError(err)
+
+	require.False(w.TickTimeout(context.Background(), "tx1", time.Second))
+	require.Equal(4, tx.Requests())
+
+	state, ok := w.State("tx1")
+	require.True(ok)
+	require.False(state.Decided)
+	require.Zero(state.Count)
+}
+
+func TestNoRepollsByDefault(t *testing.T) {
+	require := require.New(t)
+
+	tx := newRepollTransport(4, 3, 1)
+	w, err := New[string](Config{
+		K:       4,
+		Alpha:   0.75,
+		Beta:    1,
+		RoundTO: time.Second,
+	}, &rotatingCut{}, tx)
+	require.NoError(err)
+
+	require.False(w.TickTimeout(context.Background(), "tx1", time.Second))
+	require.Equal(1, tx.Requests())
+}
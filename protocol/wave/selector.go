@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wave
+
+import "github.com/luxfi/consensus/protocol/wave/fpc"
+
+// ThresholdSelector picks the FPC vote threshold for each phase.
+// fpc.Selector and fpc.AdaptiveSelector implement it.
+type ThresholdSelector interface {
+	// SelectThreshold returns the votes needed out of k in phase
+	SelectThreshold(phase uint64, k int) int
+
+	// Range returns the θ range thresholds are currently drawn from
+	Range() (min, max float64)
+}
+
+// ThresholdObserver is a ThresholdSelector that adapts to the tallies it
+// has seen, as fpc.AdaptiveSelector does. Wave feeds it every recorded
+// round's tally in order, after drawing that round's threshold.
+type ThresholdObserver interface {
+	ThresholdSelector
+	Observe(yesVotes, totalVotes int)
+}
+
+var (
+	_ ThresholdSelector = (*fpc.Selector)(nil)
+	_ ThresholdObserver = (*fpc.AdaptiveSelector)(nil)
+)
+
+// WithThresholdSelector makes wave draw every round's threshold from s, as
+// EnableFPC does, in place of a selector built from ThetaMin, ThetaMax and
+// FPCSeed. Config.EnableFPC and Config.FPCSeed are not needed with it.
+func WithThresholdSelector(s ThresholdSelector) Option {
+	return func(o *options) {
+		o.selector = s
+	}
+}
+
+// observeLocked feeds a recorded tally to an adaptive selector
+// Caller holds w.mu.
+func (w *Wave[T]) observeLocked(yesVotes, totalVotes int) {
+	if obs, ok := w.fpcSelector.(ThresholdObserver); ok {
+		obs.Observe(yesVotes, totalVotes)
+	}
+}
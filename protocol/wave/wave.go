@@ -2,6 +2,8 @@ package wave
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -10,20 +12,62 @@ import (
 	"github.com/luxfi/consensus/protocol/wave/fpc"
 )
 
+// ErrTooManyOutstanding is returned by Initialize when MaxOutstandingItems
+// items are already undecided
+var ErrTooManyOutstanding = errors.New("wave: too many outstanding items")
+
 // Photon represents a vote message in the consensus protocol
 type Photon[T comparable] struct {
-	Item      T
-	Prefer    bool
+	Item   T
+	Prefer bool
+	// Sender is the voter. A voter's repeated votes in a round count once;
+	// a transport that cannot attribute votes leaves Sender empty, and
+	// such votes are counted as they arrive.
 	Sender    types.NodeID
 	Timestamp time.Time
 }
 
-// Transport handles network communication for voting
-type Transport[T comparable] interface {
+// VoteTransport is the pluggable network edge of a round. Wave samples
+// peers, calls RequestVotes once per poll and reads the returned channel
+// until K votes arrive, the round times out or ctx is cancelled.
+// Implementations should send at most one Photon per peer, set its Sender
+// so redelivered votes are recognised, and stop sending when ctx is done. Closing the channel before K votes arrive makes the
+// remaining reads count as votes against the item. A real network transport
+// and a test stub plug in the same way; wrap one with NewTransport to pass
+// it to wave, ray, field, nova or nebula.
+type VoteTransport[T comparable] interface {
 	RequestVotes(ctx context.Context, peers []types.NodeID, item T) <-chan Photon[T]
+}
+
+// VoteTransportFunc adapts a function, such as a test stub, to VoteTransport
+type VoteTransportFunc[T comparable] func(ctx context.Context, peers []types.NodeID, item T) <-chan Photon[T]
+
+// RequestVotes calls f
+func (f VoteTransportFunc[T]) RequestVotes(ctx context.Context, peers []types.NodeID, item T) <-chan Photon[T] {
+	return f(ctx, peers, item)
+}
+
+// Transport is a VoteTransport that can also produce this node's own vote
+type Transport[T comparable] interface {
+	VoteTransport[T]
 	MakeLocalPhoton(item T, prefer bool) Photon[T]
 }
 
+// NewTransport adapts a VoteTransport into a Transport whose local photons
+// are sent as self.
+func NewTransport[T comparable](vt VoteTransport[T], self types.NodeID) Transport[T] {
+	return &voteTransport[T]{VoteTransport: vt, self: self}
+}
+
+type voteTransport[T comparable] struct {
+	VoteTransport[T]
+	self types.NodeID
+}
+
+func (t *voteTransport[T]) MakeLocalPhoton(item T, prefer bool) Photon[T] {
+	return Photon[T]{Item: item, Prefer: prefer, Sender: t.self, Timestamp: time.Now()}
+}
+
 // Config holds configuration for wave consensus
 type Config struct {
 	K         int           // Sample size
@@ -34,13 +78,62 @@ type Config struct {
 	ThetaMin  float64       // FPC minimum threshold (default: 0.5)
 	ThetaMax  float64       // FPC maximum threshold (default: 0.8)
 	FPCSeed   []byte        // FPC seed (required when EnableFPC=true); use fpc.DeriveEpochSeed
+
+	// ConcurrentRepolls is how many fresh committees are polled in parallel
+	// after a round fails to reach the threshold (0 = no re-polling). It
+	// also caps the re-polls in flight across all items.
+	ConcurrentRepolls int
+
+	// ConcurrentPolls caps how many first-round polls are in flight across
+	// all items, bounding the fan-out load on the transport (0 = no limit)
+	ConcurrentPolls int
+
+	// RejectExcessPolls refuses a poll or re-poll past its concurrency
+	// limit instead of waiting for one in flight to finish. A refused
+	// round reports that it did not reach the threshold.
+	RejectExcessPolls bool
+
+	// EffectiveK, when set, overrides K each round with the live sample
+	// size (e.g. photon.AdaptiveEmitter.EffectiveK) so thresholds track
+	// the committee actually polled
+	EffectiveK func() int
+
+	// MinRoundInterval is the minimum gap between two polls of the same
+	// item; a Tick inside the gap is skipped (0 = no limit)
+	MinRoundInterval time.Duration
+
+	// MaxItemProcessingTime bounds how long an item may stay undecided
+	// after its first round; it is then timed out (0 = no limit)
+	MaxItemProcessingTime time.Duration
+
+	// MaxOutstandingItems caps how many items may be undecided at once.
+	// New items beyond it are refused until some decide or time out;
+	// items already admitted keep being polled (0 = no limit).
+	MaxOutstandingItems int
+
+	// Clock times rounds, pacing and processing deadlines
+	// (nil = SystemClock)
+	Clock Clock
+
+	// OscillationFlips is how many preference flips mark an undecided item
+	// as oscillating (0 = DefaultOscillationFlips)
+	OscillationFlips uint32
+
+	// EscalateOscillating polls an oscillating item with FPC thresholds
+	// drawn from [ThetaMin, ThetaMax] instead of the fixed Alpha. It has no
+	// effect with EnableFPC and otherwise requires FPCSeed.
+	EscalateOscillating bool
 }
 
+// timeoutBuffer is the capacity of the Timeouts channel
+const timeoutBuffer = 256
+
 // WaveState represents the polling state of an item in wave consensus
 type WaveState struct {
-	Decided bool
-	Result  types.Decision
-	Count   uint32
+	Decided  bool
+	Result   types.Decision
+	Count    uint32
+	TimedOut bool // undecided after MaxItemProcessingTime; no longer polled
 }
 
 // Wave manages threshold voting and confidence building
@@ -50,21 +143,78 @@ type Wave[T comparable] struct {
 	tx  Transport[T]
 
 	// FPC support
-	fpcSelector *fpc.Selector
+	fpcSelector ThresholdSelector
 	phase       uint64 // Current phase for FPC threshold selection
 
+	// escalation, if set, picks the thresholds of oscillating items
+	// (see oscillation.go)
+	escalation *fpc.Selector
+
+	// agg turns poll tallies into (preferOK, confOK)
+	agg Aggregator
+
+	// verifier, if set, checks each sampled committee before it is polled
+	verifier CommitteeVerifier
+
+	// alpha, if set, supplies the threshold ratio in place of cfg.Alpha
+	alpha *AlphaController
+
+	// polls and repolls bound the polls in flight (see concurrency.go)
+	polls   *pollLimiter
+	repolls *pollLimiter
+
+	// votes drops votes redelivered within a round (see dedup.go)
+	votes *voteDedup[T]
+
 	// State tracking
-	mu     sync.RWMutex
-	states map[T]*WaveState
-	prefs  map[T]bool // current preferences
+	mu          sync.RWMutex
+	states      map[T]*WaveState
+	prefs       map[T]bool // current preferences
+	outstanding int        // undecided, not timed out items in states
+
+	// clock times poll rounds; now reads it for pacing and deadlines
+	clock Clock
+
+	// Per-item round pacing (MinRoundInterval)
+	now       func() time.Time
+	lastRound map[T]roundMark
+
+	// Per-item processing deadline (MaxItemProcessingTime)
+	started  map[T]time.Time
+	timeouts chan T
+
+	// flips counts each undecided item's preference flips; an item's
+	// entry is dropped once it decides or times out
+	flips map[T]uint32
+}
+
+// roundMark records when an item was last polled and whether that round
+// reached the threshold
+type roundMark struct {
+	at     time.Time
+	quorum bool
 }
 
 // New creates a new Wave instance.
 // When cfg.EnableFPC is true, cfg.FPCSeed must be non-empty.
-func New[T comparable](cfg Config, cut prism.Cut[T], tx Transport[T]) (Wave[T], error) {
+// Without options polls are aggregated by ThresholdAggregator.
+func New[T comparable](cfg Config, cut prism.Cut[T], tx Transport[T], opts ...Option) (Wave[T], error) {
+	o := options{aggregator: ThresholdAggregator{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	clock := cfg.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
 	// Initialize FPC selector if enabled
-	var fpcSel *fpc.Selector
-	if cfg.EnableFPC {
+	var fpcSel ThresholdSelector
+	switch {
+	case o.selector != nil:
+		fpcSel = o.selector
+	case cfg.EnableFPC:
 		thetaMin := cfg.ThetaMin
 		if thetaMin == 0 {
 			thetaMin = 0.5 // Default
@@ -73,11 +223,18 @@ func New[T comparable](cfg Config, cut prism.Cut[T], tx Transport[T]) (Wave[T],
 		if thetaMax == 0 {
 			thetaMax = 0.8 // Default
 		}
-		var err error
-		fpcSel, err = fpc.NewSelector(thetaMin, thetaMax, cfg.FPCSeed)
+		sel, err := fpc.NewSelector(thetaMin, thetaMax, cfg.FPCSeed)
 		if err != nil {
 			return Wave[T]{}, err
 		}
+		fpcSel = sel
+	}
+	var escalation *fpc.Selector
+	if fpcSel == nil {
+		var err error
+		if escalation, err = newEscalation(cfg); err != nil {
+			return Wave[T]{}, err
+		}
 	}
 
 	return Wave[T]{
@@ -85,79 +242,164 @@ func New[T comparable](cfg Config, cut prism.Cut[T], tx Transport[T]) (Wave[T],
 		cut:         cut,
 		tx:          tx,
 		fpcSelector: fpcSel,
+		escalation:  escalation,
 		phase:       0,
+		agg:         o.aggregator,
+		verifier:    o.verifier,
+		alpha:       o.alpha,
+		polls:       newPollLimiter(cfg.ConcurrentPolls, cfg.RejectExcessPolls),
+		repolls:     newPollLimiter(cfg.ConcurrentRepolls, cfg.RejectExcessPolls),
+		votes:       newVoteDedup[T](),
 		states:      make(map[T]*WaveState),
 		prefs:       make(map[T]bool),
+		clock:       clock,
+		now:         clock.Now,
+		lastRound:   make(map[T]roundMark),
+		started:     make(map[T]time.Time),
+		timeouts:    make(chan T, timeoutBuffer),
+		flips:       make(map[T]uint32),
 	}, nil
 }
 
 // Tick performs one round of sampling and threshold checking for an item
 func (w *Wave[T]) Tick(ctx context.Context, item T) {
+	w.TickTimeout(ctx, item, w.cfg.RoundTO)
+}
+
+// TickTimeout performs one round like Tick but waits at most roundTO for
+// votes. It reports whether the round reached the threshold in either
+// direction. A tick within MinRoundInterval of the item's previous poll
+// launches no poll and repeats that round's result. An item past
+// MaxItemProcessingTime is timed out instead of polled. Items that are
+// decided or timed out run no round and report false, so they cannot reset
+// a caller's round-timeout backoff; neither does a new item refused by
+// MaxOutstandingItems.
+func (w *Wave[T]) TickTimeout(ctx context.Context, item T, roundTO time.Duration) bool {
 	// Get current state or create new one
 	w.mu.Lock()
-	state, exists := w.states[item]
-	if !exists {
-		state = &WaveState{Decided: false, Result: types.DecideUndecided, Count: 0}
-		w.states[item] = state
+	state, err := w.stateLocked(item)
+	if err != nil {
+		w.mu.Unlock()
+		return false
 	}
 
-	// Skip if already decided
-	if state.Decided {
+	// Skip if already decided or timed out
+	if state.Decided || state.TimedOut {
 		w.mu.Unlock()
-		return
+		return false
+	}
+	if w.expiredLocked(item, w.now()) {
+		w.timeoutLocked(item, state)
+		w.mu.Unlock()
+		return false
+	}
+
+	// Skip if the item was polled too recently
+	if w.cfg.MinRoundInterval > 0 {
+		now := w.now()
+		if last, ok := w.lastRound[item]; ok && now.Sub(last.at) < w.cfg.MinRoundInterval {
+			w.mu.Unlock()
+			return last.quorum
+		}
+		w.lastRound[item] = roundMark{at: now}
 	}
 	w.mu.Unlock()
 
 	// Cut light rays (sample peers) and request votes
-	peers := w.cut.Sample(w.cfg.K)
-	votes := w.tx.RequestVotes(ctx, peers, item)
-
-	// Count votes
-	yesVotes := 0
-	totalVotes := 0
+	yesVotes, totalVotes, ok := w.poll(ctx, w.polls, item, roundTO)
+	if !ok {
+		return false
+	}
 
-	// Collect votes with timeout
-	timeout := time.After(w.cfg.RoundTO)
-	for {
-		select {
-		case vote := <-votes:
-			totalVotes++
-			if vote.Prefer {
-				yesVotes++
-			}
-			// Break if we have enough votes
-			if totalVotes >= w.cfg.K {
-				goto countVotes
+	// A failed round re-polls fresh committees concurrently; the first
+	// to reach the threshold stands in for the failed poll
+	if w.cfg.ConcurrentRepolls > 0 {
+		w.mu.RLock()
+		phase := w.phase + 1
+		threshold := w.itemThreshold(item, phase)
+		w.mu.RUnlock()
+		if _, confOK := w.aggregate(yesVotes, totalVotes, threshold, phase); !confOK {
+			if yes, total, ok := w.repoll(ctx, item, roundTO, threshold, phase); ok {
+				yesVotes, totalVotes = yes, total
+			} else if ctx.Err() != nil {
+				return false
 			}
-		case <-timeout:
-			goto countVotes
-		case <-ctx.Done():
-			return
 		}
 	}
 
-countVotes:
-	if totalVotes == 0 {
-		return
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	quorum, _ := w.recordLocked(item, yesVotes, totalVotes)
+	if mark, ok := w.lastRound[item]; ok {
+		mark.quorum = quorum
+		w.lastRound[item] = mark
 	}
+	return quorum
+}
 
+// PollResult is the tally of one poll round for an item
+type PollResult struct {
+	Yes   int // votes preferring the item
+	Total int // votes received
+}
+
+// RecordPoll folds one poll's tally into item's confidence, as a Tick round
+// would after collecting votes. It reports whether item became accepted. A
+// tally for a new item refused by MaxOutstandingItems is dropped.
+func (w *Wave[T]) RecordPoll(item T, yesVotes, totalVotes int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, accepted := w.recordLocked(item, yesVotes, totalVotes)
+	return accepted
+}
+
+// RecordPollBatch folds polls into item's confidence in order under a single
+// lock. The result is the same as calling RecordPoll for each poll in turn;
+// it reports whether item became accepted during the batch.
+func (w *Wave[T]) RecordPollBatch(item T, polls []PollResult) bool {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	accepted := false
+	for _, p := range polls {
+		if _, ok := w.recordLocked(item, p.Yes, p.Total); ok {
+			accepted = true
+		}
+	}
+	return accepted
+}
+
+// recordLocked applies one poll tally to item. quorum reports whether the
+// tally cleared the threshold in either direction (a decided item counts);
+// accepted reports whether this tally decided the item as accepted. Polls
+// with no votes are ignored. Caller holds w.mu.
+func (w *Wave[T]) recordLocked(item T, yesVotes, totalVotes int) (quorum, accepted bool) {
+	state, err := w.stateLocked(item)
+	if err != nil {
+		return false, false
+	}
+	if state.Decided || state.TimedOut {
+		return true, false
+	}
+	if totalVotes == 0 {
+		return false, false
+	}
 
 	// Increment phase for FPC
 	w.phase++
 
 	// Calculate threshold using FPC or fixed Alpha
-	var threshold int
-	if w.fpcSelector != nil {
-		threshold = w.fpcSelector.SelectThreshold(w.phase, w.cfg.K)
-	} else {
-		threshold = int(float64(w.cfg.K) * w.cfg.Alpha)
-	}
+	threshold := w.itemThreshold(item, w.phase)
 
-	currentPref := w.prefs[item]
+	w.observeLocked(yesVotes, totalVotes)
 
-	if yesVotes >= threshold {
+	currentPref, hadPref := w.prefs[item]
+	preferOK, confOK := w.aggregate(yesVotes, totalVotes, threshold, w.phase)
+	quorum = confOK
+	if confOK && hadPref && preferOK != currentPref {
+		w.flips[item]++
+	}
+
+	if confOK && preferOK {
 		// Strong preference for yes
 		w.prefs[item] = true
 		if currentPref {
@@ -167,7 +409,7 @@ countVotes:
 			// Preference switch
 			state.Count = 1
 		}
-	} else if (totalVotes - yesVotes) >= threshold {
+	} else if confOK {
 		// Strong preference for no
 		w.prefs[item] = false
 		if !currentPref {
@@ -185,12 +427,225 @@ countVotes:
 	// Check for decision
 	if state.Count >= w.cfg.Beta {
 		state.Decided = true
+		w.outstanding--
+		delete(w.started, item)
+		delete(w.flips, item)
 		if w.prefs[item] {
 			state.Result = types.DecideAccept
+			accepted = true
 		} else {
 			state.Result = types.DecideReject
 		}
 	}
+	return quorum, accepted
+}
+
+// Initialize admits item for polling. It returns ErrTooManyOutstanding if
+// item is new and MaxOutstandingItems items are already undecided; an item
+// already admitted, decided or not, is accepted again.
+func (w *Wave[T]) Initialize(item T) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.stateLocked(item)
+	return err
+}
+
+// Outstanding returns how many admitted items are still undecided
+func (w *Wave[T]) Outstanding() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.outstanding
+}
+
+// Forget drops item's state, freeing its MaxOutstandingItems slot if it
+// is still undecided. Callers that keep their own record of decisions use
+// it to evict decided items; a forgotten item is unknown to wave and would
+// be admitted as new if polled again.
+func (w *Wave[T]) Forget(item T) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state, ok := w.states[item]
+	if !ok {
+		return
+	}
+	if !state.Decided && !state.TimedOut {
+		w.outstanding--
+	}
+	delete(w.states, item)
+	delete(w.prefs, item)
+	delete(w.lastRound, item)
+	delete(w.started, item)
+	delete(w.flips, item)
+}
+
+// stateLocked returns item's state, creating it and starting its
+// processing clock on first use. Creating it fails with
+// ErrTooManyOutstanding at the MaxOutstandingItems limit. Caller holds w.mu.
+func (w *Wave[T]) stateLocked(item T) (*WaveState, error) {
+	state, exists := w.states[item]
+	if !exists {
+		if limit := w.cfg.MaxOutstandingItems; limit > 0 && w.outstanding >= limit {
+			return nil, fmt.Errorf("%w: limit %d", ErrTooManyOutstanding, limit)
+		}
+		state = &WaveState{Decided: false, Result: types.DecideUndecided, Count: 0}
+		w.states[item] = state
+		w.outstanding++
+		if w.cfg.MaxItemProcessingTime > 0 {
+			w.started[item] = w.now()
+		}
+	}
+	return state, nil
+}
+
+// expiredLocked reports whether item has been processing for at least
+// MaxItemProcessingTime at now. Caller holds w.mu.
+func (w *Wave[T]) expiredLocked(item T, now time.Time) bool {
+	start, ok := w.started[item]
+	return ok && w.cfg.MaxItemProcessingTime > 0 && now.Sub(start) >= w.cfg.MaxItemProcessingTime
+}
+
+// timeoutLocked marks item timed out, drops its processing state and
+// reports it on the timeouts channel. Caller holds w.mu.
+func (w *Wave[T]) timeoutLocked(item T, state *WaveState) {
+	state.TimedOut = true
+	state.Count = 0
+	w.outstanding--
+	delete(w.started, item)
+	delete(w.prefs, item)
+	delete(w.lastRound, item)
+	delete(w.flips, item)
+	select {
+	case w.timeouts <- item:
+	default:
+		// Consumer is behind; State still reports TimedOut
+	}
+}
+
+// ExpireStale times out every undecided item that has been processing for
+// MaxItemProcessingTime, including items no longer being ticked, and
+// returns them.
+func (w *Wave[T]) ExpireStale() []T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	var expired []T
+	for item := range w.started {
+		if w.expiredLocked(item, now) {
+			w.timeoutLocked(item, w.states[item])
+			expired = append(expired, item)
+		}
+	}
+	return expired
+}
+
+// Timeouts delivers items as they time out. It is buffered; if the consumer
+// falls behind further reports are dropped, but State still shows TimedOut.
+func (w *Wave[T]) Timeouts() <-chan T {
+	return w.timeouts
+}
+
+// threshold returns the vote threshold for a phase, using FPC when enabled,
+// then the AlphaController if set, and the fixed Alpha otherwise
+func (w *Wave[T]) threshold(phase uint64) int {
+	k := w.k()
+	if w.fpcSelector != nil {
+		return w.fpcSelector.SelectThreshold(phase, k)
+	}
+	alpha := w.cfg.Alpha
+	if w.alpha != nil {
+		alpha = w.alpha.Alpha()
+	}
+	return int(float64(k) * alpha)
+}
+
+// k returns the sample size for the current round
+func (w *Wave[T]) k() int {
+	if w.cfg.EffectiveK != nil {
+		if k := w.cfg.EffectiveK(); k > 0 && k < w.cfg.K {
+			return k
+		}
+	}
+	return w.cfg.K
+}
+
+// poll takes a slot from limiter, then samples a committee and collects
+// its votes for item until K distinct voters answer, the transport closes
+// the vote channel or roundTO elapses. A voter's repeated votes in the
+// round count once, and when the channel closes early the voters yet to
+// answer count as not preferring item. It returns false if no slot is
+// available, ctx is cancelled first or the committee fails verification.
+func (w *Wave[T]) poll(ctx context.Context, limiter *pollLimiter, item T, roundTO time.Duration) (yesVotes, totalVotes int, ok bool) {
+	if !limiter.acquire(ctx) {
+		return 0, 0, false
+	}
+	defer limiter.release()
+
+	k := w.k()
+	peers := w.cut.Sample(k)
+	if w.verifier != nil && w.verifier.VerifyCommittee(peers) != nil {
+		return 0, 0, false
+	}
+	round := w.votes.begin(item)
+	defer w.votes.end(round)
+	votes := w.tx.RequestVotes(ctx, peers, item)
+
+	timeout := w.clock.After(roundTO)
+	for {
+		select {
+		case vote, open := <-votes:
+			if !open {
+				// Committee members that never answered count against
+				return yesVotes, k, true
+			}
+			if !w.votes.first(round, vote.Sender) {
+				continue
+			}
+			totalVotes++
+			if vote.Prefer {
+				yesVotes++
+			}
+			// Break if we have enough votes
+			if totalVotes >= k {
+				return yesVotes, totalVotes, true
+			}
+		case <-timeout:
+			return yesVotes, totalVotes, true
+		case <-ctx.Done():
+			return 0, 0, false
+		}
+	}
+}
+
+// repoll polls up to ConcurrentRepolls fresh committees in parallel and
+// returns the votes of the first to reach quorum in either direction.
+// The remaining polls are cancelled. It returns false if none reaches it.
+func (w *Wave[T]) repoll(ctx context.Context, item T, roundTO time.Duration, threshold int, phase uint64) (yesVotes, totalVotes int, ok bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct{ yes, total int }
+	results := make(chan result, w.cfg.ConcurrentRepolls)
+	for i := 0; i < w.cfg.ConcurrentRepolls; i++ {
+		go func() {
+			yes, total, ok := w.poll(ctx, w.repolls, item, roundTO)
+			if !ok {
+				total = 0
+			}
+			results <- result{yes: yes, total: total}
+		}()
+	}
+
+	for i := 0; i < w.cfg.ConcurrentRepolls; i++ {
+		r := <-results
+		if r.total == 0 {
+			continue
+		}
+		if _, confOK := w.aggregate(r.yes, r.total, threshold, phase); confOK {
+			return r.yes, r.total, true
+		}
+	}
+	return 0, 0, false
 }
 
 // State returns the current polling state of an item
@@ -201,6 +656,11 @@ func (w *Wave[T]) State(item T) (*WaveState, bool) {
 	return state, exists
 }
 
+// Config returns the configuration wave was created with
+func (w *Wave[T]) Config() Config {
+	return w.cfg
+}
+
 // Preference returns the current preference for an item
 func (w *Wave[T]) Preference(item T) bool {
 	w.mu.RLock()
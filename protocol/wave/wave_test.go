@@ -646,3 +646,231 @@ func TestWavePreferenceNotExists(t *testing.T) {
 	pref := wave.Preference("nonexistent")
 	require.False(pref) // Default value for bool
 }
+
+// TestWaveEffectiveKThreshold tests that thresholds follow the live sample size
+func TestWaveEffectiveKThreshold(t *testing.T) {
+	require := require.New(t)
+
+	live := 3
+	cfg := Config{
+		K:          10,
+		Alpha:      0.8,
+		Beta:       2,
+		RoundTO:    100 * time.Millisecond,
+		EffectiveK: func() int { return live },
+	}
+
+	cut := newMockCut[string](10)
+	tx := newMockTransport[string]()
+	wave, _ := New[string](cfg, cut, tx)
+
+	// Only the 3 live validators answer; against K=10 they could never clear α
+	for i := 0; i < live; i++ {
+		tx.AddVote("tx1", true)
+	}
+
+	ctx := context.Background()
+	require.True(wave.TickTimeout(ctx, "tx1", cfg.RoundTO))
+	require.True(wave.TickTimeout(ctx, "tx1", cfg.RoundTO))
+
+	state, exists := wave.State("tx1")
+	require.True(exists)
+	require.True(state.Decided)
+	require.Equal(types.DecideAccept, state.Result)
+
+	// A decided item runs no round, so it reports no quorum
+	require.False(wave.TickTimeout(ctx, "tx1", cfg.RoundTO))
+
+	// An effective K above the configured K is ignored
+	live = 50
+	require.Equal(10, wave.k())
+}
+
+// countingTransport counts vote requests (polls) launched through it
+type countingTransport[T comparable] struct {
+	*mockTransport[T]
+	polls int
+}
+
+func (c *countingTransport[T]) RequestVotes(ctx context.Context, peers []types.NodeID, item T) <-chan Photon[T] {
+	c.polls++
+	return c.mockTransport.RequestVotes(ctx, peers, item)
+}
+
+// TestWaveMinRoundInterval tests that ticks closer than MinRoundInterval
+// for the same item do not launch a new poll
+func TestWaveMinRoundInterval(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Config{
+		K:                5,
+		Alpha:            0.8,
+		Beta:             10,
+		RoundTO:          100 * time.Millisecond,
+		MinRoundInterval: 50 * time.Millisecond,
+	}
+	tx := &countingTransport[string]{mockTransport: newMockTransport[string]()}
+	for i := 0; i < 5; i++ {
+		tx.AddVote("tx1", true)
+		tx.AddVote("tx2", true)
+	}
+	wave, _ := New[string](cfg, newMockCut[string](10), tx)
+
+	now := time.Unix(1000, 0)
+	wave.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.True(wave.TickTimeout(ctx, "tx1", cfg.RoundTO))
+	require.Equal(1, tx.polls)
+
+	// Too soon: no poll, previous result repeated, confidence unchanged
+	now = now.Add(cfg.MinRoundInterval - time.Millisecond)
+	require.True(wave.TickTimeout(ctx, "tx1", cfg.RoundTO))
+	require.Equal(1, tx.polls)
+	state, _ := wave.State("tx1")
+	require.Equal(uint32(1), state.Count)
+
+	// The interval is per item
+	require.True(wave.TickTimeout(ctx, "tx2", cfg.RoundTO))
+	require.Equal(2, tx.polls)
+
+	// Once the interval has passed the item is polled again
+	now = now.Add(time.Millisecond)
+	require.True(wave.TickTimeout(ctx, "tx1", cfg.RoundTO))
+	require.Equal(3, tx.polls)
+	state, _ = wave.State("tx1")
+	require.Equal(uint32(2), state.Count)
+}
+
+// TestWaveMaxItemProcessingTime tests that a stalled item is timed out
+// after MaxItemProcessingTime, reported and no longer polled
+func TestWaveMaxItemProcessingTime(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Config{
+		K:                     4,
+		Alpha:                 0.8,
+		Beta:                  3,
+		RoundTO:               100 * time.Millisecond,
+		MaxItemProcessingTime: time.Second,
+	}
+	tx := &countingTransport[string]{mockTransport: newMockTransport[string]()}
+	// An even split never clears α, so "stalled" never decides
+	tx.AddVote("stalled", true)
+	tx.AddVote("stalled", true)
+	tx.AddVote("stalled", false)
+	tx.AddVote("stalled", false)
+	wave, _ := New[string](cfg, newMockCut[string](4), tx)
+
+	now := time.Unix(1000, 0)
+	wave.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.False(wave.TickTimeout(ctx, "stalled", cfg.RoundTO))
+	now = now.Add(cfg.MaxItemProcessingTime - time.Millisecond)
+	require.False(wave.TickTimeout(ctx, "stalled", cfg.RoundTO))
+	require.Equal(2, tx.polls)
+	select {
+	case item := <-wave.Timeouts():
+		require.FailNow("timed out early", item)
+	default:
+	}
+
+	// Past the deadline the item is timed out instead of polled
+	now = now.Add(time.Millisecond)
+	require.False(wave.TickTimeout(ctx, "stalled", cfg.RoundTO))
+	require.Equal(2, tx.polls)
+	require.Equal("stalled", <-wave.Timeouts())
+
+	state, ok := wave.State("stalled")
+	require.True(ok)
+	require.True(state.TimedOut)
+	require.False(state.Decided)
+
+	// Timed-out items stay out of processing
+	require.False(wave.TickTimeout(ctx, "stalled", cfg.RoundTO))
+	require.False(wave.RecordPoll("stalled", 4, 4))
+	require.Equal(2, tx.polls)
+}
+
+// TestWaveExpireStale tests that items no longer ticked are swept
+func TestWaveExpireStale(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Config{K: 4, Alpha: 0.8, Beta: 1, RoundTO: 100 * time.Millisecond, MaxItemProcessingTime: time.Second}
+	wave, _ := New[string](cfg, newMockCut[string](4), newMockTransport[string]())
+	now := time.Unix(1000, 0)
+	wave.now = func() time.Time { return now }
+
+	wave.RecordPoll("idle", 2, 4) // no quorum
+	wave.RecordPoll("done", 4, 4) // decides at β=1
+	require.Empty(wave.ExpireStale())
+
+	now = now.Add(cfg.MaxItemProcessingTime)
+	require.Equal([]string{"idle"}, wave.ExpireStale())
+	require.Equal("idle", <-wave.Timeouts())
+
+	done, _ := wave.State("done")
+	require.True(done.Decided)
+	require.False(done.TimedOut)
+}
+
+// TestWaveMaxOutstandingItems tests that new items are refused at the
+// limit, admitted items keep being polled, and admission resumes once one
+// decides or is forgotten
+func TestWaveMaxOutstandingItems(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Config{K: 4, Alpha: 0.8, Beta: 2, RoundTO: 100 * time.Millisecond, MaxOutstandingItems: 2}
+	wave, _ := New[string](cfg, newMockCut[string](4), newMockTransport[string]())
+
+	require.NoError(wave.Initialize("a"))
+	require.NoError(wave.Initialize("b"))
+	require.Equal(2, wave.Outstanding())
+
+	require.ErrorIs(wave.Initialize("c"), ErrTooManyOutstanding)
+	require.False(wave.RecordPoll("c", 4, 4), "a tally cannot admit a new item")
+	require.False(wave.TickTimeout(context.Background(), "c", cfg.RoundTO))
+	_, ok := wave.State("c")
+	require.False(ok)
+	require.NoError(wave.Initialize("a"), "re-initializing an admitted item is not limited")
+
+	// Admitted items are still polled at the limit
+	require.False(wave.RecordPoll("a", 4, 4))
+	require.Equal(2, wave.Outstanding())
+	require.True(wave.RecordPoll("a", 4, 4))
+	require.Equal(1, wave.Outstanding())
+
+	require.NoError(wave.Initialize("c"))
+	require.Equal(2, wave.Outstanding())
+	require.ErrorIs(wave.Initialize("d"), ErrTooManyOutstanding)
+
+	// Forgetting a decided item evicts its state without freeing a slot;
+	// forgetting an undecided one frees its slot
+	wave.Forget("a")
+	_, ok = wave.State("a")
+	require.False(ok)
+	require.Equal(2, wave.Outstanding())
+	wave.Forget("b")
+	require.Equal(1, wave.Outstanding())
+	require.NoError(wave.Initialize("d"))
+}
+
+// TestWaveMaxOutstandingItemsTimeout tests that a timed-out item frees its
+// slot
+func TestWaveMaxOutstandingItemsTimeout(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Config{K: 4, Alpha: 0.8, Beta: 2, RoundTO: 100 * time.Millisecond, MaxItemProcessingTime: time.Second, MaxOutstandingItems: 1}
+	wave, _ := New[string](cfg, newMockCut[string](4), newMockTransport[string]())
+	now := time.Unix(1000, 0)
+	wave.now = func() time.Time { return now }
+
+	require.NoError(wave.Initialize("stalled"))
+	require.ErrorIs(wave.Initialize("next"), ErrTooManyOutstanding)
+
+	now = now.Add(cfg.MaxItemProcessingTime)
+	require.Equal([]string{"stalled"}, wave.ExpireStale())
+	require.Zero(wave.Outstanding())
+	require.NoError(wave.Initialize("next"))
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sim
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Response is one sampled node's answer to a poll
+type Response struct {
+	Dropped   bool          // the response never arrives
+	Latency   time.Duration // how long the response takes to arrive
+	Accept    bool          // the node votes for the correct value
+	Byzantine bool          // the node is Byzantine
+}
+
+// NetworkModel is the simulated validator set and the network between
+// them. It decides each sampled node's latency, whether its response is
+// dropped, and how it votes. Respond must draw all randomness from rng so
+// a run is reproducible from its seed.
+type NetworkModel interface {
+	// Size returns the number of nodes
+	Size() int
+
+	// Stake returns the stake of node i
+	Stake(i int) uint64
+
+	// Respond returns node i's response to one poll
+	Respond(i int, rng *rand.Rand) Response
+}
+
+// FailureMode selects which nodes Network.Fail turns Byzantine
+type FailureMode string
+
+const (
+	FailWorst  FailureMode = "worst"  // highest-stake nodes turn Byzantine first
+	FailRandom FailureMode = "random" // Byzantine nodes are picked uniformly
+)
+
+// Node is one simulated validator
+type Node struct {
+	Stake     uint64
+	Honesty   float64 // probability an honest node votes for the correct value
+	Byzantine bool    // Byzantine nodes always vote against it
+}
+
+// Network is a NetworkModel with a fixed latency plus uniform jitter and
+// independent drops. Byzantine nodes always vote against the correct value;
+// honest ones vote for it with their Honesty probability.
+type Network struct {
+	Nodes    []Node
+	Latency  time.Duration // base response latency
+	Jitter   time.Duration // extra latency drawn uniformly from [0, Jitter)
+	DropRate float64       // probability a response is lost
+}
+
+// NewNetwork returns n honest nodes with random stakes and honesty
+// probabilities drawn uniformly from [minHonesty, 1]
+func NewNetwork(n int, minHonesty float64, rng *rand.Rand) *Network {
+	net := &Network{Nodes: make([]Node, n)}
+	for i := range net.Nodes {
+		net.Nodes[i] = Node{
+			Stake:   1 + uint64(rng.ExpFloat64()*100),
+			Honesty: minHonesty + rng.Float64()*(1-minHonesty),
+		}
+	}
+	return net
+}
+
+// Fail turns a failureRate fraction of the nodes Byzantine, highest stake
+// first in FailWorst mode or uniformly at random otherwise
+func (net *Network) Fail(failureRate float64, mode FailureMode, rng *rand.Rand) {
+	order := rng.Perm(len(net.Nodes))
+	if mode == FailWorst {
+		sort.SliceStable(order, func(a, b int) bool {
+			return net.Nodes[order[a]].Stake > net.Nodes[order[b]].Stake
+		})
+	}
+	for _, i := range order[:int(float64(len(net.Nodes))*failureRate)] {
+		net.Nodes[i].Byzantine = true
+	}
+}
+
+// ByzantineNodes returns how many nodes are Byzantine
+func (net *Network) ByzantineNodes() int {
+	n := 0
+	for _, node := range net.Nodes {
+		if node.Byzantine {
+			n++
+		}
+	}
+	return n
+}
+
+// ByzantineStake returns the fraction of total stake held by Byzantine nodes
+func (net *Network) ByzantineStake() float64 {
+	var total, byzantine uint64
+	for _, node := range net.Nodes {
+		total += node.Stake
+		if node.Byzantine {
+			byzantine += node.Stake
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(byzantine) / float64(total)
+}
+
+// Size returns the number of nodes
+func (net *Network) Size() int { return len(net.Nodes) }
+
+// Stake returns the stake of node i
+func (net *Network) Stake(i int) uint64 { return net.Nodes[i].Stake }
+
+// Respond returns node i's response to one poll
+func (net *Network) Respond(i int, rng *rand.Rand) Response {
+	node := net.Nodes[i]
+	r := Response{Latency: net.Latency, Byzantine: node.Byzantine}
+	if net.Jitter > 0 {
+		r.Latency += time.Duration(rng.Int63n(int64(net.Jitter)))
+	}
+	if net.DropRate > 0 && rng.Float64() < net.DropRate {
+		r.Dropped = true
+		return r
+	}
+	if !node.Byzantine {
+		r.Accept = rng.Float64() < node.Honesty
+	}
+	return r
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sim
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorstCaseFailureTakesHighestStake(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(2))
+
+	net := NewNetwork(20, 1, rng)
+	net.Fail(0.25, FailWorst, rng)
+	require.Equal(5, net.ByzantineNodes())
+
+	var minByzantine, maxHonest uint64 = ^uint64(0), 0
+	for _, node := range net.Nodes {
+		if node.Byzantine {
+			minByzantine = min(minByzantine, node.Stake)
+		} else {
+			maxHonest = max(maxHonest, node.Stake)
+		}
+	}
+	require.GreaterOrEqual(minByzantine, maxHonest)
+
+	// Random failure marks the same number of nodes but, on average, less stake
+	var worst, random float64
+	for seed := int64(0); seed < 50; seed++ {
+		w := NewNetwork(20, 1, rand.New(rand.NewSource(seed)))
+		w.Fail(0.25, FailWorst, rand.New(rand.NewSource(seed)))
+		r := NewNetwork(20, 1, rand.New(rand.NewSource(seed)))
+		r.Fail(0.25, FailRandom, rand.New(rand.NewSource(seed)))
+		require.Equal(w.ByzantineNodes(), r.ByzantineNodes())
+		worst += w.ByzantineStake()
+		random += r.ByzantineStake()
+	}
+	require.Greater(worst, random)
+}
+
+func TestNetworkRespond(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(3))
+
+	net := &Network{
+		Nodes:   []Node{{Stake: 1, Honesty: 1}, {Stake: 1, Honesty: 1, Byzantine: true}},
+		Latency: 10 * time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+	}
+	for i := 0; i < 100; i++ {
+		r := net.Respond(0, rng)
+		require.True(r.Accept)
+		require.False(r.Dropped)
+		require.GreaterOrEqual(r.Latency, 10*time.Millisecond)
+		require.Less(r.Latency, 15*time.Millisecond)
+
+		r = net.Respond(1, rng)
+		require.True(r.Byzantine)
+		require.False(r.Accept)
+	}
+
+	// Every response of a fully lossy network is dropped
+	net.DropRate = 1
+	r := net.Respond(0, rng)
+	require.True(r.Dropped)
+	require.False(r.Accept)
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package sim simulates repeated stake-weighted polling over a modelled
+// network and measures how many rounds an item takes to finalize. Each
+// round samples K nodes; it succeeds when the accepting stake reaches
+// Alpha of the sampled stake, and Beta consecutive successes finalize.
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+)
+
+// DefaultMaxRounds bounds a trial when no WithMaxRounds option is given
+const DefaultMaxRounds = 1000
+
+// PollResult is the outcome of one simulated poll
+type PollResult struct {
+	Sampled        int           // nodes sampled
+	Votes          int           // sampled nodes that voted for the correct value in time
+	Confidence     float64       // stake fraction of the sample voting for the correct value
+	ByzantineStake float64       // stake fraction of the sample held by Byzantine nodes
+	Latency        time.Duration // time until the last counted response, or RoundTO
+	Accepted       bool          // Confidence reached Alpha
+}
+
+// Poll samples min(K, Size) nodes uniformly and weighs their votes by
+// stake. A response that is dropped, or slower than a non-zero RoundTO,
+// counts as not accepting.
+func Poll(params config.Parameters, net NetworkModel, rng *rand.Rand) PollResult {
+	k := min(params.K, net.Size())
+
+	var r PollResult
+	var sampled, accepting, byzantine uint64
+	for _, i := range rng.Perm(net.Size())[:k] {
+		stake := net.Stake(i)
+		sampled += stake
+		resp := net.Respond(i, rng)
+		if resp.Byzantine {
+			byzantine += stake
+		}
+		if resp.Dropped || (params.RoundTO > 0 && resp.Latency > params.RoundTO) {
+			r.Latency = max(r.Latency, params.RoundTO)
+			continue
+		}
+		r.Latency = max(r.Latency, resp.Latency)
+		if resp.Accept {
+			r.Votes++
+			accepting += stake
+		}
+	}
+
+	r.Sampled = k
+	if sampled > 0 {
+		r.Confidence = float64(accepting) / float64(sampled)
+		r.ByzantineStake = float64(byzantine) / float64(sampled)
+	}
+	r.Accepted = k > 0 && r.Confidence >= params.Alpha
+	return r
+}
+
+// Trial is one item's run to finality
+type Trial struct {
+	Rounds    int           // polls taken; MaxRounds if not finalized
+	Latency   time.Duration // simulated time those polls took
+	Finalized bool          // Beta consecutive polls accepted within MaxRounds
+}
+
+// FinalityDistribution holds the per-trial results of RunTrials
+type FinalityDistribution struct {
+	Seed      int64
+	MaxRounds int
+	Trials    []Trial
+}
+
+// Option configures RunTrials
+type Option func(*options)
+
+type options struct {
+	seed      int64
+	maxRounds int
+}
+
+// WithSeed fixes the seed, making the run reproducible. Without it the
+// seed is time-based and recorded in FinalityDistribution.Seed.
+func WithSeed(seed int64) Option {
+	return func(o *options) { o.seed = seed }
+}
+
+// WithMaxRounds bounds each trial to n polls (n <= 0 = DefaultMaxRounds)
+func WithMaxRounds(n int) Option {
+	return func(o *options) { o.maxRounds = n }
+}
+
+// RunTrials runs trials independent items over network and returns how
+// many rounds each took to finalize. A Beta of 0 is treated as 1, and
+// trials <= 0 yields an empty distribution.
+func RunTrials(params config.Parameters, trials int, network NetworkModel, opts ...Option) FinalityDistribution {
+	o := options{seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxRounds <= 0 {
+		o.maxRounds = DefaultMaxRounds
+	}
+	beta := max(int(params.Beta), 1)
+	rng := rand.New(rand.NewSource(o.seed)) //nolint:gosec // simulation randomness
+
+	d := FinalityDistribution{
+		Seed:      o.seed,
+		MaxRounds: o.maxRounds,
+		Trials:    make([]Trial, max(trials, 0)),
+	}
+	for t := range d.Trials {
+		trial := &d.Trials[t]
+		confidence := 0
+		for trial.Rounds < o.maxRounds {
+			p := Poll(params, network, rng)
+			trial.Rounds++
+			trial.Latency += p.Latency
+			if !p.Accepted {
+				confidence = 0
+				continue
+			}
+			if confidence++; confidence >= beta {
+				trial.Finalized = true
+				break
+			}
+		}
+	}
+	return d
+}
+
+// Finalized returns how many trials finalized within MaxRounds
+func (d FinalityDistribution) Finalized() int {
+	n := 0
+	for _, t := range d.Trials {
+		if t.Finalized {
+			n++
+		}
+	}
+	return n
+}
+
+// Mean returns the mean rounds to finality of the finalized trials, or NaN
+// if none finalized
+func (d FinalityDistribution) Mean() float64 {
+	sum, n := 0, 0
+	for _, t := range d.Trials {
+		if t.Finalized {
+			sum += t.Rounds
+			n++
+		}
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return float64(sum) / float64(n)
+}
+
+// Quantile returns the rounds to finality at quantile q in [0, 1] over all
+// trials, ranking trials that never finalized last. It returns false if the
+// quantile falls on such a trial or there are no trials.
+func (d FinalityDistribution) Quantile(q float64) (int, bool) {
+	if len(d.Trials) == 0 {
+		return 0, false
+	}
+	rounds := make([]int, 0, len(d.Trials))
+	for _, t := range d.Trials {
+		if t.Finalized {
+			rounds = append(rounds, t.Rounds)
+		}
+	}
+	sort.Ints(rounds)
+
+	rank := int(math.Ceil(q*float64(len(d.Trials)))) - 1
+	rank = min(max(rank, 0), len(d.Trials)-1)
+	if rank >= len(rounds) {
+		return 0, false
+	}
+	return rounds[rank], true
+}
+
+// Histogram returns how many finalized trials took each number of rounds
+func (d FinalityDistribution) Histogram() map[int]int {
+	h := make(map[int]int)
+	for _, t := range d.Trials {
+		if t.Finalized {
+			h[t.Rounds]++
+		}
+	}
+	return h
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/config"
+	"github.com/stretchr/testify/require"
+)
+
+func testParams(beta uint32) config.Parameters {
+	return config.Parameters{K: 20, Alpha: 0.8, Beta: beta}
+}
+
+func TestRunTrialsShiftsWithBeta(t *testing.T) {
+	require := require.New(t)
+	net := NewNetwork(50, 0.8, rand.New(rand.NewSource(1)))
+
+	prev := 0.0
+	for _, beta := range []uint32{1, 4, 8} {
+		d := RunTrials(testParams(beta), 500, net, WithSeed(7))
+		require.Len(d.Trials, 500)
+		require.Equal(500, d.Finalized())
+		for _, trial := range d.Trials {
+			require.GreaterOrEqual(trial.Rounds, int(beta))
+		}
+
+		// Every extra consecutive success needed lengthens the whole
+		// distribution, tail included
+		mean := d.Mean()
+		require.Greater(mean, prev)
+		prev = mean
+		p50, ok := d.Quantile(0.5)
+		require.True(ok)
+		p99, ok := d.Quantile(0.99)
+		require.True(ok)
+		require.GreaterOrEqual(p99, p50)
+		require.GreaterOrEqual(p50, int(beta))
+	}
+}
+
+func TestRunTrialsReproducible(t *testing.T) {
+	require := require.New(t)
+	net := NewNetwork(50, 0.8, rand.New(rand.NewSource(1)))
+	net.Latency = 10 * time.Millisecond
+	net.Jitter = 20 * time.Millisecond
+	net.DropRate = 0.05
+
+	a := RunTrials(testParams(4), 200, net, WithSeed(42))
+	b := RunTrials(testParams(4), 200, net, WithSeed(42))
+	require.Equal(a, b)
+	require.Equal(int64(42), a.Seed)
+
+	c := RunTrials(testParams(4), 200, net, WithSeed(43))
+	require.NotEqual(a.Trials, c.Trials)
+
+	// An unseeded run records the seed it used, which replays it
+	d := RunTrials(testParams(4), 200, net)
+	require.Equal(d, RunTrials(testParams(4), 200, net, WithSeed(d.Seed)))
+}
+
+func TestRunTrialsUnfinalized(t *testing.T) {
+	require := require.New(t)
+
+	// Every response arrives after RoundTO, so no poll ever accepts
+	net := NewNetwork(20, 1, rand.New(rand.NewSource(1)))
+	net.Latency = time.Second
+	params := testParams(2)
+	params.RoundTO = 100 * time.Millisecond
+
+	d := RunTrials(params, 10, net, WithSeed(1), WithMaxRounds(30))
+	require.Zero(d.Finalized())
+	require.Empty(d.Histogram())
+	require.True(math.IsNaN(d.Mean()))
+	_, ok := d.Quantile(0.5)
+	require.False(ok)
+	for _, trial := range d.Trials {
+		require.Equal(30, trial.Rounds)
+		require.Equal(30*params.RoundTO, trial.Latency)
+	}
+}
+
+func TestRunTrialsNonPositive(t *testing.T) {
+	require := require.New(t)
+
+	net := NewNetwork(20, 1, rand.New(rand.NewSource(1)))
+	for _, trials := range []int{0, -1, math.MinInt} {
+		d := RunTrials(testParams(2), trials, net, WithSeed(1))
+		require.Empty(d.Trials)
+		require.Zero(d.Finalized())
+		require.True(math.IsNaN(d.Mean()))
+		_, ok := d.Quantile(0.5)
+		require.False(ok)
+	}
+}
+
+func TestFinalityDistributionQuantile(t *testing.T) {
+	require := require.New(t)
+
+	d := FinalityDistribution{Trials: []Trial{
+		{Rounds: 3, Finalized: true},
+		{Rounds: 1, Finalized: true},
+		{Rounds: 2, Finalized: true},
+		{Rounds: 50},
+	}}
+	for q, want := range map[float64]int{0: 1, 0.25: 1, 0.5: 2, 0.75: 3} {
+		got, ok := d.Quantile(q)
+		require.True(ok, q)
+		require.Equal(want, got, q)
+	}
+	_, ok := d.Quantile(0.99)
+	require.False(ok)
+	require.Equal(map[int]int{1: 1, 2: 1, 3: 1}, d.Histogram())
+	require.InDelta(2.0, d.Mean(), 1e-9)
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"github.com/luxfi/consensus/pkg/wire"
+)
+
+// StatusOf returns the decision status of id in e. Every engine reports the
+// same four states: Unknown, Processing, Rejected or Accepted. An accepted
+// decision always reads as Accepted, and any status outside the four reads
+// as Unknown.
+func StatusOf(e Engine, id ID) Status {
+	if e.IsAccepted(id) {
+		return StatusAccepted
+	}
+	switch s := e.GetStatus(id); s {
+	case StatusProcessing, StatusRejected:
+		return s
+	default:
+		return StatusUnknown
+	}
+}
+
+// StatusFromCertificate maps wire finality onto Status. A finality
+// certificate for id means Accepted. Without one, an item the caller knows
+// about is Processing and anything else is Unknown. A certificate for a
+// different candidate is ignored.
+func StatusFromCertificate(id ID, cert *wire.Certificate, known bool) Status {
+	switch {
+	case cert != nil && cert.CandidateID == wire.CandidateID(id):
+		return StatusAccepted
+	case known:
+		return StatusProcessing
+	default:
+		return StatusUnknown
+	}
+}
+
+// StatusFromResult maps a wire result's finality flags onto Status
+func StatusFromResult(r *wire.Result) Status {
+	switch {
+	case r == nil:
+		return StatusUnknown
+	case !r.Finalized:
+		return StatusProcessing
+	case r.Accepted:
+		return StatusAccepted
+	default:
+		return StatusRejected
+	}
+}
+
+// HasFinalityCertificate reports whether an item with status s carries a
+// wire finality certificate. Only accepted items are certified; rejected
+// items are decided but have no certificate.
+func HasFinalityCertificate(s Status) bool {
+	return s == StatusAccepted
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luxfi/consensus/pkg/wire"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatusOfProgression drives an item from Processing to Accepted and
+// checks the engine status agrees with certificate presence at each step.
+func TestStatusOfProgression(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := DefaultConfig()
+	cfg.Alpha = 2
+	for name, e := range map[string]Engine{
+		"chain": NewChain(cfg),
+		"dag":   NewDAG(cfg),
+		"pq":    NewPQ(cfg),
+	} {
+		require.NoError(e.Start(ctx), name)
+
+		id := ids.GenerateTestID()
+		require.Equal(StatusUnknown, StatusOf(e, id), name)
+		require.Equal(StatusOf(e, id), StatusFromCertificate(id, nil, false), name)
+
+		require.NoError(e.Add(ctx, NewBlock(id, GenesisID, 1, nil)), name)
+		require.Equal(StatusProcessing, StatusOf(e, id), name)
+		require.Equal(StatusOf(e, id), StatusFromCertificate(id, nil, true), name)
+		require.False(HasFinalityCertificate(StatusOf(e, id)), name)
+
+		// A certificate for another candidate does not finalize id
+		other := wire.NewCertificate(wire.CandidateID(ids.GenerateTestID()), 1, wire.PolicyQuorum, nil)
+		require.Equal(StatusProcessing, StatusFromCertificate(id, other, true), name)
+
+		for i := 0; i < cfg.Alpha; i++ {
+			require.NoError(e.RecordVote(ctx, NewVote(id, VoteCommit, ids.GenerateTestNodeID())), name)
+		}
+		cert := wire.NewCertificate(wire.CandidateID(id), 1, wire.PolicyQuorum, nil)
+		require.Equal(StatusAccepted, StatusOf(e, id), name)
+		require.Equal(StatusOf(e, id), StatusFromCertificate(id, cert, true), name)
+		require.True(HasFinalityCertificate(StatusOf(e, id)), name)
+
+		require.NoError(e.Stop(), name)
+	}
+}
+
+func TestStatusFromResult(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(StatusUnknown, StatusFromResult(nil))
+	require.Equal(StatusProcessing, StatusFromResult(&wire.Result{}))
+	require.Equal(StatusAccepted, StatusFromResult(&wire.Result{Finalized: true, Accepted: true}))
+	require.Equal(StatusRejected, StatusFromResult(&wire.Result{Finalized: true}))
+	require.False(HasFinalityCertificate(StatusRejected))
+}
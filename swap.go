@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/consensus/engine"
+)
+
+// Hot-swap types re-exported from the engine package
+type (
+	Swappable = engine.Swappable
+	Handoff   = engine.Handoff
+)
+
+// ErrDraining is returned by an engine that has been swapped out
+var ErrDraining = engine.ErrDraining
+
+// ErrNotSwappable is returned by SwapEngine when either engine cannot hand
+// over or adopt consensus state
+var ErrNotSwappable = errors.New("engine does not support hot-swap")
+
+// SwapEngine hands consensus over from current to next without finalizing
+// any block twice or losing the preference. next is started first, so a
+// failed start leaves current running. current is then drained, its
+// finalized-height watermark, preference and accepted blocks are moved to
+// next, blocks still processing are re-submitted to next, and current is
+// stopped. From the drain on, current rejects blocks and votes with
+// ErrDraining; callers route them to next once SwapEngine returns. If next
+// cannot resume or take a block, current is reopened and next stopped, so
+// current keeps running as before.
+func SwapEngine(ctx context.Context, current Engine, next Engine) error {
+	from, ok := current.(Swappable)
+	if !ok {
+		return fmt.Errorf("%w: current engine %T", ErrNotSwappable, current)
+	}
+	to, ok := next.(Swappable)
+	if !ok {
+		return fmt.Errorf("%w: next engine %T", ErrNotSwappable, next)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := next.Start(ctx); err != nil {
+		return fmt.Errorf("starting next engine: %w", err)
+	}
+
+	h := from.Drain()
+	if err := handOver(ctx, to, next, h); err != nil {
+		from.Reopen()
+		return errors.Join(err, next.Stop())
+	}
+
+	if err := current.Stop(); err != nil {
+		return fmt.Errorf("stopping current engine: %w", err)
+	}
+	return nil
+}
+
+// handOver resumes next from h and re-submits h's processing blocks
+func handOver(ctx context.Context, to Swappable, next Engine, h Handoff) error {
+	if err := to.Resume(h); err != nil {
+		return fmt.Errorf("resuming next engine: %w", err)
+	}
+	for _, block := range h.Processing {
+		if err := next.Add(ctx, block); err != nil {
+			return fmt.Errorf("re-submitting block %s: %w", block.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/luxfi/consensus/engine"
+	"github.com/luxfi/consensus/engine/dag"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// finalizations counts OnAccept callbacks per block across engines
+type finalizations struct {
+	mu    sync.Mutex
+	count map[ID]int
+}
+
+func (f *finalizations) record(b *Block) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.count[b.ID]++
+}
+
+func vote(t *testing.T, e Engine, blockID ID, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		require.NoError(t, e.RecordVote(context.Background(), NewVote(blockID, VoteCommit, ids.GenerateTestNodeID())))
+	}
+}
+
+// TestSwapEngineChainToDAG swaps a chain engine for a DAG-backed block
+// engine mid-run and checks the finalized height never regresses and no
+// block is finalized twice.
+func TestSwapEngineChainToDAG(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := DefaultConfig()
+	cfg.Alpha = 2
+	fin := &finalizations{count: make(map[ID]int)}
+
+	current := NewChain(cfg)
+	current.OnAccept(fin.record)
+	require.NoError(current.Start(ctx))
+
+	// Finalize heights 1-5
+	parent := GenesisID
+	blocks := make([]*Block, 8)
+	for h := 1; h < len(blocks); h++ {
+		blocks[h] = NewBlock(ids.GenerateTestID(), parent, uint64(h), nil)
+		parent = blocks[h].ID
+		require.NoError(current.Add(ctx, blocks[h]))
+	}
+	for h := 1; h <= 5; h++ {
+		vote(t, current, blocks[h].ID, 2)
+	}
+	// Height 6 is one vote short; height 7 has none
+	vote(t, current, blocks[6].ID, 1)
+	require.Equal(uint64(5), current.FinalizedHeight())
+
+	next := dag.NewBlockEngine(cfg)
+	next.OnAccept(fin.record)
+
+	require.NoError(SwapEngine(ctx, current, next))
+
+	// Watermark and preference carried over
+	require.Equal(uint64(5), next.FinalizedHeight())
+	last, ok := next.AcceptedAt(5)
+	require.True(ok)
+	require.Equal(blocks[5].ID, last.ID)
+
+	// The drained engine rejects further work
+	require.ErrorIs(current.Add(ctx, NewBlock(ids.GenerateTestID(), parent, 8, nil)), ErrDraining)
+	require.ErrorIs(current.RecordVote(ctx, NewVote(blocks[6].ID, VoteCommit, ids.GenerateTestNodeID())), ErrDraining)
+
+	// Processing blocks were re-submitted
+	require.Equal(StatusProcessing, next.GetStatus(blocks[6].ID))
+	require.Equal(StatusProcessing, next.GetStatus(blocks[7].ID))
+
+	// Re-gossiped finalized blocks stay decided
+	require.NoError(next.Add(ctx, blocks[3]))
+	require.Equal(StatusAccepted, next.GetStatus(blocks[3].ID))
+	vote(t, next, blocks[3].ID, 2)
+
+	watermark := next.FinalizedHeight()
+	for h := 6; h <= 7; h++ {
+		vote(t, next, blocks[h].ID, 3)
+		height := next.FinalizedHeight()
+		require.GreaterOrEqual(height, watermark)
+		watermark = height
+	}
+	require.Equal(uint64(7), next.FinalizedHeight())
+
+	for h := 1; h < len(blocks); h++ {
+		require.Equal(1, fin.count[blocks[h].ID], "height %d", h)
+	}
+}
+
+type opaqueEngine struct{ Engine }
+
+func TestSwapEngineNotSwappable(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	current := NewChain(DefaultConfig())
+	require.NoError(current.Start(ctx))
+
+	err := SwapEngine(ctx, current, opaqueEngine{NewDAGEngine()})
+	require.ErrorIs(err, ErrNotSwappable)
+
+	// current is untouched
+	blk := NewBlock(ids.GenerateTestID(), GenesisID, 1, nil)
+	require.NoError(current.Add(ctx, blk))
+}
+
+func TestSwapEngineRollsBack(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := DefaultConfig()
+	cfg.Alpha = 2
+	current := NewChain(cfg)
+	require.NoError(current.Start(ctx))
+	blk := NewBlock(ids.GenerateTestID(), GenesisID, 1, nil)
+	require.NoError(current.Add(ctx, blk))
+
+	// next already holds a block, so it cannot resume current's state
+	next := dag.NewBlockEngine(cfg)
+	require.NoError(next.Add(ctx, NewBlock(ids.GenerateTestID(), GenesisID, 1, nil)))
+	require.ErrorIs(SwapEngine(ctx, current, next), engine.ErrConflict)
+
+	// current is reopened and keeps finalizing
+	vote(t, current, blk.ID, 2)
+	require.True(current.IsAccepted(blk.ID))
+	require.NoError(current.Add(ctx, NewBlock(ids.GenerateTestID(), blk.ID, 2, nil)))
+}
@@ -0,0 +1,332 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package memory is an in-process wire.Transport for integration tests,
+// benchmarks and simulations. Nodes join a shared Network, which routes
+// requests between them through goroutines with a configurable simulated
+// latency and drop rate, so no sockets or native libraries are needed.
+//
+//	net, err := memory.NewNetwork(memory.Config{
+//		Latency:     time.Millisecond,
+//		Compression: seqConfig.Compression,
+//	})
+//	a := net.Join(idA, handleA)
+//	b := net.Join(idB, handleB)
+//	a.Connect(idB)
+//	resp, err := a.Send(ctx, idB, req)
+//
+// Drops are drawn from a generator seeded by Config.Seed, so a run that
+// sends the same messages in the same order drops the same ones. Request
+// data and response candidates cross the network encoded by a
+// wire.PayloadCodec, compressed as Config.Compression selects, and are
+// decoded before they reach the handler or the caller.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/luxfi/consensus/pkg/wire"
+)
+
+var (
+	// ErrUnknownPeer is returned when addressing a node that has not
+	// joined the network, or when sending to a peer not connected
+	ErrUnknownPeer = errors.New("memory: unknown peer")
+
+	// ErrNoHandler is returned when the addressed node has no handler
+	ErrNoHandler = errors.New("memory: peer has no handler")
+
+	// ErrDropped is returned by Send when the network drops the request
+	ErrDropped = errors.New("memory: request dropped")
+)
+
+// Handler serves one request from a peer. A nil response with a nil error
+// is delivered to the sender as an empty response.
+type Handler func(ctx context.Context, from wire.VoterID, request *wire.Request) (*wire.Response, error)
+
+// Config configures a Network
+type Config struct {
+	// Latency delays every delivery and every response
+	Latency time.Duration
+
+	// DropRate is the probability in [0, 1] that a request is dropped
+	// before it reaches its peer
+	DropRate float64
+
+	// Seed seeds the drop decisions
+	Seed int64
+
+	// Compression selects the codec payloads are encoded with in transit,
+	// normally a sequencer's SequencerConfig.Compression
+	Compression wire.CompressionConfig
+}
+
+// Network routes requests between the nodes that joined it
+type Network struct {
+	cfg   Config
+	codec *wire.PayloadCodec
+
+	mu    sync.Mutex
+	nodes map[wire.VoterID]*Transport
+	rng   *rand.Rand
+}
+
+// NewNetwork returns an empty network. DropRate is clamped to [0, 1]. It
+// fails if Config.Compression names an unknown codec.
+func NewNetwork(cfg Config) (*Network, error) {
+	codec, err := wire.NewPayloadCodec(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DropRate = min(max(cfg.DropRate, 0), 1)
+	return &Network{
+		cfg:   cfg,
+		codec: codec,
+		nodes: make(map[wire.VoterID]*Transport),
+		rng:   rand.New(rand.NewSource(cfg.Seed)),
+	}, nil
+}
+
+// Join adds the node id with handler h, which may be nil and set later
+// with RegisterHandler, and returns its transport. Joining an id again
+// replaces its transport.
+func (n *Network) Join(id wire.VoterID, h Handler) *Transport {
+	t := &Transport{
+		net:     n,
+		id:      id,
+		handler: h,
+		peers:   make(map[wire.VoterID]struct{}),
+	}
+	n.mu.Lock()
+	n.nodes[id] = t
+	n.mu.Unlock()
+	return t
+}
+
+// Leave removes the node id; requests to it fail with ErrUnknownPeer
+func (n *Network) Leave(id wire.VoterID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.nodes, id)
+}
+
+// node returns the transport of a joined node
+func (n *Network) node(id wire.VoterID) (*Transport, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	t, ok := n.nodes[id]
+	return t, ok
+}
+
+// drop reports whether the next request is dropped
+func (n *Network) drop() bool {
+	if n.cfg.DropRate == 0 {
+		return false
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.rng.Float64() < n.cfg.DropRate
+}
+
+// delay waits for the configured latency or until ctx is done
+func (n *Network) delay(ctx context.Context) error {
+	if n.cfg.Latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(n.cfg.Latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Transport is one node's wire.Transport on a Network
+type Transport struct {
+	net *Network
+	id  wire.VoterID
+
+	mu      sync.RWMutex
+	handler Handler
+	peers   map[wire.VoterID]struct{}
+}
+
+var _ wire.Transport = (*Transport)(nil)
+
+// ID returns the node's ID
+func (t *Transport) ID() wire.VoterID {
+	return t.id
+}
+
+// RegisterHandler sets the handler serving requests to this node
+func (t *Transport) RegisterHandler(h Handler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handler = h
+}
+
+// Connect adds peer to the node's known peers, which Broadcast reaches
+// and Send and Query may address. The peer must have joined the network.
+func (t *Transport) Connect(peer wire.VoterID) error {
+	if _, ok := t.net.node(peer); !ok {
+		return fmt.Errorf("%w: %x", ErrUnknownPeer, peer[:8])
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peers[peer] = struct{}{}
+	return nil
+}
+
+// Peers returns the number of connected peers
+func (t *Transport) Peers() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.peers)
+}
+
+// Send implements wire.Transport. It delivers request to peer after the
+// network latency and returns its response after the latency again. A
+// dropped request fails with ErrDropped once the latency has passed.
+func (t *Transport) Send(ctx context.Context, peer wire.VoterID, request *wire.Request) (*wire.Response, error) {
+	dst, err := t.peer(peer)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := t.net.codec.EncodeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return t.deliver(ctx, dst, sent, t.net.drop())
+}
+
+// deliver hands the encoded request sent to dst after the latency and
+// returns its decoded response after the latency again, or ErrDropped if
+// dropped is set
+func (t *Transport) deliver(ctx context.Context, dst *Transport, sent *wire.Request, dropped bool) (*wire.Response, error) {
+	if err := t.net.delay(ctx); err != nil {
+		return nil, err
+	}
+	if dropped {
+		return nil, ErrDropped
+	}
+	resp, err := dst.serve(ctx, t.id, sent)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.net.delay(ctx); err != nil {
+		return nil, err
+	}
+	return t.net.codec.DecodeResponse(resp)
+}
+
+// Query implements wire.Transport. It sends request to every peer
+// concurrently and delivers each response on the returned channel, which
+// is closed once every peer has answered, failed or been dropped. A peer
+// that cannot be reached or whose handler fails answers with its error in
+// Response.Error. Drops are drawn in the order of peers.
+func (t *Transport) Query(ctx context.Context, peers []wire.VoterID, request *wire.Request) <-chan *wire.Response {
+	out := make(chan *wire.Response, len(peers))
+	sent, err := t.net.codec.EncodeRequest(request)
+	if err != nil {
+		for _, peer := range peers {
+			out <- &wire.Response{From: peer, Type: request.Type, Error: err.Error()}
+		}
+		close(out)
+		return out
+	}
+
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		dst, err := t.peer(peer)
+		if err != nil {
+			out <- &wire.Response{From: peer, Type: request.Type, Error: err.Error()}
+			continue
+		}
+		dropped := t.net.drop()
+
+		wg.Add(1)
+		go func(peer wire.VoterID) {
+			defer wg.Done()
+			resp, err := t.deliver(ctx, dst, sent, dropped)
+			switch {
+			case errors.Is(err, ErrDropped), ctx.Err() != nil:
+				return
+			case err != nil:
+				resp = &wire.Response{From: peer, Type: request.Type, Error: err.Error()}
+			}
+			out <- resp
+		}(peer)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Broadcast implements wire.Transport. It delivers request to every
+// connected peer, in ID order, without waiting for their handlers; each
+// delivery is subject to the latency and drop rate.
+func (t *Transport) Broadcast(ctx context.Context, request *wire.Request) error {
+	t.mu.RLock()
+	peers := make([]wire.VoterID, 0, len(t.peers))
+	for peer := range t.peers {
+		peers = append(peers, peer)
+	}
+	t.mu.RUnlock()
+	slices.SortFunc(peers, func(a, b wire.VoterID) int { return bytes.Compare(a[:], b[:]) })
+
+	// Query's channel holds every response, so nothing needs to drain it
+	t.Query(ctx, peers, request)
+	return nil
+}
+
+// peer returns the transport of a connected peer
+func (t *Transport) peer(id wire.VoterID) (*Transport, error) {
+	t.mu.RLock()
+	_, connected := t.peers[id]
+	t.mu.RUnlock()
+	if !connected {
+		return nil, fmt.Errorf("%w: %x not connected", ErrUnknownPeer, id[:8])
+	}
+	dst, ok := t.net.node(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %x", ErrUnknownPeer, id[:8])
+	}
+	return dst, nil
+}
+
+// serve decodes a request from from, runs the node's handler on it and
+// returns the encoded response
+func (t *Transport) serve(ctx context.Context, from wire.VoterID, sent *wire.Request) (*wire.Response, error) {
+	t.mu.RLock()
+	h := t.handler
+	t.mu.RUnlock()
+	if h == nil {
+		return nil, fmt.Errorf("%w: %x", ErrNoHandler, t.id[:8])
+	}
+	request, err := t.net.codec.DecodeRequest(sent)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h(ctx, from, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &wire.Response{Type: request.Type}
+	}
+	if resp.From == wire.EmptyVoterID {
+		resp.From = t.id
+	}
+	return t.net.codec.EncodeResponse(resp)
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luxfi/consensus/pkg/wire"
+)
+
+func newNetwork(t *testing.T, cfg Config) *Network {
+	t.Helper()
+	net, err := NewNetwork(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return net
+}
+
+func nodeID(i byte) wire.VoterID {
+	return wire.DeriveVoterID("memory-test", []byte{i})
+}
+
+// echo answers every request with its type
+func echo(_ context.Context, _ wire.VoterID, req *wire.Request) (*wire.Response, error) {
+	return &wire.Response{Type: req.Type}, nil
+}
+
+func TestSendDeliversAndResponds(t *testing.T) {
+	ctx := context.Background()
+	net := newNetwork(t, Config{Latency: 5 * time.Millisecond})
+
+	var from wire.VoterID
+	a := net.Join(nodeID(1), nil)
+	net.Join(nodeID(2), func(_ context.Context, sender wire.VoterID, req *wire.Request) (*wire.Response, error) {
+		from = sender
+		return &wire.Response{Type: req.Type}, nil
+	})
+
+	// Peers must be connected before they are addressed
+	if _, err := a.Send(ctx, nodeID(2), &wire.Request{Type: "ping"}); !errors.Is(err, ErrUnknownPeer) {
+		t.Fatalf("expected ErrUnknownPeer, got %v", err)
+	}
+	if err := a.Connect(nodeID(3)); !errors.Is(err, ErrUnknownPeer) {
+		t.Fatalf("expected ErrUnknownPeer for a node that never joined, got %v", err)
+	}
+	if err := a.Connect(nodeID(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := a.Send(ctx, nodeID(2), &wire.Request{Type: "ping"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Type != "ping" || resp.From != nodeID(2) {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if from != nodeID(1) {
+		t.Fatal("handler did not see the sender")
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("round trip took %v, below twice the latency", elapsed)
+	}
+}
+
+func TestRegisterHandler(t *testing.T) {
+	ctx := context.Background()
+	net := newNetwork(t, Config{})
+	a := net.Join(nodeID(1), nil)
+	b := net.Join(nodeID(2), nil)
+	if err := a.Connect(b.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.Send(ctx, b.ID(), &wire.Request{Type: "ping"}); !errors.Is(err, ErrNoHandler) {
+		t.Fatalf("expected ErrNoHandler, got %v", err)
+	}
+	b.RegisterHandler(echo)
+	if _, err := a.Send(ctx, b.ID(), &wire.Request{Type: "ping"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBroadcastFansOut(t *testing.T) {
+	ctx := context.Background()
+	net := newNetwork(t, Config{Latency: time.Millisecond})
+
+	const peers = 8
+	var wg sync.WaitGroup
+	wg.Add(peers)
+	var mu sync.Mutex
+	got := make(map[wire.VoterID]int)
+	src := net.Join(nodeID(0), nil)
+	for i := byte(1); i <= peers; i++ {
+		id := nodeID(i)
+		net.Join(id, func(context.Context, wire.VoterID, *wire.Request) (*wire.Response, error) {
+			mu.Lock()
+			got[id]++
+			mu.Unlock()
+			wg.Done()
+			return nil, nil
+		})
+		if err := src.Connect(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A node that joined but is not connected is not reached
+	net.Join(nodeID(99), func(context.Context, wire.VoterID, *wire.Request) (*wire.Response, error) {
+		t.Error("broadcast reached an unconnected node")
+		return nil, nil
+	})
+
+	if err := src.Broadcast(ctx, &wire.Request{Type: "block"}); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+	if len(got) != peers {
+		t.Fatalf("expected %d peers reached, got %d", peers, len(got))
+	}
+	for id, n := range got {
+		if n != 1 {
+			t.Fatalf("peer %x received %d copies", id[:4], n)
+		}
+	}
+}
+
+func TestQueryCollectsResponses(t *testing.T) {
+	ctx := context.Background()
+	net := newNetwork(t, Config{})
+	src := net.Join(nodeID(0), nil)
+	var peers []wire.VoterID
+	for i := byte(1); i <= 5; i++ {
+		net.Join(nodeID(i), echo)
+		if err := src.Connect(nodeID(i)); err != nil {
+			t.Fatal(err)
+		}
+		peers = append(peers, nodeID(i))
+	}
+	// An unconnected peer answers with an error
+	peers = append(peers, nodeID(42))
+
+	seen := make(map[wire.VoterID]bool)
+	failed := 0
+	for resp := range src.Query(ctx, peers, &wire.Request{Type: "vote_request"}) {
+		if resp.Error != "" {
+			failed++
+			continue
+		}
+		seen[resp.From] = true
+	}
+	if len(seen) != 5 || failed != 1 {
+		t.Fatalf("expected 5 responses and 1 failure, got %d and %d", len(seen), failed)
+	}
+}
+
+// dropPattern reports which of n sends to a single peer were dropped
+func dropPattern(t *testing.T, cfg Config, n int) []bool {
+	t.Helper()
+	net := newNetwork(t, cfg)
+	src := net.Join(nodeID(0), nil)
+	net.Join(nodeID(1), echo)
+	if err := src.Connect(nodeID(1)); err != nil {
+		t.Fatal(err)
+	}
+	out := make([]bool, n)
+	for i := range out {
+		_, err := src.Send(context.Background(), nodeID(1), &wire.Request{Type: "ping"})
+		switch {
+		case errors.Is(err, ErrDropped):
+			out[i] = true
+		case err != nil:
+			t.Fatal(err)
+		}
+	}
+	return out
+}
+
+func TestDropRate(t *testing.T) {
+	count := func(pattern []bool) int {
+		n := 0
+		for _, dropped := range pattern {
+			if dropped {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := count(dropPattern(t, Config{DropRate: 0}, 100)); n != 0 {
+		t.Fatalf("drop rate 0 dropped %d requests", n)
+	}
+	if n := count(dropPattern(t, Config{DropRate: 1}, 100)); n != 100 {
+		t.Fatalf("drop rate 1 delivered %d requests", 100-n)
+	}
+
+	// Half the requests are dropped, and the same seed drops the same ones
+	cfg := Config{DropRate: 0.5, Seed: 7}
+	first := dropPattern(t, cfg, 1000)
+	if n := count(first); n < 400 || n > 600 {
+		t.Fatalf("drop rate 0.5 dropped %d of 1000 requests", n)
+	}
+	second := dropPattern(t, cfg, 1000)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("request %d: drops differ between runs with the same seed", i)
+		}
+	}
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	payload := bytes.Repeat([]byte(`{"from":"0xabc","to":"0xdef","value":1000},`), 200)
+	candidate := wire.NewCandidate([]byte("memory-test"), payload, wire.CandidateID{}, 1)
+
+	cfg := wire.AgentMeshConfig([]byte("memory-test"), 3)
+	cfg.Compression.Codec = wire.CodecZstd
+	net := newNetwork(t, Config{Compression: cfg.Compression})
+
+	// The handler sees the request as sent and answers with the candidate
+	src := net.Join(nodeID(1), nil)
+	net.Join(nodeID(2), func(_ context.Context, _ wire.VoterID, req *wire.Request) (*wire.Response, error) {
+		if !bytes.Equal(req.Data, payload) {
+			return nil, errors.New("request data arrived encoded")
+		}
+		return &wire.Response{Type: req.Type, Candidate: candidate}, nil
+	})
+	if err := src.Connect(nodeID(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := src.Send(ctx, nodeID(2), &wire.Request{Type: "candidate", Data: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp.Candidate.Payload, payload) || !resp.Candidate.Verify() {
+		t.Fatal("candidate did not survive the round trip")
+	}
+	if !bytes.Equal(candidate.Payload, payload) {
+		t.Fatal("encoding modified the handler's candidate")
+	}
+
+	// Query decodes too
+	for resp := range src.Query(ctx, []wire.VoterID{nodeID(2)}, &wire.Request{Type: "candidate", Data: payload}) {
+		if resp.Error != "" || !resp.Candidate.Verify() {
+			t.Fatalf("query response %+v", resp)
+		}
+	}
+
+	// A candidate whose payload does not match its ID fails on receipt
+	forged := *candidate
+	forged.ID = wire.CandidateID{9}
+	net.Join(nodeID(3), func(context.Context, wire.VoterID, *wire.Request) (*wire.Response, error) {
+		return &wire.Response{Candidate: &forged}, nil
+	})
+	if err := src.Connect(nodeID(3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Send(ctx, nodeID(3), &wire.Request{Type: "candidate"}); !errors.Is(err, wire.ErrCandidateIDMismatch) {
+		t.Fatalf("expected ErrCandidateIDMismatch, got %v", err)
+	}
+
+	if _, err := NewNetwork(Config{Compression: wire.CompressionConfig{Codec: 0xEE}}); !errors.Is(err, wire.ErrUnknownCodec) {
+		t.Fatalf("expected ErrUnknownCodec, got %v", err)
+	}
+}
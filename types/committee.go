@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2025, Lux Industries Inc All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+)
+
+// committeeDomain separates committee commitments from other hashes
+const committeeDomain = "LUX_CONSENSUS_COMMITTEE_V1"
+
+// voteDomain separates signed vote payloads from other signed messages
+const voteDomain = "LUX_CONSENSUS_VOTE_V1"
+
+// CommitteeCommitment returns the commitment to the committee sampled for a
+// round: H(domain || round || sorted member IDs). Member order does not
+// affect the result, so every node that sampled the same set agrees on it.
+func CommitteeCommitment(round uint64, committee []NodeID) Hash {
+	sorted := slices.Clone(committee)
+	slices.SortFunc(sorted, func(a, b NodeID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	h := sha256.New()
+	h.Write([]byte(committeeDomain))
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], round)
+	h.Write(buf[:])
+	for _, id := range sorted {
+		h.Write(id[:])
+	}
+
+	var out Hash
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// SigningBytes returns the message a voter signs for v:
+// domain || block ID || vote type || round || committee commitment. Binding
+// the round and commitment means a signature cannot be replayed into another
+// round or under another committee.
+func (v *Vote) SigningBytes() []byte {
+	buf := make([]byte, 0, len(voteDomain)+len(v.BlockID)+8+8+len(v.Committee))
+	buf = append(buf, voteDomain...)
+	buf = append(buf, v.BlockID[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(v.VoteType))
+	buf = binary.BigEndian.AppendUint64(buf, v.Round)
+	return append(buf, v.Committee[:]...)
+}
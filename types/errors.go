@@ -30,4 +30,15 @@ var (
 
 	// ErrNotInitialized is returned when the engine is not initialized
 	ErrNotInitialized = errors.New("engine not initialized")
+
+	// ErrCommitteeMismatch is returned when a vote's committee commitment
+	// does not match the committee sampled for its round
+	ErrCommitteeMismatch = errors.New("committee commitment mismatch")
+
+	// ErrNotInCommittee is returned when a voter is not in its round's committee
+	ErrNotInCommittee = errors.New("voter not in committee")
+
+	// ErrUnknownRound is returned when a vote names a round with no sampled
+	// committee, either never registered or already decided
+	ErrUnknownRound = errors.New("unknown committee round")
 )
@@ -61,6 +61,13 @@ type Vote struct {
 	VoteType  VoteType `json:"vote_type"`
 	Voter     NodeID   `json:"voter"`
 	Signature []byte   `json:"signature"`
+
+	// Round is the polling round the vote was cast in
+	Round uint64 `json:"round"`
+
+	// Committee is the commitment to the sampled committee for Round
+	// (see CommitteeCommitment)
+	Committee Hash `json:"committee"`
 }
 
 // Certificate represents a consensus certificate